@@ -453,6 +453,7 @@ describe('getCompletionItems()', () => {
               "contains.file(\${1:CHANGELOG}) ",
               "contains.content(\${1:TODO}) ",
               "contains(file:\${1:CHANGELOG} content:\${2:fix}) ",
+              "contains.symbol(\${1:NewClient}) ",
               "contains.commit.after(\${1:1 month ago}) ",
               "^repo/with\\\\ a\\\\ space$ "
             ]
@@ -472,6 +473,7 @@ describe('getCompletionItems()', () => {
               "contains.file(\${1:CHANGELOG}) ",
               "contains.content(\${1:TODO}) ",
               "contains(file:\${1:CHANGELOG} content:\${2:fix}) ",
+              "contains.symbol(\${1:NewClient}) ",
               "contains.commit.after(\${1:1 month ago}) "
             ]
         `)
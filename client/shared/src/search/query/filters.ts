@@ -21,6 +21,7 @@ export enum FilterType {
     fork = 'fork',
     lang = 'lang',
     message = 'message',
+    multiline = 'multiline',
     patterntype = 'patterntype',
     repo = 'repo',
     repogroup = 'repogroup',
@@ -222,6 +223,7 @@ export const FILTERS: Record<NegatableFilter, NegatableFilterDefinition> &
     [FilterType.file]: {
         alias: 'f',
         negatable: true,
+        discreteValues: () => predicateCompletion('file'),
         description: negated =>
             `${negated ? 'Exclude' : 'Include only'} results from files matching the given search pattern.`,
         suggestions: 'File',
@@ -243,6 +245,12 @@ export const FILTERS: Record<NegatableFilter, NegatableFilterDefinition> &
         description: negated =>
             `${negated ? 'Exclude' : 'Include only'} Commits with messages matching a certain string`,
     },
+    [FilterType.multiline]: {
+        description: 'Match the search pattern against whole files, so that matches may span several lines.',
+        discreteValues: () => ['yes', 'no'].map(value => ({ label: value })),
+        default: 'no',
+        singular: true,
+    },
     [FilterType.patterntype]: {
         discreteValues: () => ['regexp', 'literal', 'structural'].map(value => ({ label: value })),
         description: 'The pattern type (regexp, literal, structural) in use',
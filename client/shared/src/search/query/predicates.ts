@@ -20,6 +20,7 @@ export const PREDICATES: Access[] = [
                 fields: [
                     { name: 'file' },
                     { name: 'content' },
+                    { name: 'symbol' },
                     {
                         name: 'commit',
                         fields: [{ name: 'after' }],
@@ -33,7 +34,11 @@ export const PREDICATES: Access[] = [
         fields: [
             {
                 name: 'contains',
-                fields: [{ name: 'content' }],
+                fields: [{ name: 'content' }, { name: 'symbol' }],
+            },
+            {
+                name: 'has',
+                fields: [{ name: 'owner' }],
             },
         ],
     },
@@ -159,6 +164,11 @@ export const predicateCompletion = (field: string): Completion[] => {
                 insertText: 'contains(file:${1:CHANGELOG} content:${2:fix})',
                 asSnippet: true,
             },
+            {
+                label: 'contains.symbol(...)',
+                insertText: 'contains.symbol(${1:NewClient})',
+                asSnippet: true,
+            },
             {
                 label: 'contains.commit.after(...)',
                 insertText: 'contains.commit.after(${1:1 month ago})',
@@ -166,5 +176,14 @@ export const predicateCompletion = (field: string): Completion[] => {
             },
         ]
     }
+    if (field === 'file') {
+        return [
+            {
+                label: 'has.owner(...)',
+                insertText: 'has.owner(${1:@team})',
+                asSnippet: true,
+            },
+        ]
+    }
     return []
 }
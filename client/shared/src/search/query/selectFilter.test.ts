@@ -47,7 +47,8 @@ describe('selectorCompletion', () => {
             symbol.struct,
             symbol.event,
             symbol.operator,
-            symbol.type-parameter
+            symbol.type-parameter,
+            symbol.container
         `)
     })
 
@@ -56,7 +57,9 @@ describe('selectorCompletion', () => {
             commit,
             commit.diff,
             commit.diff.added,
-            commit.diff.removed
+            commit.diff.removed,
+            commit.author,
+            commit.message
         `)
     })
 })
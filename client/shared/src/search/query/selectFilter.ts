@@ -11,7 +11,7 @@ export const SELECTORS: Access[] = [
     },
     {
         name: 'file',
-        fields: [{ name: 'directory' }, { name: 'path' }],
+        fields: [{ name: 'directory' }, { name: 'path' }, { name: 'owners' }],
     },
     {
         name: 'content',
@@ -45,11 +45,16 @@ export const SELECTORS: Access[] = [
             { name: 'event' },
             { name: 'operator' },
             { name: 'type-parameter' },
+            { name: 'container' },
         ],
     },
     {
         name: 'commit',
-        fields: [{ name: 'diff', fields: [{ name: 'added' }, { name: 'removed' }] }],
+        fields: [
+            { name: 'diff', fields: [{ name: 'added' }, { name: 'removed' }] },
+            { name: 'author' },
+            { name: 'message' },
+        ],
     },
 ]
 
@@ -20,7 +20,7 @@ export type SearchEvent =
     | { type: 'error'; data: ErrorLike }
     | { type: 'done'; data: {} }
 
-export type SearchMatch = ContentMatch | RepositoryMatch | CommitMatch | SymbolMatch | PathMatch
+export type SearchMatch = ContentMatch | RepositoryMatch | CommitMatch | SymbolMatch | PathMatch | OwnerMatch
 
 export interface PathMatch {
     type: 'path'
@@ -30,6 +30,7 @@ export interface PathMatch {
     repoLastFetched?: string
     branches?: string[]
     commit?: string
+    owners?: string[]
 }
 
 export interface ContentMatch {
@@ -40,6 +41,7 @@ export interface ContentMatch {
     repoLastFetched?: string
     branches?: string[]
     commit?: string
+    owners?: string[]
     lineMatches: LineMatch[]
     hunks?: DecoratedHunk[]
 }
@@ -82,6 +84,7 @@ export interface SymbolMatch {
     repoLastFetched?: string
     branches?: string[]
     commit?: string
+    owners?: string[]
     symbols: MatchedSymbol[]
 }
 
@@ -125,6 +128,16 @@ export interface RepositoryMatch {
     branches?: string[]
 }
 
+export interface OwnerMatch {
+    type: 'owner'
+    owners: string[]
+    repository: string
+    repoStars?: number
+    repoLastFetched?: string
+    branches?: string[]
+    commit?: string
+}
+
 /**
  * An aggregate type representing a progress update.
  * Should be replaced when a new ones come in.
@@ -502,13 +515,13 @@ export function getFileMatchUrl(fileMatch: ContentMatch | SymbolMatch | PathMatc
     return `/${fileMatch.repository}${revision ? '@' + revision : ''}/-/blob/${fileMatch.path}`
 }
 
-export function getRepoMatchLabel(repoMatch: RepositoryMatch): string {
+export function getRepoMatchLabel(repoMatch: RepositoryMatch | OwnerMatch): string {
     const branch = repoMatch?.branches?.[0]
     const revision = branch ? `@${branch}` : ''
     return repoMatch.repository + revision
 }
 
-export function getRepoMatchUrl(repoMatch: RepositoryMatch): string {
+export function getRepoMatchUrl(repoMatch: RepositoryMatch | OwnerMatch): string {
     const label = getRepoMatchLabel(repoMatch)
     return '/' + encodeURI(label)
 }
@@ -522,11 +535,12 @@ export function getMatchUrl(match: SearchMatch): string {
         case 'commit':
             return match.url
         case 'repo':
+        case 'owner':
             return getRepoMatchUrl(match)
     }
 }
 
-export function getMatchTitle(match: RepositoryMatch | CommitMatch): MarkdownText {
+export function getMatchTitle(match: RepositoryMatch | CommitMatch | OwnerMatch): MarkdownText {
     if (match.type === 'commit') {
         return match.label
     }
@@ -9,7 +9,7 @@ import { Markdown } from '@sourcegraph/shared/src/components/Markdown'
 import { RepoIcon } from '@sourcegraph/shared/src/components/RepoIcon'
 import { ResultContainer } from '@sourcegraph/shared/src/components/ResultContainer'
 import { SearchResultStar } from '@sourcegraph/shared/src/components/SearchResultStar'
-import { CommitMatch, getMatchTitle, RepositoryMatch } from '@sourcegraph/shared/src/search/stream'
+import { CommitMatch, getMatchTitle, OwnerMatch, RepositoryMatch } from '@sourcegraph/shared/src/search/stream'
 import { renderMarkdown } from '@sourcegraph/shared/src/util/markdown'
 import { formatRepositoryStarCount } from '@sourcegraph/shared/src/util/stars'
 
@@ -17,7 +17,7 @@ import { CommitSearchResultMatch } from './CommitSearchResultMatch'
 import styles from './SearchResult.module.scss'
 
 interface Props {
-    result: CommitMatch | RepositoryMatch
+    result: CommitMatch | RepositoryMatch | OwnerMatch
     repoName: string
     icon: React.ComponentType<{ className?: string }>
 }
@@ -52,6 +52,22 @@ export const SearchResult: React.FunctionComponent<Props> = ({ result, icon, rep
     }
 
     const renderBody = (): JSX.Element => {
+        if (result.type === 'owner') {
+            return (
+                <div className={classNames(styles.searchResultMatch, 'p-2 flex-column')}>
+                    <div className="d-flex align-items-center flex-row">
+                        <div className={styles.matchType}>
+                            <small>Code owners</small>
+                        </div>
+                        <div className={styles.divider} />
+                        <div>
+                            <small>{result.owners.join(', ')}</small>
+                        </div>
+                    </div>
+                </div>
+            )
+        }
+
         if (result.type === 'repo') {
             return (
                 <div>
@@ -168,10 +168,6 @@ export const FormTriggerArea: React.FunctionComponent<TriggerAreaProps> = ({
                             return 'Failed to parse query'
                         }
 
-                        if (!hasTypeDiffOrCommitFilter) {
-                            return 'Code monitors require queries to specify either `type:commit` or `type:diff`.'
-                        }
-
                         if (!hasRepoFilter) {
                             return 'Code monitors require queries to specify a `repo:` filter.'
                         }
@@ -244,7 +240,7 @@ export const FormTriggerArea: React.FunctionComponent<TriggerAreaProps> = ({
                                         <ValidQueryChecklistItem
                                             className="test-type-checkbox"
                                             checked={hasTypeDiffOrCommitFilter}
-                                            hint="type:diff targets code present in new commits, while type:commit targets commit messages"
+                                            hint="type:diff targets code present in new commits, while type:commit targets commit messages. Without them, the monitor notifies you about results which were not found by its previous run."
                                         >
                                             Contains a <code>type:diff</code> or <code>type:commit</code> filter
                                         </ValidQueryChecklistItem>
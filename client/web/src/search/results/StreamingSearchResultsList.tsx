@@ -1,4 +1,5 @@
 import * as H from 'history'
+import AccountGroupIcon from 'mdi-react/AccountGroupIcon'
 import AlphaSBoxIcon from 'mdi-react/AlphaSBoxIcon'
 import FileDocumentIcon from 'mdi-react/FileDocumentIcon'
 import FileIcon from 'mdi-react/FileIcon'
@@ -93,6 +94,8 @@ export const StreamingSearchResultsList: React.FunctionComponent<StreamingSearch
                     return <SearchResult icon={SourceCommitIcon} result={result} repoName={result.repository} />
                 case 'repo':
                     return <SearchResult icon={SourceRepositoryIcon} result={result} repoName={result.repository} />
+                case 'owner':
+                    return <SearchResult icon={AccountGroupIcon} result={result} repoName={result.repository} />
             }
         },
         [
@@ -73,6 +73,8 @@ type MonitorActionConnectionResolver interface {
 
 type MonitorAction interface {
 	ToMonitorEmail() (MonitorEmailResolver, bool)
+	ToMonitorWebhook() (MonitorWebhookResolver, bool)
+	ToMonitorSlackWebhook() (MonitorSlackWebhookResolver, bool)
 }
 
 type MonitorEmailResolver interface {
@@ -80,10 +82,35 @@ type MonitorEmailResolver interface {
 	Enabled() bool
 	Priority() string
 	Header() string
+	Delivery() string
+	RateLimit() MonitorActionRateLimitResolver
 	Recipients(ctx context.Context, args *ListRecipientsArgs) (MonitorActionEmailRecipientsConnectionResolver, error)
 	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
 }
 
+type MonitorWebhookResolver interface {
+	ID() graphql.ID
+	Enabled() bool
+	URL() string
+	Delivery() string
+	RateLimit() MonitorActionRateLimitResolver
+	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
+}
+
+type MonitorSlackWebhookResolver interface {
+	ID() graphql.ID
+	Enabled() bool
+	URL() string
+	Delivery() string
+	RateLimit() MonitorActionRateLimitResolver
+	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
+}
+
+type MonitorActionRateLimitResolver interface {
+	MaxNotifications() int32
+	WindowMinutes() int32
+}
+
 type MonitorEmailRecipient interface {
 	ToUser() (*UserResolver, bool)
 }
@@ -138,7 +165,9 @@ type CreateTriggerArgs struct {
 }
 
 type CreateActionArgs struct {
-	Email *CreateActionEmailArgs
+	Email        *CreateActionEmailArgs
+	Webhook      *CreateActionWebhookArgs
+	SlackWebhook *CreateActionSlackWebhookArgs
 }
 
 type CreateActionEmailArgs struct {
@@ -146,6 +175,27 @@ type CreateActionEmailArgs struct {
 	Priority   string
 	Recipients []graphql.ID
 	Header     string
+	Delivery   string
+	RateLimit  *MonitorActionRateLimitArgs
+}
+
+type CreateActionWebhookArgs struct {
+	Enabled   bool
+	URL       string
+	Delivery  string
+	RateLimit *MonitorActionRateLimitArgs
+}
+
+type CreateActionSlackWebhookArgs struct {
+	Enabled   bool
+	URL       string
+	Delivery  string
+	RateLimit *MonitorActionRateLimitArgs
+}
+
+type MonitorActionRateLimitArgs struct {
+	MaxNotifications int32
+	WindowMinutes    int32
 }
 
 type ToggleCodeMonitorArgs struct {
@@ -178,8 +228,20 @@ type EditActionEmailArgs struct {
 	Update *CreateActionEmailArgs
 }
 
+type EditActionWebhookArgs struct {
+	Id     *graphql.ID
+	Update *CreateActionWebhookArgs
+}
+
+type EditActionSlackWebhookArgs struct {
+	Id     *graphql.ID
+	Update *CreateActionSlackWebhookArgs
+}
+
 type EditActionArgs struct {
-	Email *EditActionEmailArgs
+	Email        *EditActionEmailArgs
+	Webhook      *EditActionWebhookArgs
+	SlackWebhook *EditActionSlackWebhookArgs
 }
 
 type EditTriggerArgs struct {
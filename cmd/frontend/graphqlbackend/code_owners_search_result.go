@@ -0,0 +1,41 @@
+package graphqlbackend
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// CodeOwnersSearchResultResolver is a resolver for the GraphQL type
+// `CodeOwnersSearchResult`.
+type CodeOwnersSearchResultResolver struct {
+	result.OwnerMatch
+
+	RepoResolver *RepositoryResolver
+}
+
+func (r *CodeOwnersSearchResultResolver) Owners() []string {
+	return r.OwnerMatch.Owners
+}
+
+func (r *CodeOwnersSearchResultResolver) Repository() *RepositoryResolver {
+	return r.RepoResolver
+}
+
+func (r *CodeOwnersSearchResultResolver) ToRepository() (*RepositoryResolver, bool) {
+	return nil, false
+}
+
+func (r *CodeOwnersSearchResultResolver) ToFileMatch() (*FileMatchResolver, bool) {
+	return nil, false
+}
+
+func (r *CodeOwnersSearchResultResolver) ToCommitSearchResult() (*CommitSearchResultResolver, bool) {
+	return nil, false
+}
+
+func (r *CodeOwnersSearchResultResolver) ToCodeOwnersSearchResult() (*CodeOwnersSearchResultResolver, bool) {
+	return r, true
+}
+
+func (r *CodeOwnersSearchResultResolver) ResultCount() int32 {
+	return 1
+}
@@ -93,6 +93,9 @@ func (r *CommitSearchResultResolver) ToFileMatch() (*FileMatchResolver, bool)
 func (r *CommitSearchResultResolver) ToCommitSearchResult() (*CommitSearchResultResolver, bool) {
 	return r, true
 }
+func (r *CommitSearchResultResolver) ToCodeOwnersSearchResult() (*CodeOwnersSearchResultResolver, bool) {
+	return nil, false
+}
 
 func (r *CommitSearchResultResolver) ResultCount() int32 {
 	return 1
@@ -2,13 +2,11 @@ package graphqlbackend
 
 import (
 	"context"
-	"regexp"
 
 	"github.com/cockroachdb/errors"
 	"github.com/sourcegraph/go-langserver/pkg/lsp"
 	"github.com/sourcegraph/sourcegraph/internal/compute"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
-	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
@@ -109,18 +107,34 @@ func toLspRange(r compute.Range) lsp.Range {
 // ComputeText GQL result resolver definitions.
 
 type computeTextResolver struct {
-	repository *RepositoryResolver //nolint
-	commit     string              //nolint
-	path       string              //nolint
+	repository *RepositoryResolver
+	commit     string
+	path       string
 	t          *compute.Text
 }
 
-func (c *computeTextResolver) Repository() *RepositoryResolver { return nil }
-func (r *computeTextResolver) Commit() *string                 { return nil }
-func (r *computeTextResolver) Path() *string                   { return nil }
-func (r *computeTextResolver) Kind() *string                   { return nil }
+func (c *computeTextResolver) Repository() *RepositoryResolver { return c.repository }
+func (r *computeTextResolver) Commit() *string                 { return strPtrOrNil(r.commit) }
+func (r *computeTextResolver) Path() *string                   { return strPtrOrNil(r.path) }
+func (r *computeTextResolver) Kind() *string                   { return strPtrOrNil(r.t.Kind) }
 func (r *computeTextResolver) Value() string                   { return r.t.Value }
 
+func strPtrOrNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func toComputeTextResolver(fm *result.FileMatch, t *compute.Text, db dbutil.DB) *computeTextResolver {
+	return &computeTextResolver{
+		repository: NewRepositoryResolver(db, fm.Repo.ToRepo()),
+		commit:     string(fm.CommitID),
+		path:       fm.Path,
+		t:          t,
+	}
+}
+
 // Definitions required by https://github.com/graph-gophers/graphql-go to resolve
 // a union type in GraphQL.
 
@@ -167,41 +181,25 @@ func toComputeResultResolver(r *computeMatchContextResolver) *computeResultResol
 	return &computeResultResolver{result: r}
 }
 
-func regexpFromQuery(q string) (*regexp.Regexp, error) {
-	plan, err := query.Pipeline(query.Init(q, query.SearchTypeRegex))
-	if err != nil {
-		return nil, err
-	}
-	if len(plan) != 1 {
-		return nil, errors.New("compute endpoint only supports one search pattern currently ('and' or 'or' operators are not supported yet)")
-	}
-	switch node := plan[0].Pattern.(type) {
-	case query.Operator:
-		if len(node.Operands) == 1 {
-			if pattern, ok := node.Operands[0].(query.Pattern); ok && !pattern.Negated {
-				rp, err := regexp.Compile(pattern.Value)
-				if err != nil {
-					return nil, errors.Wrap(err, "regular expression is not valid for compute endpoint")
-				}
-				return rp, nil
-			}
-		}
-		return nil, errors.New("compute endpoint only supports one search pattern currently ('and' or 'or' operators are not supported yet)")
-	case query.Pattern:
-		if !node.Negated {
-			return regexp.Compile(node.Value)
-		}
-	}
-	// unreachable
-	return nil, nil
-}
-
-func toResultResolverList(pattern *regexp.Regexp, matches []result.Match, db dbutil.DB) []*computeResultResolver {
+func toResultResolverList(cmd compute.Command, matches []result.Match, db dbutil.DB) []*computeResultResolver {
 	var computeResult []*computeResultResolver
 	for _, m := range matches {
-		if fm, ok := m.(*result.FileMatch); ok {
-			matchContext := compute.FromFileMatch(fm, pattern)
+		fm, ok := m.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+		switch c := cmd.(type) {
+		case *compute.MatchOnly:
+			matchContext := c.Run(fm)
 			computeResult = append(computeResult, toComputeResultResolver(toComputeMatchContextResolver(fm, matchContext, db)))
+		case *compute.Replace:
+			if text := c.Run(fm); text != nil {
+				computeResult = append(computeResult, &computeResultResolver{result: toComputeTextResolver(fm, text, db)})
+			}
+		case *compute.Output:
+			if text := c.Run(fm); text != nil {
+				computeResult = append(computeResult, &computeResultResolver{result: toComputeTextResolver(fm, text, db)})
+			}
 		}
 	}
 	return computeResult
@@ -210,12 +208,15 @@ func toResultResolverList(pattern *regexp.Regexp, matches []result.Match, db dbu
 // NewComputeImplementer is a function that abstracts away the need to have a
 // handle on (*schemaResolver) Compute.
 func NewComputeImplementer(ctx context.Context, db dbutil.DB, args *ComputeArgs) ([]*computeResultResolver, error) {
-	pattern, err := regexpFromQuery(args.Query)
+	computeQuery, err := compute.Parse(args.Query)
 	if err != nil {
 		return nil, err
 	}
+	if _, ok := computeQuery.Command.(*compute.CountBy); ok {
+		return nil, errors.New("content:count-by(...) is only supported by the streaming compute endpoint /compute/stream")
+	}
 	patternType := "regexp"
-	job, err := NewSearchImplementer(ctx, db, &SearchArgs{Query: args.Query, PatternType: &patternType})
+	job, err := NewSearchImplementer(ctx, db, &SearchArgs{Query: computeQuery.SearchQuery, PatternType: &patternType})
 	if err != nil {
 		return nil, err
 	}
@@ -224,7 +225,7 @@ func NewComputeImplementer(ctx context.Context, db dbutil.DB, args *ComputeArgs)
 	if err != nil {
 		return nil, err
 	}
-	return toResultResolverList(pattern, results.Matches, db), nil
+	return toResultResolverList(computeQuery.Command, results.Matches, db), nil
 }
 
 func (r *schemaResolver) Compute(ctx context.Context, args *ComputeArgs) ([]*computeResultResolver, error) {
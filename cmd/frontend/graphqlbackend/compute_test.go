@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hexops/autogold"
+	"github.com/sourcegraph/sourcegraph/internal/compute"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
@@ -20,7 +21,7 @@ func TestToResultResolverList(t *testing.T) {
 		},
 	}
 	test := func(input string) string {
-		resolvers := toResultResolverList(regexp.MustCompile(input), matches, new(dbtesting.MockDB))
+		resolvers := toResultResolverList(&compute.MatchOnly{MatchPattern: regexp.MustCompile(input)}, matches, new(dbtesting.MockDB))
 		var results []string
 		for _, r := range resolvers {
 			for _, m := range r.result.(*computeMatchContextResolver).matches {
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/cockroachdb/errors"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 
@@ -48,6 +50,48 @@ func TestRepository(t *testing.T) {
 		}
 	})
 
+	t.Run("gitea and gerrit", func(t *testing.T) {
+		resetMocks()
+		database.Mocks.Phabricator.GetByName = func(repo api.RepoName) (*types.PhabricatorRepo, error) {
+			return nil, errors.New("x")
+		}
+		for _, test := range []struct {
+			repo *types.Repo
+			want string
+		}{
+			{
+				repo: &types.Repo{
+					Name:         "gitea.example.com/foo/bar",
+					ExternalRepo: api.ExternalRepoSpec{ServiceID: "https://gitea.example.com/", ServiceType: extsvc.TypeGitea},
+					Metadata:     &gitea.Repository{HTMLURL: "https://gitea.example.com/foo/bar"},
+				},
+				want: "https://gitea.example.com/foo/bar",
+			},
+			{
+				repo: &types.Repo{
+					Name:         "gerrit.example.com/foo/bar",
+					ExternalRepo: api.ExternalRepoSpec{ServiceID: "https://gerrit.example.com/", ServiceType: extsvc.TypeGerrit},
+					Metadata:     &gerrit.Project{ID: "foo%2Fbar", Name: "foo/bar"},
+				},
+				want: "https://gerrit.example.com/admin/repos/foo%2Fbar",
+			},
+		} {
+			links, err := Repository(context.Background(), new(dbtesting.MockDB), test.repo)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := []*Resolver{
+				{
+					url:         test.want,
+					serviceKind: extsvc.TypeToKind(test.repo.ExternalRepo.ServiceType),
+					serviceType: test.repo.ExternalRepo.ServiceType,
+				},
+			}; !reflect.DeepEqual(links, want) {
+				t.Errorf("got %+v, want %+v", links, want)
+			}
+		}
+	})
+
 	t.Run("phabricator", func(t *testing.T) {
 		resetMocks()
 		database.Mocks.Phabricator.GetByName = func(repo api.RepoName) (*types.PhabricatorRepo, error) {
@@ -64,8 +64,9 @@ func (fm *FileMatchResolver) Symbols() []symbolResolver {
 }
 
 func (fm *FileMatchResolver) LineMatches() []lineMatchResolver {
-	r := make([]lineMatchResolver, 0, len(fm.FileMatch.LineMatches))
-	for _, lm := range fm.FileMatch.LineMatches {
+	lms := fm.FileMatch.AllLineMatches()
+	r := make([]lineMatchResolver, 0, len(lms))
+	for _, lm := range lms {
 		r = append(r, lineMatchResolver{lm})
 	}
 	return r
@@ -75,11 +76,21 @@ func (fm *FileMatchResolver) LimitHit() bool {
 	return fm.FileMatch.LimitHit
 }
 
+func (fm *FileMatchResolver) Owners() *[]string {
+	if fm.FileMatch.Owners == nil {
+		return nil
+	}
+	return &fm.FileMatch.Owners
+}
+
 func (fm *FileMatchResolver) ToRepository() (*RepositoryResolver, bool) { return nil, false }
 func (fm *FileMatchResolver) ToFileMatch() (*FileMatchResolver, bool)   { return fm, true }
 func (fm *FileMatchResolver) ToCommitSearchResult() (*CommitSearchResultResolver, bool) {
 	return nil, false
 }
+func (fm *FileMatchResolver) ToCodeOwnersSearchResult() (*CodeOwnersSearchResultResolver, bool) {
+	return nil, false
+}
 
 func (fm *FileMatchResolver) ResultCount() int32 {
 	return int32(fm.FileMatch.ResultCount())
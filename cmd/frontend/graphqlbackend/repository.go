@@ -289,6 +289,9 @@ func (r *RepositoryResolver) ToFileMatch() (*FileMatchResolver, bool)   { return
 func (r *RepositoryResolver) ToCommitSearchResult() (*CommitSearchResultResolver, bool) {
 	return nil, false
 }
+func (r *RepositoryResolver) ToCodeOwnersSearchResult() (*CodeOwnersSearchResultResolver, bool) {
+	return nil, false
+}
 
 func (r *RepositoryResolver) ResultCount() int32 {
 	return 1
@@ -14,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/endpoint"
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/codeowners"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
 	"github.com/sourcegraph/sourcegraph/internal/search/run"
@@ -195,6 +196,10 @@ type searchResolver struct {
 	db                  dbutil.DB
 	invalidateRepoCache bool // if true, invalidates the repo cache when evaluating search subexpressions.
 
+	// codeOwners caches the CODEOWNERS files read while evaluating the
+	// search. Use codeOwnersResolver to access it.
+	codeOwners *codeowners.Resolver
+
 	// stream if non-nil will send all search events we receive down it.
 	stream streaming.Sender
 
@@ -209,6 +214,15 @@ type searchResolver struct {
 	searcherURLs *endpoint.Map
 }
 
+// codeOwnersResolver returns the resolver of code owners shared by all
+// subexpressions of the search.
+func (r *searchResolver) codeOwnersResolver() *codeowners.Resolver {
+	if r.codeOwners == nil {
+		r.codeOwners = codeowners.NewResolver()
+	}
+	return r.codeOwners
+}
+
 func (r *searchResolver) Inputs() run.SearchInputs {
 	return *r.SearchInputs
 }
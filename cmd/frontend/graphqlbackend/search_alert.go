@@ -616,6 +616,14 @@ func alertForStalePermissions() *searchAlert {
 	}
 }
 
+func alertForUnresolvedCodeOwners(err error) *searchAlert {
+	return &searchAlert{
+		prometheusType: "unresolved_code_owners",
+		title:          "Some code owners could not be resolved",
+		description:    fmt.Sprintf("Files whose CODEOWNERS file could not be read are not included in the results of file:has.owner(). The first error was: %s", err),
+	}
+}
+
 func alertForInvalidRevision(revision string) *searchAlert {
 	revision = strings.TrimSuffix(revision, "^0")
 	return &searchAlert{
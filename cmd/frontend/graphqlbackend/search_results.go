@@ -9,6 +9,7 @@ import (
 	"regexp"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -33,6 +34,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/honey"
 	"github.com/sourcegraph/sourcegraph/internal/rcache"
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/codeowners"
 	"github.com/sourcegraph/sourcegraph/internal/search/filter"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
@@ -163,6 +165,11 @@ func matchesToResolvers(db dbutil.DB, matches []result.Match) []SearchResultReso
 				db:          db,
 				CommitMatch: *v,
 			})
+		case *result.OwnerMatch:
+			resolvers = append(resolvers, &CodeOwnersSearchResultResolver{
+				OwnerMatch:   *v,
+				RepoResolver: getRepoResolver(v.Repo, ""),
+			})
 		}
 	}
 	return resolvers
@@ -267,11 +274,12 @@ func (sr *SearchResultsResolver) blameFileMatch(ctx context.Context, fm *result.
 	}()
 
 	// Blame the first line match.
-	if len(fm.LineMatches) == 0 {
+	lms := fm.AllLineMatches()
+	if len(lms) == 0 {
 		// No line match
 		return time.Time{}, nil
 	}
-	lm := fm.LineMatches[0]
+	lm := lms[0]
 	hunks, err := git.BlameFile(ctx, fm.Repo.Name, fm.Path, &git.BlameOptions{
 		NewestCommit: fm.CommitID,
 		StartLine:    int(lm.LineNumber),
@@ -321,8 +329,8 @@ loop:
 	for _, r := range sr.Matches {
 		r := r // shadow so it doesn't change in the goroutine
 		switch m := r.(type) {
-		case *result.RepoMatch:
-			// We don't care about repo results here.
+		case *result.RepoMatch, *result.OwnerMatch:
+			// We don't care about repo or owner results here.
 			continue
 		case *result.CommitMatch:
 			// Diff searches are cheap, because we implicitly have author date info.
@@ -997,12 +1005,23 @@ func (r *searchResolver) resultsStreaming(ctx context.Context) (*SearchResultsRe
 		// Ensure downstream events sent on the stream are processed by `select:`.
 		selectPath, _ := filter.SelectPathFromString(sp) // Invariant: error already checked
 		r.stream = streaming.WithSelect(r.stream, selectPath)
+		if selectsOwners(selectPath) {
+			// Resolve owners before the select operation projects
+			// file matches onto them.
+			r.stream = codeowners.WithOwners(ctx, r.stream, r.codeOwnersResolver())
+		}
 	}
 	sr, err := r.resultsRecursive(ctx, r.Plan)
 	srr := r.resultsToResolver(sr)
 	return srr, err
 }
 
+// selectsOwners returns true if sp projects file matches onto their owners,
+// which must be resolved before.
+func selectsOwners(sp filter.SelectPath) bool {
+	return sp.Root() == filter.File && len(sp) > 1 && sp[1] == "owners"
+}
+
 func (r *searchResolver) resultsToResolver(results *SearchResults) *SearchResultsResolver {
 	if results == nil {
 		results = &SearchResults{}
@@ -1084,13 +1103,22 @@ func (r *searchResolver) resultsRecursive(ctx context.Context, plan query.Plan)
 			return r.resultsRecursive(ctx, predicatePlan)
 		}
 
-		newResult, err := r.evaluate(ctx, q)
+		owners, q := q.FileOwners()
+		newResult, err := r.evaluateOwnedBy(ctx, q, owners)
 		if err != nil {
 			// Fail if any subexpression fails.
 			return nil, err
 		}
 
 		if newResult != nil {
+			if sp, _ := q.ToParseTree().StringValue(query.FieldSelect); sp != "" {
+				selectPath, _ := filter.SelectPathFromString(sp) // Invariant: error already checked
+				if selectsOwners(selectPath) {
+					if err := r.codeOwnersResolver().AddOwners(ctx, newResult.Matches); err != nil {
+						log15.Warn("failed to resolve code owners", "error", err)
+					}
+				}
+			}
 			newResult.Matches = result.Select(newResult.Matches, q)
 			sr = union(sr, newResult)
 			if len(sr.Matches) > wantCount {
@@ -1106,19 +1134,58 @@ func (r *searchResolver) resultsRecursive(ctx context.Context, plan query.Plan)
 	return sr, err
 }
 
+// evaluateOwnedBy evaluates q and restricts its results to the files owned by
+// all of owners, which are the arguments of the file:has.owner() predicates
+// of q. Files whose owners cannot be resolved are left out and reported in an
+// alert.
+func (r *searchResolver) evaluateOwnedBy(ctx context.Context, q query.Basic, owners []string) (*SearchResults, error) {
+	if len(owners) == 0 {
+		return r.evaluate(ctx, q)
+	}
+
+	f := codeowners.NewOwnerFilter(r.codeOwnersResolver(), owners)
+	if r.stream != nil {
+		orig := r.stream
+		r.stream = f.Stream(ctx, orig)
+		defer func() { r.stream = orig }()
+	}
+
+	sr, err := r.evaluate(ctx, q)
+	if sr != nil {
+		// Results are only aggregated here if they weren't streamed.
+		sr.Matches = f.Filter(ctx, sr.Matches)
+		if ownersErr := f.Err(); ownersErr != nil {
+			sr.Alert = maxAlertByPriority(sr.Alert, alertForUnresolvedCodeOwners(ownersErr))
+		}
+	}
+	return sr, err
+}
+
 // searchResultsToRepoNodes converts a set of search results into repository nodes
-// such that they can be used to replace a repository predicate
+// such that they can be used to replace a repository predicate. File matches
+// (e.g. symbol results) are reduced to their repository.
 func searchResultsToRepoNodes(matches []result.Match) ([]query.Node, error) {
 	nodes := make([]query.Node, 0, len(matches))
+	seen := make(map[api.RepoName]struct{}, len(matches))
 	for _, match := range matches {
-		repoMatch, ok := match.(*result.RepoMatch)
-		if !ok {
+		var repoName api.RepoName
+		switch m := match.(type) {
+		case *result.RepoMatch:
+			repoName = m.Name
+		case *result.FileMatch:
+			repoName = m.Repo.Name
+		default:
 			return nil, errors.Errorf("expected type %T, but got %T", &result.RepoMatch{}, match)
 		}
 
+		if _, ok := seen[repoName]; ok {
+			continue
+		}
+		seen[repoName] = struct{}{}
+
 		nodes = append(nodes, query.Parameter{
 			Field: query.FieldRepo,
-			Value: "^" + regexp.QuoteMeta(string(repoMatch.Name)) + "$",
+			Value: "^" + regexp.QuoteMeta(string(repoName)) + "$",
 		})
 	}
 
@@ -1204,6 +1271,11 @@ func substitutePredicates(q query.Basic, evaluate func(query.Predicate) (*Search
 		name, params := query.ParseAsPredicate(value)
 		predicate := query.DefaultPredicateRegistry.Get(field, name)
 		predicate.ParseParams(params)
+		if _, ok := predicate.(*query.FileHasOwnerPredicate); ok {
+			// Filters the results of the query instead of expanding into
+			// it. See evaluateOwnedBy.
+			return orig
+		}
 		srr, err := evaluate(predicate)
 		if err != nil {
 			topErr = err
@@ -1698,6 +1770,7 @@ type SearchResultResolver interface {
 	ToRepository() (*RepositoryResolver, bool)
 	ToFileMatch() (*FileMatchResolver, bool)
 	ToCommitSearchResult() (*CommitSearchResultResolver, bool)
+	ToCodeOwnersSearchResult() (*CodeOwnersSearchResultResolver, bool)
 
 	ResultCount() int32
 }
@@ -1740,6 +1813,10 @@ func compareSearchResults(left, right result.Match, exactFilePatterns map[string
 		switch r := match.(type) {
 		case *result.RepoMatch:
 			return string(r.Name), "", nil
+		case *result.OwnerMatch:
+			// Owners are listed after the repository, in the order
+			// of their owners.
+			return string(r.Repo.Name), "~" + strings.Join(r.Owners, " "), nil
 		case *result.FileMatch:
 			return string(r.Repo.Name), r.Path, nil
 		case *result.CommitMatch:
@@ -1749,7 +1826,7 @@ func compareSearchResults(left, right result.Match, exactFilePatterns map[string
 			return "~", "~", &r.Commit.Author.Date
 		}
 		// Unreachable.
-		panic("unreachable: compareSearchResults expects RepositoryResolver, FileMatchResolver, or CommitSearchResultResolver")
+		panic("unreachable: compareSearchResults expects RepositoryResolver, FileMatchResolver, CommitSearchResultResolver, or CodeOwnersSearchResultResolver")
 	}
 
 	arepo, afile, adate := sortKeys(left)
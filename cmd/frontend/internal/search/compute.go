@@ -0,0 +1,199 @@
+package search
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/compute"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// maxAggregateCounts is the maximum number of counts sent in an aggregate
+// event. The counts with the highest values are sent.
+const maxAggregateCounts = 1000
+
+// ComputeStreamHandler is an http handler which streams back compute results.
+// It accepts a compute query in the q URL parameter.
+//
+// It sends the following events:
+//
+//	results   - a JSON array of compute.EventResult.
+//	aggregate - a compute.EventAggregate for content:count-by(...) queries. Each
+//	            aggregate event replaces the previous one. Count-by queries
+//	            search up to compute.CountByMaxResults results by default.
+//	progress  - search progress, like the search stream.
+//	error     - a streamhttp.EventError.
+//	done      - always sent last.
+func ComputeStreamHandler(db dbutil.DB) http.Handler {
+	return &computeStreamHandler{
+		streamHandler: streamHandler{
+			db:                  db,
+			newSearchResolver:   defaultNewSearchResolver,
+			flushTickerInternal: 100 * time.Millisecond,
+			pingTickerInterval:  5 * time.Second,
+		},
+	}
+}
+
+type computeStreamHandler struct {
+	streamHandler
+}
+
+func (h *computeStreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	q := r.URL.Query().Get("q")
+	if q == "" {
+		http.Error(w, "no query found", http.StatusBadRequest)
+		return
+	}
+	computeQuery, err := compute.Parse(q)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tr, ctx := trace.New(ctx, "compute.ServeStream", q)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	eventWriter, err := streamhttp.NewWriter(w)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Always send a final done event so clients know the stream is shutting
+	// down.
+	defer eventWriter.Event("done", map[string]interface{}{})
+
+	eventWriter.StatHook = eventStreamOTHook(tr.LogFields)
+
+	events, inputs, results := h.startSearch(ctx, &args{
+		Query:       computeQuery.SearchQuery,
+		Version:     "V2",
+		PatternType: "regexp",
+	})
+	events = batchEvents(events, 50*time.Millisecond)
+
+	progress := progressAggregator{
+		Start:        time.Now(),
+		Limit:        inputs.MaxResults(),
+		Trace:        trace.URL(trace.ID(ctx)),
+		DisplayLimit: math.MaxInt32,
+	}
+	sendProgress := func() {
+		_ = eventWriter.Event("progress", progress.Current())
+	}
+
+	counter := compute.NewCounter()
+	sendAggregate := func() {
+		_ = eventWriter.Event("aggregate", compute.EventAggregate{
+			Counts:   counter.Top(maxAggregateCounts),
+			Distinct: counter.Len(),
+			LimitHit: progress.Stats.IsLimitHit,
+		})
+	}
+
+	resultsBuf := streamhttp.NewJSONArrayBuf(32*1024, func(data []byte) error {
+		return eventWriter.EventBytes("results", data)
+	})
+	flush := func() {
+		if err := resultsBuf.Flush(); err != nil {
+			// EOF
+			return
+		}
+		if counter.Dirty() {
+			sendAggregate()
+		}
+		if progress.Dirty {
+			sendProgress()
+		}
+	}
+
+	flushTicker := time.NewTicker(h.flushTickerInternal)
+	defer flushTicker.Stop()
+
+	pingTicker := time.NewTicker(h.pingTickerInterval)
+	defer pingTicker.Stop()
+
+	handleEvent := func(event streaming.SearchEvent) {
+		progress.Update(event)
+		for _, match := range event.Results {
+			fm, ok := match.(*result.FileMatch)
+			if !ok {
+				continue
+			}
+			if e := toEventResult(computeQuery.Command, fm, counter); e != nil {
+				_ = resultsBuf.Append(e)
+			}
+		}
+	}
+
+LOOP:
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				break LOOP
+			}
+			handleEvent(event)
+		case <-flushTicker.C:
+			flush()
+		case <-pingTicker.C:
+			sendProgress()
+		}
+	}
+
+	flush()
+	if _, ok := computeQuery.Command.(*compute.CountBy); ok {
+		// Always send the final tally, even if it is empty.
+		sendAggregate()
+	}
+
+	if _, err = results(); err != nil {
+		_ = eventWriter.Event("error", streamhttp.EventError{Message: err.Error()})
+		return
+	}
+
+	_ = eventWriter.Event("progress", progress.Final())
+}
+
+// toEventResult runs cmd over fm. It returns the result to send, or nil if
+// there is nothing to send. The keys of aggregate commands are added to
+// counter instead.
+func toEventResult(cmd compute.Command, fm *result.FileMatch, counter *compute.Counter) *compute.EventResult {
+	e := &compute.EventResult{
+		Repository: string(fm.Repo.Name),
+		Commit:     string(fm.CommitID),
+		Path:       fm.Path,
+	}
+	switch c := cmd.(type) {
+	case *compute.MatchOnly:
+		matchContext := c.Run(fm)
+		if len(matchContext.Matches) == 0 {
+			return nil
+		}
+		e.MatchContext = matchContext
+	case *compute.Replace:
+		e.Text = c.Run(fm)
+	case *compute.Output:
+		e.Text = c.Run(fm)
+	case *compute.CountBy:
+		counter.Add(c.Run(fm)...)
+		return nil
+	}
+	if e.MatchContext == nil && e.Text == nil {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,141 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/compute"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/run"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestServeComputeStream(t *testing.T) {
+	mkFileMatch := func(repo string, lines ...string) *result.FileMatch {
+		fm := &result.FileMatch{
+			File: result.File{
+				Repo: types.RepoName{Name: api.RepoName(repo)},
+				Path: "go.mod",
+			},
+		}
+		for i, l := range lines {
+			fm.LineMatches = append(fm.LineMatches, &result.LineMatch{Preview: l, LineNumber: int32(i)})
+		}
+		return fm
+	}
+
+	matches := []result.Match{
+		mkFileMatch("a", "github.com/pkg/errors v0.9.1"),
+		mkFileMatch("b", "github.com/pkg/errors v0.9.1"),
+		mkFileMatch("c", "github.com/pkg/errors v0.8.0"),
+	}
+
+	run := func(t *testing.T, q string) (results []compute.EventResult, aggregate *compute.EventAggregate, searchQuery string) {
+		mock := &mockSearchResolver{
+			done: make(chan struct{}),
+		}
+		ts := httptest.NewServer(&computeStreamHandler{
+			streamHandler: streamHandler{
+				flushTickerInternal: 1 * time.Millisecond,
+				pingTickerInterval:  1 * time.Millisecond,
+				newSearchResolver: func(_ context.Context, _ dbutil.DB, args *graphqlbackend.SearchArgs) (searchResolver, error) {
+					mock.c = args.Stream
+					searchQuery = args.Query
+					q, err := query.ParseRegexp(args.Query)
+					if err != nil {
+						return nil, err
+					}
+					mock.inputs = &run.SearchInputs{Query: q}
+					return mock, nil
+				},
+			},
+		})
+		defer ts.Close()
+
+		resp, err := http.Get(ts.URL + "?q=" + url.QueryEscape(q))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		g := errgroup.Group{}
+		g.Go(func() error {
+			dec := streamhttp.NewDecoder(resp.Body)
+			for dec.Scan() {
+				switch string(dec.Event()) {
+				case "results":
+					var rs []compute.EventResult
+					if err := json.Unmarshal(dec.Data(), &rs); err != nil {
+						return err
+					}
+					results = append(results, rs...)
+				case "aggregate":
+					aggregate = &compute.EventAggregate{}
+					if err := json.Unmarshal(dec.Data(), aggregate); err != nil {
+						return err
+					}
+				}
+			}
+			return dec.Err()
+		})
+
+		mock.c.Send(streaming.SearchEvent{Results: matches})
+		mock.Close()
+		if err := g.Wait(); err != nil {
+			t.Fatal(err)
+		}
+		return results, aggregate, searchQuery
+	}
+
+	t.Run("output", func(t *testing.T) {
+		results, aggregate, searchQuery := run(t, `content:output(errors (v[\d.]+) -> $repo $1)`)
+		if want := `content:"errors (v[\\d.]+)"`; searchQuery != want {
+			t.Fatalf("got search query %q, want %q", searchQuery, want)
+		}
+		if aggregate != nil {
+			t.Fatalf("expected no aggregate event, got %v", aggregate)
+		}
+		var got []string
+		for _, r := range results {
+			got = append(got, r.Text.Value)
+		}
+		if want := []string{"a v0.9.1", "b v0.9.1", "c v0.8.0"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("count-by", func(t *testing.T) {
+		results, aggregate, _ := run(t, `content:count-by(errors (v[\d.]+) -> $1)`)
+		if len(results) != 0 {
+			t.Fatalf("expected no results, got %v", results)
+		}
+		want := &compute.EventAggregate{
+			Counts:   []compute.Count{{Value: "v0.9.1", Count: 2}, {Value: "v0.8.0", Count: 1}},
+			Distinct: 2,
+		}
+		if !reflect.DeepEqual(aggregate, want) {
+			t.Fatalf("got %v, want %v", aggregate, want)
+		}
+	})
+
+	t.Run("count-by limit hit", func(t *testing.T) {
+		_, aggregate, _ := run(t, `count:2 content:count-by(errors (v[\d.]+) -> $1)`)
+		if aggregate == nil || !aggregate.LimitHit {
+			t.Fatalf("expected the aggregate to report the limit hit, got %v", aggregate)
+		}
+	})
+}
@@ -153,7 +153,7 @@ func DecorateFileHunksHTML(ctx context.Context, fm *result.FileMatch) []stream.D
 		return tableRows
 	}
 
-	groups := groupLineMatches(fm.LineMatches)
+	groups := groupLineMatches(fm.AllLineMatches())
 	hunks := make([]stream.DecoratedHunk, 0, len(groups))
 	for _, group := range groups {
 		rows := spliceRows(int(group[0].LineNumber), int(group[0].LineNumber)+len(group))
@@ -423,6 +423,8 @@ func fromMatch(match result.Match, repoCache map[api.RepoID]*types.SearchedRepo)
 		return fromRepository(v, repoCache)
 	case *result.CommitMatch:
 		return fromCommit(v, repoCache)
+	case *result.OwnerMatch:
+		return fromOwner(v, repoCache)
 	default:
 		panic(fmt.Sprintf("unknown match type %T", v))
 	}
@@ -431,7 +433,7 @@ func fromMatch(match result.Match, repoCache map[api.RepoID]*types.SearchedRepo)
 func fromFileMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.SearchedRepo) streamhttp.EventMatch {
 	if len(fm.Symbols) > 0 {
 		return fromSymbolMatch(fm, repoCache)
-	} else if len(fm.LineMatches) > 0 {
+	} else if len(fm.LineMatches) > 0 || len(fm.MultilineMatches) > 0 {
 		return fromContentMatch(fm, repoCache)
 	}
 	return fromPathMatch(fm, repoCache)
@@ -444,6 +446,7 @@ func fromPathMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Searche
 		Repository:   string(fm.Repo.Name),
 		RepositoryID: int32(fm.Repo.ID),
 		Commit:       string(fm.CommitID),
+		Owners:       fm.Owners,
 	}
 
 	if r, ok := repoCache[fm.Repo.ID]; ok {
@@ -459,8 +462,10 @@ func fromPathMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Searche
 }
 
 func fromContentMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.SearchedRepo) *streamhttp.EventContentMatch {
-	lineMatches := make([]streamhttp.EventLineMatch, 0, len(fm.LineMatches))
-	for _, lm := range fm.LineMatches {
+	// Multiline matches are sent as the line matches of the lines they span.
+	lms := fm.AllLineMatches()
+	lineMatches := make([]streamhttp.EventLineMatch, 0, len(lms))
+	for _, lm := range lms {
 		lineMatches = append(lineMatches, streamhttp.EventLineMatch{
 			Line:             lm.Preview,
 			LineNumber:       lm.LineNumber,
@@ -474,6 +479,7 @@ func fromContentMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Sear
 		RepositoryID: int32(fm.Repo.ID),
 		Repository:   string(fm.Repo.Name),
 		Commit:       string(fm.CommitID),
+		Owners:       fm.Owners,
 		LineMatches:  lineMatches,
 	}
 
@@ -512,6 +518,7 @@ func fromSymbolMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Searc
 		Repository:   string(fm.Repo.Name),
 		RepositoryID: int32(fm.Repo.ID),
 		Commit:       string(fm.CommitID),
+		Owners:       fm.Owners,
 		Symbols:      symbols,
 	}
 
@@ -579,6 +586,27 @@ func fromCommit(commit *result.CommitMatch, repoCache map[api.RepoID]*types.Sear
 	return commitEvent
 }
 
+func fromOwner(om *result.OwnerMatch, repoCache map[api.RepoID]*types.SearchedRepo) *streamhttp.EventOwnerMatch {
+	ownerEvent := &streamhttp.EventOwnerMatch{
+		Type:         streamhttp.OwnerMatchType,
+		Owners:       om.Owners,
+		Repository:   string(om.Repo.Name),
+		RepositoryID: int32(om.Repo.ID),
+		Commit:       string(om.CommitID),
+	}
+
+	if r, ok := repoCache[om.Repo.ID]; ok {
+		ownerEvent.RepoStars = r.Stars
+		ownerEvent.RepoLastFetched = r.LastFetched
+	}
+
+	if om.InputRev != nil {
+		ownerEvent.Branches = []string{*om.InputRev}
+	}
+
+	return ownerEvent
+}
+
 // eventStreamOTHook returns a StatHook which logs to log.
 func eventStreamOTHook(log func(...otlog.Field)) func(streamhttp.WriterStat) {
 	return func(stat streamhttp.WriterStat) {
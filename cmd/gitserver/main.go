@@ -31,6 +31,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gomodules/proxy"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/npmpackages/npm"
 	"github.com/sourcegraph/sourcegraph/internal/hostname"
 	"github.com/sourcegraph/sourcegraph/internal/jsonc"
 	"github.com/sourcegraph/sourcegraph/internal/logging"
@@ -41,6 +43,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
 	"github.com/sourcegraph/sourcegraph/internal/tracer"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -133,21 +136,8 @@ func main() {
 			case extsvc.TypePerforce:
 				// Extract options from external service config
 				var c schema.PerforceConnection
-				for _, info := range r.Sources {
-					es, err := externalServiceStore.GetByID(ctx, info.ExternalServiceID())
-					if err != nil {
-						return nil, errors.Wrap(err, "get external service")
-					}
-
-					normalized, err := jsonc.Parse(es.Config)
-					if err != nil {
-						return nil, errors.Wrap(err, "normalize JSON")
-					}
-
-					if err = jsoniter.Unmarshal(normalized, &c); err != nil {
-						return nil, errors.Wrap(err, "unmarshal JSON")
-					}
-					break
+				if err := unmarshalSourceConfig(ctx, externalServiceStore, r, &c); err != nil {
+					return nil, err
 				}
 
 				return &server.PerforceDepotSyncer{
@@ -156,24 +146,31 @@ func main() {
 				}, nil
 			case extsvc.TypeJVMPackages:
 				var c schema.JVMPackagesConnection
-				for _, info := range r.Sources {
-					es, err := externalServiceStore.GetByID(ctx, info.ExternalServiceID())
-					if err != nil {
-						return nil, errors.Wrap(err, "get external service")
-					}
-
-					normalized, err := jsonc.Parse(es.Config)
-					if err != nil {
-						return nil, errors.Wrap(err, "normalize JSON")
-					}
-
-					if err = jsoniter.Unmarshal(normalized, &c); err != nil {
-						return nil, errors.Wrap(err, "unmarshal JSON")
-					}
-					break
+				if err := unmarshalSourceConfig(ctx, externalServiceStore, r, &c); err != nil {
+					return nil, err
 				}
 
 				return &server.JVMPackagesSyncer{Config: &c, DBStore: codeintelDB}, nil
+			case extsvc.TypeNpmPackages:
+				var c schema.NpmPackagesConnection
+				if err := unmarshalSourceConfig(ctx, externalServiceStore, r, &c); err != nil {
+					return nil, err
+				}
+				client, err := npm.NewHTTPClient(&c)
+				if err != nil {
+					return nil, err
+				}
+				return server.NewNpmPackagesSyncer(&c, codeintelDB, client), nil
+			case extsvc.TypeGoModules:
+				var c schema.GoModulesConnection
+				if err := unmarshalSourceConfig(ctx, externalServiceStore, r, &c); err != nil {
+					return nil, err
+				}
+				client, err := proxy.NewHTTPClient(&c)
+				if err != nil {
+					return nil, err
+				}
+				return server.NewGoModulesSyncer(&c, codeintelDB, client), nil
 			}
 			return &server.GitRepoSyncer{}, nil
 		},
@@ -246,6 +243,28 @@ func main() {
 	gitserver.Stop()
 }
 
+// unmarshalSourceConfig unmarshals the config of the first external service
+// that r is a source of into c.
+func unmarshalSourceConfig(ctx context.Context, externalServiceStore *database.ExternalServiceStore, r *types.Repo, c interface{}) error {
+	for _, info := range r.Sources {
+		es, err := externalServiceStore.GetByID(ctx, info.ExternalServiceID())
+		if err != nil {
+			return errors.Wrap(err, "get external service")
+		}
+
+		normalized, err := jsonc.Parse(es.Config)
+		if err != nil {
+			return errors.Wrap(err, "normalize JSON")
+		}
+
+		if err = jsoniter.Unmarshal(normalized, c); err != nil {
+			return errors.Wrap(err, "unmarshal JSON")
+		}
+		break
+	}
+	return nil
+}
+
 func getPercent(p int) (int, error) {
 	if p < 0 {
 		return 0, errors.Errorf("negative value given for percentage: %d", p)
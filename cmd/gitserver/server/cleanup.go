@@ -77,12 +77,13 @@ const reposStatsName = "repos-stats.json"
 //
 // 1. Compute the amount of space used by the repo
 // 2. Remove corrupt repos.
-// 3. Remove stale lock files.
-// 4. Ensure correct git attributes
-// 5. Scrub remote URLs
-// 6. Perform garbage collection
-// 7. Re-clone repos after a while. (simulate git gc)
-// 8. Remove repos based on disk pressure.
+// 3. Remove repos which moved to another gitserver, if rebalancing.
+// 4. Remove stale lock files.
+// 5. Ensure correct git attributes
+// 6. Scrub remote URLs
+// 7. Perform garbage collection
+// 8. Re-clone repos after a while. (simulate git gc)
+// 9. Remove repos based on disk pressure.
 func (s *Server) cleanupRepos() {
 	janitorRunning.Set(1)
 	defer janitorRunning.Set(0)
@@ -115,11 +116,33 @@ func (s *Server) cleanupRepos() {
 		return true, nil
 	}
 
+	rebalanceAddrs := s.rebalanceAddrs()
+	maybeRemoveMovedAway := func(dir GitDir) (done bool, err error) {
+		if rebalanceAddrs == nil {
+			return false, nil
+		}
+
+		movedAway, removed, err := s.maybeRemoveMovedAway(bCtx, dir, rebalanceAddrs)
+		if movedAway && !removed {
+			stats.RebalanceMovedAway++
+		}
+		if removed {
+			reposRemoved.Inc()
+		}
+		return removed, err
+	}
+
 	ensureGitAttributes := func(dir GitDir) (done bool, err error) {
 		return false, setGitAttributes(dir)
 	}
 
 	scrubRemoteURL := func(dir GitDir) (done bool, err error) {
+		// The origin remote of partial clones is their promisor remote,
+		// which never has a URL stored on disk.
+		if isPartialClone(dir) {
+			return false, nil
+		}
+
 		cmd := exec.Command("git", "remote", "remove", "origin")
 		dir.Set(cmd)
 		// ignore error since we fail if the remote has already been scrubbed.
@@ -236,6 +259,9 @@ func (s *Server) cleanupRepos() {
 		{"compute statistics", computeStats},
 		// Do some sanity checks on the repository.
 		{"maybe remove corrupt", maybeRemoveCorrupt},
+		// Once the gitserver a repo moved to has copied it, we no longer
+		// need our clone.
+		{"maybe remove moved away", maybeRemoveMovedAway},
 		// If git is interrupted it can leave lock files lying around. It does not clean
 		// these up, and instead fails commands.
 		{"remove stale locks", removeStaleLocks},
@@ -711,9 +737,17 @@ func checkMaybeCorruptRepo(repo api.RepoName, dir GitDir, stderr string) {
 // gitGC will invoke `git-gc` to clean up any garbage in the repo. It will
 // operate synchronously and be aggressive with its internal heurisitcs when
 // deciding to act (meaning it will act now at lower thresholds).
+//
+// In partial clones, git-gc also consolidates the promisor packs that are
+// created for every lazy fetch of missing objects. It can't fetch missing
+// objects itself, since the janitor doesn't pass the remote URL to git, see
+// disableLazyFetch.
 func gitGC(dir GitDir) error {
 	cmd := exec.Command("git", "-c", "gc.auto=1", "-c", "gc.autoDetach=false", "gc", "--auto")
 	dir.Set(cmd)
+	if isPartialClone(dir) {
+		disableLazyFetch(cmd)
+	}
 	err := cmd.Run()
 	if err != nil {
 		return errors.Wrapf(wrapCmdError(cmd, err), "failed to git-gc")
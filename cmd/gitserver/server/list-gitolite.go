@@ -9,7 +9,8 @@ import (
 )
 
 func (s *Server) handleListGitolite(w http.ResponseWriter, r *http.Request) {
-	defaultGitolite.listRepos(r.Context(), r.URL.Query().Get("gitolite"), w)
+	q := r.URL.Query()
+	defaultGitolite.listRepos(r.Context(), q.Get("gitolite"), q.Get("user"), w)
 }
 
 var defaultGitolite = gitoliteFetcher{client: gitoliteClient{}}
@@ -19,18 +20,20 @@ type gitoliteFetcher struct {
 }
 
 type iGitoliteClient interface {
-	ListRepos(ctx context.Context, host string) ([]*gitolite.Repo, error)
+	ListRepos(ctx context.Context, host, user string) ([]*gitolite.Repo, error)
 }
 
-// listRepos lists the repos of a Gitolite server reachable at the address in gitoliteHost
-func (g gitoliteFetcher) listRepos(ctx context.Context, gitoliteHost string, w http.ResponseWriter) {
+// listRepos lists the repos of a Gitolite server reachable at the address in
+// gitoliteHost. If user is non-empty, only the repos user has read access to
+// are listed.
+func (g gitoliteFetcher) listRepos(ctx context.Context, gitoliteHost, user string, w http.ResponseWriter) {
 	var (
 		repos = []*gitolite.Repo{}
 		err   error
 	)
 
 	if gitoliteHost != "" {
-		if repos, err = g.client.ListRepos(ctx, gitoliteHost); err != nil {
+		if repos, err = g.client.ListRepos(ctx, gitoliteHost, user); err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
@@ -44,6 +47,9 @@ func (g gitoliteFetcher) listRepos(ctx context.Context, gitoliteHost string, w h
 
 type gitoliteClient struct{}
 
-func (c gitoliteClient) ListRepos(ctx context.Context, host string) ([]*gitolite.Repo, error) {
+func (c gitoliteClient) ListRepos(ctx context.Context, host, user string) ([]*gitolite.Repo, error) {
+	if user != "" {
+		return gitolite.NewClient(host).ListReposForUser(ctx, user)
+	}
 	return gitolite.NewClient(host).ListRepos(ctx)
 }
@@ -17,6 +17,7 @@ func Test_Gitolite_listRepos(t *testing.T) {
 		listRepos       map[string][]*gitolite.Repo
 		configs         []*schema.GitoliteConnection
 		gitoliteHost    string
+		user            string
 		expResponseCode int
 		expResponseBody string
 	}{
@@ -36,19 +37,36 @@ func Test_Gitolite_listRepos(t *testing.T) {
 			expResponseCode: 200,
 			expResponseBody: `[{"Name":"myrepo","URL":"git@gitolite.example.com:myrepo"}]` + "\n",
 		},
+		{
+			listRepos: map[string][]*gitolite.Repo{
+				"git@gitolite.example.com": {
+					{Name: "myrepo", URL: "git@gitolite.example.com:myrepo"},
+				},
+				"git@gitolite.example.com alice": {
+					{Name: "alicerepo", URL: "git@gitolite.example.com:alicerepo"},
+				},
+			},
+			gitoliteHost:    "git@gitolite.example.com",
+			user:            "alice",
+			expResponseCode: 200,
+			expResponseBody: `[{"Name":"alicerepo","URL":"git@gitolite.example.com:alicerepo"}]` + "\n",
+		},
 	}
 
 	for _, test := range tests {
 		t.Run("", func(t *testing.T) {
 			g := gitoliteFetcher{
 				client: stubGitoliteClient{
-					ListRepos_: func(ctx context.Context, host string) ([]*gitolite.Repo, error) {
+					ListRepos_: func(ctx context.Context, host, user string) ([]*gitolite.Repo, error) {
+						if user != "" {
+							host += " " + user
+						}
 						return test.listRepos[host], nil
 					},
 				},
 			}
 			w := httptest.NewRecorder()
-			g.listRepos(context.Background(), test.gitoliteHost, w)
+			g.listRepos(context.Background(), test.gitoliteHost, test.user, w)
 			resp := w.Result()
 			respBody, err := io.ReadAll(resp.Body)
 			if err != nil {
@@ -65,9 +83,9 @@ func Test_Gitolite_listRepos(t *testing.T) {
 }
 
 type stubGitoliteClient struct {
-	ListRepos_ func(ctx context.Context, host string) ([]*gitolite.Repo, error)
+	ListRepos_ func(ctx context.Context, host, user string) ([]*gitolite.Repo, error)
 }
 
-func (c stubGitoliteClient) ListRepos(ctx context.Context, host string) ([]*gitolite.Repo, error) {
-	return c.ListRepos_(ctx, host)
+func (c stubGitoliteClient) ListRepos(ctx context.Context, host, user string) ([]*gitolite.Repo, error) {
+	return c.ListRepos_(ctx, host, user)
 }
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// partialCloneRemote is the name of the promisor remote of partial clones.
+// Its URL is never written to disk, see setPromisorRemoteURL.
+const partialCloneRemote = "origin"
+
+type partialCloneMapping struct {
+	pattern *regexp.Regexp
+	filter  string
+}
+
+var gitPartialClone = conf.Cached(func() interface{} {
+	return buildPartialCloneMappings(conf.ExperimentalFeatures().GitPartialClone)
+})
+
+func buildPartialCloneMappings(c []*schema.GitPartialCloneMapping) []partialCloneMapping {
+	mappings := make([]partialCloneMapping, 0, len(c))
+	for _, m := range c {
+		re, err := regexp.Compile(m.Pattern)
+		if err != nil {
+			log15.Warn("ignoring invalid gitPartialClone pattern", "pattern", m.Pattern, "error", err)
+			continue
+		}
+		mappings = append(mappings, partialCloneMapping{pattern: re, filter: m.Filter})
+	}
+	return mappings
+}
+
+// partialCloneFilter returns the object filter to clone the repository at
+// remoteURL with, or an empty string if it should be cloned in full.
+func partialCloneFilter(remoteURL *vcs.URL) string {
+	mappings := gitPartialClone().([]partialCloneMapping)
+	if len(mappings) == 0 {
+		return ""
+	}
+
+	dp := path.Join(remoteURL.Host, remoteURL.Path)
+	for _, m := range mappings {
+		if m.pattern.MatchString(dp) {
+			return m.filter
+		}
+	}
+	return ""
+}
+
+// configurePartialClone sets up the freshly initialized repository at dir as a
+// partial clone that fetches objects from partialCloneRemote, skipping those
+// excluded by filter.
+func configurePartialClone(dir GitDir, filter string) error {
+	for _, kv := range [][2]string{
+		// Extensions are only honoured by version 1 repositories.
+		{"core.repositoryFormatVersion", "1"},
+		{"extensions.partialClone", partialCloneRemote},
+		{"remote." + partialCloneRemote + ".promisor", "true"},
+		{"remote." + partialCloneRemote + ".partialCloneFilter", filter},
+	} {
+		if err := gitConfigSet(dir, kv[0], kv[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isPartialClone returns whether the repository at dir is a partial clone.
+//
+// This is called for every exec request, so we read the config file directly
+// rather than shelling out to git config.
+func isPartialClone(dir GitDir) bool {
+	b, err := os.ReadFile(dir.Path("config"))
+	if err != nil {
+		return false
+	}
+
+	section := ""
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if strings.HasPrefix(line, "[") {
+			section = strings.ToLower(strings.Trim(line, "[] \t"))
+			continue
+		}
+		if section != "extensions" {
+			continue
+		}
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) == 2 && strings.EqualFold(strings.TrimSpace(kv[0]), "partialClone") && strings.TrimSpace(kv[1]) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// setPromisorRemoteURL configures the git command cmd, which runs in a partial
+// clone, to fetch missing objects from remoteURL. The URL is passed as a
+// config option on the command line, so that credentials contained in it are
+// never written to the repository config. Like the URL passed to git fetch,
+// it is visible in the arguments of the process.
+//
+// We don't use the GIT_CONFIG_COUNT environment variables, since they need
+// git 2.31, which is newer than the git shipped in our images.
+func setPromisorRemoteURL(cmd *exec.Cmd, remoteURL *vcs.URL) {
+	args := make([]string, 0, len(cmd.Args)+2)
+	args = append(args, cmd.Args[0], "-c", "remote."+partialCloneRemote+".url="+remoteURL.String())
+	cmd.Args = append(args, cmd.Args[1:]...)
+}
+
+// remoteURLCacheTTL is how long the remote URLs of partial clones are cached
+// for lazy fetches.
+const remoteURLCacheTTL = time.Minute
+
+// remoteURLCacheSize bounds the number of cached remote URLs.
+const remoteURLCacheSize = 1000
+
+// remoteURLCache caches the remote URLs of partial clones, so that exec
+// doesn't look up the remote URL of the repository on every request. The
+// zero value is an empty cache.
+type remoteURLCache struct {
+	mu      sync.Mutex
+	entries map[api.RepoName]remoteURLCacheEntry
+}
+
+type remoteURLCacheEntry struct {
+	url     *vcs.URL
+	expires time.Time
+}
+
+// get returns the cached remote URL of repo, calling fetch if it isn't
+// cached or has expired. Errors are not cached.
+func (c *remoteURLCache) get(ctx context.Context, repo api.RepoName, fetch func(context.Context, api.RepoName) (*vcs.URL, error)) (*vcs.URL, error) {
+	now := time.Now()
+
+	c.mu.Lock()
+	e, ok := c.entries[repo]
+	c.mu.Unlock()
+	if ok && now.Before(e.expires) {
+		return e.url, nil
+	}
+
+	u, err := fetch(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if c.entries == nil || len(c.entries) >= remoteURLCacheSize {
+		c.entries = make(map[api.RepoName]remoteURLCacheEntry)
+	}
+	c.entries[repo] = remoteURLCacheEntry{url: u, expires: now.Add(remoteURLCacheTTL)}
+	c.mu.Unlock()
+
+	return u, nil
+}
+
+// configureLazyFetch configures cmd, which runs in the partial clone of repo,
+// to fetch objects that are missing locally from the code host. If we can't
+// determine the remote URL of repo, cmd fails on missing objects instead.
+func (s *Server) configureLazyFetch(ctx context.Context, repo api.RepoName, cmd *exec.Cmd) {
+	remoteURL, err := s.partialCloneURLs.get(ctx, repo, s.getRemoteURL)
+	if err != nil {
+		log15.Warn("failed to get remote URL of partial clone, disabling lazy fetch", "repo", repo, "error", err)
+		disableLazyFetch(cmd)
+		return
+	}
+
+	configureRemoteGitCommand(cmd, tlsExternal().(*tlsConfig))
+	setPromisorRemoteURL(cmd, remoteURL)
+}
+
+// disableLazyFetch configures cmd, which runs in a partial clone, to fail
+// instead of fetching missing objects from the code host.
+//
+// The promisor remote has no URL on disk, so any git fails to fetch missing
+// objects without setPromisorRemoteURL. GIT_NO_LAZY_FETCH, which is honoured
+// by git 2.44 and later, additionally stops git from trying.
+func disableLazyFetch(cmd *exec.Cmd) {
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, "GIT_NO_LAZY_FETCH=1")
+}
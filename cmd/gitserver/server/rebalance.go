@@ -0,0 +1,248 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+)
+
+var (
+	reposCopiedFromPeer = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_repos_copied_from_peer",
+		Help: "number of repos copied from another gitserver while rebalancing",
+	})
+	reposRemovedMovedAway = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_repos_removed_moved_away",
+		Help: "number of repos removed because they moved to another gitserver while rebalancing",
+	})
+)
+
+// defaultRebalanceMaxConcurrentCopies is the default maximum number of
+// repositories copied between all gitservers at the same time.
+const defaultRebalanceMaxConcurrentCopies = 10
+
+// rebalancingEnabled returns whether repositories that moved between
+// gitservers are copied from the gitserver that held them before.
+func rebalancingEnabled() bool {
+	c := conf.ExperimentalFeatures().GitServerRebalancing
+	return c != nil && c.Enabled
+}
+
+// rebalanceCopyLimit returns the maximum number of repositories this
+// gitserver copies from other gitservers at the same time. The configured
+// limit applies to all gitservers, so each of them gets an equal share.
+func rebalanceCopyLimit(addrs []string) int {
+	limit := defaultRebalanceMaxConcurrentCopies
+	if c := conf.ExperimentalFeatures().GitServerRebalancing; c != nil && c.MaxConcurrentCopies > 0 {
+		limit = c.MaxConcurrentCopies
+	}
+	if len(addrs) > 1 {
+		limit /= len(addrs)
+	}
+	if limit < 1 {
+		limit = 1
+	}
+	return limit
+}
+
+// rebalanceAddrs returns the gitserver addresses to rebalance repositories
+// across, or nil if rebalancing is disabled. It also returns nil if this
+// gitserver isn't one of them, since we then can't tell which repositories
+// belong to it.
+func (s *Server) rebalanceAddrs() []string {
+	if !rebalancingEnabled() {
+		return nil
+	}
+	addrs := conf.Get().ServiceConnections.GitServers
+	for _, addr := range addrs {
+		if s.hostnameMatch(addr) {
+			return addrs
+		}
+	}
+	return nil
+}
+
+// ownsRepo returns whether repo belongs on this gitserver, either as its
+// primary copy or as a replica.
+func (s *Server) ownsRepo(repo api.RepoName, addrs []string) bool {
+	replicationFactor := conf.ExperimentalFeatures().GitServerReplicationFactor
+	for _, addr := range gitserver.AddrsForRepo(repo, addrs, replicationFactor) {
+		if s.hostnameMatch(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// peerHasClone returns whether the gitserver at addr has a clone of repo.
+func peerHasClone(ctx context.Context, addr string, repo api.RepoName) (bool, error) {
+	b, err := json.Marshal(&protocol.IsRepoClonedRequest{Repo: repo})
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequest("POST", "http://"+addr+"/is-repo-cloned", bytes.NewReader(b))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpcli.InternalDoer.Do(req.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, errors.Errorf("unexpected status code %d from %s", resp.StatusCode, addr)
+	}
+}
+
+// findPeerClone returns the address of another gitserver that has a clone of
+// repo, or an empty string if there is none.
+func (s *Server) findPeerClone(ctx context.Context, repo api.RepoName, addrs []string) string {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		found string
+	)
+	for _, addr := range addrs {
+		if s.hostnameMatch(addr) {
+			continue
+		}
+		wg.Add(1)
+		go func(addr string) {
+			defer wg.Done()
+			cloned, err := peerHasClone(ctx, addr, repo)
+			if err != nil {
+				log15.Debug("failed to check whether gitserver has a clone", "addr", addr, "repo", repo, "error", err)
+				return
+			}
+			if !cloned {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if found == "" {
+				found = addr
+				cancel()
+			}
+		}(addr)
+	}
+	wg.Wait()
+	return found
+}
+
+// copyFromPeer copies repo into the empty directory tmpPath from another
+// gitserver that has a clone of it, fetching over that gitserver's git
+// service rather than from the code host. It returns the URL it copied from,
+// or nil if rebalancing is disabled or no other gitserver has a clone.
+func (s *Server) copyFromPeer(ctx context.Context, repo api.RepoName, lock *RepositoryLock, tmpPath string) (*vcs.URL, error) {
+	addrs := s.rebalanceAddrs()
+	if len(addrs) < 2 {
+		return nil, nil
+	}
+
+	addr := s.findPeerClone(ctx, repo, addrs)
+	if addr == "" {
+		return nil, nil
+	}
+
+	if s.rebalanceLimiter != nil {
+		var (
+			cancel context.CancelFunc
+			err    error
+		)
+		ctx, cancel, err = s.rebalanceLimiter.Acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer cancel()
+	}
+
+	atomic.AddInt64(&s.rebalanceCopying, 1)
+	defer atomic.AddInt64(&s.rebalanceCopying, -1)
+
+	peerURL, err := vcs.ParseURL("http://" + addr + "/git/" + string(repo))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(tmpPath, os.ModePerm); err != nil {
+		return nil, errors.Wrapf(err, "copy failed to create tmp dir")
+	}
+	cmd := exec.CommandContext(ctx, "git", "init", "--bare", ".")
+	cmd.Dir = tmpPath
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "copy setup failed")
+	}
+
+	log15.Info("copying repo from other gitserver", "repo", repo, "addr", addr, "tmp", tmpPath)
+
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	go readCloneProgress(newURLRedactor(peerURL), lock, pr)
+
+	// The clone on the other gitserver already only contains the refs we
+	// fetch from code hosts, so we copy all of them.
+	cmd = exec.CommandContext(ctx, "git", "fetch", "--progress", peerURL.String(), "+refs/*:refs/*")
+	cmd.Dir = tmpPath
+	if output, err := runWith(ctx, cmd, false, pw); err != nil {
+		return nil, errors.Wrapf(err, "copy from %s failed. Output: %s", addr, string(output))
+	}
+
+	atomic.AddInt64(&s.rebalanceCopied, 1)
+	reposCopiedFromPeer.Inc()
+	return peerURL, nil
+}
+
+// maybeRemoveMovedAway removes the clone of repo at dir if repo no longer
+// belongs on this gitserver and the gitserver it belongs to has copied it.
+// It returns whether repo has moved away, and whether its clone was removed.
+func (s *Server) maybeRemoveMovedAway(ctx context.Context, dir GitDir, addrs []string) (movedAway, removed bool, err error) {
+	repo := s.name(dir)
+	if s.ownsRepo(repo, addrs) {
+		return false, false, nil
+	}
+
+	// We only remove our clone once the new primary gitserver has a copy,
+	// so that it can still copy it from us.
+	addr := gitserver.AddrForRepo(repo, addrs)
+	cloned, err := peerHasClone(ctx, addr, repo)
+	if err != nil || !cloned {
+		return true, false, err
+	}
+
+	log15.Info("removing repo which moved to another gitserver", "repo", repo, "addr", addr)
+	if err := s.removeRepoDirectory(dir); err != nil {
+		return true, false, err
+	}
+	reposRemovedMovedAway.Inc()
+	return true, true, nil
+}
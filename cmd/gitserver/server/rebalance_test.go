@@ -0,0 +1,145 @@
+package server
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestRebalance(t *testing.T) {
+	ctx := context.Background()
+
+	remote := t.TempDir()
+	remoteCmd := func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, remote, name, arg...)
+	}
+	oldCommit := makeSingleCommitRepo(remoteCmd)
+
+	startServer := func(t *testing.T) (*Server, string) {
+		t.Helper()
+
+		srv := httptest.NewUnstartedServer(nil)
+		s := &Server{
+			ReposDir:         t.TempDir(),
+			GetRemoteURLFunc: staticGetRemoteURL(remote),
+			GetVCSSyncer: func(ctx context.Context, name api.RepoName) (VCSSyncer, error) {
+				return &GitRepoSyncer{}, nil
+			},
+			Hostname:   srv.Listener.Addr().String(),
+			CloneQueue: NewCloneQueue(list.New()),
+		}
+		srv.Config.Handler = s.Handler()
+		srv.Start()
+		t.Cleanup(srv.Close)
+		return s, s.Hostname
+	}
+
+	oldServer, oldAddr := startServer(t)
+	newServer, newAddr := startServer(t)
+	addrs := []string{oldAddr, newAddr}
+
+	conf.Mock(&conf.Unified{
+		SiteConfiguration: schema.SiteConfiguration{
+			ExperimentalFeatures: &schema.ExperimentalFeatures{
+				GitServerRebalancing: &schema.GitServerRebalancing{Enabled: true},
+			},
+		},
+		ServiceConnections: conftypes.ServiceConnections{GitServers: addrs},
+	})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	// Pick a repo that belongs on the new gitserver, but was cloned by the
+	// old one before the addresses changed.
+	var repo api.RepoName
+	for i := 0; repo == ""; i++ {
+		if name := api.RepoName(fmt.Sprintf("example.com/foo/bar-%d", i)); gitserver.AddrForRepo(name, addrs) == newAddr {
+			repo = name
+		}
+	}
+	if _, err := oldServer.cloneRepo(ctx, repo, &cloneOptions{Block: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// If the new gitserver cloned from the code host, it would get this commit.
+	remoteCmd("git", "commit", "--allow-empty", "-m", "new commit")
+
+	// The old gitserver keeps its clone until the new one has copied it.
+	oldServer.cleanupRepos()
+	if !repoCloned(oldServer.dir(repo)) {
+		t.Fatal("expected old gitserver to keep its clone")
+	}
+
+	if _, err := newServer.cloneRepo(ctx, repo, &cloneOptions{Block: true}); err != nil {
+		t.Fatal(err)
+	}
+	newCmd := func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, filepath.Dir(string(newServer.dir(repo))), name, arg...)
+	}
+	if have := newCmd("git", "rev-parse", "HEAD"); have != oldCommit {
+		t.Fatalf("expected repo to be copied from the old gitserver: have HEAD %s, want %s", have, oldCommit)
+	}
+
+	resp, err := http.Get("http://" + newAddr + "/repos-stats")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var stats protocol.ReposStats
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		t.Fatal(err)
+	}
+	if stats.RebalanceCopied != 1 || stats.RebalanceCopying != 0 {
+		t.Fatalf("unexpected rebalance stats: copied %d, copying %d", stats.RebalanceCopied, stats.RebalanceCopying)
+	}
+
+	oldServer.cleanupRepos()
+	if repoCloned(oldServer.dir(repo)) {
+		t.Fatal("expected old gitserver to remove its clone")
+	}
+}
+
+func TestIsReplica(t *testing.T) {
+	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+	repo := api.RepoName("repo1")
+	shards := gitserver.AddrsForRepo(repo, addrs, 2)
+
+	mockConf := func(replicationFactor int) {
+		conf.Mock(&conf.Unified{
+			SiteConfiguration: schema.SiteConfiguration{
+				ExperimentalFeatures: &schema.ExperimentalFeatures{
+					GitServerReplicationFactor: replicationFactor,
+				},
+			},
+			ServiceConnections: conftypes.ServiceConnections{GitServers: addrs},
+		})
+	}
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	mockConf(2)
+	for _, addr := range addrs {
+		s := &Server{Hostname: addr}
+		want := addr == shards[1]
+		if got := s.isReplica(repo); got != want {
+			t.Errorf("%s: isReplica = %v, want %v", addr, got, want)
+		}
+	}
+
+	mockConf(1)
+	if s := (&Server{Hostname: shards[1]}); s.isReplica(repo) {
+		t.Error("isReplica = true without replication")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 
 	"github.com/cockroachdb/errors"
 	"github.com/inconshreveable/log15"
@@ -113,8 +114,21 @@ func (s *Server) handleReposStats(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var stats protocol.ReposStats
+	if err := json.Unmarshal(b, &stats); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode %s: %v", reposStatsName, err.Error()), http.StatusInternalServerError)
+		return
+	}
+
+	// The progress of copies is tracked in memory rather than by the
+	// janitor, since it changes between janitor runs.
+	stats.RebalanceCopying = atomic.LoadInt64(&s.rebalanceCopying)
+	stats.RebalanceCopied = atomic.LoadInt64(&s.rebalanceCopied)
+
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	_, _ = w.Write(b)
+	if err := json.NewEncoder(w).Encode(&stats); err != nil {
+		log15.Error("failed to encode repos stats", "error", err)
+	}
 }
 
 func (s *Server) handleRepoCloneProgress(w http.ResponseWriter, r *http.Request) {
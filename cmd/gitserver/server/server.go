@@ -238,6 +238,20 @@ type Server struct {
 	// per gitserver instance
 	rpsLimiter *rate.Limiter
 
+	// partialCloneURLs caches the remote URLs used for lazy fetches in
+	// partial clones.
+	partialCloneURLs remoteURLCache
+
+	// rebalanceLimiter limits the number of repositories copied from other
+	// gitservers at the same time while rebalancing.
+	rebalanceLimiter *mutablelimiter.Limiter
+
+	// rebalanceCopying and rebalanceCopied count the repositories being
+	// copied and copied from other gitservers while rebalancing. They are
+	// accessed atomically.
+	rebalanceCopying int64
+	rebalanceCopied  int64
+
 	repoUpdateLocksMu sync.Mutex // protects the map below and also updates to locks.once
 	repoUpdateLocks   map[api.RepoName]*locks
 }
@@ -313,6 +327,13 @@ func (s *Server) Handler() http.Handler {
 		s.cloneableLimiter.SetLimit(limit)
 	})
 
+	// The limit on concurrent copies while rebalancing applies to all
+	// gitservers, so it changes with the number of gitservers too.
+	s.rebalanceLimiter = mutablelimiter.New(rebalanceCopyLimit(conf.Get().ServiceConnections.GitServers))
+	conf.Watch(func() {
+		s.rebalanceLimiter.SetLimit(rebalanceCopyLimit(conf.Get().ServiceConnections.GitServers))
+	})
+
 	s.rpsLimiter = rate.NewLimiter(rate.Inf, 10)
 	setRPSLimiter := func() {
 		if maxRequestsPerSecond := conf.GitMaxCodehostRequestsPerSecond(); maxRequestsPerSecond == -1 {
@@ -371,17 +392,22 @@ func (s *Server) Janitor(interval time.Duration) {
 
 // SyncRepoState syncs state on disk to the database for all repos and is
 // expected to run in a background goroutine. We perform a full sync if the known
-// gitserver addresses has changed since the last run. Otherwise, we only sync
-// repos that have not yet been assigned a shard.
+// gitserver addresses or the replication factor have changed since the last
+// run. Otherwise, we only sync repos that have not yet been assigned a shard.
 func (s *Server) SyncRepoState(interval time.Duration, batchSize, perSecond int) {
-	var previousAddrs string
+	var (
+		previousAddrs             string
+		previousReplicationFactor int
+	)
 	for {
 		addrs := conf.Get().ServiceConnections.GitServers
 		// We turn addrs into a string here for easy comparison and storage of previous
 		// addresses since we'd need to take a copy of the slice anyway.
 		currentAddrs := strings.Join(addrs, ",")
-		fullSync := currentAddrs != previousAddrs
+		replicationFactor := conf.ExperimentalFeatures().GitServerReplicationFactor
+		fullSync := currentAddrs != previousAddrs || replicationFactor != previousReplicationFactor
 		previousAddrs = currentAddrs
+		previousReplicationFactor = replicationFactor
 
 		if err := s.syncRepoState(addrs, batchSize, perSecond, fullSync); err != nil {
 			log15.Error("Syncing repo state", "error ", err)
@@ -498,6 +524,9 @@ func (s *Server) syncRepoState(addrs []string, batchSize, perSecond int, fullSyn
 	//
 	// When fullSync is false, we assume that we only need to check repos that have
 	// not yet had their shard_id allocated.
+	//
+	// Replicas don't own the database state of their repos, so for those we
+	// only make sure that they are cloned.
 
 	// Sanity check our host exists in addrs before starting any work
 	var found bool
@@ -556,6 +585,9 @@ func (s *Server) syncRepoState(addrs []string, batchSize, perSecond int, fullSyn
 		return errors.Wrap(err, "counting repos")
 	}
 
+	replicationFactor := conf.ExperimentalFeatures().GitServerReplicationFactor
+	var missingReplicas []api.RepoName
+
 	var count int
 	options := database.IterateRepoGitserverStatusOptions{}
 	if !fullSync {
@@ -567,7 +599,17 @@ func (s *Server) syncRepoState(addrs []string, batchSize, perSecond int, fullSyn
 
 		repoSyncStateCounter.WithLabelValues("check").Inc()
 		// Ensure we're only dealing with repos we are responsible for
-		if addr := gitserver.AddrForRepo(repo.Name, addrs); !s.hostnameMatch(addr) {
+		shards := gitserver.AddrsForRepo(repo.Name, addrs, replicationFactor)
+		if !s.hostnameMatch(shards[0]) {
+			for _, addr := range shards[1:] {
+				if s.hostnameMatch(addr) {
+					repoSyncStateCounter.WithLabelValues("replica").Inc()
+					if !repoCloned(s.dir(repo.Name)) {
+						missingReplicas = append(missingReplicas, repo.Name)
+					}
+					return nil
+				}
+			}
 			repoSyncStateCounter.WithLabelValues("other_shard").Inc()
 			return nil
 		}
@@ -610,6 +652,14 @@ func (s *Server) syncRepoState(addrs []string, batchSize, perSecond int, fullSyn
 	// Attempt final write
 	writeBatch()
 
+	// Clone the replicas we're missing. cloneRepo doesn't block, and skips
+	// the repos which are already being cloned.
+	for _, repo := range missingReplicas {
+		if _, cloneErr := s.cloneRepo(ctx, repo, nil); cloneErr != nil {
+			log15.Warn("Cloning missing replica", "repo", repo, "error", cloneErr)
+		}
+	}
+
 	return err
 }
 
@@ -1260,6 +1310,9 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 	cmdStart = time.Now()
 	cmd := exec.CommandContext(ctx, "git", req.Args...)
 	dir.Set(cmd)
+	if isPartialClone(dir) {
+		s.configureLazyFetch(ctx, req.Repo, cmd)
+	}
 	cmd.Stdout = stdoutW
 	cmd.Stderr = stderrW
 
@@ -1438,15 +1491,36 @@ func (s *Server) p4exec(w http.ResponseWriter, r *http.Request, req *protocol.P4
 	w.Header().Set("X-Exec-Stderr", stderr)
 }
 
+// isReplica returns whether this gitserver holds a replica of name rather
+// than its primary copy. The database state of a repo describes its primary
+// copy, so replicas must not write it.
+func (s *Server) isReplica(name api.RepoName) bool {
+	replicationFactor := conf.ExperimentalFeatures().GitServerReplicationFactor
+	addrs := conf.Get().ServiceConnections.GitServers
+	if replicationFactor < 2 || len(addrs) < 2 {
+		return false
+	}
+	shards := gitserver.AddrsForRepo(name, addrs, replicationFactor)
+	if s.hostnameMatch(shards[0]) {
+		return false
+	}
+	for _, addr := range shards[1:] {
+		if s.hostnameMatch(addr) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Server) setLastError(ctx context.Context, name api.RepoName, error string) (err error) {
-	if s.DB == nil {
+	if s.DB == nil || s.isReplica(name) {
 		return nil
 	}
 	return database.GitserverRepos(s.DB).SetLastError(ctx, name, error, s.Hostname)
 }
 
 func (s *Server) setLastFetched(ctx context.Context, name api.RepoName) error {
-	if s.DB == nil {
+	if s.DB == nil || s.isReplica(name) {
 		return nil
 	}
 
@@ -1481,7 +1555,7 @@ func (s *Server) setLastErrorNonFatal(ctx context.Context, name api.RepoName, er
 }
 
 func (s *Server) setCloneStatus(ctx context.Context, name api.RepoName, status types.CloneStatus) (err error) {
-	if s.DB == nil {
+	if s.DB == nil || s.isReplica(name) {
 		return nil
 	}
 	return database.GitserverRepos(s.DB).SetCloneStatus(ctx, name, status, s.Hostname)
@@ -1667,25 +1741,42 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir GitDir, syn
 		s.setCloneStatusNonFatal(context.Background(), repo, cloneStatus(repoCloned(dir), false))
 	}()
 
-	cmd, err := syncer.CloneCommand(ctx, remoteURL, tmpPath)
-	if err != nil {
-		return errors.Wrap(err, "get clone command")
-	}
-	if cmd.Env == nil {
-		cmd.Env = os.Environ()
+	// While rebalancing, we copy repositories that moved here from the
+	// gitserver that has them instead of cloning them from the code host.
+	// Re-clones are meant to start from scratch, so they don't copy.
+	var peerURL *vcs.URL
+	if !overwrite {
+		peerURL, err = s.copyFromPeer(ctx, repo, lock, tmpPath)
+		if err != nil {
+			log15.Warn("failed to copy repo from other gitserver, cloning from code host instead", "repo", repo, "error", err)
+			peerURL = nil
+			if err := os.RemoveAll(tmpPath); err != nil {
+				return err
+			}
+		}
 	}
 
-	// see issue #7322: skip LFS content in repositories with Git LFS configured
-	cmd.Env = append(cmd.Env, "GIT_LFS_SKIP_SMUDGE=1")
-	log15.Info("cloning repo", "repo", repo, "tmp", tmpPath, "dst", dstPath)
+	if peerURL == nil {
+		cmd, err := syncer.CloneCommand(ctx, remoteURL, tmpPath)
+		if err != nil {
+			return errors.Wrap(err, "get clone command")
+		}
+		if cmd.Env == nil {
+			cmd.Env = os.Environ()
+		}
+
+		// see issue #7322: skip LFS content in repositories with Git LFS configured
+		cmd.Env = append(cmd.Env, "GIT_LFS_SKIP_SMUDGE=1")
+		log15.Info("cloning repo", "repo", repo, "tmp", tmpPath, "dst", dstPath)
 
-	pr, pw := io.Pipe()
-	defer pw.Close()
+		pr, pw := io.Pipe()
+		defer pw.Close()
 
-	go readCloneProgress(newURLRedactor(remoteURL), lock, pr)
+		go readCloneProgress(newURLRedactor(remoteURL), lock, pr)
 
-	if output, err := runWithRemoteOpts(ctx, cmd, pw); err != nil {
-		return errors.Wrapf(err, "clone failed. Output: %s", string(output))
+		if output, err := runWithRemoteOpts(ctx, cmd, pw); err != nil {
+			return errors.Wrapf(err, "clone failed. Output: %s", string(output))
+		}
 	}
 
 	if testRepoCorrupter != nil {
@@ -1694,7 +1785,12 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir GitDir, syn
 
 	removeBadRefs(ctx, tmp)
 
-	if err := setHEAD(ctx, tmp, syncer, repo, remoteURL); err != nil {
+	headSyncer, headURL := syncer, remoteURL
+	if peerURL != nil {
+		// The clone we copied from knows which branch HEAD points to.
+		headSyncer, headURL = &GitRepoSyncer{}, peerURL
+	}
+	if err := setHEAD(ctx, tmp, headSyncer, repo, headURL); err != nil {
 		log15.Error("Failed to ensure HEAD exists", "repo", repo, "error", err)
 		return errors.Wrap(err, "failed to ensure HEAD exists")
 	}
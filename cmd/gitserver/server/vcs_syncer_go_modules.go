@@ -0,0 +1,192 @@
+package server
+
+import (
+	"archive/zip"
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gomodules/proxy"
+	"github.com/sourcegraph/sourcegraph/internal/repos"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// maxGoModuleZipSize is the maximum size of a module zip file, and of the files
+// extracted from it, as enforced by the go command. See
+// https://golang.org/ref/mod#zip-path-size-constraints.
+const maxGoModuleZipSize = 500 << 20
+
+// NewGoModulesSyncer returns a VCSSyncer for the Go modules configured in
+// config, or referenced by precise code intelligence uploads.
+func NewGoModulesSyncer(config *schema.GoModulesConnection, dbStore repos.DependencyRepoStore, client proxy.Client) *PackagesSyncer {
+	return &PackagesSyncer{
+		typ: "go_modules",
+		source: &goModulesSource{
+			config:  config,
+			dbStore: dbStore,
+			client:  client,
+		},
+	}
+}
+
+type goModulesSource struct {
+	config  *schema.GoModulesConnection
+	dbStore repos.DependencyRepoStore
+	client  proxy.Client
+}
+
+func (s *goModulesSource) dependencies(ctx context.Context, repoURLPath string) ([]packageDependency, error) {
+	mod, err := reposource.ParseGoModuleFromRepoURL(repoURLPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var dependencies []reposource.GoDependency
+	for _, d := range s.config.Dependencies {
+		if !mod.MatchesDependencyString(d) {
+			continue
+		}
+		dependency, err := reposource.ParseGoDependency(d)
+		if err != nil {
+			return nil, err
+		}
+		// Silently ignore non-existent dependencies because they are
+		// already logged out in the `GetRepo` method in
+		// internal/repos/go_modules.go.
+		if exists, err := s.client.DoesDependencyExist(ctx, dependency); err != nil {
+			return nil, err
+		} else if exists {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	totalConfigMatched := len(dependencies)
+
+	// Go modules referenced by precise code intelligence uploads are named
+	// after the URL of their module path.
+	dbDeps, err := s.dbStore.GetDependencyRepos(ctx, dbstore.GetDependencyReposOpts{
+		Scheme: "gomod",
+		Name:   "https://" + mod.Path,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get Go dependency repos from database for %s", repoURLPath)
+	}
+	// We don't check whether these exist, as existence should be verified by
+	// repo-updater.
+	for _, dep := range dbDeps {
+		dependencies = append(dependencies, reposource.GoDependency{GoModule: mod, Version: dep.Version})
+	}
+
+	if len(dependencies) == 0 {
+		return nil, errors.Errorf("no Go dependencies for URL path %s", repoURLPath)
+	}
+
+	log15.Info("fetched Go module for repo path", "repoPath", repoURLPath, "totalDB", len(dbDeps), "totalConfig", totalConfigMatched)
+	reposource.SortGoDependencies(dependencies)
+
+	result := make([]packageDependency, 0, len(dependencies))
+	seen := make(map[string]bool, len(dependencies))
+	for i := range dependencies {
+		if seen[dependencies[i].Version] {
+			continue
+		}
+		seen[dependencies[i].Version] = true
+		result = append(result, &dependencies[i])
+	}
+	return result, nil
+}
+
+func (s *goModulesSource) download(ctx context.Context, dependency packageDependency, dir string) error {
+	dep := *dependency.(*reposource.GoDependency)
+	rc, err := s.client.FetchZip(ctx, dep)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	// zip files can't be read as a stream, so we write them to a temporary
+	// file outside of dir first.
+	f, err := os.CreateTemp("", "gomod-*.zip")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	n, err := io.Copy(f, io.LimitReader(rc, maxGoModuleZipSize+1))
+	if err != nil {
+		return err
+	}
+	if n > maxGoModuleZipSize {
+		return errors.Errorf("zip of Go module %s is larger than %d bytes", dep.PackageManagerSyntax(), maxGoModuleZipSize)
+	}
+
+	if err := extractGoModuleZip(f, n, dep, dir, maxGoModuleZipSize); err != nil {
+		return errors.Wrapf(err, "failed to extract zip of Go module %s", dep.PackageManagerSyntax())
+	}
+	return nil
+}
+
+// extractGoModuleZip extracts the files of the module zip r of the given size
+// into destination. All files in module zips are contained in a top-level
+// `module@version/` directory, which is stripped. An error is returned if the
+// extracted files are larger than maxSize bytes in total.
+func extractGoModuleZip(r io.ReaderAt, size int64, dependency reposource.GoDependency, destination string, maxSize int64) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+
+	var uncompressedSize uint64
+	for _, file := range zr.File {
+		uncompressedSize += file.UncompressedSize64
+		if uncompressedSize > uint64(maxSize) {
+			return errors.Errorf("Go module is larger than %d bytes", maxSize)
+		}
+	}
+
+	// The sizes in the zip headers can be forged, so the extracted bytes are
+	// counted too.
+	remaining := maxSize
+	prefix := dependency.PackageManagerSyntax() + "/"
+	for _, file := range zr.File {
+		if !strings.HasPrefix(file.Name, prefix) || !file.Mode().IsRegular() {
+			continue
+		}
+		outputPath, ok := packageFileOutputPath(destination, strings.TrimPrefix(file.Name, prefix))
+		if !ok {
+			continue
+		}
+		n, err := copyGoModuleFile(file, outputPath, remaining+1)
+		if err != nil {
+			return err
+		}
+		if n > remaining {
+			return errors.Errorf("Go module is larger than %d bytes", maxSize)
+		}
+		remaining -= n
+	}
+	return nil
+}
+
+// copyGoModuleFile writes at most limit bytes of the zip file entry to a new
+// file at outputPath. It returns the number of bytes written.
+func copyGoModuleFile(entry *zip.File, outputPath string, limit int64) (n int64, err error) {
+	rc, err := entry.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		err1 := rc.Close()
+		if err == nil {
+			err = err1
+		}
+	}()
+
+	return copyPackageFile(io.LimitReader(rc, limit), outputPath)
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+	"os/exec"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type fakeGoProxyClient struct {
+	zips map[string][]byte
+}
+
+func (c *fakeGoProxyClient) DoesDependencyExist(ctx context.Context, dependency reposource.GoDependency) (bool, error) {
+	_, ok := c.zips[dependency.PackageManagerSyntax()]
+	return ok, nil
+}
+
+func (c *fakeGoProxyClient) FetchZip(ctx context.Context, dependency reposource.GoDependency) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(c.zips[dependency.PackageManagerSyntax()])), nil
+}
+
+func createGoModuleZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, contents := range files {
+		w, err := zw.Create(name)
+		assert.Nil(t, err)
+		_, err = w.Write([]byte(contents))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestGoModulesCloneCommand(t *testing.T) {
+	dir := t.TempDir()
+
+	client := &fakeGoProxyClient{zips: map[string][]byte{
+		"example.com/mod@v1.0.0": createGoModuleZip(t, map[string]string{
+			"example.com/mod@v1.0.0/go.mod":      "module example.com/mod\n",
+			"example.com/mod@v1.0.0/.git/config": "malicious",
+			"example.com/other@v1.0.0/other.go":  "malicious",
+		}),
+		"example.com/mod@v1.1.0": createGoModuleZip(t, map[string]string{
+			"example.com/mod@v1.1.0/go.mod": "module example.com/mod\n",
+			"example.com/mod@v1.1.0/mod.go": "package mod\n",
+		}),
+	}}
+	config := &schema.GoModulesConnection{}
+	dbStore := &fakeDependencyRepoStore{}
+	s := NewGoModulesSyncer(config, dbStore, client)
+
+	bareGitDirectory := path.Join(dir, "git")
+	remoteURL := &vcs.URL{URL: url.URL{Path: "go/example.com/mod"}}
+	clone := func(dependencies ...string) {
+		t.Helper()
+		config.Dependencies = dependencies
+		cmd, err := s.CloneCommand(context.Background(), remoteURL, bareGitDirectory)
+		assert.Nil(t, err)
+		assert.Nil(t, cmd.Run())
+	}
+
+	clone("example.com/mod@v1.0.0")
+	assertCommandOutput(t, exec.Command("git", "tag", "--list"), bareGitDirectory, "v1.0.0\n")
+	assertCommandOutput(t, exec.Command("git", "ls-tree", "-r", "--name-only", "v1.0.0"), bareGitDirectory, "go.mod\n")
+
+	// Versions referenced by precise code intelligence uploads are added too.
+	dbStore.repos = []dbstore.DependencyRepo{{ID: 1, Name: "https://example.com/mod", Version: "v1.1.0"}}
+	clone("example.com/mod@v1.0.0")
+	assertCommandOutput(t, exec.Command("git", "tag", "--list"), bareGitDirectory, "v1.0.0\nv1.1.0\n")
+	assertCommandOutput(t, exec.Command("git", "show", "v1.1.0:mod.go"), bareGitDirectory, "package mod\n")
+
+	dbStore.repos = nil
+	clone("example.com/mod@v1.0.0")
+	assertCommandOutput(t, exec.Command("git", "tag", "--list"), bareGitDirectory, "v1.0.0\n")
+}
+
+func TestExtractGoModuleZipMaxSize(t *testing.T) {
+	dependency, err := reposource.ParseGoDependency("example.com/mod@v1.0.0")
+	assert.Nil(t, err)
+	zipBytes := createGoModuleZip(t, map[string]string{
+		"example.com/mod@v1.0.0/go.mod": "module example.com/mod\n",
+		"example.com/mod@v1.0.0/big.go": strings.Repeat("a", 1000),
+	})
+	r := bytes.NewReader(zipBytes)
+
+	assert.Nil(t, extractGoModuleZip(r, r.Size(), dependency, t.TempDir(), 1023))
+	assert.NotNil(t, extractGoModuleZip(r, r.Size(), dependency, t.TempDir(), 1022))
+}
@@ -473,7 +473,14 @@ func roundJVMVersionToNearestStableVersion(javaVersion int) int {
 }
 
 func runCommandInDirectory(ctx context.Context, cmd *exec.Cmd, workingDirectory string, dependency reposource.MavenDependency) (string, error) {
-	gitName := dependency.MavenModule.CoursierSyntax() + " authors"
+	return runCommandInDirectoryAs(ctx, cmd, workingDirectory, dependency.MavenModule.CoursierSyntax())
+}
+
+// runCommandInDirectoryAs runs cmd in workingDirectory with the git author and
+// committer set to the authors of the given package, and dates set to
+// stableGitCommitDate.
+func runCommandInDirectoryAs(ctx context.Context, cmd *exec.Cmd, workingDirectory, packageName string) (string, error) {
+	gitName := packageName + " authors"
 	gitEmail := "code-intel@sourcegraph.com"
 	cmd.Dir = workingDirectory
 	cmd.Env = append(cmd.Env, "EMAIL="+gitEmail)
@@ -0,0 +1,192 @@
+package server
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/npmpackages/npm"
+	"github.com/sourcegraph/sourcegraph/internal/repos"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// maxNpmPackageSize is the maximum total size of the files extracted from an
+// npm package tarball. It guards against tarballs that decompress to far more
+// data than they were downloaded as.
+const maxNpmPackageSize = 500 << 20
+
+// NewNpmPackagesSyncer returns a VCSSyncer for the npm packages configured in
+// config, or referenced by precise code intelligence uploads.
+func NewNpmPackagesSyncer(config *schema.NpmPackagesConnection, dbStore repos.DependencyRepoStore, client npm.Client) *PackagesSyncer {
+	return &PackagesSyncer{
+		typ: "npm_packages",
+		source: &npmPackagesSource{
+			config:  config,
+			dbStore: dbStore,
+			client:  client,
+		},
+	}
+}
+
+type npmPackagesSource struct {
+	config  *schema.NpmPackagesConnection
+	dbStore repos.DependencyRepoStore
+	client  npm.Client
+}
+
+func (s *npmPackagesSource) dependencies(ctx context.Context, repoURLPath string) ([]packageDependency, error) {
+	pkg, err := reposource.ParseNpmPackageFromRepoURL(repoURLPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var dependencies []reposource.NpmDependency
+	for _, d := range s.config.Dependencies {
+		if !pkg.MatchesDependencyString(d) {
+			continue
+		}
+		dependency, err := reposource.ParseNpmDependency(d)
+		if err != nil {
+			return nil, err
+		}
+		// Silently ignore non-existent dependencies because they are
+		// already logged out in the `GetRepo` method in
+		// internal/repos/npm_packages.go.
+		if exists, err := s.client.DoesDependencyExist(ctx, dependency); err != nil {
+			return nil, err
+		} else if exists {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	totalConfigMatched := len(dependencies)
+
+	dbDeps, err := s.dbStore.GetDependencyRepos(ctx, dbstore.GetDependencyReposOpts{
+		Scheme: "npm",
+		Name:   pkg.PackageSyntax(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get npm dependency repos from database for %s", repoURLPath)
+	}
+	// We don't check whether these exist, as existence should be verified by
+	// repo-updater.
+	for _, dep := range dbDeps {
+		dependencies = append(dependencies, reposource.NpmDependency{NpmPackage: pkg, Version: dep.Version})
+	}
+
+	if len(dependencies) == 0 {
+		return nil, errors.Errorf("no npm dependencies for URL path %s", repoURLPath)
+	}
+
+	log15.Info("fetched npm package for repo path", "repoPath", repoURLPath, "totalDB", len(dbDeps), "totalConfig", totalConfigMatched)
+	reposource.SortNpmDependencies(dependencies)
+
+	result := make([]packageDependency, 0, len(dependencies))
+	seen := make(map[string]bool, len(dependencies))
+	for i := range dependencies {
+		if seen[dependencies[i].Version] {
+			continue
+		}
+		seen[dependencies[i].Version] = true
+		result = append(result, &dependencies[i])
+	}
+	return result, nil
+}
+
+func (s *npmPackagesSource) download(ctx context.Context, dependency packageDependency, dir string) error {
+	tgz, err := s.client.FetchTarball(ctx, *dependency.(*reposource.NpmDependency))
+	if err != nil {
+		return err
+	}
+	defer tgz.Close()
+
+	if err := extractNpmTarball(tgz, dir, maxNpmPackageSize); err != nil {
+		return errors.Wrapf(err, "failed to extract tarball of npm package %s", dependency.PackageManagerSyntax())
+	}
+	return nil
+}
+
+// extractNpmTarball extracts the regular files of the gzipped npm package
+// tarball r into destination. All files in npm package tarballs are contained
+// in a single top-level directory, usually `package/`, which is stripped. An
+// error is returned if the extracted files are larger than maxSize bytes in
+// total.
+func extractNpmTarball(r io.Reader, destination string, maxSize int64) error {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	remaining := maxSize
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// Symbolic links and other special files are skipped, since they
+		// could point outside of the destination directory.
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeRegA {
+			continue
+		}
+
+		name := path.Clean(strings.TrimPrefix(header.Name, "./"))
+		if name == ".." || strings.HasPrefix(name, "../") {
+			// Skip entries outside of the top-level directory.
+			continue
+		}
+		i := strings.Index(name, "/")
+		if i < 0 {
+			continue
+		}
+		outputPath, ok := packageFileOutputPath(destination, name[i+1:])
+		if !ok {
+			continue
+		}
+
+		if header.Size > remaining {
+			return errors.Errorf("npm package is larger than %d bytes", maxSize)
+		}
+		n, err := copyPackageFile(io.LimitReader(tr, remaining+1), outputPath)
+		if err != nil {
+			return err
+		}
+		if n > remaining {
+			return errors.Errorf("npm package is larger than %d bytes", maxSize)
+		}
+		remaining -= n
+	}
+}
+
+// copyPackageFile writes the contents of r to a new file at outputPath,
+// creating its parent directories. It returns the number of bytes written.
+func copyPackageFile(r io.Reader, outputPath string) (n int64, err error) {
+	if err = os.MkdirAll(path.Dir(outputPath), 0700); err != nil {
+		return 0, err
+	}
+	outputFile, err := os.OpenFile(outputPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		err1 := outputFile.Close()
+		if err == nil {
+			err = err1
+		}
+	}()
+
+	return io.Copy(outputFile, r)
+}
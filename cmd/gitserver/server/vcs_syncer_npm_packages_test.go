@@ -0,0 +1,122 @@
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type fakeNpmClient struct {
+	tarballs map[string][]byte
+}
+
+func (c *fakeNpmClient) DoesDependencyExist(ctx context.Context, dependency reposource.NpmDependency) (bool, error) {
+	_, ok := c.tarballs[dependency.PackageManagerSyntax()]
+	return ok, nil
+}
+
+func (c *fakeNpmClient) FetchTarball(ctx context.Context, dependency reposource.NpmDependency) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(c.tarballs[dependency.PackageManagerSyntax()])), nil
+}
+
+type fakeDependencyRepoStore struct {
+	repos []dbstore.DependencyRepo
+}
+
+func (s *fakeDependencyRepoStore) GetDependencyRepos(ctx context.Context, filter dbstore.GetDependencyReposOpts) ([]dbstore.DependencyRepo, error) {
+	var repos []dbstore.DependencyRepo
+	for _, repo := range s.repos {
+		if filter.Name == "" || repo.Name == filter.Name {
+			repos = append(repos, repo)
+		}
+	}
+	return repos, nil
+}
+
+func createNpmTarball(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	for name, contents := range files {
+		assert.Nil(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(contents))}))
+		_, err := tw.Write([]byte(contents))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, tw.WriteHeader(&tar.Header{Name: "package/link.js", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}))
+	assert.Nil(t, tw.Close())
+	assert.Nil(t, gzw.Close())
+	return buf.Bytes()
+}
+
+func TestNpmCloneCommand(t *testing.T) {
+	dir := t.TempDir()
+
+	client := &fakeNpmClient{tarballs: map[string][]byte{
+		"@example/pkg@1.0.0": createNpmTarball(t, map[string]string{
+			"package/index.js":        "module.exports = 1;\n",
+			"package/.git/config":     "malicious",
+			"package/../../escape.js": "malicious",
+		}),
+		"@example/pkg@2.0.0": createNpmTarball(t, map[string]string{
+			"package/index.js": "module.exports = 2;\n",
+		}),
+	}}
+	config := &schema.NpmPackagesConnection{}
+	dbStore := &fakeDependencyRepoStore{}
+	s := NewNpmPackagesSyncer(config, dbStore, client)
+
+	bareGitDirectory := path.Join(dir, "git")
+	remoteURL := &vcs.URL{URL: url.URL{Path: "npm/example/pkg"}}
+	clone := func(dependencies ...string) {
+		t.Helper()
+		config.Dependencies = dependencies
+		cmd, err := s.CloneCommand(context.Background(), remoteURL, bareGitDirectory)
+		assert.Nil(t, err)
+		assert.Nil(t, cmd.Run())
+	}
+
+	clone("@example/pkg@1.0.0")
+	assertCommandOutput(t, exec.Command("git", "tag", "--list"), bareGitDirectory, "v1.0.0\n")
+	assertCommandOutput(t, exec.Command("git", "show", "v1.0.0:index.js"), bareGitDirectory, "module.exports = 1;\n")
+	assertCommandOutput(t, exec.Command("git", "ls-tree", "-r", "--name-only", "v1.0.0"), bareGitDirectory, "index.js\n")
+
+	// Versions referenced by precise code intelligence uploads are added too.
+	dbStore.repos = []dbstore.DependencyRepo{{ID: 1, Name: "@example/pkg", Version: "2.0.0"}}
+	clone("@example/pkg@1.0.0")
+	assertCommandOutput(t, exec.Command("git", "tag", "--list"), bareGitDirectory, "v1.0.0\nv2.0.0\n")
+	assertCommandOutput(t, exec.Command("git", "show", "v2.0.0:index.js"), bareGitDirectory, "module.exports = 2;\n")
+	assertCommandOutput(t, exec.Command("git", "show", "latest:index.js"), bareGitDirectory, "module.exports = 2;\n")
+
+	// Removed versions are deleted.
+	dbStore.repos = nil
+	clone("@example/pkg@1.0.0", "@example/pkg@3.0.0")
+	assertCommandOutput(t, exec.Command("git", "tag", "--list"), bareGitDirectory, "v1.0.0\n")
+
+	_, err := os.Stat(path.Join(dir, "escape.js"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestExtractNpmTarballMaxSize(t *testing.T) {
+	tarball := createNpmTarball(t, map[string]string{
+		"package/a.js": "0123456789",
+		"package/b.js": "0123456789",
+	})
+
+	assert.Nil(t, extractNpmTarball(bytes.NewReader(tarball), t.TempDir(), 20))
+	assert.NotNil(t, extractNpmTarball(bytes.NewReader(tarball), t.TempDir(), 19))
+}
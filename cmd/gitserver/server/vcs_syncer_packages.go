@@ -0,0 +1,218 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+)
+
+// packageDependency is a single version of a package, which is mirrored as a
+// git tag by a PackagesSyncer.
+type packageDependency interface {
+	// PackageSyntax returns the name of the package, without the version.
+	PackageSyntax() string
+	// PackageManagerSyntax returns the name and version of the package as
+	// used by its package manager.
+	PackageManagerSyntax() string
+	GitTagFromVersion() string
+}
+
+// packagesSource fetches the versions of packages from a package host.
+type packagesSource interface {
+	// dependencies returns the versions of the package with the given URL
+	// path that should be mirrored, latest version first.
+	dependencies(ctx context.Context, repoURLPath string) ([]packageDependency, error)
+	// download writes the source files of the given version of a package into
+	// the empty directory dir.
+	download(ctx context.Context, dependency packageDependency, dir string) error
+}
+
+// PackagesSyncer is a VCSSyncer for package hosts, such as npm registries and
+// Go module proxies. Like the JVMPackagesSyncer, it creates a git repository
+// per package, with one tag per version of the package pointing to a commit
+// that adds its source files.
+type PackagesSyncer struct {
+	typ    string
+	source packagesSource
+}
+
+var _ VCSSyncer = &PackagesSyncer{}
+
+func (s *PackagesSyncer) Type() string {
+	return s.typ
+}
+
+// IsCloneable checks to see if the VCS remote URL is cloneable. Any non-nil
+// error indicates there is a problem.
+func (s *PackagesSyncer) IsCloneable(ctx context.Context, remoteURL *vcs.URL) error {
+	_, err := s.source.dependencies(ctx, remoteURL.Path)
+	return err
+}
+
+// CloneCommand returns the command to be executed for cloning from remote. As
+// for the JVMPackagesSyncer, the actual cloning happens inside this method
+// and the returned command is a no-op.
+func (s *PackagesSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, bareGitDirectory string) (*exec.Cmd, error) {
+	if err := os.MkdirAll(bareGitDirectory, 0755); err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "--bare", "init")
+	if _, err := runCommandInDirectoryAs(ctx, cmd, bareGitDirectory, "sourcegraph"); err != nil {
+		return nil, err
+	}
+
+	// The Fetch method is responsible for cleaning up temporary directories.
+	if err := s.Fetch(ctx, remoteURL, GitDir(bareGitDirectory)); err != nil {
+		return nil, err
+	}
+
+	// no-op command to satisfy VCSSyncer interface, see docstring for more details.
+	return exec.CommandContext(ctx, "git", "--version"), nil
+}
+
+// Fetch adds git tags for newly added versions of the package and removes git
+// tags for deleted versions.
+func (s *PackagesSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir) error {
+	dependencies, err := s.source.dependencies(ctx, remoteURL.Path)
+	if err != nil {
+		return err
+	}
+
+	out, err := runCommandInDirectoryAs(ctx, exec.CommandContext(ctx, "git", "tag"), string(dir), "sourcegraph")
+	if err != nil {
+		return err
+	}
+
+	tags := map[string]bool{}
+	for _, line := range strings.Split(out, "\n") {
+		if len(line) == 0 {
+			continue
+		}
+		tags[line] = true
+	}
+
+	for i, dependency := range dependencies {
+		if tags[dependency.GitTagFromVersion()] {
+			continue
+		}
+		// the gitPushDependencyTag method is reponsible for cleaning up temporary directories.
+		if err := s.gitPushDependencyTag(ctx, string(dir), dependency, i == 0); err != nil {
+			return errors.Wrapf(err, "error pushing dependency %q", dependency.PackageManagerSyntax())
+		}
+	}
+
+	dependencyTags := make(map[string]struct{}, len(dependencies))
+	for _, dependency := range dependencies {
+		dependencyTags[dependency.GitTagFromVersion()] = struct{}{}
+	}
+
+	for tag := range tags {
+		if _, isDependencyTag := dependencyTags[tag]; !isDependencyTag {
+			cmd := exec.CommandContext(ctx, "git", "tag", "-d", tag)
+			if _, err := runCommandInDirectoryAs(ctx, cmd, string(dir), "sourcegraph"); err != nil {
+				log15.Error("Failed to delete git tag", "error", err, "tag", tag)
+				continue
+			}
+		}
+	}
+
+	return nil
+}
+
+// RemoteShowCommand returns the command to be executed for showing remote.
+func (s *PackagesSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, err error) {
+	return exec.CommandContext(ctx, "git", "remote", "show", "./"), nil
+}
+
+// gitPushDependencyTag pushes a git tag to the given bareGitDirectory path.
+// The tag points to a commit that adds all sources of given dependency. When
+// isLatestVersion is true, the latest branch of the bare git directory is
+// also updated to point to the same commit as the git tag.
+func (s *PackagesSyncer) gitPushDependencyTag(ctx context.Context, bareGitDirectory string, dependency packageDependency, isLatestVersion bool) error {
+	tmpDirectory, err := os.MkdirTemp("", "package")
+	if err != nil {
+		return err
+	}
+	// Always clean up created temporary directories.
+	defer os.RemoveAll(tmpDirectory)
+
+	run := func(name string, arg ...string) (string, error) {
+		return runCommandInDirectoryAs(ctx, exec.CommandContext(ctx, name, arg...), tmpDirectory, dependency.PackageSyntax())
+	}
+
+	if _, err := run("git", "init"); err != nil {
+		return err
+	}
+
+	if err := s.source.download(ctx, dependency, tmpDirectory); err != nil {
+		return err
+	}
+
+	if _, err := run("git", "add", "."); err != nil {
+		return err
+	}
+	// Use --no-verify for security reasons. See https://github.com/sourcegraph/sourcegraph/pull/23399
+	if _, err := run("git", "commit", "--no-verify", "--allow-empty", "-m", dependency.PackageManagerSyntax(), "--date", stableGitCommitDate); err != nil {
+		return err
+	}
+	if _, err := run("git", "tag", "-m", dependency.PackageManagerSyntax(), dependency.GitTagFromVersion()); err != nil {
+		return err
+	}
+
+	if _, err := run("git", "remote", "add", "origin", bareGitDirectory); err != nil {
+		return err
+	}
+	// Use --no-verify for security reasons. See https://github.com/sourcegraph/sourcegraph/pull/23399
+	if _, err := run("git", "push", "--no-verify", "--force", "origin", "--tags"); err != nil {
+		return err
+	}
+
+	if isLatestVersion {
+		defaultBranch, err := run("git", "rev-parse", "--abbrev-ref", "HEAD")
+		if err != nil {
+			return err
+		}
+		// Use --no-verify for security reasons. See https://github.com/sourcegraph/sourcegraph/pull/23399
+		if _, err := run("git", "push", "--no-verify", "--force", "origin", strings.TrimSpace(defaultBranch)+":latest", dependency.GitTagFromVersion()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// packageFileOutputPath returns the path to extract the archive entry with the
+// given name to, or false if the entry must be skipped for security reasons.
+func packageFileOutputPath(destination, name string) (string, bool) {
+	if name == "" || strings.HasSuffix(name, "/") {
+		// Skip directory entries.
+		return "", false
+	}
+	if strings.HasPrefix(name, "/") {
+		// Skip absolute paths, see unzipJarFile.
+		return "", false
+	}
+	for _, elem := range strings.Split(name, "/") {
+		if strings.EqualFold(elem, ".git") {
+			// For security reasons, don't extract files under `.git/`
+			// directories. See https://github.com/sourcegraph/security-issues/issues/163
+			return "", false
+		}
+	}
+	destinationDirectory := strings.TrimSuffix(destination, string(os.PathSeparator)) + string(os.PathSeparator)
+	outputPath := path.Join(destination, name)
+	if !strings.HasPrefix(outputPath, destinationDirectory) {
+		// For security reasons, skip file if it's not a child of the target
+		// directory. See "Zip Slip Vulnerability".
+		return "", false
+	}
+	return outputPath, true
+}
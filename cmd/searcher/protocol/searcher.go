@@ -82,6 +82,11 @@ type PatternInfo struct {
 	// when finding matches.
 	IsCaseSensitive bool
 
+	// IsMultiline if true will match the pattern against whole file contents
+	// and return MultilineMatches instead of LineMatches, so that matches
+	// spanning several lines are reported as a single range.
+	IsMultiline bool
+
 	// ExcludePattern is a pattern that may not match the returned files' paths.
 	// eg '**/node_modules'
 	ExcludePattern string
@@ -150,6 +155,9 @@ func (p *PatternInfo) String() string {
 	if p.IsCaseSensitive {
 		args = append(args, "case")
 	}
+	if p.IsMultiline {
+		args = append(args, "multiline")
+	}
 	if !p.PatternMatchesContent {
 		args = append(args, "nocontent")
 	}
@@ -199,6 +207,10 @@ type FileMatch struct {
 	Path        string
 	LineMatches []LineMatch
 
+	// MultilineMatches are the matches of a multiline search, see
+	// PatternInfo.IsMultiline.
+	MultilineMatches []MultilineMatch
+
 	// MatchCount is the number of matches.  Different from len(LineMatches), as multiple
 	// lines may correspond to one logical match when doing a structural search
 	MatchCount int
@@ -221,3 +233,26 @@ type LineMatch struct {
 	// Offsets and lengths are measured in characters, not bytes.
 	OffsetAndLengths [][2]int
 }
+
+// MultilineMatch is a match which may span multiple lines.
+type MultilineMatch struct {
+	// Preview is the content of the lines spanned by the match, without the
+	// trailing newline.
+	Preview string
+
+	// Start and End are the locations of the start and the end of the match.
+	Start, End Location
+}
+
+// Location is a location in a file.
+type Location struct {
+	// Offset is the number of characters (not bytes) from the start of the
+	// preview of the match.
+	Offset int
+
+	// Line is the 0-based line number.
+	Line int
+
+	// Column is the number of characters from the start of the line.
+	Column int
+}
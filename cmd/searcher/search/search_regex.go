@@ -20,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/pathmatch"
 	"github.com/sourcegraph/sourcegraph/internal/search/casetransform"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/store"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
 )
@@ -47,6 +48,10 @@ type readerGrep struct {
 	// ignoreCase if true means we need to do case insensitive matching.
 	ignoreCase bool
 
+	// multiline if true means matches are reported as MultilineMatches
+	// rather than split into LineMatches.
+	multiline bool
+
 	// transformBuf is reused between file searches to avoid
 	// re-allocating. It is only used if we need to transform the input
 	// before matching. For example we lower case the input in the case of
@@ -62,6 +67,11 @@ type readerGrep struct {
 	// re. It is the output of the longestLiteral function. It is only set if
 	// the regex has an empty LiteralPrefix.
 	literalSubstring []byte
+
+	// indexLiteral is guaranteed to appear in any match found by re. It is
+	// looked up in the trigram index of the archive to skip files that cannot
+	// match.
+	indexLiteral []byte
 }
 
 // compile returns a readerGrep for matching p.
@@ -69,6 +79,7 @@ func compile(p *protocol.PatternInfo) (*readerGrep, error) {
 	var (
 		re               *regexp.Regexp
 		literalSubstring []byte
+		indexLiteral     []byte
 	)
 	if p.Pattern != "" {
 		expr := p.Pattern
@@ -101,15 +112,17 @@ func compile(p *protocol.PatternInfo) (*readerGrep, error) {
 			return nil, err
 		}
 
+		ast, err := syntax.Parse(expr, syntax.Perl)
+		if err != nil {
+			return nil, err
+		}
+		ast = ast.Simplify()
+		indexLiteral = []byte(longestLiteral(ast))
+
 		// Only use literalSubstring optimization if the regex engine doesn't
 		// have a prefix to use.
 		if pre, _ := re.LiteralPrefix(); pre == "" {
-			ast, err := syntax.Parse(expr, syntax.Perl)
-			if err != nil {
-				return nil, err
-			}
-			ast = ast.Simplify()
-			literalSubstring = []byte(longestLiteral(ast))
+			literalSubstring = indexLiteral
 		}
 	}
 
@@ -125,8 +138,10 @@ func compile(p *protocol.PatternInfo) (*readerGrep, error) {
 	return &readerGrep{
 		re:               re,
 		ignoreCase:       !p.IsCaseSensitive,
+		multiline:        p.IsMultiline,
 		matchPath:        matchPath,
 		literalSubstring: literalSubstring,
+		indexLiteral:     indexLiteral,
 	}, nil
 }
 
@@ -136,8 +151,10 @@ func (rg *readerGrep) Copy() *readerGrep {
 	return &readerGrep{
 		re:               rg.re,
 		ignoreCase:       rg.ignoreCase,
+		multiline:        rg.multiline,
 		matchPath:        rg.matchPath,
 		literalSubstring: rg.literalSubstring,
+		indexLiteral:     rg.indexLiteral,
 	}
 }
 
@@ -153,13 +170,9 @@ func (rg *readerGrep) matchString(s string) bool {
 	return rg.re.MatchString(s)
 }
 
-// Find returns a LineMatch for each line that matches rg in reader.
-// LimitHit is true if some matches may not have been included in the result.
-// NOTE: This is not safe to use concurrently.
-func (rg *readerGrep) Find(zf *store.ZipFile, f *store.SrcFile, limit int) (matches []protocol.LineMatch, err error) {
-	// fileMatchBuf is what we run match on, fileBuf is the original
-	// data (for Preview).
-	fileBuf := zf.DataFor(f)
+// matchBuf returns the buffer to run rg.re on for fileBuf, the contents of a
+// file in zf. It returns false if the file cannot contain a match.
+func (rg *readerGrep) matchBuf(zf *store.ZipFile, fileBuf []byte) ([]byte, bool) {
 	fileMatchBuf := fileBuf
 
 	// If we are ignoring case, we transform the input instead of
@@ -183,6 +196,50 @@ func (rg *readerGrep) Find(zf *store.ZipFile, f *store.SrcFile, limit int) (matc
 	// per-line. Additionally if we have a non-empty literalSubstring, we use
 	// that to prune out files since doing bytes.Index is very fast.
 	if !bytes.Contains(fileMatchBuf, rg.literalSubstring) {
+		return nil, false
+	}
+
+	return fileMatchBuf, true
+}
+
+// FindMultiline returns a MultilineMatch for each match of rg in f. Unlike
+// Find, a match spanning several lines is a single MultilineMatch.
+// NOTE: This is not safe to use concurrently.
+func (rg *readerGrep) FindMultiline(zf *store.ZipFile, f *store.SrcFile, limit int) ([]protocol.MultilineMatch, error) {
+	fileBuf := zf.DataFor(f)
+	fileMatchBuf, ok := rg.matchBuf(zf, fileBuf)
+	if !ok {
+		return nil, nil
+	}
+
+	// fileMatchBuf has the same length as fileBuf, so the locations of the
+	// matches are valid in fileBuf.
+	locs := rg.re.FindAllIndex(fileMatchBuf, limit+1)
+	if len(locs) == 0 {
+		return nil, nil
+	}
+
+	rms := result.NewMultilineMatches(fileBuf, locs)
+	matches := make([]protocol.MultilineMatch, 0, len(rms))
+	for _, m := range rms {
+		matches = append(matches, protocol.MultilineMatch{
+			Preview: m.Preview,
+			Start:   protocol.Location(m.Range.Start),
+			End:     protocol.Location(m.Range.End),
+		})
+	}
+	return matches, nil
+}
+
+// Find returns a LineMatch for each line that matches rg in reader.
+// LimitHit is true if some matches may not have been included in the result.
+// NOTE: This is not safe to use concurrently.
+func (rg *readerGrep) Find(zf *store.ZipFile, f *store.SrcFile, limit int) (matches []protocol.LineMatch, err error) {
+	// fileMatchBuf is what we run match on, fileBuf is the original
+	// data (for Preview).
+	fileBuf := zf.DataFor(f)
+	fileMatchBuf, ok := rg.matchBuf(zf, fileBuf)
+	if !ok {
 		return nil, nil
 	}
 
@@ -285,8 +342,19 @@ func appendMatches(matches []protocol.LineMatch, fileBuf []byte, matchLineBuf []
 	return matches
 }
 
-// FindZip is a convenience function to run Find on f.
+// FindZip is a convenience function to run Find, or FindMultiline for a
+// multiline search, on f.
 func (rg *readerGrep) FindZip(zf *store.ZipFile, f *store.SrcFile, limit int) (protocol.FileMatch, error) {
+	if rg.multiline {
+		mm, err := rg.FindMultiline(zf, f, limit)
+		return protocol.FileMatch{
+			Path:             f.Name,
+			MultilineMatches: mm,
+			MatchCount:       len(mm),
+			LimitHit:         false,
+		}, err
+	}
+
 	lm, err := rg.Find(zf, f, limit)
 	return protocol.FileMatch{
 		Path:        f.Name,
@@ -337,14 +405,14 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *store.ZipFile, limit i
 	defer cancel()
 
 	var (
-		filesmu sync.Mutex // protects files
-		files   = zf.Files
+		filesmu sync.Mutex // protects next
+		next    int        // index of the next file in zf.Files to search
 	)
 
 	if rg.re == nil || (patternMatchesPaths && !patternMatchesContent) {
 		// Fast path for only matching file paths (or with a nil pattern, which matches all files,
 		// so is effectively matching only on file paths).
-		for _, f := range files {
+		for _, f := range zf.Files {
 			if match := rg.matchPath.MatchPath(f.Name) && rg.matchString(f.Name); match == !isPatternNegated {
 				if ctx.Err() != nil {
 					return ctx.Err()
@@ -356,9 +424,21 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *store.ZipFile, limit i
 		return nil
 	}
 
+	// Files that do not contain indexLiteral cannot match the pattern. When
+	// the pattern is negated or can match paths, those files may still be
+	// results, so we search all files.
+	var candidates []bool
+	if !patternMatchesPaths && !isPatternNegated {
+		if ix := zf.TrigramIndex(); ix != nil {
+			candidates = ix.Candidates(rg.indexLiteral)
+		}
+	}
+	span.SetTag("trigramIndex", candidates != nil)
+
 	var (
 		filesSkipped  atomic.Uint32
 		filesSearched atomic.Uint32
+		filesPruned   atomic.Uint32
 	)
 
 	g, ctx := errgroup.WithContext(ctx)
@@ -370,19 +450,24 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *store.ZipFile, limit i
 			for ctx.Err() == nil {
 				// grab a file to work on
 				filesmu.Lock()
-				if len(files) == 0 {
+				if next == len(zf.Files) {
 					filesmu.Unlock()
 					return nil
 				}
-				f := &files[0]
-				files = files[1:]
+				i := next
+				next++
 				filesmu.Unlock()
+				f := &zf.Files[i]
 
 				// decide whether to process, record that decision
 				if !rg.matchPath.MatchPath(f.Name) {
 					filesSkipped.Inc()
 					continue
 				}
+				if candidates != nil && !candidates[i] {
+					filesPruned.Inc()
+					continue
+				}
 				filesSearched.Inc()
 
 				// process
@@ -390,7 +475,7 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *store.ZipFile, limit i
 				if err != nil {
 					return err
 				}
-				match := len(fm.LineMatches) > 0
+				match := len(fm.LineMatches) > 0 || len(fm.MultilineMatches) > 0
 				if !match && patternMatchesPaths {
 					// Try matching against the file path.
 					match = rg.matchString(f.Name)
@@ -415,6 +500,7 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *store.ZipFile, limit i
 	span.LogFields(
 		otlog.Int("filesSkipped", int(filesSkipped.Load())),
 		otlog.Int("filesSearched", int(filesSearched.Load())),
+		otlog.Int("filesPruned", int(filesPruned.Load())),
 	)
 
 	return err
@@ -429,6 +515,10 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *store.ZipFile, limit i
 func longestLiteral(re *syntax.Regexp) string {
 	switch re.Op {
 	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase != 0 {
+			// The literal matches other cases of re.Rune too.
+			return ""
+		}
 		return string(re.Rune)
 	case syntax.OpCapture, syntax.OpPlus:
 		return longestLiteral(re.Sub[0])
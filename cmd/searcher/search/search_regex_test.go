@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"testing"
 	"testing/iotest"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/pathmatch"
@@ -203,6 +204,7 @@ func TestLongestLiteral(t *testing.T) {
 		"(?m:^foo)": "foo",
 		"(?m:^FoO)": "FoO",
 		"[Z]":       "Z",
+		"(?i)foo":   "",
 
 		`\wddSuballocation\(dump`:    "ddSuballocation(dump",
 		`\wfoo(\dlongest\wbam)\dbar`: "longest",
@@ -341,11 +343,138 @@ func TestMaxMatches(t *testing.T) {
 	}
 }
 
+func TestRegexSearch_trigramIndex(t *testing.T) {
+	s, cleanup, err := storetest.NewStore(map[string]string{
+		"a.go": "package a\n\nfunc Hello() {}\n",
+		"b.go": "package b\n\n// hello, world\n",
+		"c.go": "package c\n",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	path, err := s.PrepareZip(context.Background(), "foo", "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef")
+	if err != nil {
+		t.Fatal(err)
+	}
+	zf, err := s.ZipCache.Get(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zf.Close()
+
+	// The index is built in the background.
+	for i := 0; i < 500 && zf.TrigramIndex() == nil; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if zf.TrigramIndex() == nil {
+		t.Fatal("timed out waiting for the trigram index")
+	}
+
+	tests := []struct {
+		name                string
+		pattern             protocol.PatternInfo
+		patternMatchesPaths bool
+		isNegated           bool
+		want                []string
+	}{{
+		name:    "literal",
+		pattern: protocol.PatternInfo{Pattern: "hello"},
+		want:    []string{"a.go", "b.go"},
+	}, {
+		name:    "case sensitive",
+		pattern: protocol.PatternInfo{Pattern: "Hello", IsCaseSensitive: true},
+		want:    []string{"a.go"},
+	}, {
+		name:    "regexp",
+		pattern: protocol.PatternInfo{Pattern: `func \w+\(`, IsRegExp: true},
+		want:    []string{"a.go"},
+	}, {
+		name:      "negated",
+		pattern:   protocol.PatternInfo{Pattern: "hello"},
+		isNegated: true,
+		want:      []string{"c.go"},
+	}, {
+		name:                "path",
+		pattern:             protocol.PatternInfo{Pattern: "c.go"},
+		patternMatchesPaths: true,
+		want:                []string{"c.go"},
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rg, err := compile(&test.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fms, _, err := regexSearchBatch(context.Background(), rg, zf, 10, true, test.patternMatchesPaths, test.isNegated)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, fm := range fms {
+				got = append(got, fm.Path)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
 // Tests that:
 //
 // - IncludePatterns can match the path in any order
 // - A path must match all (not any) of the IncludePatterns
 // - An empty pattern is allowed
+func TestRegexSearch_multiline(t *testing.T) {
+	s, cleanup, err := storetest.NewStore(map[string]string{
+		"a.go": "package a\n\nfunc Hello() {\n\treturn\n}\n",
+		"b.go": "package b\n\nfunc World() {}\n",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	path, err := s.PrepareZip(context.Background(), "foo", "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef")
+	if err != nil {
+		t.Fatal(err)
+	}
+	zf, err := s.ZipCache.Get(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zf.Close()
+
+	rg, err := compile(&protocol.PatternInfo{
+		Pattern:     `(?s)func hello\(\) \{.*?\}`,
+		IsRegExp:    true,
+		IsMultiline: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fms, _, err := regexSearchBatch(context.Background(), rg, zf, 10, true, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []protocol.FileMatch{{
+		Path: "a.go",
+		MultilineMatches: []protocol.MultilineMatch{{
+			Preview: "func Hello() {\n\treturn\n}",
+			Start:   protocol.Location{Offset: 0, Line: 2, Column: 0},
+			End:     protocol.Location{Offset: 24, Line: 4, Column: 1},
+		}},
+		MatchCount: 1,
+	}}
+	if !reflect.DeepEqual(fms, want) {
+		t.Errorf("got %+v, want %+v", fms, want)
+	}
+}
+
 func TestPathMatches(t *testing.T) {
 	zipData, err := storetest.CreateZip(map[string]string{
 		"a":   "",
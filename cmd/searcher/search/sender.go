@@ -46,7 +46,7 @@ func (m *limitedStreamCollector) Send(match protocol.FileMatch) {
 	m.cancel()
 
 	// Can't truncate a path match
-	if len(match.LineMatches) == 0 {
+	if len(match.LineMatches) == 0 && len(match.MultilineMatches) == 0 {
 		m.mux.Unlock()
 		return
 	}
@@ -57,7 +57,11 @@ func (m *limitedStreamCollector) Send(match protocol.FileMatch) {
 	// information required to properly limit. However, multiline matches
 	// are also not limited correctly in the frontend, so doing it correctly
 	// here won't fix that.
-	match.LineMatches = match.LineMatches[:m.remaining]
+	if len(match.MultilineMatches) > 0 {
+		match.MultilineMatches = match.MultilineMatches[:m.remaining]
+	} else {
+		match.LineMatches = match.LineMatches[:m.remaining]
+	}
 	match.LimitHit = true
 	match.MatchCount = m.remaining
 	m.sentCount += m.remaining
@@ -125,7 +129,7 @@ func (m *limitedStream) Send(match protocol.FileMatch) {
 	m.cancel()
 
 	// Can't truncate a path match
-	if len(match.LineMatches) == 0 {
+	if len(match.LineMatches) == 0 && len(match.MultilineMatches) == 0 {
 		m.mux.Unlock()
 		return
 	}
@@ -136,7 +140,11 @@ func (m *limitedStream) Send(match protocol.FileMatch) {
 	// information required to properly limit. However, multiline matches
 	// are also not limited correctly in the frontend, so doing it correctly
 	// here won't fix that.
-	match.LineMatches = match.LineMatches[:m.remaining]
+	if len(match.MultilineMatches) > 0 {
+		match.MultilineMatches = match.MultilineMatches[:m.remaining]
+	} else {
+		match.LineMatches = match.LineMatches[:m.remaining]
+	}
 	match.LimitHit = true
 	match.MatchCount = m.remaining
 	m.sentCount += m.remaining
@@ -35,6 +35,18 @@ type SearchArgs struct {
 	// need to match to get included in the result
 	ExcludePattern string
 
+	// Kinds, if non-empty, restricts the result to symbols of these kinds,
+	// e.g. "function". Kinds are matched case insensitively.
+	Kinds []string
+
+	// Languages, if non-empty, restricts the result to symbols in files of
+	// these languages, e.g. "Go". Languages are matched case insensitively.
+	Languages []string
+
+	// ContainerPattern is an optional regex that the name of the symbol's
+	// parent (e.g. its class) needs to match to get included in the result
+	ContainerPattern string
+
 	// First indicates that only the first n symbols should be returned.
 	First int
 }
@@ -0,0 +1,208 @@
+package symbols
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
+)
+
+// Changes are the paths that changed between two commits.
+type Changes struct {
+	Added    []string
+	Modified []string
+	Deleted  []string
+}
+
+const (
+	// maxAncestors is the number of ancestors of a commit that are checked
+	// for an already cached symbols database.
+	maxAncestors = 100
+
+	// maxIncrementalPaths is the maximum number of added and modified paths
+	// for which we derive a symbols database from an ancestor's. The paths
+	// are sent to gitserver in the archive URL, and beyond this many changes
+	// parsing the whole repository is not much slower anyway.
+	maxIncrementalPaths = 1000
+)
+
+// writeSymbolsToNewDB writes the symbols of repo@commitID to the blank
+// database file `dbFile`. If the database of a nearby ancestor is cached, it
+// copies that database and only re-parses the paths that changed since.
+// Otherwise it parses all the symbols of the repository. Only databases built
+// with the same parsers are reused.
+func (s *Service) writeSymbolsToNewDB(ctx context.Context, parsers ParserSelection, dbFile string, repoName api.RepoName, commitID api.CommitID) error {
+	updated, err := s.updateSymbolsFromAncestor(ctx, parsers, dbFile, repoName, commitID)
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+
+		log15.Warn("Failed to derive symbols from an ancestor commit, parsing all symbols.", "repo", repoName, "commitID", commitID, "error", err)
+		incrementalBuildsFailed.Inc()
+
+		// Start over from a blank database.
+		_ = os.Remove(dbFile + "-journal")
+		if err := os.Truncate(dbFile, 0); err != nil {
+			return err
+		}
+	} else if updated {
+		incrementalBuilds.Inc()
+		return nil
+	}
+
+	return s.writeAllSymbolsToNewDB(ctx, parsers, dbFile, repoName, commitID)
+}
+
+// updateSymbolsFromAncestor writes the symbols of repo@commitID to `dbFile` by
+// copying the cached database of the nearest ancestor commit and re-parsing
+// the paths that changed in between with parsers. It returns false if there is
+// no database of an ancestor built with the same parsers, in which case
+// `dbFile` is left untouched.
+func (s *Service) updateSymbolsFromAncestor(ctx context.Context, parsers ParserSelection, dbFile string, repoName api.RepoName, commitID api.CommitID) (updated bool, err error) {
+	if s.Ancestors == nil || s.GitDiff == nil {
+		return false, nil
+	}
+
+	span, ctx := ot.StartSpanFromContext(ctx, "updateSymbolsFromAncestor")
+	defer func() {
+		if err != nil {
+			ext.Error.Set(span, true)
+			span.LogFields(otlog.Error(err))
+		}
+		span.Finish()
+	}()
+	span.SetTag("repo", string(repoName))
+	span.SetTag("commit", string(commitID))
+
+	ancestors, err := s.Ancestors(ctx, repoName, commitID, maxAncestors)
+	if err != nil {
+		return false, errors.Wrap(err, "Ancestors")
+	}
+
+	var ancestor api.CommitID
+	var ancestorDBFile string
+	for _, a := range ancestors {
+		if path, ok := s.cache.Lookup(dbCacheKey(repoName, a, parsers)); ok {
+			ancestor, ancestorDBFile = a, path
+			break
+		}
+	}
+	if ancestorDBFile == "" {
+		return false, nil
+	}
+	span.SetTag("ancestor", string(ancestor))
+
+	changes, err := s.GitDiff(ctx, repoName, ancestor, commitID)
+	if err != nil {
+		return false, errors.Wrap(err, "GitDiff")
+	}
+
+	// Symbols of modified files are deleted and then parsed again, just like
+	// the symbols of added files.
+	toParse := append(append([]string{}, changes.Added...), changes.Modified...)
+	toDelete := append(append([]string{}, changes.Modified...), changes.Deleted...)
+	span.SetTag("toParse", len(toParse))
+	span.SetTag("toDelete", len(toDelete))
+	if len(toParse) > maxIncrementalPaths {
+		return false, nil
+	}
+
+	if err := copyFile(ancestorDBFile, dbFile); err != nil {
+		return false, errors.Wrap(err, "copying ancestor database")
+	}
+
+	if err := s.updateSymbols(ctx, parsers, dbFile, repoName, commitID, toParse, toDelete); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// updateSymbols deletes the symbols of the paths in toDelete from the database
+// `dbFile`, then parses and inserts the symbols of the paths in toParse with
+// parsers.
+func (s *Service) updateSymbols(ctx context.Context, parsers ParserSelection, dbFile string, repoName api.RepoName, commitID api.CommitID, toParse, toDelete []string) (err error) {
+	db, err := sqlx.Open("sqlite3_with_regexp", dbFile)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	deleteStatement, err := tx.Prepare("DELETE FROM symbols WHERE path = ?")
+	if err != nil {
+		return err
+	}
+	for _, path := range toDelete {
+		if _, err := deleteStatement.Exec(path); err != nil {
+			return err
+		}
+	}
+
+	if len(toParse) == 0 {
+		return nil
+	}
+
+	insertStatement, err := prepareInsertSymbol(tx)
+	if err != nil {
+		return err
+	}
+
+	return s.parseUncached(ctx, parsers, repoName, commitID, toParse, func(symbol result.Symbol) error {
+		symbolInDBValue := symbolToSymbolInDB(symbol)
+		_, err := insertStatement.Exec(&symbolInDBValue)
+		return err
+	})
+}
+
+// copyFile overwrites the file at dst with the contents of the file at src.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+var (
+	incrementalBuilds = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "symbols_store_incremental_builds",
+		Help: "The total number of symbols databases derived from the database of an ancestor commit.",
+	})
+	incrementalBuildsFailed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "symbols_store_incremental_builds_failed",
+		Help: "The total number of failed attempts to derive a symbols database from the database of an ancestor commit.",
+	})
+)
@@ -39,7 +39,10 @@ func (s *Service) startParsers() error {
 	return nil
 }
 
-func (s *Service) parseUncached(ctx context.Context, repo api.RepoName, commitID api.CommitID, callback func(symbol result.Symbol) error) (err error) {
+// parseUncached parses the symbols of repo@commitID with parsers and calls
+// callback for each of them. If paths is non-empty, only the files at those
+// paths are parsed.
+func (s *Service) parseUncached(ctx context.Context, parsers ParserSelection, repo api.RepoName, commitID api.CommitID, paths []string, callback func(symbol result.Symbol) error) (err error) {
 	span, ctx := ot.StartSpanFromContext(ctx, "parseUncached")
 	defer func() {
 		if err != nil {
@@ -65,7 +68,7 @@ func (s *Service) parseUncached(ctx context.Context, repo api.RepoName, commitID
 	}()
 
 	tr.LazyPrintf("fetch")
-	parseRequests, errChan, err := s.fetchRepositoryArchive(ctx, repo, commitID)
+	parseRequests, errChan, err := s.fetchRepositoryArchive(ctx, repo, commitID, paths)
 	tr.LazyPrintf("fetch (returned chans)")
 	if err != nil {
 		return err
@@ -98,21 +101,21 @@ func (s *Service) parseUncached(ctx context.Context, repo api.RepoName, commitID
 				wg.Done()
 				<-sem
 			}()
-			entries, parseErr := s.parse(ctx, req)
+			symbols, parseErr := s.parse(ctx, parsers, req)
 			if parseErr != nil && parseErr != context.Canceled && parseErr != context.DeadlineExceeded {
 				log15.Error("Error parsing symbols.", "repo", repo, "commitID", commitID, "path", req.path, "dataSize", len(req.data), "error", parseErr)
 			}
-			if len(entries) > 0 {
+			if len(symbols) > 0 {
 				mu.Lock()
 				defer mu.Unlock()
-				for _, e := range entries {
-					if e.Name == "" || strings.HasPrefix(e.Name, "__anon") || strings.HasPrefix(e.Parent, "__anon") || strings.HasPrefix(e.Name, "AnonymousFunction") || strings.HasPrefix(e.Parent, "AnonymousFunction") {
+				for _, symbol := range symbols {
+					if symbol.Name == "" || strings.HasPrefix(symbol.Name, "__anon") || strings.HasPrefix(symbol.Parent, "__anon") || strings.HasPrefix(symbol.Name, "AnonymousFunction") || strings.HasPrefix(symbol.Parent, "AnonymousFunction") {
 						continue
 					}
 					totalSymbols++
-					err = callback(entryToSymbol(e))
+					err = callback(symbol)
 					if err != nil {
-						log15.Error("Failed to add symbol", "symbol", e, "error", err)
+						log15.Error("Failed to add symbol", "symbol", symbol, "error", err)
 						return
 					}
 				}
@@ -125,8 +128,61 @@ func (s *Service) parseUncached(ctx context.Context, repo api.RepoName, commitID
 	return <-errChan
 }
 
-// parse gets a parser from the pool and uses it to satisfy the parse request.
-func (s *Service) parse(ctx context.Context, req parseRequest) (entries []*ctags.Entry, err error) {
+// Parser parses the symbols defined in a file. It allows parsing files with
+// something other than the pool of ctags processes, see ParserSelection.
+type Parser interface {
+	Parse(ctx context.Context, path string, content []byte) ([]result.Symbol, error)
+}
+
+// ParserSelection selects the parser of each file.
+type ParserSelection interface {
+	// Key identifies the selection. It is part of the cache key of symbols
+	// databases, so that a database is only reused, or derived from the
+	// database of an ancestor commit, with the parsers it was built with.
+	Key() string
+
+	// ParserForPath returns the parser for the file at path, or nil to parse
+	// the file with ctags.
+	ParserForPath(path string) Parser
+}
+
+// ctagsOnly is the ParserSelection that parses all files with ctags.
+type ctagsOnly struct{}
+
+func (ctagsOnly) Key() string                      { return "" }
+func (ctagsOnly) ParserForPath(path string) Parser { return nil }
+
+// parserSelection returns the parsers currently selected by s.Parsers.
+func (s *Service) parserSelection() ParserSelection {
+	if s.Parsers == nil {
+		return ctagsOnly{}
+	}
+	return s.Parsers()
+}
+
+// parse parses the symbols of the file in the parse request, using the parser
+// selected by parsers or else a ctags parser from the pool.
+func (s *Service) parse(ctx context.Context, parsers ParserSelection, req parseRequest) ([]result.Symbol, error) {
+	if parser := parsers.ParserForPath(req.path); parser != nil {
+		parsing.Inc()
+		defer parsing.Dec()
+		return parser.Parse(ctx, req.path, req.data)
+	}
+
+	entries, err := s.parseWithCtags(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols := make([]result.Symbol, 0, len(entries))
+	for _, e := range entries {
+		symbols = append(symbols, entryToSymbol(e))
+	}
+	return symbols, nil
+}
+
+// parseWithCtags gets a parser from the pool and uses it to satisfy the parse request.
+func (s *Service) parseWithCtags(ctx context.Context, req parseRequest) (entries []*ctags.Entry, err error) {
 	parseQueueSize.Inc()
 
 	select {
@@ -10,6 +10,7 @@ import (
 	"regexp/syntax"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/mattn/go-sqlite3"
 
@@ -20,6 +21,8 @@ import (
 	"github.com/keegancsmith/sqlf"
 	"github.com/opentracing/opentracing-go/ext"
 	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	nettrace "golang.org/x/net/trace"
 
 	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/protocol"
@@ -112,8 +115,11 @@ func (s *Service) search(ctx context.Context, args protocol.SearchArgs) (*result
 // specified in `args`. If the database doesn't already exist in the disk cache,
 // it will create a new one and write all the symbols into it.
 func (s *Service) getDBFile(ctx context.Context, args protocol.SearchArgs) (string, error) {
-	diskcacheFile, err := s.cache.OpenWithPath(ctx, fmt.Sprintf("%d-%s@%s", symbolsDBVersion, args.Repo, args.CommitID), func(fetcherCtx context.Context, tempDBFile string) error {
-		err := s.writeAllSymbolsToNewDB(fetcherCtx, tempDBFile, args.Repo, args.CommitID)
+	fetched := false
+	parsers := s.parserSelection()
+	diskcacheFile, err := s.cache.OpenWithPath(ctx, dbCacheKey(args.Repo, args.CommitID, parsers), func(fetcherCtx context.Context, tempDBFile string) error {
+		fetched = true
+		err := s.writeSymbolsToNewDB(fetcherCtx, parsers, tempDBFile, args.Repo, args.CommitID)
 		if err != nil {
 			if err == context.Canceled {
 				log15.Error("Unable to parse repository symbols within the context", "repo", args.Repo, "commit", args.CommitID, "query", args.Query)
@@ -127,9 +133,25 @@ func (s *Service) getDBFile(ctx context.Context, args protocol.SearchArgs) (stri
 	}
 	defer diskcacheFile.File.Close()
 
+	if fetched {
+		cacheMisses.Inc()
+	} else {
+		cacheHits.Inc()
+	}
+
 	return diskcacheFile.File.Name(), err
 }
 
+// dbCacheKey returns the disk cache key of the sqlite3 database for
+// repo@commitID built with parsers.
+func dbCacheKey(repo api.RepoName, commitID api.CommitID, parsers ParserSelection) string {
+	key := fmt.Sprintf("%d-%s@%s", symbolsDBVersion, repo, commitID)
+	if parsersKey := parsers.Key(); parsersKey != "" {
+		key += "-" + parsersKey
+	}
+	return key
+}
+
 // isLiteralEquality checks if the given regex matches literal strings exactly.
 // Returns whether or not the regex is exact, along with the literal string if
 // so.
@@ -170,7 +192,11 @@ func filterSymbols(ctx context.Context, db *sqlx.DB, args protocol.SearchArgs) (
 			return conditions
 		}
 
-		if isExact, symbolName, err := isLiteralEquality(regex); isExact && err == nil {
+		// Only name and path have a lowercase column for case insensitive
+		// exact matches.
+		hasLowercaseColumn := column == "name" || column == "path"
+
+		if isExact, symbolName, err := isLiteralEquality(regex); isExact && err == nil && (args.IsCaseSensitive || hasLowercaseColumn) {
 			// It looks like the user is asking for exact matches, so use `=` to
 			// get the speed boost from the index on the column.
 			if args.IsCaseSensitive {
@@ -198,18 +224,52 @@ func filterSymbols(ctx context.Context, db *sqlx.DB, args protocol.SearchArgs) (
 		return newConditions
 	}
 
+	makeInCondition := func(column string, values []string) []*sqlf.Query {
+		if len(values) == 0 {
+			return nil
+		}
+
+		items := make([]*sqlf.Query, 0, len(values))
+		for _, value := range values {
+			items = append(items, sqlf.Sprintf("%s", strings.ToLower(value)))
+		}
+		return []*sqlf.Query{sqlf.Sprintf("lower("+column+") IN (%s)", sqlf.Join(items, ","))}
+	}
+
 	var conditions []*sqlf.Query
 	conditions = append(conditions, makeCondition("name", args.Query)...)
 	for _, includePattern := range args.IncludePatterns {
 		conditions = append(conditions, makeCondition("path", includePattern)...)
 	}
 	conditions = append(conditions, negateAll(makeCondition("path", args.ExcludePattern))...)
+	conditions = append(conditions, makeCondition("parent", args.ContainerPattern)...)
+	conditions = append(conditions, makeInCondition("kind", args.Kinds)...)
+	conditions = append(conditions, makeInCondition("language", args.Languages)...)
 
-	var sqlQuery *sqlf.Query
-	if len(conditions) == 0 {
-		sqlQuery = sqlf.Sprintf("SELECT * FROM symbols LIMIT %s", args.First)
-	} else {
-		sqlQuery = sqlf.Sprintf("SELECT * FROM symbols WHERE %s LIMIT %s", sqlf.Join(conditions, "AND"), args.First)
+	selectSymbols := func(conditions []*sqlf.Query, limit int) *sqlf.Query {
+		if len(conditions) == 0 {
+			return sqlf.Sprintf("SELECT * FROM symbols LIMIT %s", limit)
+		}
+		return sqlf.Sprintf("SELECT * FROM symbols WHERE %s LIMIT %s", sqlf.Join(conditions, "AND"), limit)
+	}
+
+	sqlQuery := selectSymbols(conditions, args.First)
+	if args.Query != "" {
+		// Rank only a bounded number of candidates, so that broad queries
+		// don't sort every symbol in the repository. Exact and prefix
+		// matches are looked up through the index on their own, so that
+		// they are ranked even if they are not among the candidates.
+		candidates := []*sqlf.Query{selectSymbols(conditions, maxRankCandidates)}
+		if column, lit, ok := rankLiteral(args); ok {
+			exact := append(conditions[:len(conditions):len(conditions)], sqlf.Sprintf(column+" = %s", lit))
+			prefix := append(conditions[:len(conditions):len(conditions)], sqlf.Sprintf(column+" >= %s AND "+column+" < %s || char(1114111)", lit, lit))
+			candidates = append(candidates, selectSymbols(exact, args.First), selectSymbols(prefix, args.First))
+		}
+		for i, candidate := range candidates {
+			// A LIMIT in a compound SELECT must be in a subquery.
+			candidates[i] = sqlf.Sprintf("SELECT * FROM (%s)", candidate)
+		}
+		sqlQuery = sqlf.Sprintf("SELECT * FROM (%s) ORDER BY %s LIMIT %s", sqlf.Join(candidates, "UNION"), rankSymbols(args), args.First)
 	}
 
 	var symbolsInDB []symbolInDB
@@ -226,11 +286,94 @@ func filterSymbols(ctx context.Context, db *sqlx.DB, args protocol.SearchArgs) (
 	return res, nil
 }
 
+// maxRankCandidates is the maximum number of symbols matching a query that are
+// ranked, in addition to the exact and prefix matches of a literal query.
+// Results are taken from the ranked symbols only.
+const maxRankCandidates = 10000
+
+// rankSymbols returns the ORDER BY expressions that rank the symbols matching
+// args: symbols whose name is the query come first, then symbols whose name
+// starts with the query, then exported symbols, then symbols in files with
+// shorter paths.
+//
+// Exact and prefix matches are only ranked first if the query is a literal,
+// optionally anchored or case insensitive (see queryLiteral). Other regular
+// expressions are ranked by the remaining criteria only.
+func rankSymbols(args protocol.SearchArgs) *sqlf.Query {
+	var orderBy []*sqlf.Query
+
+	if column, lit, ok := rankLiteral(args); ok {
+		orderBy = append(orderBy, sqlf.Sprintf(
+			"CASE WHEN "+column+" = %s THEN 0 WHEN substr("+column+", 1, %s) = %s THEN 1 ELSE 2 END",
+			lit, utf8.RuneCountInString(lit), lit,
+		))
+	}
+
+	// Only Go has a syntactic notion of exported symbols (an upper case first
+	// letter). In other languages, names with a leading underscore are private
+	// by convention.
+	orderBy = append(orderBy,
+		sqlf.Sprintf("CASE WHEN language = 'Go' THEN substr(name, 1, 1) NOT BETWEEN 'A' AND 'Z' ELSE substr(name, 1, 1) = '_' END"),
+		sqlf.Sprintf("length(path)"),
+		sqlf.Sprintf("path"),
+		sqlf.Sprintf("line"),
+	)
+
+	return sqlf.Join(orderBy, ",")
+}
+
+// rankLiteral returns the literal of the query of args (see queryLiteral), and
+// the column that exact and prefix matches of it are found in.
+func rankLiteral(args protocol.SearchArgs) (column, lit string, ok bool) {
+	lit, foldCase, ok := queryLiteral(args.Query)
+	if !ok {
+		return "", "", false
+	}
+	if !args.IsCaseSensitive || foldCase {
+		return "namelowercase", strings.ToLower(lit), true
+	}
+	return "name", lit, true
+}
+
+// queryLiteral returns the literal that the regex expr matches, ignoring
+// anchors, e.g. "foo" for `^foo$`. foldCase reports whether the literal is
+// case insensitive, e.g. for `(?i)foo`, in which case lit is lower case. It
+// returns false if expr is not a literal.
+func queryLiteral(expr string) (lit string, foldCase bool, ok bool) {
+	r, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return "", false, false
+	}
+
+	if r.Op == syntax.OpConcat {
+		sub := r.Sub
+		if len(sub) > 0 && (sub[0].Op == syntax.OpBeginLine || sub[0].Op == syntax.OpBeginText) {
+			sub = sub[1:]
+		}
+		if len(sub) > 0 && (sub[len(sub)-1].Op == syntax.OpEndLine || sub[len(sub)-1].Op == syntax.OpEndText) {
+			sub = sub[:len(sub)-1]
+		}
+		if len(sub) != 1 {
+			return "", false, false
+		}
+		r = sub[0]
+	}
+
+	if r.Op != syntax.OpLiteral {
+		return "", false, false
+	}
+	if r.Flags&syntax.FoldCase != 0 {
+		// The parser stores case insensitive literals upper cased.
+		return strings.ToLower(string(r.Rune)), true, true
+	}
+	return string(r.Rune), false, true
+}
+
 // The version of the symbols database schema. This is included in the database
 // filenames to prevent a newer version of the symbols service from attempting
 // to read from a database created by an older (and likely incompatible) symbols
 // service. Increment this when you change the database schema.
-const symbolsDBVersion = 3
+const symbolsDBVersion = 4
 
 // symbolInDB is the same as `protocol.Symbol`, but with two additional columns:
 // namelowercase and pathlowercase, which enable indexed case insensitive
@@ -241,6 +384,7 @@ type symbolInDB struct {
 	Path          string
 	PathLowercase string // derived from `Path`
 	Line          int
+	EndLine       int
 	Kind          string
 	Language      string
 	Parent        string
@@ -258,6 +402,7 @@ func symbolToSymbolInDB(symbol result.Symbol) symbolInDB {
 		Path:          symbol.Path,
 		PathLowercase: strings.ToLower(symbol.Path),
 		Line:          symbol.Line,
+		EndLine:       symbol.EndLine,
 		Kind:          symbol.Kind,
 		Language:      symbol.Language,
 		Parent:        symbol.Parent,
@@ -274,6 +419,7 @@ func symbolInDBToSymbol(symbolInDB symbolInDB) result.Symbol {
 		Name:       symbolInDB.Name,
 		Path:       symbolInDB.Path,
 		Line:       symbolInDB.Line,
+		EndLine:    symbolInDB.EndLine,
 		Kind:       symbolInDB.Kind,
 		Language:   symbolInDB.Language,
 		Parent:     symbolInDB.Parent,
@@ -286,8 +432,8 @@ func symbolInDBToSymbol(symbolInDB symbolInDB) result.Symbol {
 }
 
 // writeAllSymbolsToNewDB fetches the repo@commit from gitserver, parses all the
-// symbols, and writes them to the blank database file `dbFile`.
-func (s *Service) writeAllSymbolsToNewDB(ctx context.Context, dbFile string, repoName api.RepoName, commitID api.CommitID) (err error) {
+// symbols with parsers, and writes them to the blank database file `dbFile`.
+func (s *Service) writeAllSymbolsToNewDB(ctx context.Context, parsers ParserSelection, dbFile string, repoName api.RepoName, commitID api.CommitID) (err error) {
 	db, err := sqlx.Open("sqlite3_with_regexp", dbFile)
 	if err != nil {
 		return err
@@ -307,16 +453,35 @@ func (s *Service) writeAllSymbolsToNewDB(ctx context.Context, dbFile string, rep
 		err = tx.Commit()
 	}()
 
+	if err := createSymbolsTable(tx); err != nil {
+		return err
+	}
+
+	insertStatement, err := prepareInsertSymbol(tx)
+	if err != nil {
+		return err
+	}
+
+	return s.parseUncached(ctx, parsers, repoName, commitID, nil, func(symbol result.Symbol) error {
+		symbolInDBValue := symbolToSymbolInDB(symbol)
+		_, err := insertStatement.Exec(&symbolInDBValue)
+		return err
+	})
+}
+
+// createSymbolsTable creates the symbols table and its indexes.
+func createSymbolsTable(tx *sqlx.Tx) error {
 	// The column names are the lowercase version of fields in `symbolInDB`
 	// because sqlx lowercases struct fields by default. See
 	// http://jmoiron.github.io/sqlx/#query
-	_, err = tx.Exec(
+	_, err := tx.Exec(
 		`CREATE TABLE IF NOT EXISTS symbols (
 			name VARCHAR(256) NOT NULL,
 			namelowercase VARCHAR(256) NOT NULL,
 			path VARCHAR(4096) NOT NULL,
 			pathlowercase VARCHAR(4096) NOT NULL,
 			line INT NOT NULL,
+			endline INT NOT NULL,
 			kind VARCHAR(255) NOT NULL,
 			language VARCHAR(255) NOT NULL,
 			parent VARCHAR(255) NOT NULL,
@@ -346,24 +511,17 @@ func (s *Service) writeAllSymbolsToNewDB(ctx context.Context, dbFile string, rep
 	}
 
 	_, err = tx.Exec(`CREATE INDEX pathlowercase_index ON symbols(pathlowercase);`)
-	if err != nil {
-		return err
-	}
+	return err
+}
 
-	insertStatement, err := tx.PrepareNamed(
+// prepareInsertSymbol prepares a statement that inserts a `symbolInDB` into
+// the symbols table.
+func prepareInsertSymbol(tx *sqlx.Tx) (*sqlx.NamedStmt, error) {
+	return tx.PrepareNamed(
 		fmt.Sprintf(
 			"INSERT INTO symbols %s VALUES %s",
-			"( name,  namelowercase,  path,  pathlowercase,  line,  kind,  language,  parent,  parentkind,  signature,  pattern,  filelimited)",
-			"(:name, :namelowercase, :path, :pathlowercase, :line, :kind, :language, :parent, :parentkind, :signature, :pattern, :filelimited)"))
-	if err != nil {
-		return err
-	}
-
-	return s.parseUncached(ctx, repoName, commitID, func(symbol result.Symbol) error {
-		symbolInDBValue := symbolToSymbolInDB(symbol)
-		_, err := insertStatement.Exec(&symbolInDBValue)
-		return err
-	})
+			"( name,  namelowercase,  path,  pathlowercase,  line,  endline,  kind,  language,  parent,  parentkind,  signature,  pattern,  filelimited)",
+			"(:name, :namelowercase, :path, :pathlowercase, :line, :endline, :kind, :language, :parent, :parentkind, :signature, :pattern, :filelimited)"))
 }
 
 // SanityCheck makes sure that go-sqlite3 was compiled with cgo by
@@ -384,3 +542,14 @@ func SanityCheck() error {
 
 	return nil
 }
+
+var (
+	cacheHits = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "symbols_store_cache_hits",
+		Help: "The total number of searches served from an already cached symbols database.",
+	})
+	cacheMisses = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "symbols_store_cache_misses",
+		Help: "The total number of searches that had to build a new symbols database.",
+	})
+)
@@ -3,6 +3,7 @@ package symbols
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/testutil"
 )
 
@@ -17,7 +19,9 @@ func BenchmarkSearch(b *testing.B) {
 	log15.Root().SetHandler(log15.LvlFilterHandler(log15.LvlError, log15.Root().GetHandler()))
 
 	service := Service{
-		FetchTar:  testutil.FetchTarFromGithub,
+		FetchTar: func(ctx context.Context, repo api.RepoName, commit api.CommitID, paths []string) (io.ReadCloser, error) {
+			return testutil.FetchTarFromGithub(ctx, repo, commit)
+		},
 		NewParser: NewParser,
 		Path:      "/tmp/symbols-cache",
 	}
@@ -48,7 +52,7 @@ func BenchmarkSearch(b *testing.B) {
 					b.Fatal(err)
 				}
 				defer os.Remove(tempFile.Name())
-				err = service.writeAllSymbolsToNewDB(ctx, tempFile.Name(), test.Repo, test.CommitID)
+				err = service.writeAllSymbolsToNewDB(ctx, ctagsOnly{}, tempFile.Name(), test.Repo, test.CommitID)
 				if err != nil {
 					b.Fatal(err)
 				}
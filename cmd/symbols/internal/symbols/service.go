@@ -20,9 +20,18 @@ import (
 // Service is the symbols service.
 type Service struct {
 	// FetchTar returns an io.ReadCloser to a tar archive of a repository at the specified Git
-	// remote URL and commit ID. If the error implements "BadRequest() bool", it will be used to
-	// determine if the error is a bad request (eg invalid repo).
-	FetchTar func(context.Context, api.RepoName, api.CommitID) (io.ReadCloser, error)
+	// remote URL and commit ID. If paths is non-empty, the archive only contains the files at
+	// those paths. If the error implements "BadRequest() bool", it will be used to determine if
+	// the error is a bad request (eg invalid repo).
+	FetchTar func(ctx context.Context, repo api.RepoName, commit api.CommitID, paths []string) (io.ReadCloser, error)
+
+	// Ancestors returns up to n ancestors of a commit, nearest first. Together with GitDiff it
+	// is used to derive the symbols of a commit from the cached symbols of an ancestor. If
+	// either is nil, the symbols of every commit are parsed from scratch.
+	Ancestors func(ctx context.Context, repo api.RepoName, commit api.CommitID, n int) ([]api.CommitID, error)
+
+	// GitDiff returns the paths that changed between commitA and commitB.
+	GitDiff func(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID) (*Changes, error)
 
 	// MaxConcurrentFetchTar is the maximum number of concurrent calls allowed
 	// to FetchTar. It defaults to 15.
@@ -30,6 +39,11 @@ type Service struct {
 
 	NewParser func() (ctags.Parser, error)
 
+	// Parsers, when set, returns the parsers currently selected to parse files instead of
+	// ctags. It is called once per symbols database, so that all the files of a database
+	// are parsed with the same selection.
+	Parsers func() ParserSelection
+
 	// NumParserProcesses is the maximum number of ctags parser child processes to run.
 	NumParserProcesses int
 
@@ -4,12 +4,15 @@ import (
 	"archive/tar"
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"net/http/httptest"
 	"os"
 	"reflect"
+	"sort"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/sourcegraph/go-ctags"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -57,6 +60,19 @@ func TestIsLiteralEquality(t *testing.T) {
 }
 
 func TestService(t *testing.T) {
+	// The same symbols are found whether files are parsed by the ctags parser
+	// pool or by the parser selected by Parsers.
+	t.Run("ctags", func(t *testing.T) {
+		testService(t, mockParser{"x", "y"}, nil)
+	})
+	t.Run("Parsers", func(t *testing.T) {
+		testService(t, mockParser{}, func() ParserSelection {
+			return mockParserSelection{mockSymbolParser{mockParser{"x", "y"}}}
+		})
+	})
+}
+
+func testService(t *testing.T, ctagsParser mockParser, parsers func() ParserSelection) {
 	tmpDir, err := os.MkdirTemp("", "")
 	if err != nil {
 		t.Fatal(err)
@@ -65,13 +81,14 @@ func TestService(t *testing.T) {
 
 	files := map[string]string{"a.js": "var x = 1"}
 	service := Service{
-		FetchTar: func(ctx context.Context, repo api.RepoName, commit api.CommitID) (io.ReadCloser, error) {
+		FetchTar: func(ctx context.Context, repo api.RepoName, commit api.CommitID, paths []string) (io.ReadCloser, error) {
 			return createTar(files)
 		},
 		NewParser: func() (ctags.Parser, error) {
-			return mockParser{"x", "y"}, nil
+			return ctagsParser, nil
 		},
-		Path: tmpDir,
+		Parsers: parsers,
+		Path:    tmpDir,
 	}
 
 	if err := service.Start(); err != nil {
@@ -147,6 +164,259 @@ func TestService(t *testing.T) {
 	}
 }
 
+func TestServiceFiltersAndRanking(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { os.RemoveAll(tmpDir) }()
+
+	symbols := map[string][]*ctags.Entry{
+		"a/b/long.go": {
+			{Name: "Foo", Path: "a/b/long.go", Line: 1, Kind: "function", Language: "Go"},
+		},
+		"short.go": {
+			{Name: "foobar", Path: "short.go", Line: 1, Kind: "function", Language: "Go"},
+			{Name: "FooBar", Path: "short.go", Line: 2, Kind: "function", Language: "Go"},
+			{Name: "foo", Path: "short.go", Line: 3, Kind: "field", Language: "Go", Parent: "Server", ParentKind: "struct"},
+		},
+		"x.py": {
+			{Name: "_foo", Path: "x.py", Line: 1, Kind: "function", Language: "Python"},
+			{Name: "foo_bar", Path: "x.py", Line: 2, Kind: "method", Language: "Python", Parent: "Client", ParentKind: "class"},
+		},
+	}
+	files := map[string]string{}
+	for path := range symbols {
+		files[path] = path
+	}
+	service := Service{
+		FetchTar: func(ctx context.Context, repo api.RepoName, commit api.CommitID, paths []string) (io.ReadCloser, error) {
+			return createTar(files)
+		},
+		NewParser: func() (ctags.Parser, error) {
+			return entriesParser(symbols), nil
+		},
+		Path: tmpDir,
+	}
+
+	if err := service.Start(); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(service.Handler())
+	defer server.Close()
+	client := symbolsclient.Client{
+		URL:        server.URL,
+		HTTPClient: httpcli.InternalDoer,
+	}
+
+	tests := map[string]struct {
+		args search.SymbolsParameters
+		want []string
+	}{
+		"ranking": {
+			args: search.SymbolsParameters{Query: "foo"},
+			// Exact matches, then prefix matches, then other matches. Ties
+			// are broken by exported symbols first, then shorter paths.
+			want: []string{"Foo", "foo", "foo_bar", "FooBar", "foobar", "_foo"},
+		},
+		"ranking case sensitive": {
+			args: search.SymbolsParameters{Query: "foo", IsCaseSensitive: true},
+			want: []string{"foo", "foo_bar", "foobar", "_foo"},
+		},
+		"ranking case insensitive literal": {
+			args: search.SymbolsParameters{Query: "(?i)foo", IsCaseSensitive: true},
+			want: []string{"Foo", "foo", "foo_bar", "FooBar", "foobar", "_foo"},
+		},
+		"ranking without literal query": {
+			args: search.SymbolsParameters{Query: "^_?foo.+"},
+			want: []string{"foo_bar", "FooBar", "foobar"},
+		},
+		"kinds": {
+			args: search.SymbolsParameters{Query: "foo", Kinds: []string{"Field", "method"}},
+			want: []string{"foo", "foo_bar"},
+		},
+		"languages": {
+			args: search.SymbolsParameters{Query: "foo", Languages: []string{"python"}},
+			want: []string{"foo_bar", "_foo"},
+		},
+		"container": {
+			args: search.SymbolsParameters{ContainerPattern: "^server$"},
+			want: []string{"foo"},
+		},
+		"container case sensitive": {
+			args: search.SymbolsParameters{ContainerPattern: "^server$", IsCaseSensitive: true},
+			want: nil,
+		},
+		"container regexp": {
+			args: search.SymbolsParameters{ContainerPattern: "Cli"},
+			want: []string{"foo_bar"},
+		},
+	}
+	for label, test := range tests {
+		t.Run(label, func(t *testing.T) {
+			test.args.Repo = "r"
+			test.args.First = 10
+			res, err := client.Search(context.Background(), test.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			if res != nil {
+				for _, s := range *res {
+					names = append(names, s.Name)
+				}
+			}
+			if diff := cmp.Diff(test.want, names); diff != "" {
+				t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServiceRankingBeyondCandidates(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { os.RemoveAll(tmpDir) }()
+
+	// The exact match is stored after more symbols than are ranked as
+	// candidates.
+	var entries []*ctags.Entry
+	for i := 0; i <= maxRankCandidates; i++ {
+		entries = append(entries, &ctags.Entry{Name: fmt.Sprintf("xfoo%d", i), Path: "big.go", Line: i + 1, Kind: "function", Language: "Go"})
+	}
+	entries = append(entries, &ctags.Entry{Name: "foo", Path: "big.go", Line: len(entries) + 1, Kind: "function", Language: "Go"})
+	symbols := map[string][]*ctags.Entry{"big.go": entries}
+
+	service := Service{
+		FetchTar: func(ctx context.Context, repo api.RepoName, commit api.CommitID, paths []string) (io.ReadCloser, error) {
+			return createTar(map[string]string{"big.go": "big.go"})
+		},
+		NewParser: func() (ctags.Parser, error) {
+			return entriesParser(symbols), nil
+		},
+		Path: tmpDir,
+	}
+	if err := service.Start(); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(service.Handler())
+	defer server.Close()
+	client := symbolsclient.Client{
+		URL:        server.URL,
+		HTTPClient: httpcli.InternalDoer,
+	}
+
+	for _, query := range []string{"foo", "^foo$"} {
+		res, err := client.Search(context.Background(), search.SymbolsParameters{Repo: "r", Query: query, First: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res == nil || len(*res) != 1 || (*res)[0].Name != "foo" {
+			t.Errorf("query %q: got %v, want the exact match foo", query, res)
+		}
+	}
+}
+
+func TestQueryLiteral(t *testing.T) {
+	for _, test := range []struct {
+		expr         string
+		wantLit      string
+		wantFoldCase bool
+		wantOk       bool
+	}{
+		{expr: `foo`, wantLit: "foo", wantOk: true},
+		{expr: `^foo$`, wantLit: "foo", wantOk: true},
+		{expr: `^foo`, wantLit: "foo", wantOk: true},
+		{expr: `foo\.bar`, wantLit: "foo.bar", wantOk: true},
+		{expr: `(?i)foo`, wantLit: "foo", wantFoldCase: true, wantOk: true},
+		{expr: `(?i:^foo$)`, wantLit: "foo", wantFoldCase: true, wantOk: true},
+		{expr: ``, wantOk: false},
+		{expr: `^$`, wantOk: false},
+		{expr: `foo.*`, wantOk: false},
+		{expr: `foo|bar`, wantOk: false},
+		{expr: `(`, wantOk: false},
+	} {
+		lit, foldCase, ok := queryLiteral(test.expr)
+		if lit != test.wantLit || foldCase != test.wantFoldCase || ok != test.wantOk {
+			t.Errorf("queryLiteral(%q) = %q, %t, %t, want %q, %t, %t", test.expr, lit, foldCase, ok, test.wantLit, test.wantFoldCase, test.wantOk)
+		}
+	}
+}
+
+func TestServiceIncremental(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { os.RemoveAll(tmpDir) }()
+
+	commits := map[api.CommitID]map[string]string{
+		"a": {"a.js": "x", "b.js": "y"},
+		"b": {"a.js": "x2", "c.js": "z"},
+	}
+	var fetchedPaths [][]string
+	service := Service{
+		FetchTar: func(ctx context.Context, repo api.RepoName, commit api.CommitID, paths []string) (io.ReadCloser, error) {
+			fetchedPaths = append(fetchedPaths, paths)
+			files := map[string]string{}
+			for name, body := range commits[commit] {
+				files[name] = body
+			}
+			if len(paths) > 0 {
+				files = map[string]string{}
+				for _, p := range paths {
+					files[p] = commits[commit][p]
+				}
+			}
+			return createTar(files)
+		},
+		Ancestors: func(ctx context.Context, repo api.RepoName, commit api.CommitID, n int) ([]api.CommitID, error) {
+			if commit == "b" {
+				return []api.CommitID{"a"}, nil
+			}
+			return nil, nil
+		},
+		GitDiff: func(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID) (*Changes, error) {
+			return &Changes{Added: []string{"c.js"}, Modified: []string{"a.js"}, Deleted: []string{"b.js"}}, nil
+		},
+		NewParser: func() (ctags.Parser, error) {
+			return contentParser{}, nil
+		},
+		Path: tmpDir,
+	}
+
+	if err := service.Start(); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(service.Handler())
+	defer server.Close()
+	client := symbolsclient.Client{
+		URL:        server.URL,
+		HTTPClient: httpcli.InternalDoer,
+	}
+
+	searchAt := func(commit api.CommitID) []result.Symbol {
+		res, err := client.Search(context.Background(), search.SymbolsParameters{Repo: "r", CommitID: commit, First: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sort.Slice(*res, func(i, j int) bool { return (*res)[i].Name < (*res)[j].Name })
+		return *res
+	}
+
+	if diff := cmp.Diff([]result.Symbol{{Name: "x", Path: "a.js"}, {Name: "y", Path: "b.js"}}, searchAt("a")); diff != "" {
+		t.Errorf("unexpected symbols at commit a (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]result.Symbol{{Name: "x2", Path: "a.js"}, {Name: "z", Path: "c.js"}}, searchAt("b")); diff != "" {
+		t.Errorf("unexpected symbols at commit b (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([][]string{nil, {"c.js", "a.js"}}, fetchedPaths); diff != "" {
+		t.Errorf("unexpected fetched paths (-want +got):\n%s", diff)
+	}
+}
+
 func createTar(files map[string]string) (io.ReadCloser, error) {
 	buf := new(bytes.Buffer)
 	w := tar.NewWriter(buf)
@@ -182,3 +452,45 @@ func (m mockParser) Parse(name string, content []byte) ([]*ctags.Entry, error) {
 }
 
 func (mockParser) Close() {}
+
+// mockSymbolParser is a Parser that returns the symbols of mockParser.
+type mockParserSelection struct {
+	parser Parser
+}
+
+func (m mockParserSelection) Key() string                      { return "mock" }
+func (m mockParserSelection) ParserForPath(path string) Parser { return m.parser }
+
+type mockSymbolParser struct {
+	mockParser
+}
+
+func (m mockSymbolParser) Parse(ctx context.Context, path string, content []byte) ([]result.Symbol, error) {
+	entries, err := m.mockParser.Parse(path, content)
+	if err != nil {
+		return nil, err
+	}
+	symbols := make([]result.Symbol, 0, len(entries))
+	for _, e := range entries {
+		symbols = append(symbols, entryToSymbol(e))
+	}
+	return symbols, nil
+}
+
+// entriesParser returns the entries of the parsed file.
+type entriesParser map[string][]*ctags.Entry
+
+func (m entriesParser) Parse(name string, content []byte) ([]*ctags.Entry, error) {
+	return m[name], nil
+}
+
+func (entriesParser) Close() {}
+
+// contentParser returns a single symbol named after the content of the file.
+type contentParser struct{}
+
+func (contentParser) Parse(name string, content []byte) ([]*ctags.Entry, error) {
+	return []*ctags.Entry{{Name: string(content), Path: name}}, nil
+}
+
+func (contentParser) Close() {}
@@ -0,0 +1,328 @@
+package treesitter
+
+import (
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/golang"
+	"github.com/smacker/go-tree-sitter/java"
+	"github.com/smacker/go-tree-sitter/python"
+	"github.com/smacker/go-tree-sitter/typescript/tsx"
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
+)
+
+// language describes how to find the symbols in the syntax trees of a
+// language.
+type language struct {
+	// id identifies the language in the site configuration.
+	id string
+	// name is the language name reported in symbols, as universal-ctags
+	// names it.
+	name    string
+	grammar *sitter.Language
+
+	// definitions returns the symbols defined by node n, which is enclosed by
+	// scope s.
+	definitions func(n *sitter.Node, src []byte, s scope) []definition
+}
+
+var (
+	goLanguage = &language{
+		id:          "go",
+		name:        "Go",
+		grammar:     golang.GetLanguage(),
+		definitions: goDefinitions,
+	}
+	javaLanguage = &language{
+		id:          "java",
+		name:        "Java",
+		grammar:     java.GetLanguage(),
+		definitions: javaDefinitions,
+	}
+	pythonLanguage = &language{
+		id:          "python",
+		name:        "Python",
+		grammar:     python.GetLanguage(),
+		definitions: pythonDefinitions,
+	}
+	typescriptLanguage = &language{
+		id:          "typescript",
+		name:        "TypeScript",
+		grammar:     typescript.GetLanguage(),
+		definitions: typescriptDefinitions,
+	}
+	tsxLanguage = &language{
+		id:          "typescript",
+		name:        "TypeScript",
+		grammar:     tsx.GetLanguage(),
+		definitions: typescriptDefinitions,
+	}
+)
+
+// extensions maps file extensions to their language.
+var extensions = map[string]*language{
+	".go":   goLanguage,
+	".java": javaLanguage,
+	".py":   pythonLanguage,
+	".ts":   typescriptLanguage,
+	".tsx":  tsxLanguage,
+}
+
+// isFunctionScope reports whether s is the body of a function, in which
+// variables are local and not reported as symbols.
+func isFunctionScope(s scope) bool {
+	switch s.kind {
+	case "function", "method", "constructor":
+		return true
+	}
+	return false
+}
+
+func goDefinitions(n *sitter.Node, src []byte, s scope) []definition {
+	switch n.Type() {
+	case "package_clause":
+		return []definition{{name: n.NamedChild(0), kind: "package", node: n}}
+
+	case "function_declaration":
+		return []definition{{name: n.ChildByFieldName("name"), kind: "function", node: n, signature: goSignature(n, src), isScope: true}}
+
+	case "method_declaration":
+		var receiver string
+		if params := n.ChildByFieldName("receiver"); params != nil && params.NamedChildCount() > 0 {
+			receiver = content(goTypeName(params.NamedChild(0).ChildByFieldName("type")), src)
+		}
+		return []definition{{name: n.ChildByFieldName("name"), kind: "method", node: n, signature: goSignature(n, src), parent: receiver, isScope: true}}
+
+	case "type_spec":
+		kind := "type"
+		if t := n.ChildByFieldName("type"); t != nil {
+			switch t.Type() {
+			case "struct_type":
+				kind = "struct"
+			case "interface_type":
+				kind = "interface"
+			}
+		}
+		return []definition{{name: n.ChildByFieldName("name"), kind: kind, node: n, isScope: true}}
+
+	case "type_alias":
+		return []definition{{name: n.ChildByFieldName("name"), kind: "type", node: n}}
+
+	case "field_declaration":
+		names := childrenByFieldName(n, "name")
+		if len(names) == 0 {
+			// Embedded fields are named after their type.
+			names = []*sitter.Node{goTypeName(n.ChildByFieldName("type"))}
+		}
+		return definitionsOf(names, "field", n)
+
+	case "method_spec":
+		return []definition{{name: n.ChildByFieldName("name"), kind: "method", node: n, signature: goSignature(n, src)}}
+
+	case "const_spec", "var_spec":
+		if isFunctionScope(s) {
+			return nil
+		}
+		kind := "variable"
+		if n.Type() == "const_spec" {
+			kind = "constant"
+		}
+		return definitionsOf(childrenByFieldName(n, "name"), kind, n)
+	}
+	return nil
+}
+
+// goSignature returns the parameters and results of a Go function or method.
+func goSignature(n *sitter.Node, src []byte) string {
+	signature := content(n.ChildByFieldName("parameters"), src)
+	if result := n.ChildByFieldName("result"); result != nil {
+		signature += " " + content(result, src)
+	}
+	return signature
+}
+
+// goTypeName returns the identifier of the named type t, dereferencing
+// pointers and dropping package qualifiers and type arguments.
+func goTypeName(t *sitter.Node) *sitter.Node {
+	for t != nil {
+		switch t.Type() {
+		case "pointer_type":
+			t = t.NamedChild(0)
+		case "qualified_type":
+			t = t.ChildByFieldName("name")
+		case "generic_type":
+			t = t.ChildByFieldName("type")
+		case "type_identifier":
+			return t
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func typescriptDefinitions(n *sitter.Node, src []byte, s scope) []definition {
+	switch n.Type() {
+	case "class_declaration", "abstract_class_declaration", "class":
+		return []definition{{name: n.ChildByFieldName("name"), kind: "class", node: n, isScope: true}}
+
+	case "interface_declaration":
+		return []definition{{name: n.ChildByFieldName("name"), kind: "interface", node: n, isScope: true}}
+
+	case "enum_declaration":
+		return []definition{{name: n.ChildByFieldName("name"), kind: "enum", node: n, isScope: true}}
+
+	case "enum_body":
+		var defs []definition
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			member := n.NamedChild(i)
+			name := member
+			if member.Type() == "enum_assignment" {
+				name = member.NamedChild(0)
+			}
+			defs = append(defs, definition{name: name, kind: "enumerator", node: member})
+		}
+		return defs
+
+	case "internal_module":
+		return []definition{{name: n.ChildByFieldName("name"), kind: "namespace", node: n, isScope: true}}
+
+	case "module":
+		return []definition{{name: n.ChildByFieldName("name"), kind: "module", node: n, isScope: true}}
+
+	case "function_declaration", "generator_function_declaration", "function", "generator_function":
+		return []definition{{name: n.ChildByFieldName("name"), kind: "function", node: n, signature: typescriptSignature(n, src), isScope: true}}
+
+	case "method_definition":
+		name := n.ChildByFieldName("name")
+		kind := "method"
+		if name != nil && name.Content(src) == "constructor" {
+			kind = "constructor"
+		}
+		return []definition{{name: name, kind: kind, node: n, signature: typescriptSignature(n, src), isScope: true}}
+
+	case "method_signature", "abstract_method_signature":
+		return []definition{{name: n.ChildByFieldName("name"), kind: "method", node: n, signature: typescriptSignature(n, src)}}
+
+	case "public_field_definition", "property_signature":
+		return []definition{{name: n.ChildByFieldName("name"), kind: "property", node: n}}
+
+	case "type_alias_declaration":
+		return []definition{{name: n.ChildByFieldName("name"), kind: "type", node: n}}
+
+	case "variable_declarator":
+		name := n.ChildByFieldName("name")
+		if isFunctionScope(s) || name == nil || name.Type() != "identifier" {
+			return nil
+		}
+		if value := n.ChildByFieldName("value"); value != nil {
+			switch value.Type() {
+			case "arrow_function", "function", "generator_function":
+				return []definition{{name: name, kind: "function", node: n, signature: typescriptSignature(value, src), isScope: true}}
+			}
+		}
+		kind := "variable"
+		if decl := n.Parent(); decl != nil && decl.Type() == "lexical_declaration" && decl.Child(0).Type() == "const" {
+			kind = "constant"
+		}
+		return []definition{{name: name, kind: kind, node: n}}
+	}
+	return nil
+}
+
+// typescriptSignature returns the parameters and return type of a TypeScript
+// function or method.
+func typescriptSignature(n *sitter.Node, src []byte) string {
+	return content(n.ChildByFieldName("parameters"), src) + content(n.ChildByFieldName("return_type"), src)
+}
+
+func pythonDefinitions(n *sitter.Node, src []byte, s scope) []definition {
+	switch n.Type() {
+	case "class_definition":
+		return []definition{{name: n.ChildByFieldName("name"), kind: "class", node: n, isScope: true}}
+
+	case "function_definition":
+		kind := "function"
+		if s.kind == "class" {
+			kind = "method"
+		}
+		signature := content(n.ChildByFieldName("parameters"), src)
+		if returnType := n.ChildByFieldName("return_type"); returnType != nil {
+			signature += " -> " + content(returnType, src)
+		}
+		return []definition{{name: n.ChildByFieldName("name"), kind: kind, node: n, signature: signature, isScope: true}}
+
+	case "assignment":
+		if isFunctionScope(s) || n.Parent() == nil || n.Parent().Type() != "expression_statement" {
+			return nil
+		}
+		kind := "variable"
+		if s.kind == "class" {
+			kind = "field"
+		}
+		left := n.ChildByFieldName("left")
+		if left == nil {
+			return nil
+		}
+		switch left.Type() {
+		case "identifier":
+			return []definition{{name: left, kind: kind, node: n}}
+		case "pattern_list", "tuple_pattern":
+			var names []*sitter.Node
+			for i := 0; i < int(left.NamedChildCount()); i++ {
+				if c := left.NamedChild(i); c.Type() == "identifier" {
+					names = append(names, c)
+				}
+			}
+			return definitionsOf(names, kind, n)
+		}
+	}
+	return nil
+}
+
+func javaDefinitions(n *sitter.Node, src []byte, s scope) []definition {
+	switch n.Type() {
+	case "package_declaration":
+		return []definition{{name: n.NamedChild(0), kind: "package", node: n}}
+
+	case "class_declaration":
+		return []definition{{name: n.ChildByFieldName("name"), kind: "class", node: n, isScope: true}}
+
+	case "interface_declaration":
+		return []definition{{name: n.ChildByFieldName("name"), kind: "interface", node: n, isScope: true}}
+
+	case "enum_declaration":
+		return []definition{{name: n.ChildByFieldName("name"), kind: "enum", node: n, isScope: true}}
+
+	case "annotation_type_declaration":
+		return []definition{{name: n.ChildByFieldName("name"), kind: "annotation", node: n, isScope: true}}
+
+	case "enum_constant":
+		return []definition{{name: n.ChildByFieldName("name"), kind: "enumConstant", node: n}}
+
+	case "method_declaration":
+		return []definition{{name: n.ChildByFieldName("name"), kind: "method", node: n, signature: content(n.ChildByFieldName("parameters"), src), isScope: true}}
+
+	case "constructor_declaration":
+		return []definition{{name: n.ChildByFieldName("name"), kind: "constructor", node: n, signature: content(n.ChildByFieldName("parameters"), src), isScope: true}}
+
+	case "annotation_type_element_declaration":
+		return []definition{{name: n.ChildByFieldName("name"), kind: "method", node: n}}
+
+	case "field_declaration", "constant_declaration":
+		var names []*sitter.Node
+		for _, declarator := range childrenByFieldName(n, "declarator") {
+			names = append(names, declarator.ChildByFieldName("name"))
+		}
+		return definitionsOf(names, "field", n)
+	}
+	return nil
+}
+
+// definitionsOf returns definitions of the given kind for each of names, which
+// are all defined by node n.
+func definitionsOf(names []*sitter.Node, kind string, n *sitter.Node) []definition {
+	defs := make([]definition, 0, len(names))
+	for _, name := range names {
+		defs = append(defs, definition{name: name, kind: kind, node: n})
+	}
+	return defs
+}
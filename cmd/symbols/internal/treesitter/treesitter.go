@@ -0,0 +1,220 @@
+// Package treesitter parses the symbols defined in files with tree-sitter
+// grammars. Unlike universal-ctags, tree-sitter produces a full syntax tree,
+// which gives accurate scopes, line ranges and signatures.
+package treesitter
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/errors"
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// Parser parses the symbols of files in the languages supported by
+// LanguageForPath.
+type Parser struct{}
+
+// Parse returns the symbols defined in the file at path.
+func (Parser) Parse(ctx context.Context, path string, content []byte) ([]result.Symbol, error) {
+	lang, ok := extensions[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		return nil, errors.Errorf("tree-sitter: unsupported language for %q", path)
+	}
+
+	parser := sitter.NewParser()
+	defer parser.Close()
+	parser.SetLanguage(lang.grammar)
+
+	tree, err := parser.ParseCtx(ctx, nil, content)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	e := extractor{lang: lang, path: path, src: content}
+	e.walk(tree.RootNode(), scope{})
+	e.resolveParentKinds()
+	return e.symbols, nil
+}
+
+// LanguageForPath returns the language of the file at path, e.g. "go", if
+// tree-sitter can parse it.
+func LanguageForPath(path string) (string, bool) {
+	lang, ok := extensions[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		return "", false
+	}
+	return lang.id, true
+}
+
+// maxPatternLength is the length at which the lines in symbol patterns are
+// cut off, like universal-ctags' default pattern length limit.
+const maxPatternLength = 250
+
+// scope is the innermost definition enclosing a node.
+type scope struct {
+	name string // qualified name, e.g. "Outer.Inner"
+	kind string
+}
+
+// definition is a symbol defined by a node of a syntax tree.
+type definition struct {
+	// name is the identifier of the symbol.
+	name *sitter.Node
+	kind string
+	// node spans the whole definition.
+	node      *sitter.Node
+	signature string
+	// parent, when set, is the parent of the symbol instead of the enclosing
+	// scope, e.g. the receiver type of a Go method.
+	parent string
+	// isScope is true for definitions that enclose other definitions, such as
+	// classes and functions.
+	isScope bool
+}
+
+type extractor struct {
+	lang    *language
+	path    string
+	src     []byte
+	lines   [][]byte
+	symbols []result.Symbol
+}
+
+func (e *extractor) walk(n *sitter.Node, s scope) {
+	inner := s
+	for _, d := range e.lang.definitions(n, e.src, s) {
+		if d.name == nil || d.name.IsMissing() {
+			continue
+		}
+		sym := e.symbol(d, s)
+		if sym.Name == "" {
+			continue
+		}
+		e.symbols = append(e.symbols, sym)
+		if d.isScope {
+			inner = scope{name: qualify(sym.Parent, sym.Name), kind: sym.Kind}
+		}
+	}
+
+	for i := 0; i < int(n.NamedChildCount()); i++ {
+		e.walk(n.NamedChild(i), inner)
+	}
+}
+
+func (e *extractor) symbol(d definition, s scope) result.Symbol {
+	parent, parentKind := s.name, s.kind
+	if d.parent != "" {
+		// The kind of an explicit parent is resolved once all symbols of
+		// the file are known.
+		parent, parentKind = d.parent, ""
+	}
+
+	node := d.node
+	if node == nil {
+		node = d.name
+	}
+
+	row := int(d.name.StartPoint().Row)
+	return result.Symbol{
+		Name:       d.name.Content(e.src),
+		Path:       e.path,
+		Line:       row + 1,
+		EndLine:    int(node.EndPoint().Row) + 1,
+		Kind:       d.kind,
+		Language:   e.lang.name,
+		Parent:     parent,
+		ParentKind: parentKind,
+		Signature:  d.signature,
+		Pattern:    e.pattern(row),
+	}
+}
+
+// resolveParentKinds sets the kind of explicit parents to the kind of the
+// top-level symbol of that name in the file, or "type" if there is none.
+func (e *extractor) resolveParentKinds() {
+	kinds := map[string]string{}
+	for _, s := range e.symbols {
+		if s.Parent == "" {
+			kinds[s.Name] = s.Kind
+		}
+	}
+
+	for i, s := range e.symbols {
+		if s.Parent == "" || s.ParentKind != "" {
+			continue
+		}
+		if kind, ok := kinds[s.Parent]; ok {
+			e.symbols[i].ParentKind = kind
+		} else {
+			e.symbols[i].ParentKind = "type"
+		}
+	}
+}
+
+// pattern returns the line at row in the form of a universal-ctags pattern,
+// i.e. /^line$/.
+func (e *extractor) pattern(row int) string {
+	if e.lines == nil {
+		e.lines = bytes.Split(e.src, []byte("\n"))
+	}
+	if row >= len(e.lines) {
+		return ""
+	}
+
+	line := string(bytes.TrimSuffix(e.lines[row], []byte("\r")))
+	if len(line) > maxPatternLength {
+		// Cut the line on a rune boundary to keep the pattern valid UTF-8.
+		n := maxPatternLength
+		for n > 0 && !utf8.RuneStart(line[n]) {
+			n--
+		}
+		return "/^" + escapePattern(line[:n]) + "/"
+	}
+	return "/^" + escapePattern(line) + "$/"
+}
+
+// escapePattern escapes the characters of s that are special in
+// universal-ctags patterns.
+func escapePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `/`, `\/`).Replace(s)
+}
+
+// qualify joins a parent scope and a name.
+func qualify(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+// childrenByFieldName returns the named children of n with the given field
+// name. Some grammars also assign the field to separators, such as the commas
+// between the names of Go constants, which are skipped.
+func childrenByFieldName(n *sitter.Node, field string) []*sitter.Node {
+	c := sitter.NewTreeCursor(n)
+	defer c.Close()
+
+	var children []*sitter.Node
+	for ok := c.GoToFirstChild(); ok; ok = c.GoToNextSibling() {
+		if c.CurrentFieldName() == field && c.CurrentNode().IsNamed() {
+			children = append(children, c.CurrentNode())
+		}
+	}
+	return children
+}
+
+// content returns the source of n with runs of whitespace collapsed, or the
+// empty string if n is nil.
+func content(n *sitter.Node, src []byte) string {
+	if n == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(n.Content(src)), " ")
+}
@@ -0,0 +1,172 @@
+package treesitter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		path string
+		src  string
+		want []string // line-endLine kind name parentKind:parent signature
+	}{
+		{
+			path: "a.go",
+			src: `package foo
+type S struct { A, B int; *io.Reader }
+type I interface { M(x int) error }
+func (s *S) M(a int, b string) (int, error) {
+	var local int
+	return local, nil
+}
+func F() {}
+const X, Y = 1, 2
+`,
+			want: []string{
+				`1-1 package foo : ""`,
+				`2-2 struct S : ""`,
+				`2-2 field A struct:S ""`,
+				`2-2 field B struct:S ""`,
+				`2-2 field Reader struct:S ""`,
+				`3-3 interface I : ""`,
+				`3-3 method M interface:I "(x int) error"`,
+				`4-7 method M struct:S "(a int, b string) (int, error)"`,
+				`8-8 function F : "()"`,
+				`9-9 constant X : ""`,
+				`9-9 constant Y : ""`,
+			},
+		},
+		{
+			path: "a.ts",
+			src: `namespace N {
+  export class C {
+    x: number = 1
+    constructor(a: number) {}
+    m(a: string): number { const local = 1; return local }
+  }
+}
+interface I { q(a: number): void }
+const k = 1, f = (a: number) => a
+enum E { A, B = 2 }
+`,
+			want: []string{
+				`1-7 namespace N : ""`,
+				`2-6 class C namespace:N ""`,
+				`3-3 property x class:N.C ""`,
+				`4-4 constructor constructor class:N.C "(a: number)"`,
+				`5-5 method m class:N.C "(a: string): number"`,
+				`8-8 interface I : ""`,
+				`8-8 method q interface:I "(a: number): void"`,
+				`9-9 constant k : ""`,
+				`9-9 function f : "(a: number)"`,
+				`10-10 enum E : ""`,
+				`10-10 enumerator A enum:E ""`,
+				`10-10 enumerator B enum:E ""`,
+			},
+		},
+		{
+			path: "a.py",
+			src: `class C(B):
+    x = 1
+    def m(self, a: int) -> str:
+        local = 1
+        def inner(): pass
+def f(a, *b): pass
+V, W = 1, 2
+`,
+			want: []string{
+				`1-5 class C : ""`,
+				`2-2 field x class:C ""`,
+				`3-5 method m class:C "(self, a: int) -> str"`,
+				`5-5 function inner method:C.m "()"`,
+				`6-6 function f : "(a, *b)"`,
+				`7-7 variable V : ""`,
+				`7-7 variable W : ""`,
+			},
+		},
+		{
+			path: "A.java",
+			src: `package p;
+public class C {
+  private int x, y = 1;
+  public C(int a) {}
+  public String m(int a) { int local = 1; return ""; }
+  enum E { A; }
+}
+`,
+			want: []string{
+				`1-1 package p : ""`,
+				`2-7 class C : ""`,
+				`3-3 field x class:C ""`,
+				`3-3 field y class:C ""`,
+				`4-4 constructor C class:C "(int a)"`,
+				`5-5 method m class:C "(int a)"`,
+				`6-6 enum E class:C ""`,
+				`6-6 enumConstant A enum:C.E ""`,
+			},
+		},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			symbols, err := Parser{}.Parse(context.Background(), tc.path, []byte(tc.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var have []string
+			for _, s := range symbols {
+				have = append(have, fmt.Sprintf("%d-%d %s %s %s:%s %q", s.Line, s.EndLine, s.Kind, s.Name, s.ParentKind, s.Parent, s.Signature))
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Errorf("unexpected symbols (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParse_Pattern(t *testing.T) {
+	symbols, err := Parser{}.Parse(context.Background(), "a.go", []byte("package foo\n\nconst X = `a/b\\c`\r\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if have, want := symbols[1].Pattern, "/^const X = `a\\/b\\\\c`$/"; have != want {
+		t.Errorf("unexpected pattern: have %q, want %q", have, want)
+	}
+	if have, want := symbols[1].Language, "Go"; have != want {
+		t.Errorf("unexpected language: have %q, want %q", have, want)
+	}
+}
+
+func TestParse_LongPattern(t *testing.T) {
+	// The line is cut off in the middle of the two bytes of "é", which must
+	// not end up in the pattern.
+	prefix := `var X = "` + strings.Repeat("a", 240)
+	symbols, err := Parser{}.Parse(context.Background(), "a.go", []byte("package foo\n\n"+prefix+`é"`+"\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if have, want := symbols[1].Pattern, "/^"+prefix+"/"; have != want {
+		t.Errorf("unexpected pattern: have %q, want %q", have, want)
+	}
+}
+
+func TestLanguageForPath(t *testing.T) {
+	for path, want := range map[string]string{
+		"a/b.go":    "go",
+		"b.TSX":     "typescript",
+		"c.py":      "python",
+		"D.java":    "java",
+		"README.md": "",
+	} {
+		have, _ := LanguageForPath(path)
+		if have != want {
+			t.Errorf("LanguageForPath(%q): have %q, want %q", path, have, want)
+		}
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
 	"github.com/sourcegraph/sourcegraph/internal/tracer"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
 const port = "3184"
@@ -66,10 +67,39 @@ func main() {
 	go debugserver.NewServerRoutine(ready).Start()
 
 	service := symbols.Service{
-		FetchTar: func(ctx context.Context, repo api.RepoName, commit api.CommitID) (io.ReadCloser, error) {
-			return gitserver.DefaultClient.Archive(ctx, repo, gitserver.ArchiveOptions{Treeish: string(commit), Format: "tar"})
+		FetchTar: func(ctx context.Context, repo api.RepoName, commit api.CommitID, paths []string) (io.ReadCloser, error) {
+			// Paths are passed to git archive as pathspecs, so mark them as
+			// literal to avoid matching other files, e.g. for a file named "*.go".
+			pathspecs := make([]string, 0, len(paths))
+			for _, p := range paths {
+				pathspecs = append(pathspecs, ":(literal)"+p)
+			}
+			return gitserver.DefaultClient.Archive(ctx, repo, gitserver.ArchiveOptions{Treeish: string(commit), Format: "tar", Paths: pathspecs})
+		},
+		Ancestors: func(ctx context.Context, repo api.RepoName, commit api.CommitID, n int) ([]api.CommitID, error) {
+			commits, err := git.Commits(ctx, repo, git.CommitsOptions{Range: string(commit), N: uint(n), Skip: 1})
+			if err != nil {
+				return nil, err
+			}
+			ancestors := make([]api.CommitID, 0, len(commits))
+			for _, c := range commits {
+				ancestors = append(ancestors, c.ID)
+			}
+			return ancestors, nil
+		},
+		GitDiff: func(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID) (*symbols.Changes, error) {
+			changes, err := git.DiffPaths(ctx, repo, commitA, commitB)
+			if err != nil {
+				return nil, err
+			}
+			return &symbols.Changes{
+				Added:    changes.Added,
+				Modified: changes.Modified,
+				Deleted:  changes.Deleted,
+			}, nil
 		},
 		NewParser: symbols.NewParser,
+		Parsers:   currentParsers,
 		Path:      cacheDir,
 	}
 	if mb, err := strconv.ParseInt(cacheSizeMB, 10, 64); err != nil {
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/symbols"
+	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/treesitter"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+)
+
+// treesitterLanguages is the set of languages parsed with tree-sitter instead
+// of ctags.
+type treesitterLanguages map[string]bool
+
+// currentParsers returns the parsers selected by the
+// experimentalFeatures.symbolParsers site setting.
+func currentParsers() symbols.ParserSelection {
+	languages := treesitterLanguages{}
+	parsers := conf.ExperimentalFeatures().SymbolParsers
+	if parsers == nil {
+		return languages
+	}
+
+	for language, parser := range map[string]string{
+		"go":         parsers.Go,
+		"java":       parsers.Java,
+		"python":     parsers.Python,
+		"typescript": parsers.Typescript,
+	} {
+		if parser == "tree-sitter" {
+			languages[language] = true
+		}
+	}
+	return languages
+}
+
+// Key returns e.g. "tree-sitter:go,python", or "" if all languages are parsed
+// with ctags.
+func (l treesitterLanguages) Key() string {
+	if len(l) == 0 {
+		return ""
+	}
+
+	languages := make([]string, 0, len(l))
+	for language := range l {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return "tree-sitter:" + strings.Join(languages, ",")
+}
+
+// ParserForPath returns the tree-sitter parser for the file at path if its
+// language is selected, or nil to parse the file with ctags.
+func (l treesitterLanguages) ParserForPath(path string) symbols.Parser {
+	language, ok := treesitter.LanguageForPath(path)
+	if !ok || !l[language] {
+		return nil
+	}
+	return treesitter.Parser{}
+}
@@ -0,0 +1,75 @@
+package bitbucketcloudoauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestParseProvider(t *testing.T) {
+	pc := &schema.BitbucketCloudAuthProvider{
+		ClientKey:    "my-client-key",
+		ClientSecret: "my-client-secret",
+		Type:         "bitbucketcloud",
+	}
+	p, messages := parseProvider(nil, "https://sourcegraph.example.com/.auth/bitbucketcloud/callback", pc, schema.AuthProviders{Bitbucketcloud: pc})
+	if len(messages) > 0 {
+		t.Fatalf("unexpected messages: %v", messages)
+	}
+
+	if have, want := p.ServiceID, "https://bitbucket.org/"; have != want {
+		t.Errorf("wrong service ID: have %q, want %q", have, want)
+	}
+	if have, want := p.ServiceType, extsvc.TypeBitbucketCloud; have != want {
+		t.Errorf("wrong service type: have %q, want %q", have, want)
+	}
+
+	want := oauth2.Config{
+		RedirectURL:  "https://sourcegraph.example.com/.auth/bitbucketcloud/callback",
+		ClientID:     "my-client-key",
+		ClientSecret: "my-client-secret",
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  "https://bitbucket.org/site/oauth2/authorize",
+			TokenURL: "https://bitbucket.org/site/oauth2/access_token",
+		},
+		Scopes: []string{"account", "email"},
+	}
+	if diff := cmp.Diff(want, p.OAuth2Config()); diff != "" {
+		t.Fatalf("unexpected OAuth2 config (-want +got):\n%s", diff)
+	}
+}
+
+func TestSessionIssuerHelper_VerifiedEmails(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if have, want := r.Header.Get("Authorization"), "Bearer token"; have != want {
+			t.Errorf("wrong authorization: have %q, want %q", have, want)
+		}
+		fmt.Fprint(w, `{"values": [
+			{"email": "secondary@example.com", "is_confirmed": true},
+			{"email": "unconfirmed@example.com", "is_confirmed": false},
+			{"email": "primary@example.com", "is_primary": true, "is_confirmed": true}
+		]}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	u, _ := url.Parse(srv.URL)
+	s := &sessionIssuerHelper{client: bitbucketcloud.NewClient(u, srv.Client())}
+
+	emails, err := s.verifiedEmails(context.Background(), &oauth2.Token{AccessToken: "token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"primary@example.com", "secondary@example.com"}, emails); diff != "" {
+		t.Fatalf("unexpected emails (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,147 @@
+package bitbucketcloudoauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/oauth"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+)
+
+type sessionIssuerHelper struct {
+	*extsvc.CodeHost
+	db          dbutil.DB
+	client      *bitbucketcloud.Client
+	clientKey   string
+	allowSignup bool
+}
+
+func (s *sessionIssuerHelper) GetOrCreateUser(ctx context.Context, token *oauth2.Token, anonymousUserID, firstSourceURL string) (actr *actor.Actor, safeErrMsg string, err error) {
+	bbUser, err := UserFromContext(ctx)
+	if err != nil {
+		return nil, "Could not read Bitbucket Cloud user from callback request.", errors.Wrap(err, "could not read user from context")
+	}
+
+	login, err := auth.NormalizeUsername(bbUser.Nickname)
+	if err != nil {
+		return nil, fmt.Sprintf("Error normalizing the username %q. See https://docs.sourcegraph.com/admin/auth/#username-normalization.", login), err
+	}
+
+	// 🚨 SECURITY: Ensure that the user email is verified
+	verifiedEmails, err := s.verifiedEmails(ctx, token)
+	if err != nil {
+		return nil, "Could not get the emails of the Bitbucket Cloud user.", err
+	}
+	if len(verifiedEmails) == 0 {
+		return nil, "Could not get verified email for Bitbucket Cloud user. Check that your Bitbucket Cloud account has a confirmed email that matches one of your Sourcegraph verified emails.", errors.New("no verified email")
+	}
+
+	var data extsvc.AccountData
+	bitbucketcloud.SetExternalAccountData(&data, bbUser, token)
+
+	// We first attempt to connect one of the verified emails with an existing
+	// account in Sourcegraph. If allowSignup is true, we then create an account
+	// using the primary email, which comes first.
+	type attemptConfig struct {
+		email            string
+		createIfNotExist bool
+	}
+	var attempts []attemptConfig
+	for _, email := range verifiedEmails {
+		attempts = append(attempts, attemptConfig{email: email})
+	}
+	if s.allowSignup {
+		attempts = append(attempts, attemptConfig{
+			email:            verifiedEmails[0],
+			createIfNotExist: true,
+		})
+	}
+
+	var (
+		firstSafeErrMsg string
+		firstErr        error
+	)
+	for i, attempt := range attempts {
+		userID, safeErrMsg, err := auth.GetAndSaveUser(ctx, s.db, auth.GetAndSaveUserOp{
+			UserProps: database.NewUser{
+				Username:        login,
+				Email:           attempt.email,
+				EmailIsVerified: true,
+				DisplayName:     bbUser.DisplayName,
+			},
+			ExternalAccount: extsvc.AccountSpec{
+				ServiceType: s.ServiceType,
+				ServiceID:   s.ServiceID,
+				ClientID:    s.clientKey,
+				AccountID:   bbUser.UUID,
+			},
+			ExternalAccountData: data,
+			CreateIfNotExist:    attempt.createIfNotExist,
+		})
+		if err == nil {
+			return actor.FromUser(userID), "", nil // success
+		}
+		if i == 0 {
+			firstSafeErrMsg, firstErr = safeErrMsg, err
+		}
+	}
+
+	// On failure, return the first error
+	return nil, fmt.Sprintf("No user exists matching any of the verified emails: %s.\n\nFirst error was: %s", strings.Join(verifiedEmails, ", "), firstSafeErrMsg), firstErr
+}
+
+// verifiedEmails returns the confirmed emails of the user the token belongs
+// to, starting with their primary email.
+func (s *sessionIssuerHelper) verifiedEmails(ctx context.Context, token *oauth2.Token) ([]string, error) {
+	emails, err := s.client.WithToken(token.AccessToken).CurrentUserEmails(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get Bitbucket Cloud user emails")
+	}
+
+	var verified []string
+	for _, email := range emails {
+		if !email.IsConfirmed {
+			continue
+		}
+		if email.IsPrimary {
+			verified = append([]string{email.Email}, verified...)
+		} else {
+			verified = append(verified, email.Email)
+		}
+	}
+	return verified, nil
+}
+
+// CreateCodeHostConnection is not supported, because Bitbucket Cloud code host
+// connections authenticate with app passwords rather than OAuth tokens.
+func (s *sessionIssuerHelper) CreateCodeHostConnection(ctx context.Context, token *oauth2.Token, providerID string) (safeErrMsg string, err error) {
+	return "Creating a Bitbucket Cloud code host connection from the OAuth flow is not supported.", errors.New("not supported for Bitbucket Cloud")
+}
+
+func (s *sessionIssuerHelper) DeleteStateCookie(w http.ResponseWriter) {
+	stateConfig := getStateConfig()
+	stateConfig.MaxAge = -1
+	http.SetCookie(w, oauth.NewCookie(stateConfig, ""))
+}
+
+func (s *sessionIssuerHelper) SessionData(token *oauth2.Token) oauth.SessionData {
+	return oauth.SessionData{
+		ID: providers.ConfigID{
+			ID:   s.ServiceID,
+			Type: s.ServiceType,
+		},
+		AccessToken: token.AccessToken,
+		TokenType:   token.Type(),
+	}
+}
@@ -63,7 +63,7 @@ func Init(ctx context.Context, db dbutil.DB, outOfBandMigrationRunner *oobmigrat
 			return nil
 		}
 
-		// We currently support three types of authz providers: GitHub, GitLab and Bitbucket Server.
+		// We currently support GitHub, GitLab, Bitbucket Server, Bitbucket Cloud, Gitolite, Gitea, Gerrit and Perforce authz providers.
 		authzTypes := make(map[string]struct{}, 3)
 		for _, p := range providers {
 			authzTypes[p.ServiceType()] = struct{}{}
@@ -78,6 +78,14 @@ func Init(ctx context.Context, db dbutil.DB, outOfBandMigrationRunner *oobmigrat
 				authzNames = append(authzNames, "GitLab")
 			case extsvc.TypeBitbucketServer:
 				authzNames = append(authzNames, "Bitbucket Server")
+			case extsvc.TypeBitbucketCloud:
+				authzNames = append(authzNames, "Bitbucket Cloud")
+			case extsvc.TypeGitolite:
+				authzNames = append(authzNames, "Gitolite")
+			case extsvc.TypeGitea:
+				authzNames = append(authzNames, "Gitea")
+			case extsvc.TypeGerrit:
+				authzNames = append(authzNames, "Gerrit")
 			default:
 				authzNames = append(authzNames, t)
 			}
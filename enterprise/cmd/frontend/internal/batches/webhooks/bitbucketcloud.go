@@ -0,0 +1,173 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type BitbucketCloudWebhook struct {
+	*Webhook
+}
+
+func NewBitbucketCloudWebhook(store *store.Store) *BitbucketCloudWebhook {
+	return &BitbucketCloudWebhook{
+		Webhook: &Webhook{store, extsvc.TypeBitbucketCloud},
+	}
+}
+
+func (h *BitbucketCloudWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e, extSvc, hErr := h.parseEvent(r)
+	if hErr != nil {
+		respond(w, hErr.code, hErr)
+		return
+	}
+
+	externalServiceID, err := extractExternalServiceID(extSvc)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	prs, ev, err := h.convertEvent(r.Context(), externalServiceID, e)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	m := new(multierror.Error)
+	for _, pr := range prs {
+		if pr == (PR{}) {
+			log15.Warn("Dropping Bitbucket Cloud webhook event", "type", fmt.Sprintf("%T", e))
+			continue
+		}
+
+		err := h.upsertChangesetEvent(r.Context(), externalServiceID, pr, ev)
+		if err != nil {
+			m = multierror.Append(m, err)
+		}
+	}
+	if m.ErrorOrNil() != nil {
+		respond(w, http.StatusInternalServerError, m)
+	}
+}
+
+// parseEvent authenticates and parses the webhook request. Bitbucket Cloud
+// doesn't sign webhook payloads, so the webhook URL has to carry the ID of the
+// external service and the secret configured on it as query parameters.
+func (h *BitbucketCloudWebhook) parseEvent(r *http.Request) (interface{}, *types.ExternalService, *httpError) {
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusInternalServerError, err}
+	}
+
+	externalServiceID, err := strconv.ParseInt(r.FormValue(extsvc.IDParam), 10, 64)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusBadRequest, errors.Wrap(err, "invalid external service id")}
+	}
+
+	es, err := h.Store.ExternalServices().List(r.Context(), database.ExternalServicesListOptions{
+		IDs:   []int64{externalServiceID},
+		Kinds: []string{extsvc.KindBitbucketCloud},
+	})
+	if err != nil {
+		return nil, nil, &httpError{http.StatusInternalServerError, err}
+	}
+
+	secret := r.FormValue("secret")
+
+	var extSvc *types.ExternalService
+	for _, e := range es {
+		c, _ := e.Configuration()
+		con, ok := c.(*schema.BitbucketCloudConnection)
+		if !ok || con.WebhookSecret == "" {
+			continue
+		}
+
+		if subtle.ConstantTimeCompare([]byte(con.WebhookSecret), []byte(secret)) == 1 {
+			extSvc = e
+			break
+		}
+	}
+
+	if extSvc == nil {
+		return nil, nil, &httpError{http.StatusUnauthorized, nil}
+	}
+
+	e, err := bitbucketcloud.ParseWebhookEvent(bitbucketcloud.WebhookEventKey(r), payload)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusBadRequest, errors.Wrap(err, "parsing webhook")}
+	}
+	return e, extSvc, nil
+}
+
+func (h *BitbucketCloudWebhook) convertEvent(ctx context.Context, externalServiceID string, theirs interface{}) (prs []PR, ours keyer, err error) {
+	log15.Debug("Bitbucket Cloud webhook received", "type", fmt.Sprintf("%T", theirs))
+
+	switch e := theirs.(type) {
+	case *bitbucketcloud.PullRequestApprovedEvent:
+		return []PR{bitbucketCloudPR(&e.PullRequestEvent)}, e, nil
+	case *bitbucketcloud.PullRequestUnapprovedEvent:
+		return []PR{bitbucketCloudPR(&e.PullRequestEvent)}, e, nil
+	case *bitbucketcloud.PullRequestChangesRequestCreatedEvent:
+		return []PR{bitbucketCloudPR(&e.PullRequestEvent)}, e, nil
+	case *bitbucketcloud.PullRequestChangesRequestRemovedEvent:
+		return []PR{bitbucketCloudPR(&e.PullRequestEvent)}, e, nil
+	case *bitbucketcloud.PullRequestFulfilledEvent:
+		return []PR{bitbucketCloudPR(&e.PullRequestEvent)}, e, nil
+	case *bitbucketcloud.PullRequestRejectedEvent:
+		return []PR{bitbucketCloudPR(&e.PullRequestEvent)}, e, nil
+	case *bitbucketcloud.RepoCommitStatusEvent:
+		// Commit statuses aren't tied to pull requests, so we have to find the
+		// open pull requests whose source commit the status was reported for.
+		prs, err = h.pullRequestsForCommit(ctx, externalServiceID, e.Repository.UUID, e.CommitStatus.Commit.Hash)
+		return prs, &e.CommitStatus, err
+	}
+
+	return nil, nil, nil
+}
+
+func (h *BitbucketCloudWebhook) pullRequestsForCommit(ctx context.Context, externalServiceID, repoUUID, commit string) ([]PR, error) {
+	repo, err := h.getRepoForPR(ctx, h.Store, PR{RepoExternalID: repoUUID}, externalServiceID)
+	if err != nil {
+		log15.Debug("Webhook event could not be matched to repo", "err", err)
+		return nil, nil
+	}
+
+	cs, _, err := h.Store.ListChangesets(ctx, store.ListChangesetsOpts{
+		RepoID:         repo.ID,
+		ExternalStates: []btypes.ChangesetExternalState{btypes.ChangesetExternalStateOpen},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var prs []PR
+	for _, c := range cs {
+		pr, ok := c.Metadata.(*bitbucketcloud.PullRequest)
+		if !ok || !pr.HasSourceCommit(commit) {
+			continue
+		}
+		prs = append(prs, PR{ID: pr.ID, RepoExternalID: repoUUID})
+	}
+	return prs, nil
+}
+
+func bitbucketCloudPR(e *bitbucketcloud.PullRequestEvent) PR {
+	return PR{ID: e.PullRequest.ID, RepoExternalID: e.Repository.UUID}
+}
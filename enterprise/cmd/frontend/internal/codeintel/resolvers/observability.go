@@ -21,6 +21,7 @@ type operations struct {
 	hover                     *observation.Operation
 	ranges                    *observation.Operation
 	references                *observation.Operation
+	implementations           *observation.Operation
 	documentationPage         *observation.Operation
 	documentationPathInfo     *observation.Operation
 	documentationIDsToPathIDs *observation.Operation
@@ -62,6 +63,7 @@ func newOperations(observationContext *observation.Context) *operations {
 		hover:                     op("Hover"),
 		ranges:                    op("Ranges"),
 		references:                op("References"),
+		implementations:           op("Implementations"),
 		documentationPage:         op("DocumentationPage"),
 		documentationPathInfo:     op("DocumentationPathInfo"),
 		documentationIDsToPathIDs: op("DocumentationIDsToPathIDs"),
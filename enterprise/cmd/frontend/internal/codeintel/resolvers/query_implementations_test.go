@@ -0,0 +1,149 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/bloomfilter"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func TestImplementations(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockPositionAdjuster := noopPositionAdjuster()
+
+	// Empty result set (prevents nil pointer as scanner is always non-nil)
+	mockDBStore.ReferenceIDsAndFiltersFunc.PushReturn(dbstore.PackageReferenceScannerFromSlice(), 0, nil)
+
+	locations := []lsifstore.Location{
+		{DumpID: 50, Path: "a.go", Range: testRange1},
+		{DumpID: 51, Path: "b.go", Range: testRange2},
+		{DumpID: 51, Path: "c.go", Range: testRange3},
+	}
+	mockLSIFStore.ImplementationsFunc.PushReturn(locations[:1], 1, nil)
+	mockLSIFStore.ImplementationsFunc.PushReturn(locations[1:], 2, nil)
+
+	uploads := []dbstore.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 51, Commit: "deadbeef", Root: "sub2/"},
+	}
+	resolver := newQueryResolver(
+		mockDBStore,
+		mockLSIFStore,
+		newCachedCommitChecker(mockGitserverClient),
+		mockPositionAdjuster,
+		42,
+		"deadbeef",
+		"s1/main.go",
+		uploads,
+		newOperations(&observation.TestContext),
+	)
+	adjustedLocations, _, err := resolver.Implementations(context.Background(), 10, 20, 50, "")
+	if err != nil {
+		t.Fatalf("unexpected error querying implementations: %s", err)
+	}
+
+	expectedLocations := []AdjustedLocation{
+		{Dump: uploads[0], Path: "sub1/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1},
+		{Dump: uploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange2},
+		{Dump: uploads[1], Path: "sub2/c.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange3},
+	}
+	if diff := cmp.Diff(expectedLocations, adjustedLocations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+
+	if history := mockLSIFStore.ReferencesFunc.History(); len(history) != 0 {
+		t.Errorf("unexpected call count for lsifstore.References. want=%d have=%d", 0, len(history))
+	}
+}
+
+func TestImplementationsRemote(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockPositionAdjuster := noopPositionAdjuster()
+
+	definitionUploads := []dbstore.Dump{
+		{ID: 150, Commit: "deadbeef1", Root: "sub1/"},
+	}
+	mockDBStore.DefinitionDumpsFunc.PushReturn(definitionUploads, nil)
+
+	referenceUploads := []dbstore.Dump{
+		{ID: 250, Commit: "deadbeef2", Root: "sub2/"},
+	}
+	mockDBStore.GetDumpsByIDsFunc.PushReturn(nil, nil) // empty
+	mockDBStore.GetDumpsByIDsFunc.PushReturn(referenceUploads, nil)
+
+	filter, err := bloomfilter.CreateFilter([]string{"Handler"})
+	if err != nil {
+		t.Fatalf("unexpected error encoding bloom filter: %s", err)
+	}
+	scanner := dbstore.PackageReferenceScannerFromSlice(
+		shared.PackageReference{Package: shared.Package{DumpID: 250}, Filter: filter},
+	)
+	mockDBStore.ReferenceIDsAndFiltersFunc.PushReturn(scanner, 1, nil)
+	mockGitserverClient.CommitExistsFunc.SetDefaultReturn(true, nil)
+
+	moniker := precise.MonikerData{Kind: "export", Scheme: "gomod", Identifier: "Handler", PackageInformationID: "51"}
+	mockLSIFStore.MonikersByPositionFunc.PushReturn([][]precise.MonikerData{{moniker}}, nil)
+
+	packageInformation := precise.PackageInformationData{Name: "github.com/test/server", Version: "v1.0.0"}
+	mockLSIFStore.PackageInformationFunc.PushReturn(packageInformation, true, nil)
+
+	mockLSIFStore.ImplementationsFunc.PushReturn([]lsifstore.Location{{DumpID: 50, Path: "a.go", Range: testRange1}}, 1, nil)
+
+	mockLSIFStore.BulkMonikerResultsFunc.PushReturn([]lsifstore.Location{{DumpID: 150, Path: "b.go", Range: testRange2}}, 1, nil)
+	mockLSIFStore.BulkMonikerResultsFunc.PushReturn([]lsifstore.Location{{DumpID: 250, Path: "c.go", Range: testRange3}}, 1, nil)
+
+	uploads := []dbstore.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+	}
+	resolver := newQueryResolver(
+		mockDBStore,
+		mockLSIFStore,
+		newCachedCommitChecker(mockGitserverClient),
+		mockPositionAdjuster,
+		42,
+		"deadbeef",
+		"s1/main.go",
+		uploads,
+		newOperations(&observation.TestContext),
+	)
+	adjustedLocations, _, err := resolver.Implementations(context.Background(), 10, 20, 50, "")
+	if err != nil {
+		t.Fatalf("unexpected error querying implementations: %s", err)
+	}
+
+	expectedLocations := []AdjustedLocation{
+		{Dump: uploads[0], Path: "sub1/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1},
+		{Dump: definitionUploads[0], Path: "sub1/b.go", AdjustedCommit: "deadbeef1", AdjustedRange: testRange2},
+		{Dump: referenceUploads[0], Path: "sub2/c.go", AdjustedCommit: "deadbeef2", AdjustedRange: testRange3},
+	}
+	if diff := cmp.Diff(expectedLocations, adjustedLocations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+
+	if history := mockLSIFStore.BulkMonikerResultsFunc.History(); len(history) != 2 {
+		t.Fatalf("unexpected call count for lsifstore.BulkMonikerResults. want=%d have=%d", 2, len(history))
+	} else {
+		for i, expectedIDs := range [][]int{{150}, {250}} {
+			if history[i].Arg1 != "implementations" {
+				t.Errorf("unexpected table name. want=%q have=%q", "implementations", history[i].Arg1)
+			}
+			if diff := cmp.Diff(expectedIDs, history[i].Arg2); diff != "" {
+				t.Errorf("unexpected ids (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff([]precise.MonikerData{moniker}, history[i].Arg3); diff != "" {
+				t.Errorf("unexpected monikers (-want +got):\n%s", diff)
+			}
+		}
+	}
+}
@@ -35,6 +35,33 @@ func (r *queryResolver) References(ctx context.Context, line, character, limit i
 	})
 	defer endObservation()
 
+	return r.pageCrossRepoLocations(ctx, traceLog, referencesQuery, line, character, limit, rawCursor)
+}
+
+// crossRepoLocationsQuery describes a set of locations, such as references or implementations, that
+// can span multiple repositories. Locations within the uploads visible from the target commit are found
+// by LSIF graph traversal. Remaining locations are found by a moniker search over the uploads that depend
+// on the package defining the target symbol.
+type crossRepoLocationsQuery struct {
+	// name is used to annotate errors returned from the local query.
+	name string
+
+	// local returns a page of locations within a single upload via LSIF graph traversal.
+	local func(s LSIFStore, ctx context.Context, bundleID int, path string, line, character, limit, offset int) ([]lsifstore.Location, int, error)
+
+	// tableName is the moniker table (without the lsif_data_ prefix) searched for remote locations.
+	tableName string
+}
+
+var referencesQuery = crossRepoLocationsQuery{
+	name:      "lsifstore.References",
+	local:     LSIFStore.References,
+	tableName: "references",
+}
+
+// pageCrossRepoLocations returns a page of the location set described by the given query for the
+// symbol at the given position, along with the cursor used to fetch the subsequent page of results.
+func (r *queryResolver) pageCrossRepoLocations(ctx context.Context, traceLog observation.TraceLogger, query crossRepoLocationsQuery, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error) {
 	// Maintain a map from identifers to hydrated upload records from the database. We use
 	// this map as a quick lookup when constructing the resulting location set. Any additional
 	// upload records pulled back from the database while processing this page will be added
@@ -96,7 +123,7 @@ func (r *queryResolver) References(ctx context.Context, line, character, limit i
 	}
 
 	// Query a single page of location results
-	locations, hasMore, err := r.pageReferences(ctx, adjustedUploads, orderedMonikers, definitionUploadIDs, uploadsByID, &cursor, limit)
+	locations, hasMore, err := r.pageLocations(ctx, query, adjustedUploads, orderedMonikers, definitionUploadIDs, uploadsByID, &cursor, limit)
 	if err != nil {
 		return nil, "", err
 	}
@@ -234,10 +261,10 @@ func (r *queryResolver) definitionUploadIDsFromCursor(ctx context.Context, adjus
 	return definitionUploadIDs, definitionUploads, nil
 }
 
-// pageReferences returns a slice of the result set denoted by the given cursor. The given cursor will be
+// pageLocations returns a slice of the result set denoted by the given cursor. The given cursor will be
 // adjusted to reflect the offsets required to resolve the next page of results. If there are no more pages
 // left in the result set, a false-valued flag is returned.
-func (r *queryResolver) pageReferences(ctx context.Context, adjustedUploads []adjustedUpload, orderedMonikers []precise.QualifiedMonikerData, definitionUploadIDs []int, uploadsByID map[int]dbstore.Dump, cursor *referencesCursor, limit int) ([]lsifstore.Location, bool, error) {
+func (r *queryResolver) pageLocations(ctx context.Context, query crossRepoLocationsQuery, adjustedUploads []adjustedUpload, orderedMonikers []precise.QualifiedMonikerData, definitionUploadIDs []int, uploadsByID map[int]dbstore.Dump, cursor *referencesCursor, limit int) ([]lsifstore.Location, bool, error) {
 	var locations []lsifstore.Location
 
 	// Phase 1: Gather all "local" locations via LSIF graph traversal. We'll continue to request additional
@@ -246,7 +273,7 @@ func (r *queryResolver) pageReferences(ctx context.Context, adjustedUploads []ad
 
 	if !cursor.RemotePhase {
 		for len(locations) < limit {
-			localLocations, hasMore, err := r.pageLocalReferences(ctx, adjustedUploads, cursor, limit-len(locations))
+			localLocations, hasMore, err := r.pageLocalLocations(ctx, query, adjustedUploads, cursor, limit-len(locations))
 			if err != nil {
 				return nil, false, err
 			}
@@ -266,7 +293,7 @@ func (r *queryResolver) pageReferences(ctx context.Context, adjustedUploads []ad
 
 	if cursor.RemotePhase {
 		for len(locations) < limit {
-			remoteLocations, hasMore, err := r.pageRemoteReferences(ctx, adjustedUploads, orderedMonikers, definitionUploadIDs, uploadsByID, cursor, limit-len(locations))
+			remoteLocations, hasMore, err := r.pageRemoteLocations(ctx, query, adjustedUploads, orderedMonikers, definitionUploadIDs, uploadsByID, cursor, limit-len(locations))
 			if err != nil {
 				return nil, false, err
 			}
@@ -281,11 +308,11 @@ func (r *queryResolver) pageReferences(ctx context.Context, adjustedUploads []ad
 	return locations, true, nil
 }
 
-// pageLocalReferences returns a slice of the (local) result set denoted by the given cursor fulfilled by
+// pageLocalLocations returns a slice of the (local) result set denoted by the given cursor fulfilled by
 // traversing the LSIF graph. The given cursor will be adjusted to reflect the offsets required to resolve
 // the next page of results. If there are no more pages left in the result set, a false-valued flag is
 // returned.
-func (r *queryResolver) pageLocalReferences(ctx context.Context, adjustedUploads []adjustedUpload, cursor *referencesCursor, limit int) ([]lsifstore.Location, bool, error) {
+func (r *queryResolver) pageLocalLocations(ctx context.Context, query crossRepoLocationsQuery, adjustedUploads []adjustedUpload, cursor *referencesCursor, limit int) ([]lsifstore.Location, bool, error) {
 	var allLocations []lsifstore.Location
 	for i := range adjustedUploads {
 		if len(allLocations) >= limit {
@@ -297,7 +324,8 @@ func (r *queryResolver) pageLocalReferences(ctx context.Context, adjustedUploads
 			continue
 		}
 
-		locations, totalCount, err := r.lsifStore.References(
+		locations, totalCount, err := query.local(
+			r.lsifStore,
 			ctx,
 			adjustedUploads[i].Upload.ID,
 			adjustedUploads[i].AdjustedPathInBundle,
@@ -307,7 +335,7 @@ func (r *queryResolver) pageLocalReferences(ctx context.Context, adjustedUploads
 			cursor.LocalOffset,
 		)
 		if err != nil {
-			return nil, false, errors.Wrap(err, "lsifstore.References")
+			return nil, false, errors.Wrap(err, query.name)
 		}
 
 		cursor.LocalOffset += len(locations)
@@ -328,11 +356,11 @@ func (r *queryResolver) pageLocalReferences(ctx context.Context, adjustedUploads
 // that can be passed to a single moniker search query.
 const maximumIndexesPerMonikerSearch = 50
 
-// pageRemoteReferences returns a slice of the (remote) result set denoted by the given cursor fulfilled by
+// pageRemoteLocations returns a slice of the (remote) result set denoted by the given cursor fulfilled by
 // performing a moniker search over a group of indexes. The given cursor will be adjusted to reflect the
 // offsets required to resolve the next page of results. If there are no more pages left in the result set,
 // a false-valued flag is returned.
-func (r *queryResolver) pageRemoteReferences(ctx context.Context, adjustedUploads []adjustedUpload, orderedMonikers []precise.QualifiedMonikerData, definitionUploadIDs []int, uploadsByID map[int]dbstore.Dump, cursor *referencesCursor, limit int) ([]lsifstore.Location, bool, error) {
+func (r *queryResolver) pageRemoteLocations(ctx context.Context, query crossRepoLocationsQuery, adjustedUploads []adjustedUpload, orderedMonikers []precise.QualifiedMonikerData, definitionUploadIDs []int, uploadsByID map[int]dbstore.Dump, cursor *referencesCursor, limit int) ([]lsifstore.Location, bool, error) {
 	for len(cursor.BatchIDs) == 0 {
 		if cursor.RemoteBatchOffset < 0 {
 			// No more batches
@@ -364,7 +392,7 @@ func (r *queryResolver) pageRemoteReferences(ctx context.Context, adjustedUpload
 	}
 
 	// Perform the moniker search
-	locations, totalCount, err := r.monikerLocations(ctx, monikerSearchUploads, orderedMonikers, "references", limit, cursor.RemoteOffset)
+	locations, totalCount, err := r.monikerLocations(ctx, monikerSearchUploads, orderedMonikers, query.tableName, limit, cursor.RemoteOffset)
 	if err != nil {
 		return nil, false, err
 	}
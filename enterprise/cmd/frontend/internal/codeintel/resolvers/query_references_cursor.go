@@ -8,7 +8,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 )
 
-// referencesCursor stores (enough of) the state of a previous References request used to
+// referencesCursor stores (enough of) the state of a previous References or Implementations request used to
 // calculate the offset into the result set to be returned by the current request.
 type referencesCursor struct {
 	AdjustedUploads           []cursorAdjustedUpload         `json:"adjustedUploads"`
@@ -175,6 +175,9 @@ func (r *Resolver) UpdateCodeMonitor(ctx context.Context, args *graphqlbackend.U
 	}
 
 	toCreate, toDelete, err := splitActionIDs(ctx, args, actionIDs)
+	if err != nil {
+		return nil, err
+	}
 	if len(toDelete) == len(actionIDs) {
 		return nil, errors.Errorf("you tried to delete all actions, but every monitor must be connected to at least 1 action")
 	}
@@ -187,7 +190,7 @@ func (r *Resolver) UpdateCodeMonitor(ctx context.Context, args *graphqlbackend.U
 	}
 	defer func() { err = tx.store.Done(err) }()
 
-	err = tx.store.DeleteActionsInt64(ctx, toDelete, monitorID)
+	err = tx.deleteActions(ctx, toDelete, monitorID)
 	if err != nil {
 		return nil, err
 	}
@@ -283,6 +286,44 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 		}
 		after = cur
 	}
+
+	after = nil
+	for {
+		ws, err := r.store.ListActionWebhooks(ctx, monitorID, &graphqlbackend.ListActionArgs{
+			First: int32(limit),
+			After: after,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range ws {
+			ids = append(ids, (&monitorWebhook{MonitorWebhook: w}).ID())
+		}
+		if len(ws) < limit {
+			break
+		}
+		cur := string(ids[len(ids)-1])
+		after = &cur
+	}
+
+	after = nil
+	for {
+		ws, err := r.store.ListActionSlackWebhooks(ctx, monitorID, &graphqlbackend.ListActionArgs{
+			First: int32(limit),
+			After: after,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range ws {
+			ids = append(ids, (&monitorSlackWebhook{MonitorSlackWebhook: w}).ID())
+		}
+		if len(ws) < limit {
+			break
+		}
+		cur := string(ids[len(ids)-1])
+		after = &cur
+	}
 	return ids, nil
 }
 
@@ -314,35 +355,73 @@ func (r *Resolver) actionIDsForMonitorIDINT64SinglePage(ctx context.Context, q *
 
 // splitActionIDs splits actions into three buckets: create, delete and update.
 // Note: args is mutated. After splitActionIDs, args only contains actions to be updated.
-func splitActionIDs(ctx context.Context, args *graphqlbackend.UpdateCodeMonitorArgs, actionIDs []graphql.ID) (toCreate []*graphqlbackend.CreateActionArgs, toDelete []int64, err error) {
+func splitActionIDs(ctx context.Context, args *graphqlbackend.UpdateCodeMonitorArgs, actionIDs []graphql.ID) (toCreate []*graphqlbackend.CreateActionArgs, toDelete []graphql.ID, err error) {
 	aMap := make(map[graphql.ID]struct{}, len(actionIDs))
 	for _, id := range actionIDs {
 		aMap[id] = struct{}{}
 	}
 	var toUpdateActions []*graphqlbackend.EditActionArgs
 	for _, a := range args.Actions {
-		if a.Email.Id == nil {
-			toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{Email: a.Email.Update})
+		var (
+			id     *graphql.ID
+			create *graphqlbackend.CreateActionArgs
+		)
+		switch {
+		case a.Email != nil:
+			id, create = a.Email.Id, &graphqlbackend.CreateActionArgs{Email: a.Email.Update}
+		case a.Webhook != nil:
+			id, create = a.Webhook.Id, &graphqlbackend.CreateActionArgs{Webhook: a.Webhook.Update}
+		case a.SlackWebhook != nil:
+			id, create = a.SlackWebhook.Id, &graphqlbackend.CreateActionArgs{SlackWebhook: a.SlackWebhook.Update}
+		default:
+			return nil, nil, errors.Errorf("action must be one of email, webhook or Slack webhook")
+		}
+		if id == nil {
+			toCreate = append(toCreate, create)
 			continue
 		}
-		if _, ok := aMap[*a.Email.Id]; !ok {
-			return nil, nil, errors.Errorf("unknown ID=%s for action", *a.Email.Id)
+		if _, ok := aMap[*id]; !ok {
+			return nil, nil, errors.Errorf("unknown ID=%s for action", *id)
 		}
 		toUpdateActions = append(toUpdateActions, a)
-		delete(aMap, *a.Email.Id)
+		delete(aMap, *id)
 	}
-	var actionID int64
 	for k := range aMap {
-		err = relay.UnmarshalSpec(k, &actionID)
-		if err != nil {
-			return nil, nil, err
-		}
-		toDelete = append(toDelete, actionID)
+		toDelete = append(toDelete, k)
 	}
 	args.Actions = toUpdateActions
 	return toCreate, toDelete, nil
 }
 
+// deleteActions deletes the actions with the given IDs. The kind of an ID
+// determines the type of the action.
+func (r *Resolver) deleteActions(ctx context.Context, actionIDs []graphql.ID, monitorID int64) error {
+	var emails, webhooks, slackWebhooks []int64
+	for _, id := range actionIDs {
+		var intID int64
+		if err := relay.UnmarshalSpec(id, &intID); err != nil {
+			return err
+		}
+		switch kind := relay.UnmarshalKind(id); kind {
+		case monitorActionEmailKind:
+			emails = append(emails, intID)
+		case monitorActionWebhookKind:
+			webhooks = append(webhooks, intID)
+		case monitorActionSlackWebhookKind:
+			slackWebhooks = append(slackWebhooks, intID)
+		default:
+			return errors.Errorf("unknown action kind %q", kind)
+		}
+	}
+	if err := r.store.DeleteActionsInt64(ctx, emails, monitorID); err != nil {
+		return err
+	}
+	if err := r.store.DeleteActionWebhooksInt64(ctx, webhooks, monitorID); err != nil {
+		return err
+	}
+	return r.store.DeleteActionSlackWebhooksInt64(ctx, slackWebhooks, monitorID)
+}
+
 func (r *Resolver) updateCodeMonitor(ctx context.Context, args *graphqlbackend.UpdateCodeMonitorArgs) (m graphqlbackend.MonitorResolver, err error) {
 	// Update monitor.
 	var mo *cm.Monitor
@@ -365,24 +444,36 @@ func (r *Resolver) updateCodeMonitor(ctx context.Context, args *graphqlbackend.U
 	var emailID int64
 	var e *cm.MonitorEmail
 	for i, action := range args.Actions {
-		if action.Email == nil {
-			return nil, errors.Errorf("missing email object for action %d", i)
-		}
-		err = relay.UnmarshalSpec(*action.Email.Id, &emailID)
-		if err != nil {
-			return nil, err
-		}
-		err = r.store.DeleteRecipients(ctx, emailID)
-		if err != nil {
-			return nil, err
-		}
-		e, err = r.store.UpdateActionEmail(ctx, mo.ID, action)
-		if err != nil {
-			return nil, err
-		}
-		err = r.store.CreateRecipients(ctx, action.Email.Update.Recipients, e.Id)
-		if err != nil {
-			return nil, err
+		switch {
+		case action.Email != nil:
+			err = relay.UnmarshalSpec(*action.Email.Id, &emailID)
+			if err != nil {
+				return nil, err
+			}
+			err = r.store.DeleteRecipients(ctx, emailID)
+			if err != nil {
+				return nil, err
+			}
+			e, err = r.store.UpdateActionEmail(ctx, mo.ID, action)
+			if err != nil {
+				return nil, err
+			}
+			err = r.store.CreateRecipients(ctx, action.Email.Update.Recipients, e.Id)
+			if err != nil {
+				return nil, err
+			}
+		case action.Webhook != nil:
+			_, err = r.store.UpdateActionWebhook(ctx, mo.ID, action.Webhook)
+			if err != nil {
+				return nil, err
+			}
+		case action.SlackWebhook != nil:
+			_, err = r.store.UpdateActionSlackWebhook(ctx, mo.ID, action.SlackWebhook)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errors.Errorf("missing action object for action %d", i)
 		}
 	}
 	return &monitor{
@@ -526,6 +617,8 @@ const (
 	monitorTriggerQueryKind         = "CodeMonitorTriggerQuery"
 	monitorTriggerEventKind         = "CodeMonitorTriggerEvent"
 	monitorActionEmailKind          = "CodeMonitorActionEmail"
+	monitorActionWebhookKind        = "CodeMonitorActionWebhook"
+	monitorActionSlackWebhookKind   = "CodeMonitorActionSlackWebhook"
 	monitorActionEventKind          = "CodeMonitorActionEmailEvent"
 	monitorActionEmailRecipientKind = "CodeMonitorActionEmailRecipient"
 )
@@ -571,9 +664,58 @@ func (m *monitor) Actions(ctx context.Context, args *graphqlbackend.ListActionAr
 	return m.actionConnectionResolverWithTriggerID(ctx, nil, m.Monitor.ID, args)
 }
 
+// actionKinds is the order in which actions of a monitor are listed.
+var actionKinds = []string{monitorActionEmailKind, monitorActionWebhookKind, monitorActionSlackWebhookKind}
+
 func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, triggerEventID *int, monitorID int64, args *graphqlbackend.ListActionArgs) (graphqlbackend.MonitorActionConnectionResolver, error) {
-	// For now, we only support emails as actions. Once we add other actions such as
-	// webhooks, we have to query those tables here too.
+	// Actions are listed by kind. The cursor is the ID of the last action of the
+	// previous page, so its kind tells us which kind of actions to continue with.
+	start := 0
+	if args.After != nil {
+		kind := relay.UnmarshalKind(graphql.ID(*args.After))
+		for i, k := range actionKinds {
+			if k == kind {
+				start = i
+			}
+		}
+	}
+
+	var actions []graphqlbackend.MonitorAction
+	for i := start; i < len(actionKinds) && len(actions) < int(args.First); i++ {
+		pageArgs := &graphqlbackend.ListActionArgs{First: args.First - int32(len(actions))}
+		if i == start {
+			pageArgs.After = args.After
+		}
+		var err error
+		switch actionKinds[i] {
+		case monitorActionEmailKind:
+			actions, err = r.appendEmailActions(ctx, actions, triggerEventID, monitorID, pageArgs)
+		case monitorActionWebhookKind:
+			actions, err = r.appendWebhookActions(ctx, actions, triggerEventID, monitorID, pageArgs)
+		case monitorActionSlackWebhookKind:
+			actions, err = r.appendSlackWebhookActions(ctx, actions, triggerEventID, monitorID, pageArgs)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var totalCount int32
+	for _, count := range []func(context.Context, int64) (int32, error){
+		r.store.TotalCountActionEmails,
+		r.store.TotalCountActionWebhooks,
+		r.store.TotalCountActionSlackWebhooks,
+	} {
+		c, err := count(ctx, monitorID)
+		if err != nil {
+			return nil, err
+		}
+		totalCount += c
+	}
+	return &monitorActionConnection{actions: actions, totalCount: totalCount}, nil
+}
+
+func (r *Resolver) appendEmailActions(ctx context.Context, actions []graphqlbackend.MonitorAction, triggerEventID *int, monitorID int64, args *graphqlbackend.ListActionArgs) ([]graphqlbackend.MonitorAction, error) {
 	q, err := r.store.ReadActionEmailQuery(ctx, monitorID, args)
 	if err != nil {
 		return nil, err
@@ -587,11 +729,6 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 	if err != nil {
 		return nil, err
 	}
-	totalCount, err := r.store.TotalCountActionEmails(ctx, monitorID)
-	if err != nil {
-		return nil, err
-	}
-	actions := make([]graphqlbackend.MonitorAction, 0, len(es))
 	for _, e := range es {
 		actions = append(actions, &action{
 			email: &monitorEmail{
@@ -601,7 +738,41 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 			},
 		})
 	}
-	return &monitorActionConnection{actions: actions, totalCount: totalCount}, nil
+	return actions, nil
+}
+
+func (r *Resolver) appendWebhookActions(ctx context.Context, actions []graphqlbackend.MonitorAction, triggerEventID *int, monitorID int64, args *graphqlbackend.ListActionArgs) ([]graphqlbackend.MonitorAction, error) {
+	ws, err := r.store.ListActionWebhooks(ctx, monitorID, args)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range ws {
+		actions = append(actions, &action{
+			webhook: &monitorWebhook{
+				Resolver:       r,
+				MonitorWebhook: w,
+				triggerEventID: triggerEventID,
+			},
+		})
+	}
+	return actions, nil
+}
+
+func (r *Resolver) appendSlackWebhookActions(ctx context.Context, actions []graphqlbackend.MonitorAction, triggerEventID *int, monitorID int64, args *graphqlbackend.ListActionArgs) ([]graphqlbackend.MonitorAction, error) {
+	ws, err := r.store.ListActionSlackWebhooks(ctx, monitorID, args)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range ws {
+		actions = append(actions, &action{
+			slackWebhook: &monitorSlackWebhook{
+				Resolver:            r,
+				MonitorSlackWebhook: w,
+				triggerEventID:      triggerEventID,
+			},
+		})
+	}
+	return actions, nil
 }
 
 //
@@ -705,7 +876,15 @@ func (m *monitorTriggerEvent) Status() (string, error) {
 	return "", errors.Errorf("unknown status: %s", m.State)
 }
 
+// limitHitMessage is the message of successful events whose run hit the
+// result limit of code monitors.
+const limitHitMessage = "The query matched too many results. Only the first results are monitored; make the query more specific to monitor all of them."
+
 func (m *monitorTriggerEvent) Message() *string {
+	if m.FailureMessage == nil && m.LimitHit {
+		msg := limitHitMessage
+		return &msg
+	}
 	return m.FailureMessage
 }
 
@@ -743,20 +922,36 @@ func (a *monitorActionConnection) PageInfo(ctx context.Context) (*graphqlutil.Pa
 	if email, ok := last.ToMonitorEmail(); ok {
 		return graphqlutil.NextPageCursor(string(email.ID())), nil
 	}
-	return nil, errors.Errorf("we only support email actions for now")
+	if webhook, ok := last.ToMonitorWebhook(); ok {
+		return graphqlutil.NextPageCursor(string(webhook.ID())), nil
+	}
+	if slackWebhook, ok := last.ToMonitorSlackWebhook(); ok {
+		return graphqlutil.NextPageCursor(string(slackWebhook.ID())), nil
+	}
+	return nil, errors.Errorf("unknown action type")
 }
 
 //
 // Action <<UNION>>
 //
 type action struct {
-	email graphqlbackend.MonitorEmailResolver
+	email        graphqlbackend.MonitorEmailResolver
+	webhook      graphqlbackend.MonitorWebhookResolver
+	slackWebhook graphqlbackend.MonitorSlackWebhookResolver
 }
 
 func (a *action) ToMonitorEmail() (graphqlbackend.MonitorEmailResolver, bool) {
 	return a.email, a.email != nil
 }
 
+func (a *action) ToMonitorWebhook() (graphqlbackend.MonitorWebhookResolver, bool) {
+	return a.webhook, a.webhook != nil
+}
+
+func (a *action) ToMonitorSlackWebhook() (graphqlbackend.MonitorSlackWebhookResolver, bool) {
+	return a.slackWebhook, a.slackWebhook != nil
+}
+
 //
 // Email
 //
@@ -818,6 +1013,14 @@ func (m *monitorEmail) Header() string {
 	return m.MonitorEmail.Header
 }
 
+func (m *monitorEmail) Delivery() string {
+	return m.MonitorEmail.Delivery
+}
+
+func (m *monitorEmail) RateLimit() graphqlbackend.MonitorActionRateLimitResolver {
+	return newMonitorActionRateLimit(m.MonitorEmail.DeliveryPolicy)
+}
+
 func (m *monitorEmail) ID() graphql.ID {
 	return relay.MarshalID(monitorActionEmailKind, m.Id)
 }
@@ -838,9 +1041,129 @@ func (m *monitorEmail) Events(ctx context.Context, args *graphqlbackend.ListEven
 	return &monitorActionEventConnection{events: events, totalCount: totalCount}, nil
 }
 
+//
+// Webhook
+//
+type monitorWebhook struct {
+	*Resolver
+	*cm.MonitorWebhook
+
+	// If triggerEventID == nil, all events of this action will be returned.
+	// Otherwise, only those events of this action which are related to the specified
+	// trigger event will be returned.
+	triggerEventID *int
+}
+
+func (m *monitorWebhook) ID() graphql.ID {
+	return relay.MarshalID(monitorActionWebhookKind, m.Id)
+}
+
+func (m *monitorWebhook) Enabled() bool {
+	return m.MonitorWebhook.Enabled
+}
+
+func (m *monitorWebhook) URL() string {
+	return m.MonitorWebhook.URL
+}
+
+func (m *monitorWebhook) Delivery() string {
+	return m.MonitorWebhook.Delivery
+}
+
+func (m *monitorWebhook) RateLimit() graphqlbackend.MonitorActionRateLimitResolver {
+	return newMonitorActionRateLimit(m.MonitorWebhook.DeliveryPolicy)
+}
+
+func (m *monitorWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	ajs, err := m.store.ReadActionWebhookEvents(ctx, m.Id, m.triggerEventID, args)
+	if err != nil {
+		return nil, err
+	}
+	totalCount, err := m.store.TotalActionWebhookEvents(ctx, m.Id, m.triggerEventID)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]graphqlbackend.MonitorActionEventResolver, len(ajs))
+	for i, aj := range ajs {
+		events[i] = &monitorActionEvent{Resolver: m.Resolver, ActionJob: aj}
+	}
+	return &monitorActionEventConnection{events: events, totalCount: totalCount}, nil
+}
+
+//
+// Slack webhook
+//
+type monitorSlackWebhook struct {
+	*Resolver
+	*cm.MonitorSlackWebhook
+
+	// If triggerEventID == nil, all events of this action will be returned.
+	// Otherwise, only those events of this action which are related to the specified
+	// trigger event will be returned.
+	triggerEventID *int
+}
+
+func (m *monitorSlackWebhook) ID() graphql.ID {
+	return relay.MarshalID(monitorActionSlackWebhookKind, m.Id)
+}
+
+func (m *monitorSlackWebhook) Enabled() bool {
+	return m.MonitorSlackWebhook.Enabled
+}
+
+func (m *monitorSlackWebhook) URL() string {
+	return m.MonitorSlackWebhook.URL
+}
+
+func (m *monitorSlackWebhook) Delivery() string {
+	return m.MonitorSlackWebhook.Delivery
+}
+
+func (m *monitorSlackWebhook) RateLimit() graphqlbackend.MonitorActionRateLimitResolver {
+	return newMonitorActionRateLimit(m.MonitorSlackWebhook.DeliveryPolicy)
+}
+
+func (m *monitorSlackWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	ajs, err := m.store.ReadActionSlackWebhookEvents(ctx, m.Id, m.triggerEventID, args)
+	if err != nil {
+		return nil, err
+	}
+	totalCount, err := m.store.TotalActionSlackWebhookEvents(ctx, m.Id, m.triggerEventID)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]graphqlbackend.MonitorActionEventResolver, len(ajs))
+	for i, aj := range ajs {
+		events[i] = &monitorActionEvent{Resolver: m.Resolver, ActionJob: aj}
+	}
+	return &monitorActionEventConnection{events: events, totalCount: totalCount}, nil
+}
+
 //
 // MonitorActionEmailRecipientConnection
 //
+// newMonitorActionRateLimit returns nil if the notifications of an action are
+// not rate limited.
+func newMonitorActionRateLimit(p cm.DeliveryPolicy) graphqlbackend.MonitorActionRateLimitResolver {
+	if p.MaxNotifications == nil || p.NotificationWindow == nil {
+		return nil
+	}
+	return &monitorActionRateLimit{maxNotifications: *p.MaxNotifications, windowMinutes: *p.NotificationWindow}
+}
+
+type monitorActionRateLimit struct {
+	maxNotifications int32
+	windowMinutes    int32
+}
+
+func (r *monitorActionRateLimit) MaxNotifications() int32 {
+	return r.maxNotifications
+}
+
+func (r *monitorActionRateLimit) WindowMinutes() int32 {
+	return r.windowMinutes
+}
+
 type monitorActionEmailRecipientsConnection struct {
 	recipients     []graphqlbackend.NamespaceResolver
 	nextPageCursor string
@@ -84,6 +84,13 @@ func transformBatchSpecWorkspaceExecutionJobRecord(ctx context.Context, s batche
 		return apiclient.Job{}, errors.Wrap(err, "creating internal access token")
 	}
 
+	// Jobs refreshing a changeset execute the workspace against the new head
+	// of the base branch.
+	commit := workspace.Commit
+	if job.Commit != "" {
+		commit = job.Commit
+	}
+
 	executionInput := batcheslib.WorkspacesExecutionInput{
 		RawSpec: batchSpec.RawSpec,
 		Workspaces: []*batcheslib.Workspace{
@@ -94,7 +101,7 @@ func transformBatchSpecWorkspaceExecutionJobRecord(ctx context.Context, s batche
 				},
 				Branch: batcheslib.WorkspaceBranch{
 					Name:   workspace.Branch,
-					Target: batcheslib.Commit{OID: workspace.Commit},
+					Target: batcheslib.Commit{OID: commit},
 				},
 				Path:               workspace.Path,
 				OnlyFetchWorkspace: workspace.OnlyFetchWorkspace,
@@ -513,6 +513,14 @@ func (s *PermsSyncer) syncRepoPerms(ctx context.Context, repoID api.RepoID, noPe
 			return errors.Wrap(s.permsStore.TouchRepoPermissions(ctx, int32(repoID)), "touch repository permissions")
 		}
 
+		// Some code hosts (e.g. Gitolite) cannot list the users who have access to a
+		// repository. Permissions of those repositories are only synced in the
+		// user-centric way, so we must not overwrite them with an empty set here.
+		if errors.As(err, &authz.ErrUnimplemented{}) {
+			log15.Debug("PermsSyncer.syncRepoPerms.unimplemented", "repoID", repo.ID, "err", err)
+			return errors.Wrap(s.permsStore.TouchRepoPermissions(ctx, int32(repoID)), "touch repository permissions")
+		}
+
 		if err != nil {
 			// Process partial results if this is an initial fetch.
 			if !noPerms {
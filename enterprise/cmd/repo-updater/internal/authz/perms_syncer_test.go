@@ -484,6 +484,60 @@ func TestPermsSyncer_syncRepoPerms(t *testing.T) {
 		}
 	})
 
+	t.Run("TouchRepoPermissions is called when FetchRepoPerms is unimplemented", func(t *testing.T) {
+		p := &mockProvider{
+			serviceType: extsvc.TypeGitolite,
+			serviceID:   "git@gitolite.example.com",
+			fetchRepoPerms: func(ctx context.Context, repo *extsvc.Repository, opts authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+				return nil, authz.ErrUnimplemented{Feature: "gitolite.FetchRepoPerms"}
+			},
+		}
+		authz.SetProviders(false, []authz.Provider{p})
+		defer authz.SetProviders(true, nil)
+
+		calledTouchRepoPermissions := false
+		edb.Mocks.Perms.TouchRepoPermissions = func(ctx context.Context, repoID int32) error {
+			calledTouchRepoPermissions = true
+			return nil
+		}
+		edb.Mocks.Perms.SetRepoPermissions = func(_ context.Context, p *authz.RepoPermissions) error {
+			return errors.New("not supposed to be called")
+		}
+		database.Mocks.Repos.List = func(context.Context, database.ReposListOptions) ([]*types.Repo, error) {
+			return []*types.Repo{
+				{
+					ID:      1,
+					Private: true,
+					ExternalRepo: api.ExternalRepoSpec{
+						ServiceType: extsvc.TypeGitolite,
+						ServiceID:   "git@gitolite.example.com",
+					},
+					Sources: map[string]*types.SourceInfo{
+						p.URN(): {},
+					},
+				},
+			}, nil
+		}
+		database.Mocks.Repos.ListExternalServiceUserIDsByRepoID = func(ctx context.Context, repoID api.RepoID) ([]int32, error) {
+			return []int32{}, nil
+		}
+		defer func() {
+			edb.Mocks.Perms = edb.MockPerms{}
+			database.Mocks.Repos = database.MockRepos{}
+		}()
+
+		s := newPermsSyncer(repos.NewStore(&dbtesting.MockDB{}, sql.TxOptions{}))
+
+		err := s.syncRepoPerms(context.Background(), 1, false, authz.FetchPermsOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !calledTouchRepoPermissions {
+			t.Fatal("!calledTouchRepoPermissions")
+		}
+	})
+
 	t.Run("identify authz provider by URN", func(t *testing.T) {
 		// Even though both p1 and p2 are pointing to the same code host,
 		// but p2 should not be used because it is not responsible for listing
@@ -3,6 +3,7 @@ package indexing
 import (
 	"context"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -22,6 +23,25 @@ import (
 
 var schemeToExternalService = map[string]string{
 	"semanticdb": extsvc.KindJVMPackages,
+	"npm":        extsvc.KindNpmPackages,
+}
+
+// externalServiceKindForPackage returns the kind of the external service that
+// syncs the repository of the given package, or false if the package resolves
+// to a repository on a regular code host.
+func externalServiceKindForPackage(pkg precise.Package) (string, bool) {
+	if pkg.Scheme == "gomod" {
+		// lsif-go names packages after the URL of their module path. Modules
+		// hosted on GitHub resolve to their upstream repository, all others
+		// are synced from a Go module proxy.
+		if !strings.HasPrefix(pkg.Name, "https://") || strings.HasPrefix(pkg.Name, "https://github.com/") {
+			return "", false
+		}
+		return extsvc.KindGoModules, true
+	}
+
+	kind, ok := schemeToExternalService[pkg.Scheme]
+	return kind, ok
 }
 
 // NewDependencySyncScheduler returns a new worker instance that processes
@@ -94,7 +114,7 @@ func (h *dependencySyncSchedulerHandler) Handle(ctx context.Context, record work
 			Version: packageReference.Package.Version,
 		}
 
-		extsvcKind, ok := schemeToExternalService[packageReference.Scheme]
+		extsvcKind, ok := externalServiceKindForPackage(pkg)
 		// add entry for empty string/kind here so dependencies such as lsif-go ones still get
 		// an associated dependency indexing job
 		kinds[extsvcKind] = struct{}{}
@@ -2,6 +2,7 @@ package indexing
 
 import (
 	"context"
+	"sort"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -106,3 +107,48 @@ func TestDependencySyncSchedulerGomod(t *testing.T) {
 		t.Errorf("unexpected number of calls to InsertCloneableDependencyRepo. want=%d have=%d", 0, len(mockDBStore.InsertCloneableDependencyRepoFunc.History()))
 	}
 }
+
+func TestDependencySyncSchedulerGoModuleProxy(t *testing.T) {
+	newOperations(&observation.TestContext)
+	mockWorkerStore := NewMockWorkerStore()
+	mockDBStore := NewMockDBStore()
+	mockExtsvcStore := NewMockExternalServiceStore()
+	mockDBStore.WithFunc.SetDefaultReturn(mockDBStore)
+	mockScanner := NewMockPackageReferenceScanner()
+	mockDBStore.ReferencesForUploadFunc.SetDefaultReturn(mockScanner, nil)
+	mockDBStore.GetUploadByIDFunc.SetDefaultReturn(dbstore.Upload{ID: 42, RepositoryID: 50, Indexer: "lsif-go"}, true, nil)
+	mockScanner.NextFunc.PushReturn(shared.PackageReference{Package: shared.Package{DumpID: 42, Scheme: "gomod", Name: "https://golang.org/x/text", Version: "v0.3.7"}}, true, nil)
+	mockScanner.NextFunc.PushReturn(shared.PackageReference{Package: shared.Package{DumpID: 42, Scheme: "gomod", Name: "https://github.com/sample/text", Version: "v3.2.0"}}, true, nil)
+
+	handler := dependencySyncSchedulerHandler{
+		dbStore:     mockDBStore,
+		workerStore: mockWorkerStore,
+		extsvcStore: mockExtsvcStore,
+	}
+
+	job := dbstore.DependencySyncingJob{
+		UploadID: 42,
+	}
+	if err := handler.Handle(context.Background(), job); err != nil {
+		t.Fatalf("unexpected error performing update: %s", err)
+	}
+
+	var kinds []string
+	for _, call := range mockDBStore.InsertDependencyIndexingJobFunc.History() {
+		kinds = append(kinds, call.Arg2)
+	}
+	sort.Strings(kinds)
+
+	expectedKinds := []string{"", extsvc.KindGoModules}
+	if diff := cmp.Diff(expectedKinds, kinds); diff != "" {
+		t.Errorf("unexpected kinds (-want +got):\n%s", diff)
+	}
+
+	if len(mockExtsvcStore.ListFunc.History()) != 1 {
+		t.Errorf("unexpected number of calls to extsvc.List. want=%d have=%d", 1, len(mockExtsvcStore.ListFunc.History()))
+	}
+
+	if len(mockDBStore.InsertCloneableDependencyRepoFunc.History()) != 1 {
+		t.Errorf("unexpected number of calls to InsertCloneableDependencyRepo. want=%d have=%d", 1, len(mockDBStore.InsertCloneableDependencyRepoFunc.History()))
+	}
+}
@@ -78,6 +78,9 @@ func TestAuthzProvidersFromConfig(t *testing.T) {
 		description                  string
 		cfg                          conf.Unified
 		gitlabConnections            []*schema.GitLabConnection
+		gitoliteConnections          []*schema.GitoliteConnection
+		giteaConnections             []*schema.GiteaConnection
+		gerritConnections            []*schema.GerritConnection
 		bitbucketServerConnections   []*schema.BitbucketServerConnection
 		expAuthzAllowAccessByDefault bool
 		expAuthzProviders            func(*testing.T, []authz.Provider)
@@ -391,6 +394,69 @@ func TestAuthzProvidersFromConfig(t *testing.T) {
 			},
 		},
 
+		{
+			description: "Gitolite connection with authorization enabled",
+			cfg:         conf.Unified{},
+			gitoliteConnections: []*schema.GitoliteConnection{
+				{
+					Authorization: &schema.GitoliteAuthorization{},
+					Host:          "git@gitolite.mycorp.org",
+					Prefix:        "gitolite.mycorp.org/",
+				},
+				{
+					Host:   "git@gitolite-public.mycorp.org",
+					Prefix: "gitolite-public.mycorp.org/",
+				},
+			},
+			expAuthzAllowAccessByDefault: true,
+			expAuthzProviders: func(t *testing.T, have []authz.Provider) {
+				if len(have) != 1 {
+					t.Fatalf("want 1 provider but got %d", len(have))
+				}
+
+				if have[0].ServiceType() != extsvc.TypeGitolite || have[0].ServiceID() != "git@gitolite.mycorp.org" {
+					t.Fatalf("no Gitolite authz provider returned")
+				}
+			},
+		},
+
+		{
+			description: "Gitea and Gerrit connections with authorization enabled",
+			cfg:         conf.Unified{},
+			giteaConnections: []*schema.GiteaConnection{
+				{
+					Authorization: &schema.GiteaAuthorization{},
+					Url:           "https://gitea.mycorp.org",
+					Token:         "secret-token",
+				},
+				{
+					Url:   "https://gitea-public.mycorp.org",
+					Token: "secret-token",
+				},
+			},
+			gerritConnections: []*schema.GerritConnection{
+				{
+					Authorization: &schema.GerritAuthorization{},
+					Url:           "https://gerrit.mycorp.org",
+					Username:      "admin",
+					Password:      "secret-password",
+				},
+			},
+			expAuthzAllowAccessByDefault: true,
+			expAuthzProviders: func(t *testing.T, have []authz.Provider) {
+				if len(have) != 2 {
+					t.Fatalf("want 2 providers but got %d", len(have))
+				}
+
+				if have[0].ServiceType() != extsvc.TypeGitea || have[0].ServiceID() != "https://gitea.mycorp.org/" {
+					t.Fatalf("no Gitea authz provider returned")
+				}
+				if have[1].ServiceType() != extsvc.TypeGerrit || have[1].ServiceID() != "https://gerrit.mycorp.org/" {
+					t.Fatalf("no Gerrit authz provider returned")
+				}
+			},
+		},
+
 		// For Sourcegraph authz provider
 		{
 			description: "Conflicted configuration between Sourcegraph and GitLab authz provider",
@@ -462,6 +528,9 @@ func TestAuthzProvidersFromConfig(t *testing.T) {
 		store := fakeStore{
 			gitlabs:          test.gitlabConnections,
 			bitbucketServers: test.bitbucketServerConnections,
+			gitolites:        test.gitoliteConnections,
+			giteas:           test.giteaConnections,
+			gerrits:          test.gerritConnections,
 		}
 
 		allowAccessByDefault, authzProviders, seriousProblems, _ := ProvidersFromConfig(
@@ -501,6 +570,10 @@ type fakeStore struct {
 	gitlabs          []*schema.GitLabConnection
 	githubs          []*schema.GitHubConnection
 	bitbucketServers []*schema.BitbucketServerConnection
+	bitbucketClouds  []*schema.BitbucketCloudConnection
+	gitolites        []*schema.GitoliteConnection
+	giteas           []*schema.GiteaConnection
+	gerrits          []*schema.GerritConnection
 	perforces        []*schema.PerforceConnection
 }
 
@@ -537,6 +610,34 @@ func (s fakeStore) List(ctx context.Context, opt database.ExternalServicesListOp
 					Config: mustMarshalJSONString(bbs),
 				})
 			}
+		case extsvc.KindBitbucketCloud:
+			for _, bbc := range s.bitbucketClouds {
+				svcs = append(svcs, &types.ExternalService{
+					Kind:   kind,
+					Config: mustMarshalJSONString(bbc),
+				})
+			}
+		case extsvc.KindGitolite:
+			for _, g := range s.gitolites {
+				svcs = append(svcs, &types.ExternalService{
+					Kind:   kind,
+					Config: mustMarshalJSONString(g),
+				})
+			}
+		case extsvc.KindGitea:
+			for _, g := range s.giteas {
+				svcs = append(svcs, &types.ExternalService{
+					Kind:   kind,
+					Config: mustMarshalJSONString(g),
+				})
+			}
+		case extsvc.KindGerrit:
+			for _, g := range s.gerrits {
+				svcs = append(svcs, &types.ExternalService{
+					Kind:   kind,
+					Config: mustMarshalJSONString(g),
+				})
+			}
 		case extsvc.KindPerforce:
 			for _, p := range s.perforces {
 				svcs = append(svcs, &types.ExternalService{
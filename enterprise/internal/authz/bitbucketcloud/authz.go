@@ -0,0 +1,96 @@
+package bitbucketcloud
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// NewAuthzProviders returns the set of Bitbucket Cloud authz providers derived from the connections.
+// It also returns any validation problems with the config, separating these into "serious problems" and
+// "warnings". "Serious problems" are those that should make Sourcegraph set authz.allowAccessByDefault
+// to false. "Warnings" are all other validation problems.
+func NewAuthzProviders(
+	conns []*types.BitbucketCloudConnection,
+	authProviders []schema.AuthProviders,
+) (ps []authz.Provider, problems []string, warnings []string) {
+	// Auth providers (i.e. login mechanisms)
+	bitbucketCloudAuthProviders := make(map[string]*schema.BitbucketCloudAuthProvider)
+	for _, p := range authProviders {
+		if p.Bitbucketcloud == nil {
+			continue
+		}
+		rawURL := p.Bitbucketcloud.Url
+		if rawURL == "" {
+			rawURL = "https://bitbucket.org/"
+		}
+		id := rawURL
+		if u, err := url.Parse(rawURL); err == nil {
+			id = extsvc.NewCodeHost(u, extsvc.TypeBitbucketCloud).ServiceID
+		}
+		bitbucketCloudAuthProviders[id] = p.Bitbucketcloud
+	}
+
+	for _, c := range conns {
+		p, err := newAuthzProvider(c)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		} else if p == nil {
+			continue
+		}
+
+		// Permissions are only fetched for the accounts linked by the
+		// Bitbucket Cloud OAuth provider. Without one, repos with restricted
+		// permissions will not be visible to non-admins.
+		if _, exists := bitbucketCloudAuthProviders[p.ServiceID()]; !exists {
+			warnings = append(warnings,
+				fmt.Sprintf("Bitbucket Cloud config for %[1]s has `authorization` enabled, "+
+					"but no authentication provider matching %[1]q was found. "+
+					"Check the [**site configuration**](/site-admin/configuration) to "+
+					"verify an entry in [`auth.providers`](https://docs.sourcegraph.com/admin/auth) exists for %[1]s.",
+					p.ServiceID()))
+		}
+
+		ps = append(ps, p)
+	}
+
+	for _, p := range ps {
+		for _, problem := range p.Validate() {
+			warnings = append(warnings, fmt.Sprintf("BitbucketCloud config for %s was invalid: %s", p.ServiceID(), problem))
+		}
+	}
+
+	return ps, problems, warnings
+}
+
+func newAuthzProvider(c *types.BitbucketCloudConnection) (authz.Provider, error) {
+	if c.Authorization == nil {
+		return nil, nil
+	}
+
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	apiURL := c.ApiURL
+	if apiURL == "" {
+		apiURL = "https://api.bitbucket.org"
+	}
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return nil, err
+	}
+
+	cli := bitbucketcloud.NewClient(extsvc.NormalizeBaseURL(u), nil)
+	cli.Username = c.Username
+	cli.AppPassword = c.AppPassword
+
+	return NewProvider(cli, extsvc.NewCodeHost(baseURL, extsvc.TypeBitbucketCloud), c.URN), nil
+}
@@ -0,0 +1,48 @@
+package bitbucketcloud
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestNewAuthzProviders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"values": [{"permission": "owner", "workspace": {"slug": "sourcegraph"}}]}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	conns := []*types.BitbucketCloudConnection{{
+		URN: "extsvc:bitbucketCloud:1",
+		BitbucketCloudConnection: &schema.BitbucketCloudConnection{
+			Url:           "https://bitbucket.org",
+			ApiURL:        srv.URL,
+			Authorization: &schema.BitbucketCloudAuthorization{},
+		},
+	}}
+
+	t.Run("no authentication provider", func(t *testing.T) {
+		ps, problems, warnings := NewAuthzProviders(conns, nil)
+		if len(ps) != 1 || len(problems) != 0 {
+			t.Fatalf("want 1 provider and no problems, got %d providers and problems %v", len(ps), problems)
+		}
+		if len(warnings) != 1 || !strings.Contains(warnings[0], "no authentication provider matching") {
+			t.Fatalf("want a warning about the missing authentication provider, got %v", warnings)
+		}
+	})
+
+	t.Run("matching authentication provider", func(t *testing.T) {
+		authProviders := []schema.AuthProviders{{
+			Bitbucketcloud: &schema.BitbucketCloudAuthProvider{Type: "bitbucketcloud"},
+		}}
+		ps, problems, warnings := NewAuthzProviders(conns, authProviders)
+		if len(ps) != 1 || len(problems) != 0 || len(warnings) != 0 {
+			t.Fatalf("want 1 provider and no problems or warnings, got %d providers, problems %v and warnings %v", len(ps), problems, warnings)
+		}
+	})
+}
@@ -0,0 +1,185 @@
+// Package bitbucketcloud contains an authorization provider for Bitbucket Cloud.
+package bitbucketcloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// Provider is an implementation of AuthzProvider that provides repository permissions as
+// determined from the Bitbucket Cloud workspace and repository permissions APIs.
+type Provider struct {
+	urn      string
+	client   *bitbucketcloud.Client
+	codeHost *extsvc.CodeHost
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+// NewProvider returns a new Bitbucket Cloud authorization provider that uses
+// the given bitbucketcloud.Client to talk to the Bitbucket Cloud API. The
+// client's user must own the workspaces whose permissions are enforced.
+// Permissions are only fetched for the Bitbucket Cloud accounts linked to
+// Sourcegraph users by signing in with Bitbucket Cloud.
+func NewProvider(cli *bitbucketcloud.Client, codeHost *extsvc.CodeHost, urn string) *Provider {
+	return &Provider{
+		urn:      urn,
+		client:   cli,
+		codeHost: codeHost,
+	}
+}
+
+// Validate validates that the Provider has access to the Bitbucket Cloud API
+// with the credentials it was configured with, and that it owns at least one
+// workspace.
+func (p *Provider) Validate() []string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	workspaces, err := p.workspaces(ctx)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	if len(workspaces) == 0 {
+		return []string{fmt.Sprintf("user %q does not own any workspaces, so no repository permissions can be fetched", p.client.Username)}
+	}
+	return nil
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ServiceID returns the absolute URL that identifies the Bitbucket Cloud instance
+// this provider is configured with.
+func (p *Provider) ServiceID() string { return p.codeHost.ServiceID }
+
+// ServiceType returns the type of this Provider, namely, "bitbucketCloud".
+func (p *Provider) ServiceType() string { return p.codeHost.ServiceType }
+
+// FetchAccount implements the authz.Provider interface. It always returns
+// nil, because Bitbucket Cloud accounts are only linked to Sourcegraph users
+// when they sign in with the Bitbucket Cloud authentication provider. We
+// don't match accounts by username, since Sourcegraph usernames don't prove
+// ownership of a Bitbucket Cloud account.
+func (p *Provider) FetchAccount(context.Context, *types.User, []*extsvc.Account, []string) (*extsvc.Account, error) {
+	return nil, nil
+}
+
+// FetchUserPerms returns a list of repository UUIDs that the given account
+// has read access to in the workspaces owned by the provider's user. The
+// repository UUID has the same value as it would be used as
+// api.ExternalRepoSpec.ID.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-permissions-repositories-get
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, opts authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, errors.Errorf("not a code host of the account: want %q but have %q",
+			p.codeHost.ServiceID, account.AccountSpec.ServiceID)
+	}
+
+	workspaces, err := p.workspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	perms := &authz.ExternalUserPermissions{}
+	// The account ID of a Bitbucket Cloud account is its UUID.
+	query := fmt.Sprintf("user.uuid=%q", account.AccountID)
+	for _, workspace := range workspaces {
+		t := &bitbucketcloud.PageToken{}
+		for first := true; first || t.HasMore(); first = false {
+			var rps []*bitbucketcloud.RepoPermission
+			rps, t, err = p.client.WorkspaceRepoPermissions(ctx, t, workspace, query)
+			if err != nil {
+				return perms, err
+			}
+			for _, rp := range rps {
+				if rp.Repository != nil {
+					perms.Exacts = append(perms.Exacts, extsvc.RepoID(rp.Repository.UUID))
+				}
+			}
+		}
+	}
+
+	return perms, nil
+}
+
+// FetchRepoPerms returns a list of account UUIDs (on code host) who have read
+// access to the given repo on the code host. The account UUID has the same
+// value as it would be used as extsvc.Account.AccountID. The returned list
+// includes both direct access and access inherited from group membership.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-permissions-repositories-repo-slug-get
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository, opts authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+	switch {
+	case repo == nil:
+		return nil, errors.New("no repo provided")
+	case !extsvc.IsHostOfRepo(p.codeHost, &repo.ExternalRepoSpec):
+		return nil, errors.Errorf("not a code host of the repo: want %q but have %q",
+			p.codeHost.ServiceID, repo.ServiceID)
+	}
+
+	// The URI of a Bitbucket Cloud repository is "<host>/<workspace>/<slug>".
+	parts := strings.Split(repo.URI, "/")
+	if len(parts) != 3 {
+		return nil, errors.Errorf("malformed Bitbucket Cloud repository URI %q", repo.URI)
+	}
+	workspace, slug := parts[1], parts[2]
+
+	var (
+		ids []extsvc.AccountID
+		err error
+	)
+	t := &bitbucketcloud.PageToken{}
+	for first := true; first || t.HasMore(); first = false {
+		var rps []*bitbucketcloud.RepoPermission
+		rps, t, err = p.client.RepoPermissions(ctx, t, workspace, slug)
+		if err != nil {
+			return ids, err
+		}
+		for _, rp := range rps {
+			if rp.User != nil {
+				ids = append(ids, extsvc.AccountID(rp.User.UUID))
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+// workspaces returns the slugs of the workspaces owned by the provider's user.
+func (p *Provider) workspaces(ctx context.Context) (slugs []string, err error) {
+	t := &bitbucketcloud.PageToken{}
+	for first := true; first || t.HasMore(); first = false {
+		var perms []*bitbucketcloud.WorkspacePermission
+		perms, t, err = p.client.AdministeredWorkspaces(ctx, t)
+		if err != nil {
+			return nil, errors.Wrap(err, "list administered workspaces")
+		}
+		for _, perm := range perms {
+			if perm.Workspace != nil {
+				slugs = append(slugs, perm.Workspace.Slug)
+			}
+		}
+	}
+	return slugs, nil
+}
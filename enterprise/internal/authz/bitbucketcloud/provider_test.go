@@ -0,0 +1,119 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func newTestProvider(t *testing.T) *Provider {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/2.0/user/permissions/workspaces", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"values": [
+			{"permission": "owner", "workspace": {"slug": "sourcegraph"}},
+			{"permission": "owner", "workspace": {"slug": "other"}}
+		]}`)
+	})
+	mux.HandleFunc("/2.0/workspaces/sourcegraph/permissions/repositories", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("q") != `user.uuid="{alice}"` {
+			fmt.Fprint(w, `{"values": []}`)
+			return
+		}
+		fmt.Fprint(w, `{"values": [{"permission": "read", "repository": {"uuid": "{repo-1}"}}]}`)
+	})
+	mux.HandleFunc("/2.0/workspaces/other/permissions/repositories", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"values": [{"permission": "admin", "repository": {"uuid": "{repo-2}"}}]}`)
+	})
+	mux.HandleFunc("/2.0/workspaces/sourcegraph/permissions/repositories/secret", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"values": [
+			{"permission": "read", "user": {"uuid": "{alice}"}},
+			{"permission": "write", "user": {"uuid": "{bob}"}}
+		]}`)
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	apiURL, _ := url.Parse(srv.URL)
+	cli := bitbucketcloud.NewClient(apiURL, srv.Client())
+	cli.Username = "admin"
+	cli.AppPassword = "password"
+
+	baseURL, _ := url.Parse("https://bitbucket.org")
+	return NewProvider(cli, extsvc.NewCodeHost(baseURL, extsvc.TypeBitbucketCloud), "extsvc:bitbucketCloud:1")
+}
+
+func TestProvider_FetchAccount(t *testing.T) {
+	p := newTestProvider(t)
+
+	// Accounts are only linked by signing in with Bitbucket Cloud, never by
+	// matching usernames.
+	acct, err := p.FetchAccount(context.Background(), &types.User{ID: 1, Username: "alice"}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acct != nil {
+		t.Fatalf("want nil account but got %+v", acct)
+	}
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	p := newTestProvider(t)
+
+	perms, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: extsvc.TypeBitbucketCloud,
+			ServiceID:   "https://bitbucket.org/",
+			AccountID:   "{alice}",
+		},
+	}, authz.FetchPermsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &authz.ExternalUserPermissions{Exacts: []extsvc.RepoID{"{repo-1}", "{repo-2}"}}
+	if diff := cmp.Diff(want, perms); diff != "" {
+		t.Fatalf("unexpected permissions (-want +got):\n%s", diff)
+	}
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	p := newTestProvider(t)
+
+	ids, err := p.FetchRepoPerms(context.Background(), &extsvc.Repository{
+		URI: "bitbucket.org/sourcegraph/secret",
+		ExternalRepoSpec: api.ExternalRepoSpec{
+			ID:          "{repo-1}",
+			ServiceType: extsvc.TypeBitbucketCloud,
+			ServiceID:   "https://bitbucket.org/",
+		},
+	}, authz.FetchPermsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []extsvc.AccountID{"{alice}", "{bob}"}
+	if diff := cmp.Diff(want, ids); diff != "" {
+		t.Fatalf("unexpected account IDs (-want +got):\n%s", diff)
+	}
+}
+
+func TestProvider_Validate(t *testing.T) {
+	p := newTestProvider(t)
+	if problems := p.Validate(); len(problems) > 0 {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+}
@@ -0,0 +1,30 @@
+package gerrit
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// NewAuthzProviders returns the set of Gerrit authz providers derived from the
+// connections. Connections with an invalid URL are reported as serious
+// problems.
+func NewAuthzProviders(conns []*types.GerritConnection) (ps []authz.Provider, problems []string, warnings []string) {
+	for _, c := range conns {
+		if c.Authorization == nil {
+			continue
+		}
+		baseURL, err := url.Parse(c.Url)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("Could not parse URL for Gerrit instance %q: %s", c.Url, err))
+			continue
+		}
+		baseURL = extsvc.NormalizeBaseURL(baseURL)
+		ps = append(ps, NewProvider(c.URN, gerrit.NewClient(baseURL, c.Username, c.Password, nil)))
+	}
+	return ps, problems, warnings
+}
@@ -0,0 +1,121 @@
+// Package gerrit contains an authorization provider for Gerrit.
+package gerrit
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cockroachdb/errors"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+var _ authz.Provider = (*Provider)(nil)
+
+// Provider implements authz.Provider for Gerrit project permissions.
+type Provider struct {
+	urn      string
+	codeHost *extsvc.CodeHost
+	client   *gerrit.Client
+}
+
+// NewProvider returns a new Gerrit authorization provider that uses the given
+// client, whose user must have the Run As global capability. It assumes
+// usernames of Sourcegraph accounts match 1-1 with Gerrit usernames.
+func NewProvider(urn string, client *gerrit.Client) *Provider {
+	return &Provider{
+		urn: urn,
+		codeHost: &extsvc.CodeHost{
+			ServiceID:   client.URL.String(),
+			ServiceType: extsvc.TypeGerrit,
+		},
+		client: client,
+	}
+}
+
+// FetchAccount returns the Gerrit account of the given user, which has the
+// same username as the user.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, _ []*extsvc.Account, _ []string) (_ *extsvc.Account, err error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	accountData, err := json.Marshal(gerrit.AccountData{Username: user.Username})
+	if err != nil {
+		return nil, err
+	}
+
+	return &extsvc.Account{
+		UserID: user.ID,
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.codeHost.ServiceType,
+			ServiceID:   p.codeHost.ServiceID,
+			AccountID:   user.Username,
+		},
+		AccountData: extsvc.AccountData{
+			Data: (*json.RawMessage)(&accountData),
+		},
+	}, nil
+}
+
+// FetchUserPerms returns the IDs of the projects the given account has read
+// access to, as reported by listing projects on behalf of the account's user.
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, opts authz.FetchPermsOptions) (_ *authz.ExternalUserPermissions, err error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, errors.Errorf("not a code host of the account: want %q but have %q",
+			p.codeHost.ServiceID, account.AccountSpec.ServiceID)
+	}
+
+	tr, ctx := trace.New(ctx, "gerrit.authz.provider.FetchUserPerms", "")
+	defer func() {
+		tr.LogFields(otlog.String("account.id", account.AccountID))
+		if err != nil {
+			tr.SetError(err)
+		}
+		tr.Finish()
+	}()
+
+	client := p.client.WithRunAs(account.AccountID)
+
+	var extIDs []extsvc.RepoID
+	for skip, more := 0, true; more; {
+		var projects []*gerrit.Project
+		projects, more, err = client.ListProjects(ctx, skip)
+		if err != nil {
+			return nil, errors.Wrap(err, "list projects for user")
+		}
+		for _, proj := range projects {
+			extIDs = append(extIDs, extsvc.RepoID(proj.ID))
+		}
+		skip += len(projects)
+	}
+
+	return &authz.ExternalUserPermissions{
+		Exacts: extIDs,
+	}, nil
+}
+
+// FetchRepoPerms is unimplemented, because Gerrit has no means to list the
+// users who can read a project. Project permissions are only synced through
+// FetchUserPerms.
+func (p *Provider) FetchRepoPerms(context.Context, *extsvc.Repository, authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+	return nil, authz.ErrUnimplemented{Feature: "gerrit.FetchRepoPerms"}
+}
+
+func (p *Provider) ServiceType() string { return p.codeHost.ServiceType }
+func (p *Provider) ServiceID() string   { return p.codeHost.ServiceID }
+func (p *Provider) URN() string         { return p.urn }
+
+// Validate always succeeds, because checking whether the user has the Run As
+// capability requires a round trip to the Gerrit instance.
+func (p *Provider) Validate() (problems []string) {
+	return nil
+}
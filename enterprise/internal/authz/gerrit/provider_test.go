@@ -0,0 +1,114 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func newTestProvider(t *testing.T, handler http.Handler) *Provider {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewProvider("extsvc:gerrit:1", gerrit.NewClient(u, "admin", "secret", nil))
+}
+
+func TestProvider_FetchAccount(t *testing.T) {
+	p := newTestProvider(t, http.NotFoundHandler())
+
+	acct, err := p.FetchAccount(context.Background(), &types.User{ID: 42, Username: "alice"}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := extsvc.AccountSpec{
+		ServiceType: extsvc.TypeGerrit,
+		ServiceID:   p.ServiceID(),
+		AccountID:   "alice",
+	}
+	if diff := cmp.Diff(want, acct.AccountSpec); diff != "" {
+		t.Fatalf("unexpected account spec (-want +got):\n%s", diff)
+	}
+	if acct.UserID != 42 {
+		t.Fatalf("UserID: want 42 but got %d", acct.UserID)
+	}
+	if got, want := string(*acct.Data), `{"username":"alice"}`; got != want {
+		t.Fatalf("Data: want %q but got %q", want, got)
+	}
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a/projects/", func(w http.ResponseWriter, r *http.Request) {
+		if have, want := r.Header.Get("X-Gerrit-RunAs"), "alice"; have != want {
+			t.Errorf("unexpected X-Gerrit-RunAs header. want=%q have=%q", want, have)
+		}
+		switch r.URL.Query().Get("S") {
+		case "0":
+			fmt.Fprint(w, `)]}'
+{"platform/build": {"id": "platform%2Fbuild", "_more_projects": true}}`)
+		case "1":
+			fmt.Fprint(w, `)]}'
+{"tools/repo": {"id": "tools%2Frepo"}}`)
+		default:
+			t.Errorf("unexpected skip %q", r.URL.Query().Get("S"))
+		}
+	})
+	p := newTestProvider(t, mux)
+
+	t.Run("wrong code host", func(t *testing.T) {
+		_, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeGerrit,
+				ServiceID:   "https://other.example.com/",
+				AccountID:   "alice",
+			},
+		}, authz.FetchPermsOptions{})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		perms, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeGerrit,
+				ServiceID:   p.ServiceID(),
+				AccountID:   "alice",
+			},
+		}, authz.FetchPermsOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &authz.ExternalUserPermissions{Exacts: []extsvc.RepoID{"platform%2Fbuild", "tools%2Frepo"}}
+		if diff := cmp.Diff(want, perms); diff != "" {
+			t.Fatalf("unexpected permissions (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	p := newTestProvider(t, http.NotFoundHandler())
+
+	_, err := p.FetchRepoPerms(context.Background(), &extsvc.Repository{}, authz.FetchPermsOptions{})
+	if !errors.As(err, &authz.ErrUnimplemented{}) {
+		t.Fatalf("want ErrUnimplemented but got %v", err)
+	}
+}
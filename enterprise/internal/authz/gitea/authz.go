@@ -0,0 +1,30 @@
+package gitea
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// NewAuthzProviders returns the set of Gitea authz providers derived from the
+// connections. Connections with an invalid URL are reported as serious
+// problems.
+func NewAuthzProviders(conns []*types.GiteaConnection) (ps []authz.Provider, problems []string, warnings []string) {
+	for _, c := range conns {
+		if c.Authorization == nil {
+			continue
+		}
+		baseURL, err := url.Parse(c.Url)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("Could not parse URL for Gitea instance %q: %s", c.Url, err))
+			continue
+		}
+		baseURL = extsvc.NormalizeBaseURL(baseURL)
+		ps = append(ps, NewProvider(c.URN, gitea.NewClient(baseURL, c.Token, nil)))
+	}
+	return ps, problems, warnings
+}
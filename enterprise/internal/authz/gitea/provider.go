@@ -0,0 +1,123 @@
+// Package gitea contains an authorization provider for Gitea.
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+var _ authz.Provider = (*Provider)(nil)
+
+// Provider implements authz.Provider for Gitea repository permissions.
+type Provider struct {
+	urn      string
+	codeHost *extsvc.CodeHost
+	client   *gitea.Client
+}
+
+// NewProvider returns a new Gitea authorization provider that uses the given
+// client, which must be authenticated as a Gitea site admin. It assumes
+// usernames of Sourcegraph accounts match 1-1 with Gitea usernames.
+func NewProvider(urn string, client *gitea.Client) *Provider {
+	return &Provider{
+		urn: urn,
+		codeHost: &extsvc.CodeHost{
+			ServiceID:   client.URL.String(),
+			ServiceType: extsvc.TypeGitea,
+		},
+		client: client,
+	}
+}
+
+// FetchAccount returns the Gitea account of the given user, which has the same
+// username as the user.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, _ []*extsvc.Account, _ []string) (_ *extsvc.Account, err error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	accountData, err := json.Marshal(gitea.AccountData{Username: user.Username})
+	if err != nil {
+		return nil, err
+	}
+
+	return &extsvc.Account{
+		UserID: user.ID,
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.codeHost.ServiceType,
+			ServiceID:   p.codeHost.ServiceID,
+			AccountID:   user.Username,
+		},
+		AccountData: extsvc.AccountData{
+			Data: (*json.RawMessage)(&accountData),
+		},
+	}, nil
+}
+
+// FetchUserPerms returns the IDs of the repositories the given account has
+// read access to, as reported by searching repositories on behalf of the
+// account's user.
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, opts authz.FetchPermsOptions) (_ *authz.ExternalUserPermissions, err error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, errors.Errorf("not a code host of the account: want %q but have %q",
+			p.codeHost.ServiceID, account.AccountSpec.ServiceID)
+	}
+
+	tr, ctx := trace.New(ctx, "gitea.authz.provider.FetchUserPerms", "")
+	defer func() {
+		tr.LogFields(otlog.String("account.id", account.AccountID))
+		if err != nil {
+			tr.SetError(err)
+		}
+		tr.Finish()
+	}()
+
+	client := p.client.WithSudo(account.AccountID)
+
+	var extIDs []extsvc.RepoID
+	for page := 1; page != 0; {
+		repos, next, err := client.SearchRepos(ctx, "", page)
+		if err != nil {
+			return nil, errors.Wrap(err, "list repositories for user")
+		}
+		for _, r := range repos {
+			extIDs = append(extIDs, extsvc.RepoID(strconv.FormatInt(r.ID, 10)))
+		}
+		page = next
+	}
+
+	return &authz.ExternalUserPermissions{
+		Exacts: extIDs,
+	}, nil
+}
+
+// FetchRepoPerms is unimplemented, because Gitea can only list the direct
+// collaborators of a repository, not the users who have access through
+// organization teams. Repository permissions are only synced through
+// FetchUserPerms.
+func (p *Provider) FetchRepoPerms(context.Context, *extsvc.Repository, authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+	return nil, authz.ErrUnimplemented{Feature: "gitea.FetchRepoPerms"}
+}
+
+func (p *Provider) ServiceType() string { return p.codeHost.ServiceType }
+func (p *Provider) ServiceID() string   { return p.codeHost.ServiceID }
+func (p *Provider) URN() string         { return p.urn }
+
+// Validate always succeeds, because checking whether the token belongs to a
+// site admin requires a round trip to the Gitea instance.
+func (p *Provider) Validate() (problems []string) {
+	return nil
+}
@@ -0,0 +1,113 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func newTestProvider(t *testing.T, handler http.Handler) *Provider {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewProvider("extsvc:gitea:1", gitea.NewClient(u, "secret", nil))
+}
+
+func TestProvider_FetchAccount(t *testing.T) {
+	p := newTestProvider(t, http.NotFoundHandler())
+
+	acct, err := p.FetchAccount(context.Background(), &types.User{ID: 42, Username: "alice"}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := extsvc.AccountSpec{
+		ServiceType: extsvc.TypeGitea,
+		ServiceID:   p.ServiceID(),
+		AccountID:   "alice",
+	}
+	if diff := cmp.Diff(want, acct.AccountSpec); diff != "" {
+		t.Fatalf("unexpected account spec (-want +got):\n%s", diff)
+	}
+	if acct.UserID != 42 {
+		t.Fatalf("UserID: want 42 but got %d", acct.UserID)
+	}
+	if got, want := string(*acct.Data), `{"username":"alice"}`; got != want {
+		t.Fatalf("Data: want %q but got %q", want, got)
+	}
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/repos/search", func(w http.ResponseWriter, r *http.Request) {
+		if have, want := r.Header.Get("Sudo"), "alice"; have != want {
+			t.Errorf("unexpected Sudo header. want=%q have=%q", want, have)
+		}
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/api/v1/repos/search?limit=50&page=2>; rel="next"`, r.Host))
+			fmt.Fprint(w, `{"ok": true, "data": [{"id": 1, "full_name": "org/foo"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"ok": true, "data": [{"id": 2, "full_name": "alice/bar"}]}`)
+		default:
+			t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+	})
+	p := newTestProvider(t, mux)
+
+	t.Run("wrong code host", func(t *testing.T) {
+		_, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeGitea,
+				ServiceID:   "https://other.example.com/",
+				AccountID:   "alice",
+			},
+		}, authz.FetchPermsOptions{})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		perms, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeGitea,
+				ServiceID:   p.ServiceID(),
+				AccountID:   "alice",
+			},
+		}, authz.FetchPermsOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &authz.ExternalUserPermissions{Exacts: []extsvc.RepoID{"1", "2"}}
+		if diff := cmp.Diff(want, perms); diff != "" {
+			t.Fatalf("unexpected permissions (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	p := newTestProvider(t, http.NotFoundHandler())
+
+	_, err := p.FetchRepoPerms(context.Background(), &extsvc.Repository{}, authz.FetchPermsOptions{})
+	if !errors.As(err, &authz.ErrUnimplemented{}) {
+		t.Fatalf("want ErrUnimplemented but got %v", err)
+	}
+}
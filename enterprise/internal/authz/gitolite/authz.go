@@ -0,0 +1,19 @@
+package gitolite
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// NewAuthzProviders returns the set of Gitolite authz providers derived from
+// the connections. Gitolite connections have no authorization settings that
+// can be invalid, so no problems or warnings are returned.
+func NewAuthzProviders(conns []*types.GitoliteConnection) (ps []authz.Provider, problems []string, warnings []string) {
+	for _, c := range conns {
+		if c.Authorization == nil {
+			continue
+		}
+		ps = append(ps, NewProvider(c.URN, c.Host))
+	}
+	return ps, problems, warnings
+}
@@ -0,0 +1,126 @@
+// Package gitolite contains an authorization provider for Gitolite.
+package gitolite
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cockroachdb/errors"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitolite"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+var _ authz.Provider = (*Provider)(nil)
+
+// Provider implements authz.Provider for Gitolite repository permissions.
+type Provider struct {
+	urn      string
+	host     string
+	codeHost *extsvc.CodeHost
+
+	lister gitoliteLister
+}
+
+type gitoliteLister interface {
+	ListGitoliteForUser(ctx context.Context, gitoliteHost, user string) ([]*gitolite.Repo, error)
+}
+
+// NewProvider returns a new Gitolite authorization provider for the Gitolite
+// server at host. It assumes usernames of Sourcegraph accounts match 1-1 with
+// Gitolite usernames. The Gitolite server is queried through our default
+// gitserver client, since only gitserver holds the SSH keys for it.
+func NewProvider(urn, host string) *Provider {
+	return &Provider{
+		urn:  urn,
+		host: host,
+		codeHost: &extsvc.CodeHost{
+			ServiceID:   gitolite.ServiceID(host),
+			ServiceType: extsvc.TypeGitolite,
+		},
+		lister: gitserver.DefaultClient,
+	}
+}
+
+// FetchAccount returns the Gitolite account of the given user, which has the
+// same username as the user.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, _ []*extsvc.Account, _ []string) (_ *extsvc.Account, err error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	accountData, err := json.Marshal(gitolite.AccountData{Username: user.Username})
+	if err != nil {
+		return nil, err
+	}
+
+	return &extsvc.Account{
+		UserID: user.ID,
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.codeHost.ServiceType,
+			ServiceID:   p.codeHost.ServiceID,
+			AccountID:   user.Username,
+		},
+		AccountData: extsvc.AccountData{
+			Data: (*json.RawMessage)(&accountData),
+		},
+	}, nil
+}
+
+// FetchUserPerms returns the names of the repositories the given account has
+// read access to, as reported by running `info` on behalf of the account's
+// user.
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, opts authz.FetchPermsOptions) (_ *authz.ExternalUserPermissions, err error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, errors.Errorf("not a code host of the account: want %q but have %q",
+			p.codeHost.ServiceID, account.AccountSpec.ServiceID)
+	}
+
+	tr, ctx := trace.New(ctx, "gitolite.authz.provider.FetchUserPerms", "")
+	defer func() {
+		tr.LogFields(otlog.String("account.id", account.AccountID))
+		if err != nil {
+			tr.SetError(err)
+		}
+		tr.Finish()
+	}()
+
+	repos, err := p.lister.ListGitoliteForUser(ctx, p.host, account.AccountID)
+	if err != nil {
+		return nil, errors.Wrap(err, "list repositories for user")
+	}
+
+	extIDs := make([]extsvc.RepoID, 0, len(repos))
+	for _, r := range repos {
+		extIDs = append(extIDs, extsvc.RepoID(r.Name))
+	}
+
+	return &authz.ExternalUserPermissions{
+		Exacts: extIDs,
+	}, nil
+}
+
+// FetchRepoPerms is unimplemented, because Gitolite has no means to list the
+// users who have access to a repository. Repository permissions are only
+// synced through FetchUserPerms.
+func (p *Provider) FetchRepoPerms(context.Context, *extsvc.Repository, authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+	return nil, authz.ErrUnimplemented{Feature: "gitolite.FetchRepoPerms"}
+}
+
+func (p *Provider) ServiceType() string { return p.codeHost.ServiceType }
+func (p *Provider) ServiceID() string   { return p.codeHost.ServiceID }
+func (p *Provider) URN() string         { return p.urn }
+
+// Validate always succeeds, because checking whether the sudo command is
+// usable requires a round trip through gitserver to the Gitolite server.
+func (p *Provider) Validate() (problems []string) {
+	return nil
+}
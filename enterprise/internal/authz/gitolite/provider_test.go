@@ -0,0 +1,94 @@
+package gitolite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitolite"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type mockLister func(ctx context.Context, gitoliteHost, user string) ([]*gitolite.Repo, error)
+
+func (f mockLister) ListGitoliteForUser(ctx context.Context, gitoliteHost, user string) ([]*gitolite.Repo, error) {
+	return f(ctx, gitoliteHost, user)
+}
+
+func TestProvider_FetchAccount(t *testing.T) {
+	p := NewProvider("extsvc:gitolite:1", "git@gitolite.example.com")
+
+	acct, err := p.FetchAccount(context.Background(), &types.User{ID: 42, Username: "alice"}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := extsvc.AccountSpec{
+		ServiceType: extsvc.TypeGitolite,
+		ServiceID:   "git@gitolite.example.com",
+		AccountID:   "alice",
+	}
+	if diff := cmp.Diff(want, acct.AccountSpec); diff != "" {
+		t.Fatalf("unexpected account spec (-want +got):\n%s", diff)
+	}
+	if acct.UserID != 42 {
+		t.Fatalf("UserID: want 42 but got %d", acct.UserID)
+	}
+	if got, want := string(*acct.Data), `{"username":"alice"}`; got != want {
+		t.Fatalf("Data: want %q but got %q", want, got)
+	}
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	p := NewProvider("extsvc:gitolite:1", "git@gitolite.example.com")
+	p.lister = mockLister(func(ctx context.Context, gitoliteHost, user string) ([]*gitolite.Repo, error) {
+		if gitoliteHost != "git@gitolite.example.com" || user != "alice" {
+			return nil, errors.Errorf("unexpected host %q or user %q", gitoliteHost, user)
+		}
+		return []*gitolite.Repo{{Name: "foo"}, {Name: "bar/baz"}}, nil
+	})
+
+	t.Run("wrong code host", func(t *testing.T) {
+		_, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeGitolite,
+				ServiceID:   "git@other.example.com",
+				AccountID:   "alice",
+			},
+		}, authz.FetchPermsOptions{})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		perms, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeGitolite,
+				ServiceID:   "git@gitolite.example.com",
+				AccountID:   "alice",
+			},
+		}, authz.FetchPermsOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &authz.ExternalUserPermissions{Exacts: []extsvc.RepoID{"foo", "bar/baz"}}
+		if diff := cmp.Diff(want, perms); diff != "" {
+			t.Fatalf("unexpected permissions (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	p := NewProvider("extsvc:gitolite:1", "git@gitolite.example.com")
+
+	_, err := p.FetchRepoPerms(context.Background(), &extsvc.Repository{}, authz.FetchPermsOptions{})
+	if !errors.As(err, &authz.ErrUnimplemented{}) {
+		t.Fatalf("want ErrUnimplemented but got %v", err)
+	}
+}
@@ -27,6 +27,8 @@ func Routines(ctx context.Context, batchesStore *store.Store, cf *httpcli.Factor
 		newReconcilerWorkerResetter(reconcilerWorkerStore, metrics),
 
 		newSpecExpireJob(ctx, batchesStore),
+		newRolloutReleaseJob(ctx, batchesStore),
+		newStaleChangesetsJob(ctx, batchesStore),
 
 		scheduler.NewScheduler(ctx, batchesStore),
 
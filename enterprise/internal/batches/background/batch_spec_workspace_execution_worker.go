@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -13,6 +14,7 @@ import (
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -179,7 +181,11 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) MarkComplete(ctx context.Contex
 		return s.Store.MarkFailed(ctx, id, fmt.Sprintf("failed to delete internal access token: %s", err), options)
 	}
 
-	err = setChangesetSpecIDs(ctx, tx, job.BatchSpecWorkspaceID, changesetSpecIDs)
+	if job.ChangesetID != 0 {
+		err = replaceChangesetSpec(ctx, tx, job.BatchSpecWorkspaceID, job.ChangesetID, changesetSpecIDs)
+	} else {
+		err = setChangesetSpecIDs(ctx, tx, job.BatchSpecWorkspaceID, changesetSpecIDs)
+	}
 	if err != nil {
 		return false, tx.Done(err)
 	}
@@ -218,6 +224,90 @@ func setChangesetSpecIDs(ctx context.Context, tx *store.Store, batchSpecWorkspac
 	return tx.Exec(ctx, sqlf.Sprintf(setChangesetSpecIDsOnBatchSpecWorkspace, marshaledIDs, batchSpecWorkspaceID))
 }
 
+const replaceChangesetSpecOnBatchSpec = `
+UPDATE changeset_specs
+SET batch_spec_id = CASE
+	WHEN id = %s THEN NULL
+	ELSE (SELECT batch_spec_id FROM batch_spec_workspaces WHERE id = %s LIMIT 1)
+END
+WHERE id = %s OR id = %s
+`
+
+const replaceChangesetSpecIDOnBatchSpecWorkspace = `
+UPDATE batch_spec_workspaces
+SET changeset_spec_ids = (changeset_spec_ids - %s::text) || jsonb_build_object(%s::text, '{}'::jsonb)
+WHERE id = %s
+`
+
+// replaceChangesetSpec makes the changeset spec that was created when the
+// workspace of the given changeset was re-executed the current spec of the
+// changeset and enqueues the changeset, so the reconciler pushes the
+// refreshed diff.
+//
+// Only the superseded spec is replaced in the batch spec and the workspace.
+// The other specs created by the execution are for branches the batch change
+// doesn't publish, so they stay detached and expire.
+func replaceChangesetSpec(ctx context.Context, tx *store.Store, batchSpecWorkspaceID, changesetID int64, changesetSpecIDs []int64) error {
+	if len(changesetSpecIDs) == 0 {
+		return nil
+	}
+
+	ch, err := tx.GetChangesetByID(ctx, changesetID)
+	if err != nil {
+		return err
+	}
+
+	current, err := tx.GetChangesetSpecByID(ctx, ch.CurrentSpecID)
+	if err != nil {
+		return err
+	}
+
+	specs, _, err := tx.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{IDs: changesetSpecIDs})
+	if err != nil {
+		return err
+	}
+
+	var refreshed *btypes.ChangesetSpec
+	for _, spec := range specs {
+		if spec.RepoID == current.RepoID && spec.Spec.HeadRef == current.Spec.HeadRef {
+			refreshed = spec
+			break
+		}
+	}
+	if refreshed == nil {
+		// The steps no longer produce a diff for the branch of the
+		// changeset, so there is nothing we could push.
+		return nil
+	}
+
+	// The batch spec now references the refreshed spec in place of the
+	// current one, so that re-applying it doesn't produce two specs for the
+	// same branch.
+	if err := tx.Exec(ctx, sqlf.Sprintf(
+		replaceChangesetSpecOnBatchSpec,
+		current.ID,
+		batchSpecWorkspaceID,
+		current.ID,
+		refreshed.ID,
+	)); err != nil {
+		return err
+	}
+	if err := tx.Exec(ctx, sqlf.Sprintf(
+		replaceChangesetSpecIDOnBatchSpecWorkspace,
+		strconv.FormatInt(current.ID, 10),
+		strconv.FormatInt(refreshed.ID, 10),
+		batchSpecWorkspaceID,
+	)); err != nil {
+		return err
+	}
+
+	ch.PreviousSpecID = ch.CurrentSpecID
+	ch.CurrentSpecID = refreshed.ID
+	ch.ResetReconcilerState(global.DefaultReconcilerEnqueueState())
+
+	return tx.UpdateChangeset(ctx, ch)
+}
+
 func loadAndExtractChangesetSpecIDs(ctx context.Context, s *store.Store, id int64) (*btypes.BatchSpecWorkspaceExecutionJob, []int64, error) {
 	job, err := s.GetBatchSpecWorkspaceExecutionJob(ctx, store.GetBatchSpecWorkspaceExecutionJobOpts{ID: id})
 	if err != nil {
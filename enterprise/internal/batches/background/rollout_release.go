@@ -0,0 +1,43 @@
+package background
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/reconciler"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+const rolloutReleaseInterval = 2 * time.Minute
+
+// newRolloutReleaseJob periodically enqueues the changesets that are held back
+// by the rollout of their batch change, once enough changesets of the earlier
+// stages have been merged.
+func newRolloutReleaseJob(ctx context.Context, cstore *store.Store) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		rolloutReleaseInterval,
+		goroutine.NewHandlerWithErrorMessage("release batch changes rollout stages", func(ctx context.Context) error {
+			batchChanges, _, err := cstore.ListBatchChanges(ctx, store.ListBatchChangesOpts{
+				State:                 btypes.BatchChangeStateOpen,
+				OnlyWithActiveRollout: true,
+			})
+			if err != nil {
+				return errors.Wrap(err, "ListBatchChanges")
+			}
+
+			var errs *multierror.Error
+			for _, batchChange := range batchChanges {
+				if err := reconciler.EnqueueReleasedRolloutChangesets(ctx, cstore, batchChange.ID); err != nil {
+					errs = multierror.Append(errs, errors.Wrapf(err, "batch change %d", batchChange.ID))
+				}
+			}
+			return errs.ErrorOrNil()
+		}),
+	)
+}
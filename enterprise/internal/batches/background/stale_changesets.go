@@ -0,0 +1,134 @@
+package background
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+const staleChangesetsInterval = 5 * time.Minute
+
+// newStaleChangesetsJob periodically re-executes the workspaces of changesets
+// that can no longer be merged into their base branch, for all batch changes
+// that are configured to keep their changesets up to date.
+func newStaleChangesetsJob(ctx context.Context, cstore *store.Store) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		staleChangesetsInterval,
+		goroutine.NewHandlerWithErrorMessage("refresh stale batch changes changesets", func(ctx context.Context) error {
+			batchChanges, _, err := cstore.ListBatchChanges(ctx, store.ListBatchChangesOpts{
+				State:            btypes.BatchChangeStateOpen,
+				OnlyKeepUpToDate: true,
+			})
+			if err != nil {
+				return errors.Wrap(err, "ListBatchChanges")
+			}
+
+			var errs *multierror.Error
+			for _, batchChange := range batchChanges {
+				if err := refreshStaleChangesets(ctx, cstore, batchChange.ID); err != nil {
+					errs = multierror.Append(errs, errors.Wrapf(err, "batch change %d", batchChange.ID))
+				}
+			}
+			return errs.ErrorOrNil()
+		}),
+	)
+}
+
+// refreshStaleChangesets enqueues a new execution of the workspace of every
+// open changeset owned by the given batch change that has a merge conflict
+// with its base branch. The workspace is executed against the current head of
+// the base branch, and the resulting changeset spec replaces the current spec
+// of the changeset once the execution completes.
+//
+// A failure to refresh one changeset doesn't keep the others from being
+// refreshed; all errors are returned together.
+func refreshStaleChangesets(ctx context.Context, cstore *store.Store, batchChangeID int64) error {
+	published := btypes.ChangesetPublicationStatePublished
+	cs, _, err := cstore.ListChangesets(ctx, store.ListChangesetsOpts{
+		OwnedByBatchChangeID: batchChangeID,
+		PublicationState:     &published,
+		ReconcilerStates:     []btypes.ReconcilerState{btypes.ReconcilerStateCompleted},
+		ExternalStates:       []btypes.ChangesetExternalState{btypes.ChangesetExternalStateOpen, btypes.ChangesetExternalStateDraft},
+	})
+	if err != nil {
+		return errors.Wrap(err, "ListChangesets")
+	}
+
+	var errs *multierror.Error
+	for _, ch := range cs {
+		if !ch.HasMergeConflict() || ch.CurrentSpecID == 0 {
+			continue
+		}
+		if err := refreshStaleChangeset(ctx, cstore, ch); err != nil {
+			log15.Error("Refreshing stale changeset failed", "changeset", ch.ID, "error", err)
+			errs = multierror.Append(errs, errors.Wrapf(err, "changeset %d", ch.ID))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+func refreshStaleChangeset(ctx context.Context, cstore *store.Store, ch *btypes.Changeset) (err error) {
+	workspace, err := cstore.GetBatchSpecWorkspace(ctx, store.GetBatchSpecWorkspaceOpts{ChangesetSpecID: ch.CurrentSpecID})
+	if err == store.ErrNoResults {
+		// The changeset spec wasn't created by a server-side execution, so
+		// there is nothing we can re-execute.
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	jobs, err := cstore.ListBatchSpecWorkspaceExecutionJobs(ctx, store.ListBatchSpecWorkspaceExecutionJobsOpts{
+		ChangesetID: ch.ID,
+	})
+	if err != nil {
+		return err
+	}
+	// The workspace was last executed against the commit of the latest
+	// refresh job, or against its own commit if it was never refreshed.
+	lastCommit := workspace.Commit
+	for _, job := range jobs {
+		if job.State == btypes.BatchSpecWorkspaceExecutionJobStateQueued || job.State == btypes.BatchSpecWorkspaceExecutionJobStateProcessing {
+			return nil
+		}
+		if job.Commit != "" {
+			lastCommit = job.Commit
+		}
+	}
+
+	repo, err := cstore.Repos().Get(ctx, ch.RepoID)
+	if err != nil {
+		return err
+	}
+
+	head, err := git.ResolveRevision(ctx, repo.Name, workspace.Branch, git.ResolveRevisionOptions{
+		NoEnsureRevision: true,
+	})
+	if err != nil {
+		return err
+	}
+	if head == api.CommitID(lastCommit) {
+		// The conflict isn't caused by a new commit on the base branch, so
+		// re-executing the steps against the same commit won't resolve it.
+		return nil
+	}
+
+	log15.Info("Refreshing stale changeset", "changeset", ch.ID, "workspace", workspace.ID, "commit", head)
+
+	// The workspace belongs to the applied batch spec and keeps recording the
+	// commit it was executed against. The job carries the new commit.
+	return cstore.CreateBatchSpecWorkspaceExecutionJob(ctx, &btypes.BatchSpecWorkspaceExecutionJob{
+		BatchSpecWorkspaceID: workspace.ID,
+		ChangesetID:          ch.ID,
+		Commit:               string(head),
+	})
+}
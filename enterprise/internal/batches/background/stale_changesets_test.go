@@ -0,0 +1,132 @@
+package background
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestRefreshStaleChangesets(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := actor.WithInternalActor(context.Background())
+	db := dbtest.NewDB(t, "")
+	user := ct.CreateTestUser(t, db, true)
+	repo, _ := ct.CreateTestRepo(t, ctx, db)
+
+	s := store.New(db, &observation.TestContext, nil)
+
+	batchSpec := &btypes.BatchSpec{UserID: user.ID, NamespaceUserID: user.ID}
+	if err := s.CreateBatchSpec(ctx, batchSpec); err != nil {
+		t.Fatal(err)
+	}
+	batchChange := ct.CreateBatchChange(t, ctx, s, "stale-changesets", user.ID, batchSpec.ID)
+
+	createChangeset := func(t *testing.T, mergeable string) (*btypes.Changeset, *btypes.BatchSpecWorkspace) {
+		t.Helper()
+
+		spec := ct.CreateChangesetSpec(t, ctx, s, ct.TestSpecOpts{
+			User:      user.ID,
+			Repo:      repo.ID,
+			BatchSpec: batchSpec.ID,
+			HeadRef:   "refs/heads/stale-" + mergeable,
+		})
+
+		workspace := &btypes.BatchSpecWorkspace{
+			BatchSpecID:      batchSpec.ID,
+			ChangesetSpecIDs: []int64{spec.ID},
+			RepoID:           repo.ID,
+			Branch:           "refs/heads/main",
+			Commit:           "0ld",
+			Steps:            []batcheslib.Step{},
+		}
+		if err := s.CreateBatchSpecWorkspace(ctx, workspace); err != nil {
+			t.Fatal(err)
+		}
+
+		ch := ct.CreateChangeset(t, ctx, s, ct.TestChangesetOpts{
+			Repo:                repo.ID,
+			BatchChanges:        []btypes.BatchChangeAssoc{{BatchChangeID: batchChange.ID}},
+			OwnedByBatchChange:  batchChange.ID,
+			CurrentSpec:         spec.ID,
+			ExternalServiceType: extsvc.TypeGitHub,
+			ExternalID:          mergeable,
+			PublicationState:    btypes.ChangesetPublicationStatePublished,
+			ExternalState:       btypes.ChangesetExternalStateOpen,
+			ReconcilerState:     btypes.ReconcilerStateCompleted,
+			Metadata:            &github.PullRequest{Mergeable: mergeable},
+		})
+		return ch, workspace
+	}
+
+	conflicting, conflictingWorkspace := createChangeset(t, github.PullRequestMergeableStateConflicting)
+	mergeable, _ := createChangeset(t, "MERGEABLE")
+
+	git.Mocks.ResolveRevision = func(spec string, opt git.ResolveRevisionOptions) (api.CommitID, error) {
+		return "n3w", nil
+	}
+	t.Cleanup(git.ResetMocks)
+
+	assertJobs := func(t *testing.T, ch *btypes.Changeset, want int) []*btypes.BatchSpecWorkspaceExecutionJob {
+		t.Helper()
+
+		jobs, err := s.ListBatchSpecWorkspaceExecutionJobs(ctx, store.ListBatchSpecWorkspaceExecutionJobsOpts{ChangesetID: ch.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(jobs) != want {
+			t.Fatalf("wrong number of jobs for changeset %d: have %d, want %d", ch.ID, len(jobs), want)
+		}
+		return jobs
+	}
+
+	// Running the refresh twice should only enqueue one job, since the
+	// first one is still queued.
+	for i := 0; i < 2; i++ {
+		if err := refreshStaleChangesets(ctx, s, batchChange.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	jobs := assertJobs(t, conflicting, 1)
+	assertJobs(t, mergeable, 0)
+
+	if jobs[0].Commit != "n3w" {
+		t.Fatalf("wrong job commit: have %q, want %q", jobs[0].Commit, "n3w")
+	}
+
+	// The workspace of the applied batch spec still records the commit it
+	// was executed against.
+	reloaded, err := s.GetBatchSpecWorkspace(ctx, store.GetBatchSpecWorkspaceOpts{ID: conflictingWorkspace.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.Commit != "0ld" {
+		t.Fatalf("wrong workspace commit: have %q, want %q", reloaded.Commit, "0ld")
+	}
+
+	// Once the job completed, the changeset isn't refreshed again as long as
+	// the base branch doesn't move.
+	if err := s.Exec(ctx, sqlf.Sprintf("UPDATE batch_spec_workspace_execution_jobs SET state = 'completed' WHERE id = %s", jobs[0].ID)); err != nil {
+		t.Fatal(err)
+	}
+	if err := refreshStaleChangesets(ctx, s, batchChange.ID); err != nil {
+		t.Fatal(err)
+	}
+	assertJobs(t, conflicting, 1)
+}
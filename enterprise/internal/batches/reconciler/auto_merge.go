@@ -0,0 +1,70 @@
+package reconciler
+
+import (
+	"context"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+// planAutoMerge adds a merge operation to the given plan if the batch change
+// owning the changeset has an auto-merge policy that allows merging the
+// changeset now.
+//
+// Auto-merging only happens when there is nothing else to reconcile, since
+// pushing or updating the changeset invalidates its check and review state.
+// Only one attempt is made per head commit of the changeset, and changesets
+// that would exceed the hourly cap of the policy are left alone until they're
+// enqueued again by the syncer.
+//
+// tx must be a transaction: when the policy has an hourly cap, the batch
+// change stays locked until the merge has been recorded, so that concurrent
+// workers can't both see room under the cap.
+func planAutoMerge(ctx context.Context, tx *store.Store, pl *Plan) error {
+	ch := pl.Changeset
+	if !pl.Ops.IsNone() || !ch.AutoMergeable() {
+		return nil
+	}
+
+	template, err := tx.GetChangesetTemplate(ctx, ch.OwnedByBatchChangeID)
+	if err != nil || template == nil || template.AutoMerge == nil {
+		return err
+	}
+	policy := template.AutoMerge
+
+	headRefOid, err := ch.HeadRefOid()
+	if err != nil || headRefOid == "" {
+		return err
+	}
+
+	if failed, err := tx.HasAutoMergeFailed(ctx, ch.ID, headRefOid); err != nil || failed {
+		// If we already failed to merge this commit, we wait for a new one.
+		return err
+	}
+
+	if policy.MaxMergesPerHour > 0 {
+		if _, err := tx.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: ch.OwnedByBatchChangeID, ForUpdate: true}); err != nil {
+			return err
+		}
+
+		merged, err := tx.CountChangesetEvents(ctx, store.CountChangesetEventsOpts{
+			OwnedByBatchChangeID: ch.OwnedByBatchChangeID,
+			Kinds:                []btypes.ChangesetEventKind{btypes.ChangesetEventKindAutoMerged},
+			CreatedAfter:         tx.Clock()().Add(-time.Hour),
+		})
+		if err != nil {
+			return err
+		}
+		if merged >= policy.MaxMergesPerHour {
+			log15.Info("Auto-merge cap reached", "changeset", ch.ID, "batchChange", ch.OwnedByBatchChangeID, "maxMergesPerHour", policy.MaxMergesPerHour)
+			return nil
+		}
+	}
+
+	pl.AutoMerge = policy
+	pl.AddOp(btypes.ReconcilerOperationMerge)
+	return nil
+}
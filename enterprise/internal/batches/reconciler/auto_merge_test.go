@@ -0,0 +1,157 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestPlanAutoMerge_IntegrationTest(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := actor.WithInternalActor(context.Background())
+	db := dbtest.NewDB(t, "")
+
+	bstore := store.New(db, &observation.TestContext, nil)
+
+	admin := ct.CreateTestUser(t, db, true)
+	repo, _ := ct.CreateTestRepo(t, ctx, db)
+
+	githubPR := buildGithubPR(time.Now(), btypes.ChangesetExternalStateOpen)
+	githubPR.HeadRefOid = "f00bar"
+
+	createBatchChange := func(t *testing.T, autoMerge *batcheslib.AutoMerge) *btypes.BatchChange {
+		t.Helper()
+
+		batchSpec := &btypes.BatchSpec{
+			UserID:          admin.ID,
+			NamespaceUserID: admin.ID,
+			Spec: &batcheslib.BatchSpec{
+				Name: "auto-merge-test",
+				ChangesetTemplate: &batcheslib.ChangesetTemplate{
+					Branch:    "branch-name",
+					AutoMerge: autoMerge,
+				},
+			},
+		}
+		if err := bstore.CreateBatchSpec(ctx, batchSpec); err != nil {
+			t.Fatal(err)
+		}
+
+		return ct.CreateBatchChange(t, ctx, bstore, "auto-merge-test", admin.ID, batchSpec.ID)
+	}
+
+	createChangeset := func(t *testing.T, batchChange *btypes.BatchChange, reviewState btypes.ChangesetReviewState) *btypes.Changeset {
+		t.Helper()
+
+		return ct.CreateChangeset(t, ctx, bstore, ct.TestChangesetOpts{
+			Repo:                repo.ID,
+			BatchChanges:        []btypes.BatchChangeAssoc{{BatchChangeID: batchChange.ID}},
+			OwnedByBatchChange:  batchChange.ID,
+			ExternalServiceType: extsvc.TypeGitHub,
+			ExternalID:          githubPR.ID,
+			PublicationState:    btypes.ChangesetPublicationStatePublished,
+			ExternalState:       btypes.ChangesetExternalStateOpen,
+			ExternalCheckState:  btypes.ChangesetCheckStatePassed,
+			ExternalReviewState: reviewState,
+			Metadata:            githubPR,
+		})
+	}
+
+	planFor := func(t *testing.T, ch *btypes.Changeset) *Plan {
+		t.Helper()
+
+		pl := &Plan{Changeset: ch}
+		if err := planAutoMerge(ctx, bstore, pl); err != nil {
+			t.Fatal(err)
+		}
+		return pl
+	}
+
+	wantMerge := Operations{btypes.ReconcilerOperationMerge}
+
+	t.Run("no policy", func(t *testing.T) {
+		batchChange := createBatchChange(t, nil)
+		ch := createChangeset(t, batchChange, btypes.ChangesetReviewStateApproved)
+
+		if pl := planFor(t, ch); !pl.Ops.IsNone() {
+			t.Fatalf("unexpected operations: %s", pl.Ops)
+		}
+	})
+
+	t.Run("not approved", func(t *testing.T) {
+		batchChange := createBatchChange(t, &batcheslib.AutoMerge{})
+		ch := createChangeset(t, batchChange, btypes.ChangesetReviewStatePending)
+
+		if pl := planFor(t, ch); !pl.Ops.IsNone() {
+			t.Fatalf("unexpected operations: %s", pl.Ops)
+		}
+	})
+
+	t.Run("mergeable", func(t *testing.T) {
+		batchChange := createBatchChange(t, &batcheslib.AutoMerge{Strategy: batcheslib.AutoMergeStrategySquash})
+		ch := createChangeset(t, batchChange, btypes.ChangesetReviewStateApproved)
+
+		pl := planFor(t, ch)
+		if !pl.Ops.Equal(wantMerge) {
+			t.Fatalf("wrong operations: have %s, want %s", pl.Ops, wantMerge)
+		}
+		if !pl.AutoMerge.Squash() {
+			t.Fatalf("wrong policy: %+v", pl.AutoMerge)
+		}
+	})
+
+	t.Run("already failed for commit", func(t *testing.T) {
+		batchChange := createBatchChange(t, &batcheslib.AutoMerge{})
+		ch := createChangeset(t, batchChange, btypes.ChangesetReviewStateApproved)
+
+		ev := &btypes.ChangesetAutoMergeEvent{HeadRefOid: githubPR.HeadRefOid, Error: "merge conflict"}
+		if err := bstore.UpsertChangesetEvents(ctx, &btypes.ChangesetEvent{
+			ChangesetID: ch.ID,
+			Kind:        btypes.ChangesetEventKindAutoMergeFailed,
+			Key:         ev.Key(),
+			Metadata:    ev,
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if pl := planFor(t, ch); !pl.Ops.IsNone() {
+			t.Fatalf("unexpected operations: %s", pl.Ops)
+		}
+	})
+
+	t.Run("cap reached", func(t *testing.T) {
+		batchChange := createBatchChange(t, &batcheslib.AutoMerge{MaxMergesPerHour: 1})
+		merged := createChangeset(t, batchChange, btypes.ChangesetReviewStateApproved)
+		ch := createChangeset(t, batchChange, btypes.ChangesetReviewStateApproved)
+
+		if pl := planFor(t, ch); !pl.Ops.Equal(wantMerge) {
+			t.Fatalf("wrong operations: have %s, want %s", pl.Ops, wantMerge)
+		}
+
+		ev := &btypes.ChangesetAutoMergeEvent{HeadRefOid: githubPR.HeadRefOid}
+		if err := bstore.UpsertChangesetEvents(ctx, &btypes.ChangesetEvent{
+			ChangesetID: merged.ID,
+			Kind:        btypes.ChangesetEventKindAutoMerged,
+			Key:         ev.Key(),
+			Metadata:    ev,
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if pl := planFor(t, ch); !pl.Ops.IsNone() {
+			t.Fatalf("unexpected operations: %s", pl.Ops)
+		}
+	})
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 )
 
 // executePlan executes the given reconciler plan.
@@ -30,6 +31,7 @@ func executePlan(ctx context.Context, gitserverClient GitserverClient, sourcer s
 		tx:                tx,
 		ch:                plan.Changeset,
 		spec:              plan.ChangesetSpec,
+		autoMerge:         plan.AutoMerge,
 	}
 
 	return e.Run(ctx, plan)
@@ -42,6 +44,7 @@ type executor struct {
 	tx                *store.Store
 	ch                *btypes.Changeset
 	spec              *btypes.ChangesetSpec
+	autoMerge         *batcheslib.AutoMerge
 
 	css  sources.ChangesetSource
 	repo *types.Repo
@@ -102,6 +105,9 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 		case btypes.ReconcilerOperationArchive:
 			e.archiveChangeset()
 
+		case btypes.ReconcilerOperationMerge:
+			err = e.mergeChangeset(ctx)
+
 		default:
 			err = errors.Errorf("executor operation %q not implemented", op)
 		}
@@ -314,6 +320,42 @@ func (e *executor) closeChangeset(ctx context.Context) (err error) {
 	return nil
 }
 
+// mergeChangeset merges the given changeset on its code host according to the
+// auto-merge policy of its batch change, and records the outcome as a
+// changeset event. Changesets the code host refuses to merge are recorded as
+// failed, so that they aren't retried until a new commit is pushed.
+func (e *executor) mergeChangeset(ctx context.Context) (err error) {
+	headRefOid, err := e.ch.HeadRefOid()
+	if err != nil {
+		return err
+	}
+
+	ev := &btypes.ChangesetAutoMergeEvent{
+		HeadRefOid: headRefOid,
+		Squash:     e.autoMerge.Squash(),
+		CreatedAt:  e.tx.Clock()(),
+	}
+	kind := btypes.ChangesetEventKindAutoMerged
+
+	cs := &sources.Changeset{Changeset: e.ch, Repo: e.repo}
+	if err := e.css.MergeChangeset(ctx, cs, ev.Squash); err != nil {
+		var notMergeable *sources.ChangesetNotMergeableError
+		if !errors.As(err, &notMergeable) {
+			return errors.Wrap(err, "merging changeset")
+		}
+
+		kind = btypes.ChangesetEventKindAutoMergeFailed
+		ev.Error = err.Error()
+	}
+
+	return e.tx.UpsertChangesetEvents(ctx, &btypes.ChangesetEvent{
+		ChangesetID: e.ch.ID,
+		Kind:        kind,
+		Key:         ev.Key(),
+		Metadata:    ev,
+	})
+}
+
 // undraftChangeset marks the given changeset on its code host as ready for review.
 func (e *executor) undraftChangeset(ctx context.Context) (err error) {
 	draftCss, err := sources.ToDraftChangesetSource(e.css)
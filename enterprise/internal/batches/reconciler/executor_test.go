@@ -28,6 +28,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 )
 
 func TestExecutor_ExecutePlan(t *testing.T) {
@@ -60,6 +61,8 @@ func TestExecutor_ExecutePlan(t *testing.T) {
 	githubHeadRef := git.EnsureRefPrefix(githubPR.HeadRefName)
 	draftGithubPR := buildGithubPR(clock(), btypes.ChangesetExternalStateDraft)
 	closedGitHubPR := buildGithubPR(clock(), btypes.ChangesetExternalStateClosed)
+	mergeableGitHubPR := buildGithubPR(clock(), btypes.ChangesetExternalStateOpen)
+	mergeableGitHubPR.HeadRefOid = "f00bar"
 
 	notFoundErr := sources.ChangesetNotFoundError{
 		Changeset: &sources.Changeset{
@@ -84,6 +87,7 @@ func TestExecutor_ExecutePlan(t *testing.T) {
 		wantCloseOnCodeHost       bool
 		wantLoadFromCodeHost      bool
 		wantReopenOnCodeHost      bool
+		wantMergeOnCodeHost       bool
 
 		wantGitserverCommit bool
 
@@ -249,6 +253,35 @@ func TestExecutor_ExecutePlan(t *testing.T) {
 				DiffStat:         state.DiffStat,
 			},
 		},
+		"auto-merge changeset": {
+			hasCurrentSpec: true,
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalID:       githubPR.ID,
+				ExternalBranch:   githubHeadRef,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				Metadata:         mergeableGitHubPR,
+			},
+			plan: &Plan{
+				Ops: Operations{
+					btypes.ReconcilerOperationMerge,
+				},
+				AutoMerge: &batcheslib.AutoMerge{Strategy: batcheslib.AutoMergeStrategySquash},
+			},
+			sourcerMetadata: mergeableGitHubPR,
+
+			wantMergeOnCodeHost: true,
+
+			wantChangeset: ct.ChangesetAssertions{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalID:       githubPR.ID,
+				ExternalBranch:   githubHeadRef,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				Title:            githubPR.Title,
+				Body:             githubPR.Body,
+				DiffStat:         state.DiffStat,
+			},
+		},
 		"close open changeset": {
 			hasCurrentSpec: true,
 			changeset: ct.TestChangesetOpts{
@@ -551,6 +584,20 @@ func TestExecutor_ExecutePlan(t *testing.T) {
 				t.Fatalf("wrong CloseChangeset call. wantCalled=%t, wasCalled=%t", want, have)
 			}
 
+			if have, want := fakeSource.MergeChangesetCalled, tc.wantMergeOnCodeHost; have != want {
+				t.Fatalf("wrong MergeChangeset call. wantCalled=%t, wasCalled=%t", want, have)
+			}
+
+			if tc.wantMergeOnCodeHost {
+				if _, err := cstore.GetChangesetEvent(ctx, store.GetChangesetEventOpts{
+					ChangesetID: changeset.ID,
+					Kind:        btypes.ChangesetEventKindAutoMerged,
+					Key:         "f00bar",
+				}); err != nil {
+					t.Fatalf("auto-merge event not recorded: %s", err)
+				}
+			}
+
 			if tc.wantNonRetryableErr {
 				return
 			}
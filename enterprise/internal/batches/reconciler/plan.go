@@ -8,6 +8,7 @@ import (
 	"github.com/cockroachdb/errors"
 
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 )
 
 var operationPrecedence = map[btypes.ReconcilerOperation]int{
@@ -23,6 +24,7 @@ var operationPrecedence = map[btypes.ReconcilerOperation]int{
 	btypes.ReconcilerOperationUpdate:       4,
 	btypes.ReconcilerOperationSleep:        5,
 	btypes.ReconcilerOperationSync:         6,
+	btypes.ReconcilerOperationMerge:        7,
 }
 
 type Operations []btypes.ReconcilerOperation
@@ -97,11 +99,25 @@ type Plan struct {
 	// The Delta between a possible previous ChangesetSpec and the current
 	// ChangesetSpec.
 	Delta *ChangesetSpecDelta
+
+	// The auto-merge policy to merge the changeset with, if the plan contains
+	// a merge operation.
+	AutoMerge *batcheslib.AutoMerge
 }
 
 func (p *Plan) AddOp(op btypes.ReconcilerOperation) { p.Ops = append(p.Ops, op) }
 func (p *Plan) SetOp(op btypes.ReconcilerOperation) { p.Ops = Operations{op} }
 
+// publishes returns whether the plan publishes the changeset on the code host.
+func (p *Plan) publishes() bool {
+	for _, op := range p.Ops {
+		if op == btypes.ReconcilerOperationPublish || op == btypes.ReconcilerOperationPublishDraft {
+			return true
+		}
+	}
+	return false
+}
+
 // DeterminePlan looks at the given changeset to determine what action the
 // reconciler should take.
 // It consumes the current and the previous changeset spec, if they exist. If
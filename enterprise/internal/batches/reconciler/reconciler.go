@@ -79,6 +79,14 @@ func (r *Reconciler) process(ctx context.Context, tx *store.Store, ch *btypes.Ch
 		return err
 	}
 
+	if err := planRolloutHold(ctx, tx, plan); err != nil {
+		return err
+	}
+
+	if err := planAutoMerge(ctx, tx, plan); err != nil {
+		return err
+	}
+
 	log15.Info("Reconciler processing changeset", "changeset", ch.ID, "operations", plan.Ops)
 
 	return executePlan(
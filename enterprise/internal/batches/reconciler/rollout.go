@@ -0,0 +1,151 @@
+package reconciler
+
+import (
+	"context"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+// planRolloutHold removes all operations from the given plan if it would
+// publish a changeset whose rollout stage hasn't been released yet. The
+// changeset stays unpublished until EnqueueReleasedRolloutChangesets enqueues
+// it again once enough changesets of the earlier stages have been merged.
+func planRolloutHold(ctx context.Context, tx *store.Store, pl *Plan) error {
+	ch := pl.Changeset
+	// The first stage is never held, so we don't need to load the rollout.
+	if ch.RolloutStage == nil || *ch.RolloutStage == 0 || !ch.Unpublished() || !pl.publishes() {
+		return nil
+	}
+
+	rollout, changesets, err := loadRollout(ctx, tx, ch.OwnedByBatchChangeID)
+	if err != nil || rollout == nil {
+		return err
+	}
+
+	if released := releasedRolloutStage(rollout, ch.OwnedByBatchChangeID, changesets); int(*ch.RolloutStage) > released {
+		log15.Info("Holding changeset until earlier rollout stages are merged", "changeset", ch.ID, "stage", *ch.RolloutStage, "releasedStage", released)
+		pl.Ops = nil
+	}
+	return nil
+}
+
+// EnqueueReleasedRolloutChangesets enqueues the changesets of the given batch
+// change that are held back by its rollout, but whose stage has been released
+// in the meantime.
+func EnqueueReleasedRolloutChangesets(ctx context.Context, tx *store.Store, batchChangeID int64) error {
+	rollout, changesets, err := loadRollout(ctx, tx, batchChangeID)
+	if err != nil || rollout == nil {
+		return err
+	}
+
+	released := releasedRolloutStage(rollout, batchChangeID, changesets)
+
+	var held btypes.Changesets
+	var specIDs []int64
+	for _, ch := range changesets {
+		if ch.RolloutStage != nil && int(*ch.RolloutStage) <= released && ch.Unpublished() && ch.CurrentSpecID != 0 && ch.ReconcilerState == btypes.ReconcilerStateCompleted {
+			held = append(held, ch)
+			specIDs = append(specIDs, ch.CurrentSpecID)
+		}
+	}
+	if len(held) == 0 {
+		return nil
+	}
+
+	specs, _, err := tx.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{IDs: specIDs})
+	if err != nil {
+		return err
+	}
+	specsByID := make(map[int64]*btypes.ChangesetSpec, len(specs))
+	for _, spec := range specs {
+		specsByID[spec.ID] = spec
+	}
+
+	for _, ch := range held {
+		spec, ok := specsByID[ch.CurrentSpecID]
+		if !ok {
+			continue
+		}
+
+		// Changesets that aren't meant to be published aren't held back by
+		// the rollout, so there's nothing to do for them.
+		calc := calculatePublicationState(spec.Spec.Published, ch.UiPublicationState)
+		if !calc.IsPublished() && !calc.IsDraft() {
+			continue
+		}
+
+		if err := tx.EnqueueChangeset(ctx, ch, global.DefaultReconcilerEnqueueState(), btypes.ReconcilerStateCompleted); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadRollout returns the rollout in the current batch spec of the given batch
+// change along with the changesets owned by the batch change. The rollout is
+// nil if the batch spec doesn't define one.
+func loadRollout(ctx context.Context, tx *store.Store, batchChangeID int64) (*batcheslib.Rollout, btypes.Changesets, error) {
+	template, err := tx.GetChangesetTemplate(ctx, batchChangeID)
+	if err != nil || template == nil || template.Rollout == nil {
+		return nil, nil, err
+	}
+
+	changesets, _, err := tx.ListChangesets(ctx, store.ListChangesetsOpts{OwnedByBatchChangeID: batchChangeID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return template.Rollout, changesets, nil
+}
+
+// releasedRolloutStage returns the latest stage of the given rollout whose
+// changesets may be published, which is the first stage that doesn't have
+// enough merged changesets yet.
+//
+// Changesets that won't ever be merged, because they were closed, archived or
+// detached from the batch change, don't count towards their stage.
+func releasedRolloutStage(rollout *batcheslib.Rollout, batchChangeID int64, changesets btypes.Changesets) int {
+	total := make(map[int]int)
+	merged := make(map[int]int)
+	for _, ch := range changesets {
+		if ch.RolloutStage == nil || !inRollout(ch, batchChangeID) {
+			continue
+		}
+		stage := int(*ch.RolloutStage)
+		total[stage]++
+		if ch.ExternalState == btypes.ChangesetExternalStateMerged {
+			merged[stage]++
+		}
+	}
+
+	for i, stage := range rollout.Stages {
+		if merged[i]*100 < stage.MergedThreshold()*total[i] {
+			return i
+		}
+	}
+	return rollout.FinalStage()
+}
+
+// inRollout returns whether the given changeset still takes part in the
+// rollout of the given batch change.
+func inRollout(ch *btypes.Changeset, batchChangeID int64) bool {
+	switch ch.ExternalState {
+	case btypes.ChangesetExternalStateClosed, btypes.ChangesetExternalStateDeleted:
+		return false
+	}
+	if ch.Closing {
+		return false
+	}
+	for _, assoc := range ch.BatchChanges {
+		if assoc.BatchChangeID == batchChangeID {
+			return !assoc.Detach && !assoc.Archive && !assoc.IsArchived
+		}
+	}
+	return false
+}
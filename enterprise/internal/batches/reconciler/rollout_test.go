@@ -0,0 +1,211 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestReleasedRolloutStage(t *testing.T) {
+	rollout := &batcheslib.Rollout{Stages: []batcheslib.RolloutStage{
+		{Repositories: []string{"github.com/sourcegraph/core-lib"}},
+		{Percentage: 10, MergedPercentage: 50},
+	}}
+
+	const batchChangeID = 1
+	changeset := func(stage int32, state btypes.ChangesetExternalState) *btypes.Changeset {
+		return &btypes.Changeset{
+			RolloutStage:  &stage,
+			ExternalState: state,
+			BatchChanges:  []btypes.BatchChangeAssoc{{BatchChangeID: batchChangeID}},
+		}
+	}
+	withAssoc := func(ch *btypes.Changeset, assoc btypes.BatchChangeAssoc) *btypes.Changeset {
+		ch.BatchChanges = []btypes.BatchChangeAssoc{assoc}
+		return ch
+	}
+
+	for name, tc := range map[string]struct {
+		changesets btypes.Changesets
+		want       int
+	}{
+		"no changesets": {
+			want: 2,
+		},
+		"first stage open": {
+			changesets: btypes.Changesets{
+				changeset(0, btypes.ChangesetExternalStateOpen),
+				changeset(1, ""),
+				changeset(2, ""),
+			},
+			want: 0,
+		},
+		"first stage merged": {
+			changesets: btypes.Changesets{
+				changeset(0, btypes.ChangesetExternalStateMerged),
+				changeset(1, btypes.ChangesetExternalStateOpen),
+				changeset(1, btypes.ChangesetExternalStateOpen),
+				changeset(2, ""),
+			},
+			want: 1,
+		},
+		"second stage reached merged percentage": {
+			changesets: btypes.Changesets{
+				changeset(0, btypes.ChangesetExternalStateMerged),
+				changeset(1, btypes.ChangesetExternalStateMerged),
+				changeset(1, btypes.ChangesetExternalStateOpen),
+				changeset(2, ""),
+			},
+			want: 2,
+		},
+		"empty stages": {
+			changesets: btypes.Changesets{
+				changeset(2, ""),
+			},
+			want: 2,
+		},
+		"changesets outside of the rollout": {
+			changesets: btypes.Changesets{
+				{ExternalState: btypes.ChangesetExternalStateOpen, BatchChanges: []btypes.BatchChangeAssoc{{BatchChangeID: batchChangeID}}},
+				changeset(2, ""),
+			},
+			want: 2,
+		},
+		"closed, archived and detached changesets": {
+			changesets: btypes.Changesets{
+				changeset(0, btypes.ChangesetExternalStateMerged),
+				changeset(0, btypes.ChangesetExternalStateClosed),
+				changeset(0, btypes.ChangesetExternalStateDeleted),
+				withAssoc(changeset(0, btypes.ChangesetExternalStateOpen), btypes.BatchChangeAssoc{BatchChangeID: batchChangeID, IsArchived: true}),
+				withAssoc(changeset(0, btypes.ChangesetExternalStateOpen), btypes.BatchChangeAssoc{BatchChangeID: batchChangeID, Archive: true}),
+				withAssoc(changeset(0, btypes.ChangesetExternalStateOpen), btypes.BatchChangeAssoc{BatchChangeID: batchChangeID, Detach: true}),
+				withAssoc(changeset(0, btypes.ChangesetExternalStateOpen), btypes.BatchChangeAssoc{BatchChangeID: 2}),
+				changeset(1, ""),
+			},
+			want: 1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := releasedRolloutStage(rollout, batchChangeID, tc.changesets); have != tc.want {
+				t.Errorf("wrong released stage: have %d, want %d", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestRollout_IntegrationTest(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := actor.WithInternalActor(context.Background())
+	db := dbtest.NewDB(t, "")
+
+	bstore := store.New(db, &observation.TestContext, nil)
+
+	admin := ct.CreateTestUser(t, db, true)
+	repo, _ := ct.CreateTestRepo(t, ctx, db)
+
+	batchSpec := &btypes.BatchSpec{
+		UserID:          admin.ID,
+		NamespaceUserID: admin.ID,
+		Spec: &batcheslib.BatchSpec{
+			Name: "rollout-test",
+			ChangesetTemplate: &batcheslib.ChangesetTemplate{
+				Branch: "branch-name",
+				Rollout: &batcheslib.Rollout{Stages: []batcheslib.RolloutStage{
+					{Repositories: []string{string(repo.Name)}},
+				}},
+			},
+		},
+	}
+	if err := bstore.CreateBatchSpec(ctx, batchSpec); err != nil {
+		t.Fatal(err)
+	}
+	batchChange := ct.CreateBatchChange(t, ctx, bstore, "rollout-test", admin.ID, batchSpec.ID)
+
+	createChangeset := func(t *testing.T, stage int32, externalState btypes.ChangesetExternalState) *btypes.Changeset {
+		t.Helper()
+
+		spec := ct.CreateChangesetSpec(t, ctx, bstore, ct.TestSpecOpts{
+			User:      admin.ID,
+			Repo:      repo.ID,
+			BatchSpec: batchSpec.ID,
+			HeadRef:   "refs/heads/branch-name",
+			Published: true,
+		})
+
+		opts := ct.TestChangesetOpts{
+			Repo:               repo.ID,
+			CurrentSpec:        spec.ID,
+			BatchChanges:       []btypes.BatchChangeAssoc{{BatchChangeID: batchChange.ID}},
+			OwnedByBatchChange: batchChange.ID,
+			RolloutStage:       &stage,
+			PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+		}
+		if externalState != "" {
+			opts.PublicationState = btypes.ChangesetPublicationStatePublished
+			opts.ExternalState = externalState
+			opts.ExternalID = spec.RandID
+		}
+		return ct.CreateChangeset(t, ctx, bstore, opts)
+	}
+
+	first := createChangeset(t, 0, btypes.ChangesetExternalStateOpen)
+	held := createChangeset(t, 1, "")
+
+	t.Run("held", func(t *testing.T) {
+		pl := &Plan{Changeset: held, Ops: Operations{btypes.ReconcilerOperationPublish, btypes.ReconcilerOperationPush}}
+		if err := planRolloutHold(ctx, bstore, pl); err != nil {
+			t.Fatal(err)
+		}
+		if !pl.Ops.IsNone() {
+			t.Fatalf("unexpected operations: %s", pl.Ops)
+		}
+
+		if err := EnqueueReleasedRolloutChangesets(ctx, bstore, batchChange.ID); err != nil {
+			t.Fatal(err)
+		}
+		reloaded, err := bstore.GetChangesetByID(ctx, held.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := reloaded.ReconcilerState, btypes.ReconcilerStateCompleted; have != want {
+			t.Fatalf("wrong reconciler state: have %s, want %s", have, want)
+		}
+	})
+
+	t.Run("released", func(t *testing.T) {
+		first.ExternalState = btypes.ChangesetExternalStateMerged
+		if err := bstore.UpdateChangeset(ctx, first); err != nil {
+			t.Fatal(err)
+		}
+
+		pl := &Plan{Changeset: held, Ops: Operations{btypes.ReconcilerOperationPublish, btypes.ReconcilerOperationPush}}
+		if err := planRolloutHold(ctx, bstore, pl); err != nil {
+			t.Fatal(err)
+		}
+		if pl.Ops.IsNone() {
+			t.Fatal("changeset is still held")
+		}
+
+		if err := EnqueueReleasedRolloutChangesets(ctx, bstore, batchChange.ID); err != nil {
+			t.Fatal(err)
+		}
+		reloaded, err := bstore.GetChangesetByID(ctx, held.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := reloaded.ReconcilerState, btypes.ReconcilerStateQueued; have != want {
+			t.Fatalf("wrong reconciler state: have %s, want %s", have, want)
+		}
+	})
+}
@@ -66,6 +66,7 @@ type operations struct {
 	getNewestBatchSpec                   *observation.Operation
 	moveBatchChange                      *observation.Operation
 	closeBatchChange                     *observation.Operation
+	toggleBatchChangeKeepUpToDate        *observation.Operation
 	deleteBatchChange                    *observation.Operation
 	enqueueChangesetSync                 *observation.Operation
 	reenqueueChangeset                   *observation.Operation
@@ -113,6 +114,7 @@ func newOperations(observationContext *observation.Context) *operations {
 			getNewestBatchSpec:                   op("GetNewestBatchSpec"),
 			moveBatchChange:                      op("MoveBatchChange"),
 			closeBatchChange:                     op("CloseBatchChange"),
+			toggleBatchChangeKeepUpToDate:        op("ToggleBatchChangeKeepUpToDate"),
 			deleteBatchChange:                    op("DeleteBatchChange"),
 			enqueueChangesetSync:                 op("EnqueueChangesetSync"),
 			reenqueueChangeset:                   op("ReenqueueChangeset"),
@@ -687,6 +689,30 @@ func (s *Service) CloseBatchChange(ctx context.Context, id int64, closeChangeset
 	return batchChange, nil
 }
 
+// ToggleBatchChangeKeepUpToDate sets whether the changesets of the
+// BatchChange with the given ID are re-executed when their base branch moves
+// and they can no longer be merged.
+func (s *Service) ToggleBatchChangeKeepUpToDate(ctx context.Context, id int64, value bool) (batchChange *btypes.BatchChange, err error) {
+	ctx, endObservation := s.operations.toggleBatchChangeKeepUpToDate.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	batchChange, err = s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: id})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting batch change")
+	}
+
+	if err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DB(), batchChange.InitialApplierID); err != nil {
+		return nil, err
+	}
+
+	if batchChange.KeepUpToDate == value {
+		return batchChange, nil
+	}
+
+	batchChange.KeepUpToDate = value
+	return batchChange, s.store.UpdateBatchChange(ctx, batchChange)
+}
+
 // DeleteBatchChange deletes the BatchChange with the given ID if it hasn't been
 // deleted yet.
 func (s *Service) DeleteBatchChange(ctx context.Context, id int64) (err error) {
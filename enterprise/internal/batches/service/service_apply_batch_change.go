@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database/locker"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 )
 
 // ErrApplyClosedBatchChange is returned by ApplyBatchChange when the batch change
@@ -155,11 +156,27 @@ func (s *Service) ApplyBatchChange(
 		return nil, err
 	}
 
+	// Assign the changesets to the stages of the rollout, if the batch spec
+	// has one. The reconciler holds changesets in later stages as unpublished
+	// until the earlier stages have been merged.
+	var rollout *batcheslib.Rollout
+	if batchSpec.Spec.ChangesetTemplate != nil {
+		rollout = batchSpec.Spec.ChangesetTemplate.Rollout
+	}
+	if err := opts.PublicationStates.prepareRolloutStages(rollout, mappings); err != nil {
+		return nil, err
+	}
+
 	// Upsert all changesets.
 	for _, changeset := range changesets {
 		if state := opts.PublicationStates.get(changeset.CurrentSpecID); state != nil {
 			changeset.UiPublicationState = state
 		}
+		if stage, ok := opts.PublicationStates.getRolloutStage(changeset.CurrentSpecID); ok {
+			changeset.RolloutStage = &stage
+		} else if rollout == nil {
+			changeset.RolloutStage = nil
+		}
 
 		if err := tx.UpsertChangeset(ctx, changeset); err != nil {
 			return nil, err
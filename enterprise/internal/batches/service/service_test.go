@@ -146,6 +146,11 @@ func TestServicePermissionLevels(t *testing.T) {
 				tc.assertFunc(t, err)
 			})
 
+			t.Run("ToggleBatchChangeKeepUpToDate", func(t *testing.T) {
+				_, err := svc.ToggleBatchChangeKeepUpToDate(currentUserCtx, batchChange.ID, true)
+				tc.assertFunc(t, err)
+			})
+
 			t.Run("DeleteBatchChange", func(t *testing.T) {
 				err := svc.DeleteBatchChange(currentUserCtx, batchChange.ID)
 				tc.assertFunc(t, err)
@@ -314,6 +319,32 @@ func TestService(t *testing.T) {
 		})
 	})
 
+	t.Run("ToggleBatchChangeKeepUpToDate", func(t *testing.T) {
+		spec := testBatchSpec(admin.ID)
+		if err := s.CreateBatchSpec(ctx, spec); err != nil {
+			t.Fatal(err)
+		}
+
+		batchChange := testBatchChange(admin.ID, spec)
+		if err := s.CreateBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, value := range []bool{true, false} {
+			if _, err := svc.ToggleBatchChangeKeepUpToDate(adminCtx, batchChange.ID, value); err != nil {
+				t.Fatal(err)
+			}
+
+			reloaded, err := s.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: batchChange.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reloaded.KeepUpToDate != value {
+				t.Fatalf("wrong KeepUpToDate: have %t, want %t", reloaded.KeepUpToDate, value)
+			}
+		}
+	})
+
 	t.Run("EnqueueChangesetSync", func(t *testing.T) {
 		spec := testBatchSpec(user.ID)
 		if err := s.CreateBatchSpec(ctx, spec); err != nil {
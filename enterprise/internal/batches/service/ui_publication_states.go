@@ -15,9 +15,14 @@ import (
 // External users must call Add() to add changeset spec random IDs to the
 // struct, then process() must be called before publication states can be
 // retrieved using get().
+//
+// If the batch spec defines a rollout, prepareRolloutStages() must be called
+// as well, so that the reconciler can hold changesets in later stages as
+// unpublished until earlier stages have been merged.
 type UiPublicationStates struct {
-	rand map[string]batches.PublishedValue
-	id   map[int64]*btypes.ChangesetUiPublicationState
+	rand   map[string]batches.PublishedValue
+	id     map[int64]*btypes.ChangesetUiPublicationState
+	stages map[int64]int32
 }
 
 // Add adds a changeset spec random ID to the publication states.
@@ -42,6 +47,53 @@ func (ps *UiPublicationStates) get(id int64) *btypes.ChangesetUiPublicationState
 	return nil
 }
 
+// getRolloutStage returns the rollout stage of the changeset spec with the
+// given ID. The second return value is false if the changeset spec isn't part
+// of a rollout.
+func (ps *UiPublicationStates) getRolloutStage(id int64) (int32, bool) {
+	stage, ok := ps.stages[id]
+	return stage, ok
+}
+
+// prepareRolloutStages assigns the changeset specs in the current rewirer
+// mappings to the stages of the given rollout. Changeset specs that import
+// existing changesets aren't part of the rollout, since they're published
+// already.
+func (ps *UiPublicationStates) prepareRolloutStages(rollout *batches.Rollout, mappings btypes.RewirerMappings) error {
+	ps.stages = nil
+	if rollout == nil {
+		return nil
+	}
+
+	var (
+		ids     []int64
+		targets []batches.RolloutTarget
+	)
+	for _, mapping := range mappings {
+		if mapping.ChangesetSpecID == 0 || mapping.ChangesetSpec.Spec.IsImportingExisting() {
+			continue
+		}
+
+		target := batches.RolloutTarget{Branch: mapping.ChangesetSpec.Spec.HeadRef}
+		if mapping.Repo != nil {
+			target.Repository = string(mapping.Repo.Name)
+		}
+		ids = append(ids, mapping.ChangesetSpecID)
+		targets = append(targets, target)
+	}
+
+	stages, err := rollout.AssignStages(targets)
+	if err != nil {
+		return err
+	}
+
+	ps.stages = make(map[int64]int32, len(ids))
+	for i, id := range ids {
+		ps.stages[id] = int32(stages[i])
+	}
+	return nil
+}
+
 // prepareAndValidate looks up the random changeset spec IDs, and ensures that
 // the changeset specs are included in the current rewirer mappings and are
 // eligible for a UI publication state.
@@ -7,6 +7,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 )
 
@@ -166,3 +168,57 @@ func TestUiPublicationStates_prepareEmpty(t *testing.T) {
 		})
 	}
 }
+
+func TestUiPublicationStates_prepareRolloutStages(t *testing.T) {
+	mapping := func(specID int64, repo string, spec *batcheslib.ChangesetSpec) *btypes.RewirerMapping {
+		return &btypes.RewirerMapping{
+			ChangesetSpecID: specID,
+			ChangesetSpec:   &btypes.ChangesetSpec{ID: specID, Spec: spec},
+			Repo:            &types.Repo{Name: api.RepoName(repo)},
+		}
+	}
+	branchSpec := &batcheslib.ChangesetSpec{HeadRef: "refs/heads/my-branch"}
+
+	mappings := btypes.RewirerMappings{
+		mapping(1, "github.com/sourcegraph/app", branchSpec),
+		mapping(2, "github.com/sourcegraph/core-lib", branchSpec),
+		mapping(3, "github.com/sourcegraph/imported", &batcheslib.ChangesetSpec{ExternalID: "123"}),
+		// Changesets that are detached or archived have no changeset spec.
+		{ChangesetID: 4},
+	}
+
+	t.Run("no rollout", func(t *testing.T) {
+		var ps UiPublicationStates
+		if err := ps.prepareRolloutStages(nil, mappings); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := ps.getRolloutStage(1); ok {
+			t.Error("unexpected rollout stage")
+		}
+	})
+
+	t.Run("rollout", func(t *testing.T) {
+		var ps UiPublicationStates
+		rollout := &batcheslib.Rollout{Stages: []batcheslib.RolloutStage{
+			{Repositories: []string{"*/core-lib@my-branch"}},
+		}}
+		if err := ps.prepareRolloutStages(rollout, mappings); err != nil {
+			t.Fatal(err)
+		}
+
+		want := map[int64]int32{1: 1, 2: 0}
+		if diff := cmp.Diff(want, ps.stages); diff != "" {
+			t.Errorf("unexpected stages (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		var ps UiPublicationStates
+		rollout := &batcheslib.Rollout{Stages: []batcheslib.RolloutStage{
+			{Repositories: []string{"[core"}},
+		}}
+		if err := ps.prepareRolloutStages(rollout, mappings); err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+}
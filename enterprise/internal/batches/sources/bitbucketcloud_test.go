@@ -0,0 +1,240 @@
+package sources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// newBitbucketCloudTestSource returns a BitbucketCloudSource talking to a
+// test server backed by the given handlers, keyed by "METHOD path".
+func newBitbucketCloudTestSource(t *testing.T, handlers map[string]string) *BitbucketCloudSource {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := handlers[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if body == "400" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	svc := &types.ExternalService{
+		Kind: extsvc.KindBitbucketCloud,
+		Config: marshalJSON(t, &schema.BitbucketCloudConnection{
+			Url:         "https://bitbucket.org",
+			ApiURL:      srv.URL,
+			Username:    "user",
+			AppPassword: "password",
+		}),
+	}
+
+	src, err := NewBitbucketCloudSource(svc, httpcli.NewFactory(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return src
+}
+
+const bitbucketCloudPullRequestsPath = "/2.0/repositories/sourcegraph/src-cli/pullrequests"
+
+func newBitbucketCloudTestChangeset() *Changeset {
+	return &Changeset{
+		Title:   "This is a test PR",
+		Body:    "This is the description",
+		HeadRef: "refs/heads/my-branch",
+		BaseRef: "refs/heads/main",
+		Repo: &types.Repo{
+			Metadata: &bitbucketcloud.Repo{FullName: "sourcegraph/src-cli"},
+		},
+		Changeset: &btypes.Changeset{},
+	}
+}
+
+func TestBitbucketCloudSource_CreateChangeset(t *testing.T) {
+	for name, tc := range map[string]struct {
+		existing   string
+		wantExists bool
+		wantID     string
+	}{
+		"new": {
+			existing: `{"values": []}`,
+			wantID:   "2",
+		},
+		"already exists": {
+			existing:   `{"values": [{"id": 1, "state": "OPEN", "source": {"branch": {"name": "my-branch"}}}]}`,
+			wantExists: true,
+			wantID:     "1",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			src := newBitbucketCloudTestSource(t, map[string]string{
+				"GET " + bitbucketCloudPullRequestsPath:                 tc.existing,
+				"POST " + bitbucketCloudPullRequestsPath:                `{"id": 2, "state": "OPEN", "source": {"branch": {"name": "my-branch"}}}`,
+				"GET " + bitbucketCloudPullRequestsPath + "/1/statuses": `{"values": []}`,
+				"GET " + bitbucketCloudPullRequestsPath + "/2/statuses": `{"values": []}`,
+			})
+
+			cs := newBitbucketCloudTestChangeset()
+			exists, err := src.CreateChangeset(context.Background(), cs)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if exists != tc.wantExists {
+				t.Errorf("wrong exists value: have %v, want %v", exists, tc.wantExists)
+			}
+			if cs.Changeset.ExternalID != tc.wantID {
+				t.Errorf("wrong external ID: have %q, want %q", cs.Changeset.ExternalID, tc.wantID)
+			}
+			if have, want := cs.Changeset.ExternalBranch, "refs/heads/my-branch"; have != want {
+				t.Errorf("wrong external branch: have %q, want %q", have, want)
+			}
+			if have, want := cs.Changeset.ExternalServiceType, extsvc.TypeBitbucketCloud; have != want {
+				t.Errorf("wrong external service type: have %q, want %q", have, want)
+			}
+		})
+	}
+}
+
+func TestBitbucketCloudSource_LoadChangeset(t *testing.T) {
+	src := newBitbucketCloudTestSource(t, map[string]string{
+		"GET " + bitbucketCloudPullRequestsPath + "/1":          `{"id": 1, "state": "DECLINED"}`,
+		"GET " + bitbucketCloudPullRequestsPath + "/1/statuses": `{"values": [{"key": "ci", "state": "SUCCESSFUL", "commit": {"hash": "abc"}}]}`,
+	})
+
+	t.Run("found", func(t *testing.T) {
+		cs := newBitbucketCloudTestChangeset()
+		cs.Changeset.ExternalID = "1"
+
+		if err := src.LoadChangeset(context.Background(), cs); err != nil {
+			t.Fatal(err)
+		}
+
+		pr := cs.Changeset.Metadata.(*bitbucketcloud.PullRequest)
+		if pr.State != bitbucketcloud.PullRequestStateDeclined || len(pr.Statuses) != 1 {
+			t.Errorf("unexpected pull request: %+v", pr)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		cs := newBitbucketCloudTestChangeset()
+		cs.Changeset.ExternalID = "999"
+
+		err := src.LoadChangeset(context.Background(), cs)
+		if !errors.HasType(err, ChangesetNotFoundError{}) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestBitbucketCloudSource_ReopenChangeset(t *testing.T) {
+	src := newBitbucketCloudTestSource(t, map[string]string{
+		"GET " + bitbucketCloudPullRequestsPath:                 `{"values": []}`,
+		"POST " + bitbucketCloudPullRequestsPath:                `{"id": 2, "state": "OPEN"}`,
+		"GET " + bitbucketCloudPullRequestsPath + "/2/statuses": `{"values": []}`,
+	})
+
+	cs := newBitbucketCloudTestChangeset()
+	if err := cs.Changeset.SetMetadata(&bitbucketcloud.PullRequest{ID: 1, State: bitbucketcloud.PullRequestStateDeclined}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := src.ReopenChangeset(context.Background(), cs); err != nil {
+		t.Fatal(err)
+	}
+
+	// Declined pull requests can't be reopened, so a new one is opened.
+	if have, want := cs.Changeset.ExternalID, "2"; have != want {
+		t.Errorf("wrong external ID: have %q, want %q", have, want)
+	}
+}
+
+func TestBitbucketCloudSource_MergeChangeset(t *testing.T) {
+	src := newBitbucketCloudTestSource(t, map[string]string{
+		"POST " + bitbucketCloudPullRequestsPath + "/1/merge": `{"id": 1, "state": "MERGED"}`,
+		"POST " + bitbucketCloudPullRequestsPath + "/2/merge": "400",
+	})
+
+	t.Run("merged", func(t *testing.T) {
+		cs := newBitbucketCloudTestChangeset()
+		if err := cs.Changeset.SetMetadata(&bitbucketcloud.PullRequest{ID: 1, State: bitbucketcloud.PullRequestStateOpen}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := src.MergeChangeset(context.Background(), cs, true); err != nil {
+			t.Fatal(err)
+		}
+		if pr := cs.Changeset.Metadata.(*bitbucketcloud.PullRequest); pr.State != bitbucketcloud.PullRequestStateMerged {
+			t.Errorf("unexpected state: %q", pr.State)
+		}
+	})
+
+	t.Run("not mergeable", func(t *testing.T) {
+		cs := newBitbucketCloudTestChangeset()
+		if err := cs.Changeset.SetMetadata(&bitbucketcloud.PullRequest{ID: 2, State: bitbucketcloud.PullRequestStateOpen}); err != nil {
+			t.Fatal(err)
+		}
+
+		err := src.MergeChangeset(context.Background(), cs, false)
+		var e *ChangesetNotMergeableError
+		if !errors.As(err, &e) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestBitbucketCloudSource_WithAuthenticator(t *testing.T) {
+	src := newBitbucketCloudTestSource(t, nil)
+
+	t.Run("supported", func(t *testing.T) {
+		for name, a := range map[string]auth.Authenticator{
+			"BasicAuth":        &auth.BasicAuth{Username: "user", Password: "app-password"},
+			"BasicAuthWithSSH": &auth.BasicAuthWithSSH{BasicAuth: auth.BasicAuth{Username: "user", Password: "app-password"}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				newSrc, err := src.WithAuthenticator(a)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				client := newSrc.(*BitbucketCloudSource).client
+				if client.Username != "user" || client.AppPassword != "app-password" {
+					t.Errorf("unexpected credentials: %q %q", client.Username, client.AppPassword)
+				}
+			})
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		for name, a := range map[string]auth.Authenticator{
+			"nil":         nil,
+			"OAuthBearer": &auth.OAuthBearerToken{Token: "abcdef"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				if _, err := src.WithAuthenticator(a); err == nil {
+					t.Error("unexpected nil error")
+				} else if !errors.HasType(err, UnsupportedAuthenticatorError{}) {
+					t.Errorf("unexpected error of type %T: %v", err, err)
+				}
+			})
+		}
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -276,6 +277,75 @@ func TestComputeBitbucketBuildStatus(t *testing.T) {
 	}
 }
 
+func TestComputeBitbucketCloudBuildStatus(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+	lastSynced := now.Add(-1 * time.Minute)
+	statusEvent := func(commit, key string, state bitbucketcloud.CommitStatusState) *btypes.ChangesetEvent {
+		return &btypes.ChangesetEvent{
+			Kind: btypes.ChangesetEventKindBitbucketCloudCommitStatus,
+			Metadata: &bitbucketcloud.CommitStatus{
+				StatusKey: key,
+				State:     state,
+				Commit:    bitbucketcloud.PullRequestCommit{Hash: commit},
+				UpdatedOn: now,
+			},
+		}
+	}
+
+	pr := &bitbucketcloud.PullRequest{
+		Source: bitbucketcloud.PullRequestBranch{
+			Commit: bitbucketcloud.PullRequestCommit{Hash: "abcdef"},
+		},
+		Statuses: []*bitbucketcloud.CommitStatus{
+			{StatusKey: "ctx1", State: bitbucketcloud.CommitStatusStateInProgress, Commit: bitbucketcloud.PullRequestCommit{Hash: "abcdef0123"}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		events []*btypes.ChangesetEvent
+		want   btypes.ChangesetCheckState
+	}{
+		{
+			name: "synced status only",
+			want: btypes.ChangesetCheckStatePending,
+		},
+		{
+			name: "webhook overrides synced status",
+			events: []*btypes.ChangesetEvent{
+				statusEvent("abcdef0123", "ctx1", bitbucketcloud.CommitStatusStateSuccessful),
+			},
+			want: btypes.ChangesetCheckStatePassed,
+		},
+		{
+			name: "stopped is failed",
+			events: []*btypes.ChangesetEvent{
+				statusEvent("abcdef0123", "ctx1", bitbucketcloud.CommitStatusStateSuccessful),
+				statusEvent("abcdef0123", "ctx2", bitbucketcloud.CommitStatusStateStopped),
+			},
+			want: btypes.ChangesetCheckStateFailed,
+		},
+		{
+			name: "other commits are ignored",
+			events: []*btypes.ChangesetEvent{
+				statusEvent("012345", "ctx1", bitbucketcloud.CommitStatusStateFailed),
+			},
+			want: btypes.ChangesetCheckStatePending,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have := computeBitbucketCloudBuildStatus(lastSynced, pr, tc.events)
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
 func TestComputeGitLabCheckState(t *testing.T) {
 	t.Parallel()
 
@@ -464,6 +534,26 @@ func TestComputeReviewState(t *testing.T) {
 			},
 			want: btypes.ChangesetReviewStateChangesRequested,
 		},
+		{
+			name:      "bitbucketcloud - no events",
+			changeset: bitbucketCloudChangeset(daysAgo(10), bitbucketcloud.PullRequestStateOpen, bitbucketcloud.ParticipantStateChangesRequested),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStateChangesRequested,
+		},
+		{
+			name:      "bitbucketcloud - changeset older than events",
+			changeset: bitbucketCloudChangeset(daysAgo(10), bitbucketcloud.PullRequestStateOpen, bitbucketcloud.ParticipantStateChangesRequested),
+			history: []changesetStatesAtTime{
+				{t: daysAgo(0), reviewState: btypes.ChangesetReviewStateApproved},
+			},
+			want: btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name:      "bitbucketcloud - pending reviewer",
+			changeset: bitbucketCloudChangeset(daysAgo(0), bitbucketcloud.PullRequestStateOpen, ""),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
 		{
 			name:      "gitlab - no events, no approvals",
 			changeset: gitLabChangeset(daysAgo(0), gitlab.MergeRequestStateOpened, []*gitlab.Note{}),
@@ -595,6 +685,26 @@ func TestComputeExternalState(t *testing.T) {
 			},
 			want: btypes.ChangesetExternalStateDeleted,
 		},
+		{
+			name:      "bitbucketcloud - declined",
+			changeset: bitbucketCloudChangeset(daysAgo(0), bitbucketcloud.PullRequestStateDeclined, ""),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "bitbucketcloud - superseded",
+			changeset: bitbucketCloudChangeset(daysAgo(0), bitbucketcloud.PullRequestStateSuperseded, ""),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "bitbucketcloud - changeset older than events",
+			changeset: bitbucketCloudChangeset(daysAgo(10), bitbucketcloud.PullRequestStateOpen, ""),
+			history: []changesetStatesAtTime{
+				{t: daysAgo(0), externalState: btypes.ChangesetExternalStateMerged},
+			},
+			want: btypes.ChangesetExternalStateMerged,
+		},
 		{
 			name:      "github draft - no events",
 			changeset: setDraft(githubChangeset(daysAgo(10), "OPEN")),
@@ -846,6 +956,19 @@ func bitbucketChangeset(updatedAt time.Time, state, reviewStatus string) *btypes
 	}
 }
 
+func bitbucketCloudChangeset(updatedAt time.Time, state bitbucketcloud.PullRequestState, reviewState bitbucketcloud.ParticipantState) *btypes.Changeset {
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeBitbucketCloud,
+		UpdatedAt:           updatedAt,
+		Metadata: &bitbucketcloud.PullRequest{
+			State: state,
+			Participants: []bitbucketcloud.Participant{
+				{Role: bitbucketcloud.ParticipantRoleReviewer, State: reviewState},
+			},
+		},
+	}
+}
+
 func githubChangeset(updatedAt time.Time, state string) *btypes.Changeset {
 	return &btypes.Changeset{
 		ExternalServiceType: extsvc.TypeGitHub,
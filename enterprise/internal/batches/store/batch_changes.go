@@ -31,6 +31,7 @@ var batchChangeColumns = []*sqlf.Query{
 	sqlf.Sprintf("batch_changes.updated_at"),
 	sqlf.Sprintf("batch_changes.closed_at"),
 	sqlf.Sprintf("batch_changes.batch_spec_id"),
+	sqlf.Sprintf("batch_changes.keep_up_to_date"),
 }
 
 // batchChangeInsertColumns is the list of batch changes columns that are
@@ -48,6 +49,7 @@ var batchChangeInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("updated_at"),
 	sqlf.Sprintf("closed_at"),
 	sqlf.Sprintf("batch_spec_id"),
+	sqlf.Sprintf("keep_up_to_date"),
 }
 
 // CreateBatchChange creates the given batch change.
@@ -65,7 +67,7 @@ func (s *Store) CreateBatchChange(ctx context.Context, c *btypes.BatchChange) (e
 var createBatchChangeQueryFmtstr = `
 -- source: enterprise/internal/batches/store.go:CreateBatchChange
 INSERT INTO batch_changes (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -92,6 +94,7 @@ func (s *Store) createBatchChangeQuery(c *btypes.BatchChange) *sqlf.Query {
 		c.UpdatedAt,
 		nullTimeColumn(c.ClosedAt),
 		c.BatchSpecID,
+		c.KeepUpToDate,
 		sqlf.Join(batchChangeColumns, ", "),
 	)
 }
@@ -111,7 +114,7 @@ func (s *Store) UpdateBatchChange(ctx context.Context, c *btypes.BatchChange) (e
 var updateBatchChangeQueryFmtstr = `
 -- source: enterprise/internal/batches/store.go:UpdateBatchChange
 UPDATE batch_changes
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING %s
 `
@@ -133,6 +136,7 @@ func (s *Store) updateBatchChangeQuery(c *btypes.BatchChange) *sqlf.Query {
 		c.UpdatedAt,
 		nullTimeColumn(c.ClosedAt),
 		c.BatchSpecID,
+		c.KeepUpToDate,
 		c.ID,
 		sqlf.Join(batchChangeColumns, ", "),
 	)
@@ -250,6 +254,10 @@ type GetBatchChangeOpts struct {
 
 	BatchSpecID int64
 	Name        string
+
+	// ForUpdate locks the batch change until the end of the current
+	// transaction.
+	ForUpdate bool
 }
 
 // GetBatchChange gets a batch change matching the given options.
@@ -283,6 +291,7 @@ LEFT JOIN users namespace_user ON batch_changes.namespace_user_id = namespace_us
 LEFT JOIN orgs  namespace_org  ON batch_changes.namespace_org_id = namespace_org.id
 WHERE %s
 LIMIT 1
+%s  -- optional FOR UPDATE
 `
 
 func getBatchChangeQuery(opts *GetBatchChangeOpts) *sqlf.Query {
@@ -314,10 +323,18 @@ func getBatchChangeQuery(opts *GetBatchChangeOpts) *sqlf.Query {
 		preds = append(preds, sqlf.Sprintf("TRUE"))
 	}
 
+	forUpdate := &sqlf.Query{}
+	if opts.ForUpdate {
+		// The namespace tables are on the nullable side of the joins and
+		// can't be locked.
+		forUpdate = sqlf.Sprintf("FOR UPDATE OF batch_changes")
+	}
+
 	return sqlf.Sprintf(
 		getBatchChangesQueryFmtstr,
 		sqlf.Join(batchChangeColumns, ", "),
 		sqlf.Join(preds, "\n AND "),
+		forUpdate,
 	)
 }
 
@@ -424,6 +441,15 @@ type ListBatchChangesOpts struct {
 	NamespaceOrgID  int32
 
 	RepoID api.RepoID
+
+	// OnlyWithActiveRollout filters the batch changes to those whose current
+	// batch spec defines a rollout, and which still own changesets that are
+	// held back by it.
+	OnlyWithActiveRollout bool
+
+	// OnlyKeepUpToDate filters the batch changes to those that keep their
+	// changesets up to date with their base branch.
+	OnlyKeepUpToDate bool
 }
 
 // ListBatchChanges lists batch changes with the given filters.
@@ -514,6 +540,28 @@ func listBatchChangesQuery(opts *ListBatchChangesOpts, repoAuthzConds *sqlf.Quer
 		)`, opts.RepoID, repoAuthzConds))
 	}
 
+	if opts.OnlyWithActiveRollout {
+		preds = append(preds, sqlf.Sprintf(`EXISTS(
+			SELECT 1 FROM batch_specs
+			WHERE
+				batch_specs.id = batch_changes.batch_spec_id AND
+				batch_specs.spec->'changesetTemplate' ? 'rollout'
+		)`))
+		// Changesets in the first stage are never held back.
+		preds = append(preds, sqlf.Sprintf(`EXISTS(
+			SELECT 1 FROM changesets
+			WHERE
+				changesets.owned_by_batch_change_id = batch_changes.id AND
+				changesets.rollout_stage > 0 AND
+				changesets.publication_state = %s AND
+				changesets.reconciler_state = %s
+		)`, btypes.ChangesetPublicationStateUnpublished, btypes.ReconcilerStateCompleted.ToDB()))
+	}
+
+	if opts.OnlyKeepUpToDate {
+		preds = append(preds, sqlf.Sprintf("batch_changes.keep_up_to_date"))
+	}
+
 	if len(preds) == 0 {
 		preds = append(preds, sqlf.Sprintf("TRUE"))
 	}
@@ -540,5 +588,6 @@ func scanBatchChange(c *btypes.BatchChange, s scanner) error {
 		&c.UpdatedAt,
 		&dbutil.NullTime{Time: &c.ClosedAt},
 		&c.BatchSpecID,
+		&c.KeepUpToDate,
 	)
 }
@@ -18,6 +18,8 @@ import (
 
 var batchSpecWorkspaceExecutionJobInsertColumns = []string{
 	"batch_spec_workspace_id",
+	"changeset_id",
+	"commit",
 
 	"created_at",
 	"updated_at",
@@ -28,6 +30,8 @@ var BatchSpecWorkspaceExecutionJobColums = SQLColumns{
 
 	"batch_spec_workspace_execution_jobs.batch_spec_workspace_id",
 	"batch_spec_workspace_execution_jobs.access_token_id",
+	"batch_spec_workspace_execution_jobs.changeset_id",
+	"batch_spec_workspace_execution_jobs.commit",
 
 	"batch_spec_workspace_execution_jobs.state",
 	"batch_spec_workspace_execution_jobs.failure_message",
@@ -87,6 +91,8 @@ func (s *Store) CreateBatchSpecWorkspaceExecutionJob(ctx context.Context, jobs .
 			if err := inserter.Insert(
 				ctx,
 				job.BatchSpecWorkspaceID,
+				nullInt64Column(job.ChangesetID),
+				nullStringColumn(job.Commit),
 				job.CreatedAt,
 				job.UpdatedAt,
 			); err != nil {
@@ -173,6 +179,7 @@ type ListBatchSpecWorkspaceExecutionJobsOpts struct {
 	State                 btypes.BatchSpecWorkspaceExecutionJobState
 	WorkerHostname        string
 	BatchSpecWorkspaceIDs []int64
+	ChangesetID           int64
 }
 
 // ListBatchSpecWorkspaceExecutionJobs lists batch changes with the given filters.
@@ -221,6 +228,10 @@ func listBatchSpecWorkspaceExecutionJobsQuery(opts ListBatchSpecWorkspaceExecuti
 		preds = append(preds, sqlf.Sprintf("batch_spec_workspace_execution_jobs.batch_spec_workspace_id = ANY (%s)", pq.Array(opts.BatchSpecWorkspaceIDs)))
 	}
 
+	if opts.ChangesetID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_workspace_execution_jobs.changeset_id = %s", opts.ChangesetID))
+	}
+
 	if len(preds) == 0 {
 		preds = append(preds, sqlf.Sprintf("TRUE"))
 	}
@@ -327,6 +338,8 @@ func scanBatchSpecWorkspaceExecutionJob(wj *btypes.BatchSpecWorkspaceExecutionJo
 		&wj.ID,
 		&wj.BatchSpecWorkspaceID,
 		&dbutil.NullInt64{N: &wj.AccessTokenID},
+		&dbutil.NullInt64{N: &wj.ChangesetID},
+		&dbutil.NullString{S: &wj.Commit},
 		&wj.State,
 		&dbutil.NullString{S: &failureMessage},
 		&dbutil.NullTime{Time: &wj.StartedAt},
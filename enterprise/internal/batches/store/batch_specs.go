@@ -214,6 +214,10 @@ ON
 type GetBatchSpecOpts struct {
 	ID     int64
 	RandID string
+
+	// BatchChangeID selects the batch spec currently applied to the given
+	// batch change.
+	BatchChangeID int64
 }
 
 // GetBatchSpec gets a BatchSpec matching the given options.
@@ -221,6 +225,7 @@ func (s *Store) GetBatchSpec(ctx context.Context, opts GetBatchSpecOpts) (spec *
 	ctx, endObservation := s.operations.getBatchSpec.With(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.Int("ID", int(opts.ID)),
 		log.String("randID", opts.RandID),
+		log.Int("batchChangeID", int(opts.BatchChangeID)),
 	}})
 	defer endObservation(1, observation.Args{})
 
@@ -258,6 +263,10 @@ func getBatchSpecQuery(opts *GetBatchSpecOpts) *sqlf.Query {
 		preds = append(preds, sqlf.Sprintf("rand_id = %s", opts.RandID))
 	}
 
+	if opts.BatchChangeID != 0 {
+		preds = append(preds, sqlf.Sprintf("id = (SELECT batch_spec_id FROM batch_changes WHERE id = %s)", opts.BatchChangeID))
+	}
+
 	if len(preds) == 0 {
 		preds = append(preds, sqlf.Sprintf("TRUE"))
 	}
@@ -269,6 +278,24 @@ func getBatchSpecQuery(opts *GetBatchSpecOpts) *sqlf.Query {
 	)
 }
 
+// GetChangesetTemplate returns the changeset template in the batch spec
+// currently applied to the given batch change, or nil if it has none.
+func (s *Store) GetChangesetTemplate(ctx context.Context, batchChangeID int64) (*batcheslib.ChangesetTemplate, error) {
+	if batchChangeID == 0 {
+		return nil, errors.New("no batch change given")
+	}
+
+	spec, err := s.GetBatchSpec(ctx, GetBatchSpecOpts{BatchChangeID: batchChangeID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "retrieving batch spec of batch change: %d", batchChangeID)
+	}
+
+	if spec.Spec == nil {
+		return nil, nil
+	}
+	return spec.Spec.ChangesetTemplate, nil
+}
+
 // GetNewestBatchSpecOpts captures the query options needed to get the latest
 // batch spec for the given parameters. One of the namespace fields and all
 // the others must be defined.
@@ -47,6 +47,20 @@ func (s *Store) GetChangesetEvent(ctx context.Context, opts GetChangesetEventOpt
 	return &c, nil
 }
 
+// HasAutoMergeFailed returns whether auto-merging the given head commit of
+// the changeset has already failed.
+func (s *Store) HasAutoMergeFailed(ctx context.Context, changesetID int64, headRefOid string) (bool, error) {
+	_, err := s.GetChangesetEvent(ctx, GetChangesetEventOpts{
+		ChangesetID: changesetID,
+		Kind:        btypes.ChangesetEventKindAutoMergeFailed,
+		Key:         headRefOid,
+	})
+	if err == ErrNoResults {
+		return false, nil
+	}
+	return err == nil, err
+}
+
 var getChangesetEventsQueryFmtstr = `
 -- source: enterprise/internal/batches/store.go:GetChangesetEvent
 SELECT
@@ -155,7 +169,10 @@ func listChangesetEventsQuery(opts *ListChangesetEventsOpts) *sqlf.Query {
 // CountChangesetEventsOpts captures the query options needed for
 // counting changeset events.
 type CountChangesetEventsOpts struct {
-	ChangesetID int64
+	ChangesetID          int64
+	OwnedByBatchChangeID int64
+	Kinds                []btypes.ChangesetEventKind
+	CreatedAfter         time.Time
 }
 
 // CountChangesetEvents returns the number of changeset events in the database.
@@ -181,6 +198,18 @@ func countChangesetEventsQuery(opts *CountChangesetEventsOpts) *sqlf.Query {
 		preds = append(preds, sqlf.Sprintf("changeset_id = %s", opts.ChangesetID))
 	}
 
+	if opts.OwnedByBatchChangeID != 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_id IN (SELECT id FROM changesets WHERE owned_by_batch_change_id = %s)", opts.OwnedByBatchChangeID))
+	}
+
+	if len(opts.Kinds) > 0 {
+		preds = append(preds, sqlf.Sprintf("kind = ANY (%s)", pq.Array(opts.Kinds)))
+	}
+
+	if !opts.CreatedAfter.IsZero() {
+		preds = append(preds, sqlf.Sprintf("created_at > %s", opts.CreatedAfter))
+	}
+
 	if len(preds) == 0 {
 		preds = append(preds, sqlf.Sprintf("TRUE"))
 	}
@@ -91,6 +91,26 @@ func testStoreChangesetEvents(t *testing.T, ctx context.Context, s *Store, clock
 		if have, want := count, 1; have != want {
 			t.Fatalf("have count: %d, want: %d", have, want)
 		}
+
+		count, err = s.CountChangesetEvents(ctx, CountChangesetEventsOpts{
+			Kinds: []btypes.ChangesetEventKind{btypes.ChangesetEventKindGitHubClosed, btypes.ChangesetEventKindGitHubAssigned},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := count, 2; have != want {
+			t.Fatalf("have count: %d, want: %d", have, want)
+		}
+
+		count, err = s.CountChangesetEvents(ctx, CountChangesetEventsOpts{CreatedAfter: clock.Now()})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := count, 0; have != want {
+			t.Fatalf("have count: %d, want: %d", have, want)
+		}
 	})
 
 	t.Run("Get", func(t *testing.T) {
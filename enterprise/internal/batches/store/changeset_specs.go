@@ -454,7 +454,7 @@ func (s *Store) ListChangesetSpecsWithConflictingHeadRef(ctx context.Context, ba
 }
 
 // DeleteExpiredChangesetSpecs deletes each ChangesetSpec that has not been
-// attached to a BatchSpec within ChangesetSpecTTL, OR that is attached
+// attached to a BatchSpec or a Changeset within ChangesetSpecTTL, OR that is attached
 // to a BatchSpec that is not applied and is not attached to a Changeset
 // within BatchSpecTTL
 func (s *Store) DeleteExpiredChangesetSpecs(ctx context.Context) (err error) {
@@ -478,6 +478,10 @@ WHERE
   AND
   -- and it was never attached to a batch_spec
   batch_spec_id IS NULL
+  AND
+  -- and it is not attached to a changeset, which is the case for specs
+  -- that were replaced when a changeset was refreshed
+  NOT EXISTS(SELECT 1 FROM changesets WHERE current_spec_id = cspecs.id OR previous_spec_id = cspecs.id)
 )
 OR
 (
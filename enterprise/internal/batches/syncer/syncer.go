@@ -10,6 +10,7 @@ import (
 	"github.com/inconshreveable/log15"
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
@@ -491,7 +492,37 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, source sources.Chan
 		return err
 	}
 
-	return tx.UpsertChangesetEvents(ctx, events...)
+	if err := tx.UpsertChangesetEvents(ctx, events...); err != nil {
+		return err
+	}
+
+	return enqueueAutoMerge(ctx, tx, c)
+}
+
+// enqueueAutoMerge enqueues the changeset for the reconciler if it can now be
+// merged according to the auto-merge policy of its batch change. The
+// reconciler then decides whether to merge it.
+func enqueueAutoMerge(ctx context.Context, tx *store.Store, c *btypes.Changeset) error {
+	if !c.AutoMergeable() || c.ReconcilerState != btypes.ReconcilerStateCompleted {
+		return nil
+	}
+
+	template, err := tx.GetChangesetTemplate(ctx, c.OwnedByBatchChangeID)
+	if err != nil || template == nil || template.AutoMerge == nil {
+		return err
+	}
+
+	// The reconciler only makes one attempt per head commit, so there's no
+	// point in enqueueing the changeset until a new commit is pushed.
+	headRefOid, err := c.HeadRefOid()
+	if err != nil || headRefOid == "" {
+		return err
+	}
+	if failed, err := tx.HasAutoMergeFailed(ctx, c.ID, headRefOid); err != nil || failed {
+		return err
+	}
+
+	return tx.EnqueueChangeset(ctx, c, global.DefaultReconcilerEnqueueState(), btypes.ReconcilerStateCompleted)
 }
 
 func loadChangesetSource(ctx context.Context, cf *httpcli.Factory, syncStore SyncStore, repo *types.Repo) (sources.ChangesetSource, error) {
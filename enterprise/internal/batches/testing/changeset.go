@@ -43,6 +43,7 @@ type TestChangesetOpts struct {
 	SyncErrorMessage string
 
 	OwnedByBatchChange int64
+	RolloutStage       *int32
 
 	Closing    bool
 	IsArchived bool
@@ -93,6 +94,7 @@ func BuildChangeset(opts TestChangesetOpts) *btypes.Changeset {
 		UiPublicationState: opts.UiPublicationState,
 
 		OwnedByBatchChangeID: opts.OwnedByBatchChange,
+		RolloutStage:         opts.RolloutStage,
 
 		Closing: opts.Closing,
 
@@ -28,6 +28,10 @@ type BatchChange struct {
 
 	ClosedAt time.Time
 
+	// KeepUpToDate is set when changesets with merge conflicts should be
+	// re-executed against the new head of their base branch.
+	KeepUpToDate bool
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
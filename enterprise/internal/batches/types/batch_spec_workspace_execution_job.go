@@ -42,6 +42,13 @@ type BatchSpecWorkspaceExecutionJob struct {
 	BatchSpecWorkspaceID int64
 	AccessTokenID        int64
 
+	// ChangesetID is set when the job re-executes the workspace of a
+	// published changeset whose base branch moved.
+	ChangesetID int64
+	// Commit is the new head of the base branch the workspace is re-executed
+	// against. It is only set together with ChangesetID.
+	Commit string
+
 	State           BatchSpecWorkspaceExecutionJobState
 	FailureMessage  *string
 	StartedAt       time.Time
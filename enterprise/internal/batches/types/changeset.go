@@ -11,6 +11,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -271,6 +272,10 @@ type Changeset struct {
 	PublicationState   ChangesetPublicationState // "unpublished", "published"
 	UiPublicationState *ChangesetUiPublicationState
 
+	// RolloutStage is the stage of the batch change's rollout this changeset
+	// is published in. It's nil if the changeset isn't part of a rollout.
+	RolloutStage *int32
+
 	// All of the following fields are used by workerutil.Worker.
 	ReconcilerState  ReconcilerState
 	FailureMessage   *string
@@ -310,6 +315,49 @@ func (c *Changeset) Complete() bool {
 		c.ExternalState != ChangesetExternalStateDraft
 }
 
+// AutoMergeable returns whether the Changeset can be merged by the auto-merge
+// policy of the batch change that owns it: it has to be open and still
+// attached to its owning batch change, and its checks have to have passed
+// and its reviews have to be approved.
+func (c *Changeset) AutoMergeable() bool {
+	if c.OwnedByBatchChangeID == 0 || c.Closing || !c.Published() {
+		return false
+	}
+
+	attached := false
+	for _, assoc := range c.BatchChanges {
+		if assoc.BatchChangeID == c.OwnedByBatchChangeID {
+			attached = !assoc.Detach && !assoc.Archive && !assoc.IsArchived
+		}
+	}
+
+	return attached &&
+		c.ExternalState == ChangesetExternalStateOpen &&
+		c.ExternalCheckState == ChangesetCheckStatePassed &&
+		c.ExternalReviewState == ChangesetReviewStateApproved
+}
+
+// HasMergeConflict returns whether the code host reported that the Changeset
+// can no longer be merged into its base branch because of conflicts.
+//
+// Only GitHub and GitLab report conflicts in the metadata we sync. Changesets
+// on other code hosts never have a merge conflict, so they are never
+// refreshed because of one.
+func (c *Changeset) HasMergeConflict() bool {
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		return m.Mergeable == github.PullRequestMergeableStateConflicting
+	case *gitlab.MergeRequest:
+		return m.MergeStatus == gitlab.MergeStatusCannotBeMerged
+	case *bitbucketserver.PullRequest, *bitbucketcloud.PullRequest:
+		// Bitbucket only reports conflicts when merging, not on the pull
+		// request.
+		return false
+	default:
+		return false
+	}
+}
+
 // Published returns whether the Changeset's PublicationState is Published.
 func (c *Changeset) Published() bool { return c.PublicationState.Published() }
 
@@ -373,6 +421,12 @@ func (c *Changeset) SetMetadata(meta interface{}) error {
 		c.ExternalServiceType = extsvc.TypeBitbucketServer
 		c.ExternalBranch = git.EnsureRefPrefix(pr.FromRef.ID)
 		c.ExternalUpdatedAt = unixMilliToTime(int64(pr.UpdatedDate))
+	case *bitbucketcloud.PullRequest:
+		c.Metadata = pr
+		c.ExternalID = strconv.FormatInt(pr.ID, 10)
+		c.ExternalServiceType = extsvc.TypeBitbucketCloud
+		c.ExternalBranch = git.EnsureRefPrefix(pr.Source.Branch.Name)
+		c.ExternalUpdatedAt = pr.UpdatedOn
 	case *gitlab.MergeRequest:
 		c.Metadata = pr
 		c.ExternalID = strconv.FormatInt(int64(pr.IID), 10)
@@ -402,6 +456,8 @@ func (c *Changeset) Title() (string, error) {
 		return m.Title, nil
 	case *bitbucketserver.PullRequest:
 		return m.Title, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Title, nil
 	case *gitlab.MergeRequest:
 		return m.Title, nil
 	default:
@@ -419,6 +475,8 @@ func (c *Changeset) AuthorName() (string, error) {
 			return "", nil
 		}
 		return m.Author.User.Name, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Author.Nickname, nil
 	case *gitlab.MergeRequest:
 		return m.Author.Username, nil
 	default:
@@ -442,6 +500,9 @@ func (c *Changeset) AuthorEmail() (string, error) {
 			return "", nil
 		}
 		return m.Author.User.EmailAddress, nil
+	case *bitbucketcloud.PullRequest:
+		// Bitbucket Cloud doesn't expose the email addresses of accounts.
+		return "", nil
 	case *gitlab.MergeRequest:
 		return m.Author.Email, nil
 	default:
@@ -458,6 +519,8 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return m.CreatedAt
 	case *bitbucketserver.PullRequest:
 		return unixMilliToTime(int64(m.CreatedDate))
+	case *bitbucketcloud.PullRequest:
+		return m.CreatedOn
 	case *gitlab.MergeRequest:
 		return m.CreatedAt.Time
 	default:
@@ -472,6 +535,8 @@ func (c *Changeset) Body() (string, error) {
 		return m.Body, nil
 	case *bitbucketserver.PullRequest:
 		return m.Description, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Description, nil
 	case *gitlab.MergeRequest:
 		return m.Description, nil
 	default:
@@ -509,6 +574,8 @@ func (c *Changeset) URL() (s string, err error) {
 		}
 		selfLink := m.Links.Self[0]
 		return selfLink.Href, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Links.HTML.Href, nil
 	case *gitlab.MergeRequest:
 		return m.WebURL, nil
 	default:
@@ -600,6 +667,33 @@ func (c *Changeset) Events() (events []*ChangesetEvent, err error) {
 			}
 		}
 
+	case *bitbucketcloud.PullRequest:
+		// Merges and declines of Bitbucket Cloud pull requests are only
+		// received through webhooks. Reviews are also derived from the
+		// participants of the pull request.
+		reviews := m.ReviewEvents()
+		events = make([]*ChangesetEvent, 0, len(reviews)+len(m.Statuses))
+		for _, r := range reviews {
+			kind, err := ChangesetEventKindFor(r)
+			if err != nil {
+				return nil, err
+			}
+			appendEvent(&ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         r.Key(),
+				Kind:        kind,
+				Metadata:    r,
+			})
+		}
+		for _, s := range m.Statuses {
+			appendEvent(&ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         s.Key(),
+				Kind:        ChangesetEventKindBitbucketCloudCommitStatus,
+				Metadata:    s,
+			})
+		}
+
 	case *gitlab.MergeRequest:
 		events = make([]*ChangesetEvent, 0, len(m.Notes)+len(m.ResourceStateEvents)+len(m.Pipelines))
 		var kind ChangesetEventKind
@@ -656,6 +750,8 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return m.HeadRefOid, nil
 	case *bitbucketserver.PullRequest:
 		return "", nil
+	case *bitbucketcloud.PullRequest:
+		return m.Source.Commit.Hash, nil
 	case *gitlab.MergeRequest:
 		return m.DiffRefs.HeadSHA, nil
 	default:
@@ -671,6 +767,8 @@ func (c *Changeset) HeadRef() (string, error) {
 		return "refs/heads/" + m.HeadRefName, nil
 	case *bitbucketserver.PullRequest:
 		return m.FromRef.ID, nil
+	case *bitbucketcloud.PullRequest:
+		return "refs/heads/" + m.Source.Branch.Name, nil
 	case *gitlab.MergeRequest:
 		return "refs/heads/" + m.SourceBranch, nil
 	default:
@@ -687,6 +785,8 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return m.BaseRefOid, nil
 	case *bitbucketserver.PullRequest:
 		return "", nil
+	case *bitbucketcloud.PullRequest:
+		return m.Destination.Commit.Hash, nil
 	case *gitlab.MergeRequest:
 		return m.DiffRefs.BaseSHA, nil
 	default:
@@ -702,6 +802,8 @@ func (c *Changeset) BaseRef() (string, error) {
 		return "refs/heads/" + m.BaseRefName, nil
 	case *bitbucketserver.PullRequest:
 		return m.ToRef.ID, nil
+	case *bitbucketcloud.PullRequest:
+		return "refs/heads/" + m.Destination.Branch.Name, nil
 	case *gitlab.MergeRequest:
 		return "refs/heads/" + m.TargetBranch, nil
 	default:
@@ -964,6 +1066,20 @@ func ChangesetEventKindFor(e interface{}) (ChangesetEventKind, error) {
 		return ChangesetEventKind("bitbucketserver:participant_status:" + strings.ToLower(string(e.Action))), nil
 	case *bitbucketserver.CommitStatus:
 		return ChangesetEventKindBitbucketServerCommitStatus, nil
+	case *bitbucketcloud.PullRequestApprovedEvent:
+		return ChangesetEventKindBitbucketCloudApproved, nil
+	case *bitbucketcloud.PullRequestUnapprovedEvent:
+		return ChangesetEventKindBitbucketCloudUnapproved, nil
+	case *bitbucketcloud.PullRequestChangesRequestCreatedEvent:
+		return ChangesetEventKindBitbucketCloudChangesRequestCreated, nil
+	case *bitbucketcloud.PullRequestChangesRequestRemovedEvent:
+		return ChangesetEventKindBitbucketCloudChangesRequestRemoved, nil
+	case *bitbucketcloud.PullRequestFulfilledEvent:
+		return ChangesetEventKindBitbucketCloudFulfilled, nil
+	case *bitbucketcloud.PullRequestRejectedEvent:
+		return ChangesetEventKindBitbucketCloudRejected, nil
+	case *bitbucketcloud.CommitStatus:
+		return ChangesetEventKindBitbucketCloudCommitStatus, nil
 	case *gitlab.Pipeline:
 		return ChangesetEventKindGitLabPipeline, nil
 	case *gitlab.ReviewApprovedEvent:
@@ -999,6 +1115,28 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (interface{}, error) {
 		default:
 			return new(bitbucketserver.Activity), nil
 		}
+	case strings.HasPrefix(string(k), "sourcegraph"):
+		switch k {
+		case ChangesetEventKindAutoMerged, ChangesetEventKindAutoMergeFailed:
+			return new(ChangesetAutoMergeEvent), nil
+		}
+	case strings.HasPrefix(string(k), "bitbucketcloud"):
+		switch k {
+		case ChangesetEventKindBitbucketCloudApproved:
+			return new(bitbucketcloud.PullRequestApprovedEvent), nil
+		case ChangesetEventKindBitbucketCloudUnapproved:
+			return new(bitbucketcloud.PullRequestUnapprovedEvent), nil
+		case ChangesetEventKindBitbucketCloudChangesRequestCreated:
+			return new(bitbucketcloud.PullRequestChangesRequestCreatedEvent), nil
+		case ChangesetEventKindBitbucketCloudChangesRequestRemoved:
+			return new(bitbucketcloud.PullRequestChangesRequestRemovedEvent), nil
+		case ChangesetEventKindBitbucketCloudFulfilled:
+			return new(bitbucketcloud.PullRequestFulfilledEvent), nil
+		case ChangesetEventKindBitbucketCloudRejected:
+			return new(bitbucketcloud.PullRequestRejectedEvent), nil
+		case ChangesetEventKindBitbucketCloudCommitStatus:
+			return new(bitbucketcloud.CommitStatus), nil
+		}
 	case strings.HasPrefix(string(k), "github"):
 		switch k {
 		case ChangesetEventKindGitHubAssigned:
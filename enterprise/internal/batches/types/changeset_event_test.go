@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -236,6 +237,56 @@ func TestChangesetEvent(t *testing.T) {
 		})
 	}
 
+	{ // Bitbucket Cloud
+		approver := bitbucketcloud.Account{UUID: "{approver}"}
+		requester := bitbucketcloud.Account{UUID: "{requester}"}
+		date := time.Date(2021, 8, 1, 10, 0, 0, 0, time.UTC)
+		status := &bitbucketcloud.CommitStatus{StatusKey: "build", Commit: bitbucketcloud.PullRequestCommit{Hash: "abcdef"}}
+
+		pr := &bitbucketcloud.PullRequest{
+			ID: 12,
+			Participants: []bitbucketcloud.Participant{
+				{User: approver, State: bitbucketcloud.ParticipantStateApproved, ParticipatedOn: date},
+				{User: requester, State: bitbucketcloud.ParticipantStateChangesRequested, ParticipatedOn: date},
+				{User: bitbucketcloud.Account{UUID: "{reviewer}"}, Role: bitbucketcloud.ParticipantRoleReviewer},
+			},
+			Statuses: []*bitbucketcloud.CommitStatus{status},
+		}
+
+		approved := &bitbucketcloud.PullRequestApprovedEvent{
+			PullRequestEvent: bitbucketcloud.PullRequestEvent{Actor: approver, PullRequest: bitbucketcloud.PullRequest{ID: 12}},
+			Approval:         bitbucketcloud.Approval{Date: date, User: approver},
+		}
+		changesRequested := &bitbucketcloud.PullRequestChangesRequestCreatedEvent{
+			PullRequestEvent: bitbucketcloud.PullRequestEvent{Actor: requester, PullRequest: bitbucketcloud.PullRequest{ID: 12}},
+			ChangesRequest:   bitbucketcloud.Approval{Date: date, User: requester},
+		}
+
+		cases = append(cases, testCase{
+			name: "bitbucketcloud",
+			changeset: Changeset{
+				ID:       25,
+				Metadata: pr,
+			},
+			events: []*ChangesetEvent{{
+				ChangesetID: 25,
+				Kind:        ChangesetEventKindBitbucketCloudApproved,
+				Key:         "12:approved:{approver}:1627812000000000000",
+				Metadata:    approved,
+			}, {
+				ChangesetID: 25,
+				Kind:        ChangesetEventKindBitbucketCloudChangesRequestCreated,
+				Key:         "12:changes_request_created:{requester}:1627812000000000000",
+				Metadata:    changesRequested,
+			}, {
+				ChangesetID: 25,
+				Kind:        ChangesetEventKindBitbucketCloudCommitStatus,
+				Key:         status.Key(),
+				Metadata:    status,
+			}},
+		})
+	}
+
 	{ // GitLab
 		notes := []*gitlab.Note{
 			{ID: 11, System: false, Body: "this is a user note"},
@@ -8,6 +8,7 @@ import (
 	"github.com/sourcegraph/go-diff/diff"
 
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -570,6 +571,95 @@ func TestChangesetMetadata(t *testing.T) {
 	}
 }
 
+func TestChangeset_AutoMergeable(t *testing.T) {
+	mergeable := func() *Changeset {
+		return &Changeset{
+			OwnedByBatchChangeID: 1,
+			BatchChanges:         []BatchChangeAssoc{{BatchChangeID: 1}},
+			PublicationState:     ChangesetPublicationStatePublished,
+			ExternalState:        ChangesetExternalStateOpen,
+			ExternalCheckState:   ChangesetCheckStatePassed,
+			ExternalReviewState:  ChangesetReviewStateApproved,
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		modify func(c *Changeset)
+		want   bool
+	}{
+		"mergeable": {
+			modify: func(c *Changeset) {},
+			want:   true,
+		},
+		"imported": {
+			modify: func(c *Changeset) { c.OwnedByBatchChangeID = 0 },
+		},
+		"detached": {
+			modify: func(c *Changeset) { c.BatchChanges[0].Detach = true },
+		},
+		"archived": {
+			modify: func(c *Changeset) { c.BatchChanges[0].IsArchived = true },
+		},
+		"closing": {
+			modify: func(c *Changeset) { c.Closing = true },
+		},
+		"draft": {
+			modify: func(c *Changeset) { c.ExternalState = ChangesetExternalStateDraft },
+		},
+		"checks pending": {
+			modify: func(c *Changeset) { c.ExternalCheckState = ChangesetCheckStatePending },
+		},
+		"changes requested": {
+			modify: func(c *Changeset) { c.ExternalReviewState = ChangesetReviewStateChangesRequested },
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := mergeable()
+			tc.modify(c)
+
+			if have := c.AutoMergeable(); have != tc.want {
+				t.Errorf("wrong result: have %v, want %v", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestChangeset_HasMergeConflict(t *testing.T) {
+	for name, tc := range map[string]struct {
+		metadata interface{}
+		want     bool
+	}{
+		"no metadata": {},
+		"github mergeable": {
+			metadata: &github.PullRequest{Mergeable: "MERGEABLE"},
+		},
+		"github conflicting": {
+			metadata: &github.PullRequest{Mergeable: github.PullRequestMergeableStateConflicting},
+			want:     true,
+		},
+		"gitlab mergeable": {
+			metadata: &gitlab.MergeRequest{MergeStatus: gitlab.MergeStatusCanBeMerged},
+		},
+		"gitlab conflicting": {
+			metadata: &gitlab.MergeRequest{MergeStatus: gitlab.MergeStatusCannotBeMerged},
+			want:     true,
+		},
+		"bitbucket server": {
+			metadata: &bitbucketserver.PullRequest{},
+		},
+		"bitbucket cloud": {
+			metadata: &bitbucketcloud.PullRequest{State: bitbucketcloud.PullRequestStateOpen},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := &Changeset{Metadata: tc.metadata}
+			if have := c.HasMergeConflict(); have != tc.want {
+				t.Errorf("wrong result: have %v, want %v", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestChangeset_ResetReconcilerState(t *testing.T) {
 	for name, tc := range map[string]struct {
 		changeset *Changeset
@@ -3,6 +3,7 @@ package enqueuer
 import (
 	"strings"
 
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 )
@@ -10,7 +11,9 @@ import (
 func InferRepositoryAndRevision(pkg precise.Package) (repoName, gitTagOrCommit string, ok bool) {
 	for _, fn := range []func(pkg precise.Package) (string, string, bool){
 		inferGoRepositoryAndRevision,
+		inferGoModuleProxyRepositoryAndRevision,
 		inferJVMRepositoryAndRevision,
+		inferNpmRepositoryAndRevision,
 	} {
 		if repoName, gitTagOrCommit, ok := fn(pkg); ok {
 			return repoName, gitTagOrCommit, true
@@ -42,9 +45,35 @@ func inferGoRepositoryAndRevision(pkg precise.Package) (string, string, bool) {
 	return strings.Join(repoParts, "/"), version, true
 }
 
+// inferGoModuleProxyRepositoryAndRevision infers the repository of Go modules
+// not hosted on GitHub, which are synced from a Go module proxy.
+func inferGoModuleProxyRepositoryAndRevision(pkg precise.Package) (string, string, bool) {
+	if pkg.Scheme != "gomod" || !strings.HasPrefix(pkg.Name, GitHubScheme) || strings.HasPrefix(pkg.Name, GitHubScheme+"github.com/") {
+		return "", "", false
+	}
+
+	dependency, err := reposource.ParseGoDependency(pkg.Name[len(GitHubScheme):] + "@" + pkg.Version)
+	if err != nil {
+		return "", "", false
+	}
+	return string(dependency.RepoName()), dependency.GitTagFromVersion(), true
+}
+
 func inferJVMRepositoryAndRevision(pkg precise.Package) (string, string, bool) {
 	if pkg.Scheme != "semanticdb" {
 		return "", "", false
 	}
 	return pkg.Name, "v" + pkg.Version, true
 }
+
+func inferNpmRepositoryAndRevision(pkg precise.Package) (string, string, bool) {
+	if pkg.Scheme != "npm" {
+		return "", "", false
+	}
+
+	dependency, err := reposource.ParseNpmDependency(pkg.Name + "@" + pkg.Version)
+	if err != nil {
+		return "", "", false
+	}
+	return string(dependency.RepoName()), dependency.GitTagFromVersion(), true
+}
@@ -21,6 +21,7 @@ var tableNames = []string{
 	"lsif_data_definitions_schema_versions",
 	"lsif_data_references",
 	"lsif_data_references_schema_versions",
+	"lsif_data_implementations",
 }
 
 func (s *Store) Clear(ctx context.Context, bundleIDs ...int) (err error) {
@@ -14,6 +14,7 @@ type operations struct {
 	diagnostics                   *observation.Operation
 	exists                        *observation.Operation
 	hover                         *observation.Operation
+	implementations               *observation.Operation
 	monikerResults                *observation.Operation
 	monikersByPosition            *observation.Operation
 	packageInformation            *observation.Operation
@@ -31,6 +32,7 @@ type operations struct {
 	writeDocuments                *observation.Operation
 	writeMeta                     *observation.Operation
 	writeReferences               *observation.Operation
+	writeImplementations          *observation.Operation
 	writeResultChunks             *observation.Operation
 	writeDocumentationPages       *observation.Operation
 	writeDocumentationPathInfo    *observation.Operation
@@ -73,6 +75,7 @@ func newOperations(observationContext *observation.Context) *operations {
 		diagnostics:                   op("Diagnostics"),
 		exists:                        op("Exists"),
 		hover:                         op("Hover"),
+		implementations:               op("Implementations"),
 		monikerResults:                op("MonikerResults"),
 		monikersByPosition:            op("MonikersByPosition"),
 		packageInformation:            op("PackageInformation"),
@@ -90,6 +93,7 @@ func newOperations(observationContext *observation.Context) *operations {
 		writeDocuments:                op("WriteDocuments"),
 		writeMeta:                     op("WriteMeta"),
 		writeReferences:               op("WriteReferences"),
+		writeImplementations:          op("WriteImplementations"),
 		writeResultChunks:             op("WriteResultChunks"),
 		writeDocumentationPages:       op("WriteDocumentationPages"),
 		writeDocumentationPathInfo:    op("WriteDocumentationPathInfo"),
@@ -23,6 +23,8 @@ type MonitorEmail struct {
 	CreatedAt time.Time
 	ChangedBy int32
 	ChangedAt time.Time
+
+	DeliveryPolicy
 }
 
 func (s *Store) UpdateActionEmail(ctx context.Context, monitorID int64, action *graphqlbackend.EditActionArgs) (e *MonitorEmail, err error) {
@@ -79,7 +81,7 @@ func (s *Store) TotalCountActionEmails(ctx context.Context, monitorID int64) (co
 }
 
 const actionEmailByIDFmtStr = `
-SELECT id, monitor, enabled, priority, header, created_by, created_at, changed_by, changed_at
+SELECT id, monitor, enabled, priority, header, created_by, created_at, changed_by, changed_at, delivery, max_notifications, notification_window
 FROM cm_emails
 WHERE id = %s
 `
@@ -109,6 +111,9 @@ UPDATE cm_emails
 SET enabled = %s,
 	priority = %s,
 	header = %s,
+	delivery = %s,
+	max_notifications = %s,
+	notification_window = %s,
 	changed_by = %s,
 	changed_at = %s
 WHERE id = %s
@@ -125,6 +130,10 @@ func (s *Store) updateActionEmailQuery(ctx context.Context, monitorID int64, arg
 	if err != nil {
 		return nil, err
 	}
+	p, err := newDeliveryPolicy(args.Update.Delivery, args.Update.RateLimit)
+	if err != nil {
+		return nil, err
+	}
 	now := s.Now()
 	a := actor.FromContext(ctx)
 	return sqlf.Sprintf(
@@ -132,6 +141,9 @@ func (s *Store) updateActionEmailQuery(ctx context.Context, monitorID int64, arg
 		args.Update.Enabled,
 		args.Update.Priority,
 		args.Update.Header,
+		p.Delivery,
+		p.MaxNotifications,
+		p.NotificationWindow,
 		a.UID,
 		now,
 		actionID,
@@ -141,7 +153,7 @@ func (s *Store) updateActionEmailQuery(ctx context.Context, monitorID int64, arg
 }
 
 const readActionEmailFmtStr = `
-SELECT id, monitor, enabled, priority, header, created_by, created_at, changed_by, changed_at
+SELECT id, monitor, enabled, priority, header, created_by, created_at, changed_by, changed_at, delivery, max_notifications, notification_window
 FROM cm_emails
 WHERE monitor = %s
 AND id > %s
@@ -163,12 +175,16 @@ func (s *Store) ReadActionEmailQuery(ctx context.Context, monitorID int64, args
 
 const createActionEmailFmtStr = `
 INSERT INTO cm_emails
-(monitor, enabled, priority, header, created_by, created_at, changed_by, changed_at)
-VALUES (%s,%s,%s,%s,%s,%s,%s,%s)
+(monitor, enabled, priority, header, delivery, max_notifications, notification_window, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s)
 RETURNING %s;
 `
 
 func (s *Store) createActionEmailQuery(ctx context.Context, monitorID int64, args *graphqlbackend.CreateActionEmailArgs) (*sqlf.Query, error) {
+	p, err := newDeliveryPolicy(args.Delivery, args.RateLimit)
+	if err != nil {
+		return nil, err
+	}
 	now := s.Now()
 	a := actor.FromContext(ctx)
 	return sqlf.Sprintf(
@@ -177,6 +193,9 @@ func (s *Store) createActionEmailQuery(ctx context.Context, monitorID int64, arg
 		args.Enabled,
 		args.Priority,
 		args.Header,
+		p.Delivery,
+		p.MaxNotifications,
+		p.NotificationWindow,
 		a.UID,
 		now,
 		a.UID,
@@ -209,6 +228,9 @@ var EmailsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_emails.created_at"),
 	sqlf.Sprintf("cm_emails.changed_by"),
 	sqlf.Sprintf("cm_emails.changed_at"),
+	sqlf.Sprintf("cm_emails.delivery"),
+	sqlf.Sprintf("cm_emails.max_notifications"),
+	sqlf.Sprintf("cm_emails.notification_window"),
 }
 
 func ScanEmails(rows *sql.Rows) (ms []*MonitorEmail, err error) {
@@ -224,6 +246,9 @@ func ScanEmails(rows *sql.Rows) (ms []*MonitorEmail, err error) {
 			&m.CreatedAt,
 			&m.ChangedBy,
 			&m.ChangedAt,
+			&m.Delivery,
+			&m.MaxNotifications,
+			&m.NotificationWindow,
 		); err != nil {
 			return nil, err
 		}
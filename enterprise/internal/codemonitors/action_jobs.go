@@ -3,6 +3,7 @@ package codemonitors
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -14,8 +15,13 @@ import (
 )
 
 type ActionJob struct {
-	Id           int
-	Email        int64
+	Id int
+
+	// Exactly one of Email, Webhook and SlackWebhook is non-nil.
+	Email        *int64
+	Webhook      *int64
+	SlackWebhook *int64
+
 	TriggerEvent int
 
 	// Fields demanded by any dbworker.
@@ -38,13 +44,24 @@ type ActionJobMetadata struct {
 	MonitorID   int64
 	NumResults  *int
 
-	// The query with after: filter.
+	// The query with after: filter of the first trigger event.
 	Query string
+
+	// The search results of the trigger events, as returned by the GraphQL
+	// API. Results is nil if they were not logged.
+	Results json.RawMessage
+
+	// NumTriggerEvents is the number of trigger events sent with the job. It
+	// is greater than 1 for digests and postponed jobs of rate limited
+	// actions.
+	NumTriggerEvents int
 }
 
 var ActionJobsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_action_jobs.id"),
 	sqlf.Sprintf("cm_action_jobs.email"),
+	sqlf.Sprintf("cm_action_jobs.webhook"),
+	sqlf.Sprintf("cm_action_jobs.slack_webhook"),
 	sqlf.Sprintf("cm_action_jobs.trigger_event"),
 	sqlf.Sprintf("cm_action_jobs.state"),
 	sqlf.Sprintf("cm_action_jobs.failure_message"),
@@ -56,8 +73,8 @@ var ActionJobsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_action_jobs.log_contents"),
 }
 
-const readActionEmailEventsFmtStr = `
-SELECT id, email, trigger_event, state, failure_message, started_at, finished_at, process_after, num_resets, num_failures, log_contents
+const readActionEventsFmtStr = `
+SELECT id, email, webhook, slack_webhook, trigger_event, state, failure_message, started_at, finished_at, process_after, num_resets, num_failures, log_contents
 FROM cm_action_jobs
 WHERE %s
 AND id > %s
@@ -65,19 +82,28 @@ ORDER BY id ASC
 LIMIT %s;
 `
 
-func (s *Store) ReadActionEmailEvents(ctx context.Context, emailID int64, triggerEventID *int, args *graphqlbackend.ListEventsArgs) (js []*ActionJob, err error) {
-	var where *sqlf.Query
-	if triggerEventID == nil {
-		where = sqlf.Sprintf("email = %s", emailID)
-	} else {
-		where = sqlf.Sprintf("email = %s AND trigger_event = %s", emailID, *triggerEventID)
+func (s *Store) ReadActionEmailEvents(ctx context.Context, emailID int64, triggerEventID *int, args *graphqlbackend.ListEventsArgs) ([]*ActionJob, error) {
+	return s.readActionEvents(ctx, sqlf.Sprintf("email = %s", emailID), triggerEventID, args)
+}
+
+func (s *Store) ReadActionWebhookEvents(ctx context.Context, webhookID int64, triggerEventID *int, args *graphqlbackend.ListEventsArgs) ([]*ActionJob, error) {
+	return s.readActionEvents(ctx, sqlf.Sprintf("webhook = %s", webhookID), triggerEventID, args)
+}
+
+func (s *Store) ReadActionSlackWebhookEvents(ctx context.Context, slackWebhookID int64, triggerEventID *int, args *graphqlbackend.ListEventsArgs) ([]*ActionJob, error) {
+	return s.readActionEvents(ctx, sqlf.Sprintf("slack_webhook = %s", slackWebhookID), triggerEventID, args)
+}
+
+func (s *Store) readActionEvents(ctx context.Context, where *sqlf.Query, triggerEventID *int, args *graphqlbackend.ListEventsArgs) (js []*ActionJob, err error) {
+	if triggerEventID != nil {
+		where = sqlf.Sprintf("%s AND trigger_event = %s", where, *triggerEventID)
 	}
 	var rows *sql.Rows
 	after, err := unmarshalAfter(args.After)
 	if err != nil {
 		return nil, err
 	}
-	rows, err = s.Query(ctx, sqlf.Sprintf(readActionEmailEventsFmtStr, where, after, args.First))
+	rows, err = s.Query(ctx, sqlf.Sprintf(readActionEventsFmtStr, where, after, args.First))
 	if err != nil {
 		return nil, err
 	}
@@ -85,66 +111,151 @@ func (s *Store) ReadActionEmailEvents(ctx context.Context, emailID int64, trigge
 	return scanActionJobs(rows, err)
 }
 
-const totalActionEmailEventsFmtStr = `
+const totalActionEventsFmtStr = `
 SELECT COUNT(*)
 FROM cm_action_jobs
 WHERE %s
 `
 
-func (s *Store) TotalActionEmailEvents(ctx context.Context, emailID int64, triggerEventID *int) (totalCount int32, err error) {
-	var where *sqlf.Query
-	if triggerEventID == nil {
-		where = sqlf.Sprintf("email = %s", emailID)
-	} else {
-		where = sqlf.Sprintf("email = %s AND trigger_event = %s", emailID, *triggerEventID)
+func (s *Store) TotalActionEmailEvents(ctx context.Context, emailID int64, triggerEventID *int) (int32, error) {
+	return s.totalActionEvents(ctx, sqlf.Sprintf("email = %s", emailID), triggerEventID)
+}
+
+func (s *Store) TotalActionWebhookEvents(ctx context.Context, webhookID int64, triggerEventID *int) (int32, error) {
+	return s.totalActionEvents(ctx, sqlf.Sprintf("webhook = %s", webhookID), triggerEventID)
+}
+
+func (s *Store) TotalActionSlackWebhookEvents(ctx context.Context, slackWebhookID int64, triggerEventID *int) (int32, error) {
+	return s.totalActionEvents(ctx, sqlf.Sprintf("slack_webhook = %s", slackWebhookID), triggerEventID)
+}
+
+func (s *Store) totalActionEvents(ctx context.Context, where *sqlf.Query, triggerEventID *int) (totalCount int32, err error) {
+	if triggerEventID != nil {
+		where = sqlf.Sprintf("%s AND trigger_event = %s", where, *triggerEventID)
 	}
-	err = s.QueryRow(ctx, sqlf.Sprintf(totalActionEmailEventsFmtStr, where)).Scan(&totalCount)
+	err = s.QueryRow(ctx, sqlf.Sprintf(totalActionEventsFmtStr, where)).Scan(&totalCount)
 	if err != nil {
 		return -1, err
 	}
 	return totalCount, nil
 }
 
-const enqueueActionEmailFmtStr = `
+// enqueueActionJobsFmtStr enqueues a job for each enabled action of a query
+// which has no pending job. The trigger events of the query which happen while
+// a job is pending are sent with the pending job.
+//
+// Jobs of digest actions are processed at the start of the next hour or day.
+// Jobs of rate limited actions are postponed until the notifications sent
+// within the window of the action drop below its maximum.
+const enqueueActionJobsFmtStr = `
 WITH due AS (
-	SELECT e.id, e.monitor, e.enabled, e.priority, e.header, e.created_by, e.created_at, e.changed_by, e.changed_at
-	FROM cm_emails e INNER JOIN cm_queries q ON e.monitor = q.monitor
-	WHERE q.id = %s AND e.enabled = true
-),
-busy AS (
-    SELECT DISTINCT email as id FROM cm_action_jobs
-    WHERE state = 'queued'
-    OR state = 'processing'
+	SELECT a.id, a.delivery, a.max_notifications, a.notification_window
+	FROM %s a INNER JOIN cm_queries q ON a.monitor = q.monitor
+	WHERE q.id = %s AND a.enabled = true
+)
+INSERT INTO cm_action_jobs (%s, trigger_event, process_after)
+SELECT due.id, %s::integer, GREATEST(
+	CASE due.delivery
+		WHEN 'HOURLY_DIGEST' THEN date_trunc('hour', %s::timestamptz AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' + interval '1 hour'
+		WHEN 'DAILY_DIGEST' THEN date_trunc('day', %s::timestamptz AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' + interval '1 day'
+	END,
+	(
+		SELECT j.finished_at + due.notification_window * interval '1 minute'
+		FROM cm_action_jobs j
+		WHERE j.%s = due.id
+		AND j.state = 'completed'
+		AND due.max_notifications IS NOT NULL
+		AND j.finished_at > %s::timestamptz - due.notification_window * interval '1 minute'
+		ORDER BY j.finished_at DESC
+		OFFSET due.max_notifications - 1
+		LIMIT 1
+	)
 )
-INSERT INTO cm_action_jobs (email, trigger_event)
-SELECT id, %s::integer from due EXCEPT SELECT id, %s::integer from busy ORDER BY id
+FROM due
+WHERE NOT EXISTS (
+	SELECT 1 FROM cm_action_jobs j
+	WHERE j.%s = due.id
+	AND (j.state = 'queued' OR j.state = 'processing')
+)
+ORDER BY due.id
 `
 
+func (s *Store) enqueueActionJobs(ctx context.Context, table, column string, queryID int64, triggerEventID int) error {
+	now := s.Now()
+	col := sqlf.Sprintf(column)
+	return s.Store.Exec(ctx, sqlf.Sprintf(
+		enqueueActionJobsFmtStr,
+		sqlf.Sprintf(table),
+		queryID,
+		col,
+		triggerEventID,
+		now,
+		now,
+		col,
+		now,
+		col,
+	))
+}
+
 func (s *Store) EnqueueActionEmailsForQueryIDInt64(ctx context.Context, queryID int64, triggerEventID int) (err error) {
-	return s.Store.Exec(ctx, sqlf.Sprintf(enqueueActionEmailFmtStr, queryID, triggerEventID, triggerEventID))
+	return s.enqueueActionJobs(ctx, "cm_emails", "email", queryID, triggerEventID)
 }
 
+func (s *Store) EnqueueActionWebhooksForQueryIDInt64(ctx context.Context, queryID int64, triggerEventID int) (err error) {
+	return s.enqueueActionJobs(ctx, "cm_webhooks", "webhook", queryID, triggerEventID)
+}
+
+func (s *Store) EnqueueActionSlackWebhooksForQueryIDInt64(ctx context.Context, queryID int64, triggerEventID int) (err error) {
+	return s.enqueueActionJobs(ctx, "cm_slack_webhooks", "slack_webhook", queryID, triggerEventID)
+}
+
+// getActionJobMetadataFmtStr selects the metadata of the trigger event of an
+// action job and of all later trigger events of the same query with results.
+// Those happened while the job was pending, e.g. waiting for a digest, and
+// are sent with the job.
 const getActionJobMetadataFmtStr = `
-select cm.description, ctj.query_string, cm.id as monitorID, ctj.num_results from
-cm_action_jobs caj
-inner join cm_trigger_jobs ctj on caj.trigger_event = ctj.id
-inner join cm_queries cq on cq.id = ctj.query
-inner join cm_monitors cm on cm.id = cq.monitor
-where caj.id = %s
+WITH job AS (
+	SELECT caj.trigger_event, ctj.query
+	FROM cm_action_jobs caj
+	INNER JOIN cm_trigger_jobs ctj ON caj.trigger_event = ctj.id
+	WHERE caj.id = %s
+),
+events AS (
+	SELECT ctj.id, ctj.num_results, ctj.search_results
+	FROM cm_trigger_jobs ctj, job
+	WHERE ctj.query = job.query
+	AND (ctj.id = job.trigger_event OR (ctj.id > job.trigger_event AND ctj.results IS TRUE))
+)
+SELECT
+	cm.description,
+	first.query_string,
+	cm.id AS monitorID,
+	(SELECT SUM(num_results)::integer FROM events),
+	(
+		SELECT jsonb_agg(r.value ORDER BY e.id, r.ordinality)
+		FROM events e, jsonb_array_elements(e.search_results) WITH ORDINALITY r
+	),
+	(SELECT COUNT(*) FROM events)
+FROM job
+INNER JOIN cm_trigger_jobs first ON first.id = job.trigger_event
+INNER JOIN cm_queries cq ON cq.id = job.query
+INNER JOIN cm_monitors cm ON cm.id = cq.monitor
 `
 
 func (s *Store) GetActionJobMetadata(ctx context.Context, recordID int) (m *ActionJobMetadata, err error) {
 	row := s.Store.QueryRow(ctx, sqlf.Sprintf(getActionJobMetadataFmtStr, recordID))
 	m = &ActionJobMetadata{}
-	err = row.Scan(&m.Description, &m.Query, &m.MonitorID, &m.NumResults)
+	var results []byte
+	err = row.Scan(&m.Description, &m.Query, &m.MonitorID, &m.NumResults, &results, &m.NumTriggerEvents)
 	if err != nil {
 		return nil, err
 	}
+	m.Results = results
 	return m, nil
 }
 
 const actionJobForIDFmtStr = `
-SELECT id, email, trigger_event, state, failure_message, started_at, finished_at, process_after, num_resets, num_failures, log_contents
+SELECT id, email, webhook, slack_webhook, trigger_event, state, failure_message, started_at, finished_at, process_after, num_resets, num_failures, log_contents
 FROM cm_action_jobs
 WHERE id = %s
 `
@@ -190,6 +301,8 @@ func scanActionJobs(rows *sql.Rows, err error) ([]*ActionJob, error) {
 		if err := rows.Scan(
 			&aj.Id,
 			&aj.Email,
+			&aj.Webhook,
+			&aj.SlackWebhook,
 			&aj.TriggerEvent,
 			&aj.State,
 			&aj.FailureMessage,
@@ -2,11 +2,14 @@ package codemonitors
 
 import (
 	"database/sql"
+	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 )
 
 func TestEnqueueActionEmailsForQueryIDInt64QueryByRecordID(t *testing.T) {
@@ -35,9 +38,10 @@ func TestEnqueueActionEmailsForQueryIDInt64QueryByRecordID(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	wantEmailID := int64(1)
 	want := &ActionJob{
 		Id:             1,
-		Email:          1,
+		Email:          &wantEmailID,
 		TriggerEvent:   1,
 		State:          "queued",
 		FailureMessage: nil,
@@ -53,6 +57,61 @@ func TestEnqueueActionEmailsForQueryIDInt64QueryByRecordID(t *testing.T) {
 	}
 }
 
+func TestEnqueueActionWebhooksForQueryIDInt64(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx, s := newTestStore(t)
+	_, _, _, userCTX := newTestUser(ctx, t)
+	m, err := s.insertTestMonitor(userCTX, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := s.CreateActionWebhook(userCTX, m.ID, &graphqlbackend.CreateActionWebhookArgs{
+		Enabled: true,
+		URL:     "https://example.com/webhook",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sw, err := s.CreateActionSlackWebhook(userCTX, m.ID, &graphqlbackend.CreateActionSlackWebhookArgs{
+		Enabled: true,
+		URL:     "https://hooks.slack.com/services/test",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.EnqueueTriggerQueries(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.EnqueueActionWebhooksForQueryIDInt64(ctx, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.EnqueueActionSlackWebhooksForQueryIDInt64(ctx, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.ReadActionWebhookEvents(ctx, w.Id, nil, &graphqlbackend.ListEventsArgs{First: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Webhook == nil || *got[0].Webhook != w.Id || got[0].Email != nil || got[0].SlackWebhook != nil {
+		t.Fatalf("unexpected webhook jobs %+v", got)
+	}
+
+	got, err = s.ReadActionSlackWebhookEvents(ctx, sw.Id, nil, &graphqlbackend.ListEventsArgs{First: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].SlackWebhook == nil || *got[0].SlackWebhook != sw.Id || got[0].Email != nil || got[0].Webhook != nil {
+		t.Fatalf("unexpected Slack webhook jobs %+v", got)
+	}
+}
+
 func TestGetActionJobMetadata(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -92,12 +151,118 @@ func TestGetActionJobMetadata(t *testing.T) {
 		Query:       wantQuery,
 		NumResults:  &wantNumResults,
 		MonitorID:   wantMonitorID,
+
+		NumTriggerEvents: 1,
 	}
 	if diff := cmp.Diff(got, want); diff != "" {
 		t.Fatalf("diff: %s", diff)
 	}
 }
 
+func TestEnqueueActionJobsDeliveryPolicy(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx, s := newTestStore(t)
+	_, _, _, userCTX := newTestUser(ctx, t)
+	m, err := s.insertTestMonitor(userCTX, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest, err := s.CreateActionWebhook(userCTX, m.ID, &graphqlbackend.CreateActionWebhookArgs{
+		Enabled:  true,
+		URL:      "https://example.com/digest",
+		Delivery: DeliveryHourlyDigest,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	limited, err := s.CreateActionSlackWebhook(userCTX, m.ID, &graphqlbackend.CreateActionSlackWebhookArgs{
+		Enabled:   true,
+		URL:       "https://hooks.slack.com/services/test",
+		RateLimit: &graphqlbackend.MonitorActionRateLimitArgs{MaxNotifications: 1, WindowMinutes: 60},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A notification of the rate limited action was sent 10 minutes ago.
+	lastSent := s.Now().Add(-10 * time.Minute)
+	err = s.Exec(ctx, sqlf.Sprintf(`
+INSERT INTO cm_trigger_jobs (query, state, finished_at) VALUES (1, 'completed', %s);
+INSERT INTO cm_action_jobs (slack_webhook, trigger_event, state, finished_at) VALUES (%s, 1, 'completed', %s);
+`, lastSent, limited.Id, lastSent))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Two runs with results.
+	for i, numResults := range []int{2, 3} {
+		triggerEventID := i + 2
+		err = s.Exec(ctx, sqlf.Sprintf("INSERT INTO cm_trigger_jobs (id, query) VALUES (%s, 1)", triggerEventID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		results := make([]interface{}, numResults)
+		for j := range results {
+			results[j] = map[string]interface{}{"run": triggerEventID}
+		}
+		if err = s.LogSearchResults(ctx, results, triggerEventID); err != nil {
+			t.Fatal(err)
+		}
+		if err = s.LogSearch(ctx, testQuery, numResults, triggerEventID); err != nil {
+			t.Fatal(err)
+		}
+		if err = s.EnqueueActionWebhooksForQueryIDInt64(ctx, 1, triggerEventID); err != nil {
+			t.Fatal(err)
+		}
+		if err = s.EnqueueActionSlackWebhooksForQueryIDInt64(ctx, 1, triggerEventID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	jobs, err := s.ReadActionWebhookEvents(ctx, digest.Id, nil, &graphqlbackend.ListEventsArgs{First: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 {
+		t.Fatalf("got %d digest jobs, want 1", len(jobs))
+	}
+	nextHour := s.Now().UTC().Truncate(time.Hour).Add(time.Hour)
+	if jobs[0].ProcessAfter == nil || !jobs[0].ProcessAfter.Equal(nextHour) {
+		t.Fatalf("got process_after %v, want %v", jobs[0].ProcessAfter, nextHour)
+	}
+
+	// The digest contains the results of both runs.
+	metadata, err := s.GetActionJobMetadata(ctx, jobs[0].Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata.NumTriggerEvents != 2 || metadata.NumResults == nil || *metadata.NumResults != 5 {
+		t.Fatalf("got %d trigger events with %v results, want 2 with 5", metadata.NumTriggerEvents, metadata.NumResults)
+	}
+	var results []interface{}
+	if err := json.Unmarshal(metadata.Results, &results); err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 5 {
+		t.Fatalf("got %d results, want 5", len(results))
+	}
+
+	jobs, err = s.ReadActionSlackWebhookEvents(ctx, limited.Id, nil, &graphqlbackend.ListEventsArgs{First: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("got %d rate limited jobs, want 2", len(jobs))
+	}
+	windowEnd := lastSent.Add(time.Hour)
+	if jobs[1].ProcessAfter == nil || !jobs[1].ProcessAfter.Equal(windowEnd) {
+		t.Fatalf("got process_after %v, want %v", jobs[1].ProcessAfter, windowEnd)
+	}
+}
+
 func TestScanActionJobs(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
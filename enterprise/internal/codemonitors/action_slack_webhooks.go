@@ -0,0 +1,250 @@
+package codemonitors
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+type MonitorSlackWebhook struct {
+	Id        int64
+	Monitor   int64
+	Enabled   bool
+	URL       string
+	CreatedBy int32
+	CreatedAt time.Time
+	ChangedBy int32
+	ChangedAt time.Time
+
+	DeliveryPolicy
+}
+
+// slackWebhookHost is the host of all Slack incoming webhook URLs.
+const slackWebhookHost = "hooks.slack.com"
+
+// validateSlackWebhookURL returns an error if rawURL is not a Slack incoming
+// webhook URL.
+func validateSlackWebhookURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.Wrap(err, "invalid Slack webhook URL")
+	}
+	if u.Scheme != "https" || strings.ToLower(u.Hostname()) != slackWebhookHost {
+		return errors.Errorf("Slack webhook URL must start with https://%s/", slackWebhookHost)
+	}
+	return nil
+}
+
+const createActionSlackWebhookFmtStr = `
+INSERT INTO cm_slack_webhooks
+(monitor, enabled, url, delivery, max_notifications, notification_window, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s,%s)
+RETURNING %s;
+`
+
+func (s *Store) CreateActionSlackWebhook(ctx context.Context, monitorID int64, args *graphqlbackend.CreateActionSlackWebhookArgs) (*MonitorSlackWebhook, error) {
+	if err := validateSlackWebhookURL(args.URL); err != nil {
+		return nil, err
+	}
+	p, err := newDeliveryPolicy(args.Delivery, args.RateLimit)
+	if err != nil {
+		return nil, err
+	}
+	now := s.Now()
+	a := actor.FromContext(ctx)
+	q := sqlf.Sprintf(
+		createActionSlackWebhookFmtStr,
+		monitorID,
+		args.Enabled,
+		args.URL,
+		p.Delivery,
+		p.MaxNotifications,
+		p.NotificationWindow,
+		a.UID,
+		now,
+		a.UID,
+		now,
+		sqlf.Join(SlackWebhooksColumns, ", "),
+	)
+	return s.runSlackWebhookQuery(ctx, q)
+}
+
+const updateActionSlackWebhookFmtStr = `
+UPDATE cm_slack_webhooks
+SET enabled = %s,
+	url = %s,
+	delivery = %s,
+	max_notifications = %s,
+	notification_window = %s,
+	changed_by = %s,
+	changed_at = %s
+WHERE id = %s
+AND monitor = %s
+RETURNING %s;
+`
+
+func (s *Store) UpdateActionSlackWebhook(ctx context.Context, monitorID int64, args *graphqlbackend.EditActionSlackWebhookArgs) (*MonitorSlackWebhook, error) {
+	if args.Id == nil {
+		return nil, errors.Errorf("nil is not a valid action ID")
+	}
+	if err := validateSlackWebhookURL(args.Update.URL); err != nil {
+		return nil, err
+	}
+	var actionID int64
+	if err := relay.UnmarshalSpec(*args.Id, &actionID); err != nil {
+		return nil, err
+	}
+	p, err := newDeliveryPolicy(args.Update.Delivery, args.Update.RateLimit)
+	if err != nil {
+		return nil, err
+	}
+	now := s.Now()
+	a := actor.FromContext(ctx)
+	q := sqlf.Sprintf(
+		updateActionSlackWebhookFmtStr,
+		args.Update.Enabled,
+		args.Update.URL,
+		p.Delivery,
+		p.MaxNotifications,
+		p.NotificationWindow,
+		a.UID,
+		now,
+		actionID,
+		monitorID,
+		sqlf.Join(SlackWebhooksColumns, ", "),
+	)
+	return s.runSlackWebhookQuery(ctx, q)
+}
+
+const deleteActionSlackWebhooksFmtStr = `DELETE FROM cm_slack_webhooks WHERE id IN (%s) AND monitor = %s`
+
+func (s *Store) DeleteActionSlackWebhooksInt64(ctx context.Context, actionIDs []int64, monitorID int64) error {
+	if len(actionIDs) == 0 {
+		return nil
+	}
+	deleteIDs := make([]*sqlf.Query, 0, len(actionIDs))
+	for _, id := range actionIDs {
+		deleteIDs = append(deleteIDs, sqlf.Sprintf("%d", id))
+	}
+	return s.Exec(ctx, sqlf.Sprintf(deleteActionSlackWebhooksFmtStr, sqlf.Join(deleteIDs, ", "), monitorID))
+}
+
+const totalCountActionSlackWebhooksFmtStr = `
+SELECT COUNT(*)
+FROM cm_slack_webhooks
+WHERE monitor = %s;
+`
+
+func (s *Store) TotalCountActionSlackWebhooks(ctx context.Context, monitorID int64) (count int32, err error) {
+	err = s.QueryRow(ctx, sqlf.Sprintf(totalCountActionSlackWebhooksFmtStr, monitorID)).Scan(&count)
+	return count, err
+}
+
+const actionSlackWebhookByIDFmtStr = `
+SELECT %s
+FROM cm_slack_webhooks
+WHERE id = %s
+`
+
+func (s *Store) ActionSlackWebhookByIDInt64(ctx context.Context, slackWebhookID int64) (*MonitorSlackWebhook, error) {
+	return s.runSlackWebhookQuery(ctx, sqlf.Sprintf(actionSlackWebhookByIDFmtStr, sqlf.Join(SlackWebhooksColumns, ", "), slackWebhookID))
+}
+
+const listActionSlackWebhooksFmtStr = `
+SELECT %s
+FROM cm_slack_webhooks
+WHERE monitor = %s
+AND id > %s
+ORDER BY id ASC
+LIMIT %s;
+`
+
+// ListActionSlackWebhooks returns the Slack webhook actions of a monitor. The cursor
+// args.After is the ID of a Slack webhook action.
+func (s *Store) ListActionSlackWebhooks(ctx context.Context, monitorID int64, args *graphqlbackend.ListActionArgs) ([]*MonitorSlackWebhook, error) {
+	after, err := unmarshalAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.Query(ctx, sqlf.Sprintf(
+		listActionSlackWebhooksFmtStr,
+		sqlf.Join(SlackWebhooksColumns, ", "),
+		monitorID,
+		after,
+		args.First,
+	))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return ScanSlackWebhooks(rows)
+}
+
+func (s *Store) runSlackWebhookQuery(ctx context.Context, q *sqlf.Query) (*MonitorSlackWebhook, error) {
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ws, err := ScanSlackWebhooks(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(ws) == 0 {
+		return nil, errors.Errorf("operation failed. Query should have returned 1 row")
+	}
+	return ws[0], nil
+}
+
+var SlackWebhooksColumns = []*sqlf.Query{
+	sqlf.Sprintf("cm_slack_webhooks.id"),
+	sqlf.Sprintf("cm_slack_webhooks.monitor"),
+	sqlf.Sprintf("cm_slack_webhooks.enabled"),
+	sqlf.Sprintf("cm_slack_webhooks.url"),
+	sqlf.Sprintf("cm_slack_webhooks.delivery"),
+	sqlf.Sprintf("cm_slack_webhooks.max_notifications"),
+	sqlf.Sprintf("cm_slack_webhooks.notification_window"),
+	sqlf.Sprintf("cm_slack_webhooks.created_by"),
+	sqlf.Sprintf("cm_slack_webhooks.created_at"),
+	sqlf.Sprintf("cm_slack_webhooks.changed_by"),
+	sqlf.Sprintf("cm_slack_webhooks.changed_at"),
+}
+
+func ScanSlackWebhooks(rows *sql.Rows) (ws []*MonitorSlackWebhook, err error) {
+	for rows.Next() {
+		w := &MonitorSlackWebhook{}
+		if err = rows.Scan(
+			&w.Id,
+			&w.Monitor,
+			&w.Enabled,
+			&w.URL,
+			&w.Delivery,
+			&w.MaxNotifications,
+			&w.NotificationWindow,
+			&w.CreatedBy,
+			&w.CreatedAt,
+			&w.ChangedBy,
+			&w.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		ws = append(ws, w)
+	}
+	err = rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
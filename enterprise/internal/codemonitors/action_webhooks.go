@@ -0,0 +1,261 @@
+package codemonitors
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+type MonitorWebhook struct {
+	Id        int64
+	Monitor   int64
+	Enabled   bool
+	URL       string
+	CreatedBy int32
+	CreatedAt time.Time
+	ChangedBy int32
+	ChangedAt time.Time
+
+	DeliveryPolicy
+}
+
+// validateWebhookURL returns an error if rawURL is not a valid URL for a
+// webhook action. It must not point to a private IP address or localhost, so
+// that users cannot make requests to internal services. Host names resolving
+// to private IP addresses are refused when sending.
+func validateWebhookURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.Wrap(err, "invalid webhook URL")
+	}
+	host := strings.ToLower(u.Hostname())
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return errors.Errorf("webhook URL must start with https:// or http://")
+	}
+	if host == "" {
+		return errors.Errorf("webhook URL must have a host")
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errors.Errorf("webhook URL must not point to localhost")
+	}
+	if ip := net.ParseIP(host); ip != nil && httpcli.IsPrivateIP(ip) {
+		return errors.Errorf("webhook URL must not point to a private IP address")
+	}
+	return nil
+}
+
+const createActionWebhookFmtStr = `
+INSERT INTO cm_webhooks
+(monitor, enabled, url, delivery, max_notifications, notification_window, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s,%s)
+RETURNING %s;
+`
+
+func (s *Store) CreateActionWebhook(ctx context.Context, monitorID int64, args *graphqlbackend.CreateActionWebhookArgs) (*MonitorWebhook, error) {
+	if err := validateWebhookURL(args.URL); err != nil {
+		return nil, err
+	}
+	p, err := newDeliveryPolicy(args.Delivery, args.RateLimit)
+	if err != nil {
+		return nil, err
+	}
+	now := s.Now()
+	a := actor.FromContext(ctx)
+	q := sqlf.Sprintf(
+		createActionWebhookFmtStr,
+		monitorID,
+		args.Enabled,
+		args.URL,
+		p.Delivery,
+		p.MaxNotifications,
+		p.NotificationWindow,
+		a.UID,
+		now,
+		a.UID,
+		now,
+		sqlf.Join(WebhooksColumns, ", "),
+	)
+	return s.runWebhookQuery(ctx, q)
+}
+
+const updateActionWebhookFmtStr = `
+UPDATE cm_webhooks
+SET enabled = %s,
+	url = %s,
+	delivery = %s,
+	max_notifications = %s,
+	notification_window = %s,
+	changed_by = %s,
+	changed_at = %s
+WHERE id = %s
+AND monitor = %s
+RETURNING %s;
+`
+
+func (s *Store) UpdateActionWebhook(ctx context.Context, monitorID int64, args *graphqlbackend.EditActionWebhookArgs) (*MonitorWebhook, error) {
+	if args.Id == nil {
+		return nil, errors.Errorf("nil is not a valid action ID")
+	}
+	if err := validateWebhookURL(args.Update.URL); err != nil {
+		return nil, err
+	}
+	var actionID int64
+	if err := relay.UnmarshalSpec(*args.Id, &actionID); err != nil {
+		return nil, err
+	}
+	p, err := newDeliveryPolicy(args.Update.Delivery, args.Update.RateLimit)
+	if err != nil {
+		return nil, err
+	}
+	now := s.Now()
+	a := actor.FromContext(ctx)
+	q := sqlf.Sprintf(
+		updateActionWebhookFmtStr,
+		args.Update.Enabled,
+		args.Update.URL,
+		p.Delivery,
+		p.MaxNotifications,
+		p.NotificationWindow,
+		a.UID,
+		now,
+		actionID,
+		monitorID,
+		sqlf.Join(WebhooksColumns, ", "),
+	)
+	return s.runWebhookQuery(ctx, q)
+}
+
+const deleteActionWebhooksFmtStr = `DELETE FROM cm_webhooks WHERE id IN (%s) AND monitor = %s`
+
+func (s *Store) DeleteActionWebhooksInt64(ctx context.Context, actionIDs []int64, monitorID int64) error {
+	if len(actionIDs) == 0 {
+		return nil
+	}
+	deleteIDs := make([]*sqlf.Query, 0, len(actionIDs))
+	for _, id := range actionIDs {
+		deleteIDs = append(deleteIDs, sqlf.Sprintf("%d", id))
+	}
+	return s.Exec(ctx, sqlf.Sprintf(deleteActionWebhooksFmtStr, sqlf.Join(deleteIDs, ", "), monitorID))
+}
+
+const totalCountActionWebhooksFmtStr = `
+SELECT COUNT(*)
+FROM cm_webhooks
+WHERE monitor = %s;
+`
+
+func (s *Store) TotalCountActionWebhooks(ctx context.Context, monitorID int64) (count int32, err error) {
+	err = s.QueryRow(ctx, sqlf.Sprintf(totalCountActionWebhooksFmtStr, monitorID)).Scan(&count)
+	return count, err
+}
+
+const actionWebhookByIDFmtStr = `
+SELECT %s
+FROM cm_webhooks
+WHERE id = %s
+`
+
+func (s *Store) ActionWebhookByIDInt64(ctx context.Context, webhookID int64) (*MonitorWebhook, error) {
+	return s.runWebhookQuery(ctx, sqlf.Sprintf(actionWebhookByIDFmtStr, sqlf.Join(WebhooksColumns, ", "), webhookID))
+}
+
+const listActionWebhooksFmtStr = `
+SELECT %s
+FROM cm_webhooks
+WHERE monitor = %s
+AND id > %s
+ORDER BY id ASC
+LIMIT %s;
+`
+
+// ListActionWebhooks returns the webhook actions of a monitor. The cursor
+// args.After is the ID of a webhook action.
+func (s *Store) ListActionWebhooks(ctx context.Context, monitorID int64, args *graphqlbackend.ListActionArgs) ([]*MonitorWebhook, error) {
+	after, err := unmarshalAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.Query(ctx, sqlf.Sprintf(
+		listActionWebhooksFmtStr,
+		sqlf.Join(WebhooksColumns, ", "),
+		monitorID,
+		after,
+		args.First,
+	))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return ScanWebhooks(rows)
+}
+
+func (s *Store) runWebhookQuery(ctx context.Context, q *sqlf.Query) (*MonitorWebhook, error) {
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ws, err := ScanWebhooks(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(ws) == 0 {
+		return nil, errors.Errorf("operation failed. Query should have returned 1 row")
+	}
+	return ws[0], nil
+}
+
+var WebhooksColumns = []*sqlf.Query{
+	sqlf.Sprintf("cm_webhooks.id"),
+	sqlf.Sprintf("cm_webhooks.monitor"),
+	sqlf.Sprintf("cm_webhooks.enabled"),
+	sqlf.Sprintf("cm_webhooks.url"),
+	sqlf.Sprintf("cm_webhooks.delivery"),
+	sqlf.Sprintf("cm_webhooks.max_notifications"),
+	sqlf.Sprintf("cm_webhooks.notification_window"),
+	sqlf.Sprintf("cm_webhooks.created_by"),
+	sqlf.Sprintf("cm_webhooks.created_at"),
+	sqlf.Sprintf("cm_webhooks.changed_by"),
+	sqlf.Sprintf("cm_webhooks.changed_at"),
+}
+
+func ScanWebhooks(rows *sql.Rows) (ws []*MonitorWebhook, err error) {
+	for rows.Next() {
+		w := &MonitorWebhook{}
+		if err = rows.Scan(
+			&w.Id,
+			&w.Monitor,
+			&w.Enabled,
+			&w.URL,
+			&w.Delivery,
+			&w.MaxNotifications,
+			&w.NotificationWindow,
+			&w.CreatedBy,
+			&w.CreatedAt,
+			&w.ChangedBy,
+			&w.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		ws = append(ws, w)
+	}
+	err = rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
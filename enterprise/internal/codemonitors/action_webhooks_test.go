@@ -0,0 +1,44 @@
+package codemonitors
+
+import "testing"
+
+func TestValidateWebhookURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{url: "https://example.com/webhook"},
+		{url: "http://example.com:8080/webhook"},
+		{url: "ftp://example.com/webhook", wantErr: true},
+		{url: "example.com/webhook", wantErr: true},
+		{url: "http://localhost:3080/.api/graphql", wantErr: true},
+		{url: "http://127.0.0.1/", wantErr: true},
+		{url: "http://10.0.0.1/", wantErr: true},
+		{url: "http://169.254.169.254/latest/meta-data", wantErr: true},
+		{url: "http://[::1]:80/", wantErr: true},
+	}
+	for _, tt := range tests {
+		err := validateWebhookURL(tt.url)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateWebhookURL(%q): got error %v, want error %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateSlackWebhookURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{url: "https://hooks.slack.com/services/T0/B0/x"},
+		{url: "http://hooks.slack.com/services/T0/B0/x", wantErr: true},
+		{url: "https://example.com/services/T0/B0/x", wantErr: true},
+		{url: "https://hooks.slack.com.example.com/services", wantErr: true},
+	}
+	for _, tt := range tests {
+		err := validateSlackWebhookURL(tt.url)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateSlackWebhookURL(%q): got error %v, want error %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
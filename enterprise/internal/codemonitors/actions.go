@@ -3,18 +3,73 @@ package codemonitors
 import (
 	"context"
 
+	"github.com/cockroachdb/errors"
+
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 )
 
+// DeliveryPolicy controls when an action sends its notifications. It is stored
+// with the action and applied when action jobs are enqueued.
+type DeliveryPolicy struct {
+	// Delivery is one of IMMEDIATE, HOURLY_DIGEST and DAILY_DIGEST.
+	Delivery string
+
+	// An action sends at most MaxNotifications notifications within
+	// NotificationWindow minutes. Both are nil if the notifications of an
+	// action are not rate limited.
+	MaxNotifications   *int32
+	NotificationWindow *int32
+}
+
+const (
+	DeliveryImmediate    = "IMMEDIATE"
+	DeliveryHourlyDigest = "HOURLY_DIGEST"
+	DeliveryDailyDigest  = "DAILY_DIGEST"
+)
+
+func newDeliveryPolicy(delivery string, rateLimit *graphqlbackend.MonitorActionRateLimitArgs) (*DeliveryPolicy, error) {
+	p := &DeliveryPolicy{Delivery: delivery}
+	switch delivery {
+	case "":
+		p.Delivery = DeliveryImmediate
+	case DeliveryImmediate, DeliveryHourlyDigest, DeliveryDailyDigest:
+	default:
+		return nil, errors.Errorf("invalid delivery %q", delivery)
+	}
+	if rateLimit != nil {
+		if rateLimit.MaxNotifications < 1 || rateLimit.WindowMinutes < 1 {
+			return nil, errors.Errorf("maxNotifications and windowMinutes of a rate limit must be positive")
+		}
+		p.MaxNotifications = &rateLimit.MaxNotifications
+		p.NotificationWindow = &rateLimit.WindowMinutes
+	}
+	return p, nil
+}
+
 func (s *Store) CreateActions(ctx context.Context, args []*graphqlbackend.CreateActionArgs, monitorID int64) (err error) {
 	for _, a := range args {
-		e, err := s.CreateActionEmail(ctx, monitorID, a)
-		if err != nil {
-			return err
-		}
-		err = s.CreateRecipients(ctx, a.Email.Recipients, e.Id)
-		if err != nil {
-			return err
+		switch {
+		case a.Email != nil:
+			e, err := s.CreateActionEmail(ctx, monitorID, a)
+			if err != nil {
+				return err
+			}
+			err = s.CreateRecipients(ctx, a.Email.Recipients, e.Id)
+			if err != nil {
+				return err
+			}
+		case a.Webhook != nil:
+			_, err = s.CreateActionWebhook(ctx, monitorID, a.Webhook)
+			if err != nil {
+				return err
+			}
+		case a.SlackWebhook != nil:
+			_, err = s.CreateActionSlackWebhook(ctx, monitorID, a.SlackWebhook)
+			if err != nil {
+				return err
+			}
+		default:
+			return errors.Errorf("action must be one of email, webhook or Slack webhook")
 		}
 	}
 	return err
@@ -0,0 +1,51 @@
+package codemonitors
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+)
+
+func TestNewDeliveryPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		delivery  string
+		rateLimit *graphqlbackend.MonitorActionRateLimitArgs
+		want      string
+		wantErr   bool
+	}{
+		{name: "default", want: DeliveryImmediate},
+		{name: "digest", delivery: DeliveryDailyDigest, want: DeliveryDailyDigest},
+		{name: "unknown delivery", delivery: "WEEKLY_DIGEST", wantErr: true},
+		{
+			name:      "rate limit",
+			rateLimit: &graphqlbackend.MonitorActionRateLimitArgs{MaxNotifications: 3, WindowMinutes: 60},
+			want:      DeliveryImmediate,
+		},
+		{
+			name:      "empty window",
+			rateLimit: &graphqlbackend.MonitorActionRateLimitArgs{MaxNotifications: 3},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := newDeliveryPolicy(tt.delivery, tt.rateLimit)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Delivery != tt.want {
+				t.Fatalf("got delivery %q, want %q", p.Delivery, tt.want)
+			}
+			if (tt.rateLimit == nil) != (p.MaxNotifications == nil) {
+				t.Fatalf("got max notifications %v", p.MaxNotifications)
+			}
+		})
+	}
+}
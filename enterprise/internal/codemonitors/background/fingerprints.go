@@ -0,0 +1,196 @@
+package background
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+const (
+	// maxResults is the number of results a run of a query which does not
+	// search commits or diffs asks for at most.
+	maxResults = 1000
+
+	// maxFingerprints is the number of result fingerprints stored for a
+	// query at most. It leaves room for the fingerprints an incomplete run
+	// keeps from the previous run.
+	maxFingerprints = 10 * maxResults
+)
+
+// isCommitQuery returns true if queryString searches commits or diffs. New
+// results of those queries are found with an after: filter. New results of all
+// other queries, e.g. content or symbol searches, are found by comparing the
+// fingerprints of the results of consecutive runs.
+func isCommitQuery(queryString string) bool {
+	nodes, err := query.Parse(queryString, query.SearchTypeLiteral)
+	if err != nil {
+		// Keep the behavior monitors had before they supported other
+		// queries. The search will report the error.
+		return true
+	}
+	commit := false
+	query.VisitField(nodes, query.FieldType, func(value string, negated bool, _ query.Annotation) {
+		if !negated && (value == "commit" || value == "diff") {
+			commit = true
+		}
+	})
+	return commit
+}
+
+// newQueryWithCount returns queryString with a count of maxResults, unless it
+// already sets a lower count. Results are compared with the results of the last
+// run, so the count is set explicitly instead of relying on the default limit,
+// and bounded so that broad queries don't search and store everything.
+func newQueryWithCount(queryString string) string {
+	nodes, err := query.Parse(queryString, query.SearchTypeLiteral)
+	if err != nil {
+		return queryString
+	}
+	count := -1
+	query.VisitField(nodes, query.FieldCount, func(value string, _ bool, _ query.Annotation) {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			// count:all
+			n = maxResults + 1
+		}
+		count = n
+	})
+	switch {
+	case count < 0:
+		return fmt.Sprintf("%s count:%d", queryString, maxResults)
+	case count <= maxResults:
+		return queryString
+	default:
+		return query.StringHuman(query.OverrideField(nodes, query.FieldCount, strconv.Itoa(maxResults)))
+	}
+}
+
+// diffResults returns the parts of results whose fingerprints are not in
+// previous, and the fingerprints of all of results.
+//
+// The lines and symbols of a file match are fingerprinted separately, so a
+// file match with one new line is reported with only that line. Line numbers
+// are not part of a fingerprint, so a line which merely moved is not new.
+func diffResults(results []interface{}, previous []string) (added []interface{}, fingerprints []string) {
+	seen := make(map[string]struct{}, len(previous))
+	for _, fp := range previous {
+		seen[fp] = struct{}{}
+	}
+	isNew := func(fp string) bool {
+		fingerprints = append(fingerprints, fp)
+		_, ok := seen[fp]
+		return !ok
+	}
+
+	for _, r := range results {
+		m, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch stringField(m, "__typename") {
+		case "FileMatch":
+			if fm := diffFileMatch(m, isNew); fm != nil {
+				added = append(added, fm)
+			}
+		case "Repository":
+			if isNew(fingerprint("repo", stringField(m, "name"))) {
+				added = append(added, m)
+			}
+		default:
+			b, _ := json.Marshal(m)
+			if isNew(fingerprint("result", string(b))) {
+				added = append(added, m)
+			}
+		}
+	}
+	return added, fingerprints
+}
+
+// diffFileMatch returns a copy of the file match m with only its new lines and
+// symbols, or nil if nothing in m is new.
+func diffFileMatch(m map[string]interface{}, isNew func(string) bool) map[string]interface{} {
+	repo := stringField(m, "repository", "name")
+	path := stringField(m, "file", "path")
+
+	lineMatches, _ := m["lineMatches"].([]interface{})
+	symbols, _ := m["symbols"].([]interface{})
+	if len(lineMatches) == 0 && len(symbols) == 0 {
+		// A path match.
+		if isNew(fingerprint("path", repo, path)) {
+			return m
+		}
+		return nil
+	}
+
+	// Identical lines are told apart by their number of occurrences, so that
+	// another copy of an existing line is new.
+	occurrences := map[string]int{}
+	var newLineMatches []interface{}
+	for _, l := range lineMatches {
+		lm, ok := l.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		preview := stringField(lm, "preview")
+		occurrences[preview]++
+		if isNew(fingerprint("line", repo, path, preview, fmt.Sprint(occurrences[preview]))) {
+			newLineMatches = append(newLineMatches, lm)
+		}
+	}
+
+	var newSymbols []interface{}
+	for _, s := range symbols {
+		sm, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if isNew(fingerprint("symbol", repo, path, stringField(sm, "kind"), stringField(sm, "containerName"), stringField(sm, "name"))) {
+			newSymbols = append(newSymbols, sm)
+		}
+	}
+
+	if len(newLineMatches) == 0 && len(newSymbols) == 0 {
+		return nil
+	}
+	fm := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		fm[k] = v
+	}
+	fm["lineMatches"] = orEmpty(newLineMatches)
+	fm["symbols"] = orEmpty(newSymbols)
+	return fm
+}
+
+func fingerprint(parts ...string) string {
+	h := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(h[:16])
+}
+
+// stringField returns the string at path in the decoded JSON object m, or ""
+// if there is none.
+func stringField(m map[string]interface{}, path ...string) string {
+	for i, key := range path {
+		if i == len(path)-1 {
+			s, _ := m[key].(string)
+			return s
+		}
+		next, ok := m[key].(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		m = next
+	}
+	return ""
+}
+
+func orEmpty(s []interface{}) []interface{} {
+	if s == nil {
+		return []interface{}{}
+	}
+	return s
+}
@@ -0,0 +1,103 @@
+package background
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIsCommitQuery(t *testing.T) {
+	tests := map[string]bool{
+		"repo:foo type:diff os.Exit":   true,
+		"repo:foo type:commit fix":     true,
+		"repo:foo os.Exit":             false,
+		"repo:foo type:symbol Handler": false,
+		"repo:foo -type:diff os.Exit":  false,
+	}
+	for q, want := range tests {
+		if got := isCommitQuery(q); got != want {
+			t.Errorf("isCommitQuery(%q) = %t, want %t", q, got, want)
+		}
+	}
+}
+
+func TestNewQueryWithCount(t *testing.T) {
+	tests := map[string]string{
+		"TODO(security)":            "TODO(security) count:1000",
+		"TODO(security) count:50":   "TODO(security) count:50",
+		"TODO(security) count:5000": "count:1000 TODO(security)",
+		"TODO(security) count:all":  "count:1000 TODO(security)",
+	}
+	for q, want := range tests {
+		if got := newQueryWithCount(q); got != want {
+			t.Errorf("newQueryWithCount(%q) = %q, want %q", q, got, want)
+		}
+	}
+}
+
+func TestDiffResults(t *testing.T) {
+	decode := func(s string) []interface{} {
+		var v []interface{}
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	first := decode(`[
+		{"__typename": "FileMatch", "repository": {"name": "a"}, "file": {"path": "main.go"},
+		 "lineMatches": [{"preview": "os.Exit(1)", "lineNumber": 10}], "symbols": []},
+		{"__typename": "Repository", "name": "b"}
+	]`)
+	_, baseline := diffResults(first, nil)
+
+	t.Run("unchanged", func(t *testing.T) {
+		added, _ := diffResults(first, baseline)
+		if len(added) != 0 {
+			t.Fatalf("expected no new results, got %v", added)
+		}
+	})
+
+	t.Run("moved line", func(t *testing.T) {
+		added, _ := diffResults(decode(`[
+			{"__typename": "FileMatch", "repository": {"name": "a"}, "file": {"path": "main.go"},
+			 "lineMatches": [{"preview": "os.Exit(1)", "lineNumber": 42}], "symbols": []}
+		]`), baseline)
+		if len(added) != 0 {
+			t.Fatalf("expected no new results, got %v", added)
+		}
+	})
+
+	t.Run("additions", func(t *testing.T) {
+		added, fingerprints := diffResults(decode(`[
+			{"__typename": "FileMatch", "repository": {"name": "a"}, "file": {"path": "main.go"},
+			 "lineMatches": [
+				{"preview": "os.Exit(1)", "lineNumber": 10},
+				{"preview": "os.Exit(1)", "lineNumber": 20},
+				{"preview": "os.Exit(2)", "lineNumber": 30}
+			 ],
+			 "symbols": []},
+			{"__typename": "FileMatch", "repository": {"name": "c"}, "file": {"path": "server.go"},
+			 "lineMatches": [], "symbols": [{"name": "Exit", "containerName": "", "kind": "FUNCTION"}]},
+			{"__typename": "Repository", "name": "b"}
+		]`), baseline)
+
+		want := decode(`[
+			{"__typename": "FileMatch", "repository": {"name": "a"}, "file": {"path": "main.go"},
+			 "lineMatches": [
+				{"preview": "os.Exit(1)", "lineNumber": 20},
+				{"preview": "os.Exit(2)", "lineNumber": 30}
+			 ],
+			 "symbols": []},
+			{"__typename": "FileMatch", "repository": {"name": "c"}, "file": {"path": "server.go"},
+			 "lineMatches": [], "symbols": [{"name": "Exit", "containerName": "", "kind": "FUNCTION"}]}
+		]`)
+		if diff := cmp.Diff(want, added); diff != "" {
+			t.Fatalf("unexpected new results (-want +got):\n%s", diff)
+		}
+		if len(fingerprints) != 5 {
+			t.Fatalf("got %d fingerprints, want 5", len(fingerprints))
+		}
+	})
+}
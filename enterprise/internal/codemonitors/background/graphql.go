@@ -32,13 +32,27 @@ const gqlSearchQuery = `query Search(
 			timedout { name }
 			results {
 				__typename
+				... on Repository {
+					name
+				}
 				... on FileMatch {
+					repository {
+						name
+					}
+					file {
+						path
+					}
 					limitHit
 					lineMatches {
 						preview
 						lineNumber
 						offsetAndLengths
 					}
+					symbols {
+						name
+						containerName
+						kind
+					}
 				}
 				... on CommitSearchResult {
 					refs {
@@ -111,6 +125,7 @@ type gqlSearchResponse struct {
 		Search struct {
 			Results struct {
 				ApproximateResultCount string
+				LimitHit               bool
 				Cloning                []*api.Repo
 				Timedout               []*api.Repo
 				Results                []interface{}
@@ -0,0 +1,143 @@
+package background
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+
+	cm "github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/email"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+const (
+	utmSourceWebhook = "code-monitoring-webhook"
+	utmSourceSlack   = "code-monitoring-slack"
+)
+
+// webhookPayload is the JSON body of the POST request sent by webhook actions.
+type webhookPayload struct {
+	MonitorDescription string          `json:"monitorDescription"`
+	MonitorURL         string          `json:"monitorURL"`
+	Query              string          `json:"query"`
+	SearchURL          string          `json:"searchURL"`
+	NumResults         int             `json:"numResults"`
+	Results            json.RawMessage `json:"results"`
+}
+
+func newWebhookPayload(ctx context.Context, m *cm.ActionJobMetadata) (*webhookPayload, error) {
+	searchURL, err := email.GetSearchURL(ctx, m.Query, utmSourceWebhook)
+	if err != nil {
+		return nil, err
+	}
+	monitorURL, err := email.GetCodeMonitorURL(ctx, m.MonitorID, utmSourceWebhook)
+	if err != nil {
+		return nil, err
+	}
+	results := m.Results
+	if results == nil {
+		results = json.RawMessage("[]")
+	}
+	return &webhookPayload{
+		MonitorDescription: m.Description,
+		MonitorURL:         monitorURL,
+		Query:              m.Query,
+		SearchURL:          searchURL,
+		NumResults:         zeroOrVal(m.NumResults),
+		Results:            results,
+	}, nil
+}
+
+// slackPayload is the body of a message posted to a Slack incoming webhook.
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+func newSlackPayload(ctx context.Context, m *cm.ActionJobMetadata) (*slackPayload, error) {
+	searchURL, err := email.GetSearchURL(ctx, m.Query, utmSourceSlack)
+	if err != nil {
+		return nil, err
+	}
+	monitorURL, err := email.GetCodeMonitorURL(ctx, m.MonitorID, utmSourceSlack)
+	if err != nil {
+		return nil, err
+	}
+	numResults := zeroOrVal(m.NumResults)
+	results := "results"
+	if numResults == 1 {
+		results = "result"
+	}
+	return &slackPayload{
+		Text: fmt.Sprintf(
+			"Code monitor *%s* found %d new search %s.\n<%s|View search results> | <%s|Edit code monitor>",
+			escapeSlackText(m.Description), numResults, results, searchURL, monitorURL,
+		),
+	}, nil
+}
+
+// escapeSlackText escapes the control characters of Slack's mrkdwn format.
+func escapeSlackText(s string) string {
+	var b bytes.Buffer
+	for _, r := range s {
+		switch r {
+		case '&':
+			b.WriteString("&amp;")
+		case '<':
+			b.WriteString("&lt;")
+		case '>':
+			b.WriteString("&gt;")
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// webhookDoer is used to send webhooks. The URLs of webhooks are supplied by
+// users, so it refuses to connect to internal services.
+var webhookDoer, _ = httpcli.ExternalClientFactory.Doer(httpcli.DenyPrivateIPsOpt)
+
+// postJSON POSTs payload as JSON to url. Any response with a non-2xx status is
+// an error.
+func postJSON(ctx context.Context, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "Marshal")
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "NewRequest")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := webhookDoer.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrap(err, "Post")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("unexpected status code %d: %s", resp.StatusCode, b)
+	}
+	return nil
+}
+
+func sendWebhook(ctx context.Context, w *cm.MonitorWebhook, m *cm.ActionJobMetadata) error {
+	payload, err := newWebhookPayload(ctx, m)
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, w.URL, payload)
+}
+
+func sendSlackWebhook(ctx context.Context, w *cm.MonitorSlackWebhook, m *cm.ActionJobMetadata) error {
+	payload, err := newSlackPayload(ctx, m)
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, w.URL, payload)
+}
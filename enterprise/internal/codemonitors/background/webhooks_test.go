@@ -0,0 +1,120 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	cm "github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/email"
+)
+
+func TestSendWebhooks(t *testing.T) {
+	email.MockExternalURL = func() *url.URL {
+		externalURL, _ := url.Parse("https://www.sourcegraph.com")
+		return externalURL
+	}
+	defer func() { email.MockExternalURL = nil }()
+
+	// The test servers listen on localhost, which webhookDoer refuses to
+	// connect to.
+	doer := webhookDoer
+	webhookDoer = http.DefaultClient
+	defer func() { webhookDoer = doer }()
+
+	numResults := 2
+	metadata := &cm.ActionJobMetadata{
+		Description: "leaked <secrets>",
+		MonitorID:   1,
+		NumResults:  &numResults,
+		Query:       "secret type:diff",
+		Results:     json.RawMessage(`[{"__typename":"CommitSearchResult"},{"__typename":"CommitSearchResult"}]`),
+	}
+
+	var (
+		gotContentType string
+		gotBody        []byte
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotContentType = r.Header.Get("Content-Type")
+		gotBody, _ = io.ReadAll(r.Body)
+	}))
+	defer ts.Close()
+
+	t.Run("webhook", func(t *testing.T) {
+		err := sendWebhook(context.Background(), &cm.MonitorWebhook{URL: ts.URL}, metadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gotContentType != "application/json" {
+			t.Fatalf("got content type %q", gotContentType)
+		}
+		var got map[string]interface{}
+		if err := json.Unmarshal(gotBody, &got); err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]interface{}{
+			"monitorDescription": "leaked <secrets>",
+			"monitorURL":         "https://www.sourcegraph.com/code-monitoring/Q29kZU1vbml0b3I6MQ==?utm_source=code-monitoring-webhook",
+			"query":              "secret type:diff",
+			"searchURL":          "https://www.sourcegraph.com/search?q=secret+type%3Adiff&utm_source=code-monitoring-webhook",
+			"numResults":         float64(2),
+			"results": []interface{}{
+				map[string]interface{}{"__typename": "CommitSearchResult"},
+				map[string]interface{}{"__typename": "CommitSearchResult"},
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("unexpected payload (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("slack", func(t *testing.T) {
+		err := sendSlackWebhook(context.Background(), &cm.MonitorSlackWebhook{URL: ts.URL}, metadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got slackPayload
+		if err := json.Unmarshal(gotBody, &got); err != nil {
+			t.Fatal(err)
+		}
+		want := "Code monitor *leaked &lt;secrets&gt;* found 2 new search results.\n" +
+			"<https://www.sourcegraph.com/search?q=secret+type%3Adiff&utm_source=code-monitoring-slack|View search results> | " +
+			"<https://www.sourcegraph.com/code-monitoring/Q29kZU1vbml0b3I6MQ==?utm_source=code-monitoring-slack|Edit code monitor>"
+		if got.Text != want {
+			t.Fatalf("got text %q, want %q", got.Text, want)
+		}
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "invalid_token", http.StatusForbidden)
+		}))
+		defer ts.Close()
+
+		err := sendSlackWebhook(context.Background(), &cm.MonitorSlackWebhook{URL: ts.URL}, metadata)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("private IP", func(t *testing.T) {
+		webhookDoer = doer
+		defer func() { webhookDoer = http.DefaultClient }()
+
+		gotBody = nil
+		err := sendWebhook(context.Background(), &cm.MonitorWebhook{URL: ts.URL}, metadata)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if gotBody != nil {
+			t.Fatal("webhook was sent to a private IP address")
+		}
+	})
+}
@@ -152,7 +152,13 @@ func (r *queryRunner) Handle(ctx context.Context, record workerutil.Record) (err
 	if err != nil {
 		return err
 	}
-	newQuery := newQueryWithAfterFilter(q)
+	commitQuery := isCommitQuery(q.QueryString)
+	var newQuery string
+	if commitQuery {
+		newQuery = newQueryWithAfterFilter(q)
+	} else {
+		newQuery = newQueryWithCount(q.QueryString)
+	}
 
 	// Search.
 	var results *gqlSearchResponse
@@ -160,18 +166,53 @@ func (r *queryRunner) Handle(ctx context.Context, record workerutil.Record) (err
 	if err != nil {
 		return err
 	}
-	var numResults int
+	var newResults []interface{}
 	if results != nil {
-		numResults = len(results.Data.Search.Results.Results)
+		newResults = results.Data.Search.Results.Results
+	}
+	if !commitQuery {
+		var limitHit bool
+		newResults, limitHit, err = diffWithLastRun(ctx, s, q.Id, results)
+		if err != nil {
+			return err
+		}
+		if limitHit {
+			log15.Warn("code monitor query hit the result limit", "query", q.Id, "limit", maxResults)
+			err = s.LogLimitHit(ctx, record.RecordID())
+			if err != nil {
+				return errors.Errorf("LogLimitHit: %w", err)
+			}
+		}
 	}
+	numResults := len(newResults)
 	if numResults > 0 {
 		err := s.EnqueueActionEmailsForQueryIDInt64(ctx, q.Id, record.RecordID())
 		if err != nil {
 			return errors.Errorf("store.EnqueueActionEmailsForQueryIDInt64: %w", err)
 		}
+		err = s.EnqueueActionWebhooksForQueryIDInt64(ctx, q.Id, record.RecordID())
+		if err != nil {
+			return errors.Errorf("store.EnqueueActionWebhooksForQueryIDInt64: %w", err)
+		}
+		err = s.EnqueueActionSlackWebhooksForQueryIDInt64(ctx, q.Id, record.RecordID())
+		if err != nil {
+			return errors.Errorf("store.EnqueueActionSlackWebhooksForQueryIDInt64: %w", err)
+		}
+		// Webhook and Slack actions send the results as payload.
+		err = s.LogSearchResults(ctx, newResults, record.RecordID())
+		if err != nil {
+			return errors.Errorf("LogSearchResults: %w", err)
+		}
 	}
 	// Log next_run and latest_result to table cm_queries.
-	newLatestResult := latestResultTime(q.LatestResult, results, err)
+	var newLatestResult time.Time
+	if commitQuery {
+		newLatestResult = latestResultTime(q.LatestResult, results, err)
+	} else {
+		// Results of other queries have no time. latest_result is the time
+		// of the last run instead.
+		newLatestResult = s.Now()
+	}
 	err = s.SetTriggerQueryNextRun(ctx, q.Id, s.Clock()().Add(5*time.Minute), newLatestResult.UTC())
 	if err != nil {
 		return err
@@ -184,6 +225,62 @@ func (r *queryRunner) Handle(ctx context.Context, record workerutil.Record) (err
 	return nil
 }
 
+// diffWithLastRun returns the results which were not found by the last run of
+// the trigger query and stores the fingerprints of results as the baseline of
+// the next run. The first run only stores the baseline and returns no results.
+//
+// limitHit is true if the search hit its result limit, or if fingerprints had
+// to be dropped to store at most maxFingerprints.
+func diffWithLastRun(ctx context.Context, s *cm.Store, queryID int64, results *gqlSearchResponse) (added []interface{}, limitHit bool, err error) {
+	previous, err := s.ResultFingerprints(ctx, queryID)
+	if err != nil {
+		return nil, false, errors.Errorf("store.ResultFingerprints: %w", err)
+	}
+	var matches []interface{}
+	if results != nil {
+		matches = results.Data.Search.Results.Results
+	}
+	added, fingerprints := diffResults(matches, previous)
+	limitHit = results != nil && results.Data.Search.Results.LimitHit
+
+	if results != nil && !isComplete(results) {
+		// Results which are missing from an incomplete result set are not
+		// gone, so we keep their fingerprints. Otherwise they would be
+		// reported as new once they are found again.
+		found := make(map[string]struct{}, len(fingerprints))
+		for _, fp := range fingerprints {
+			found[fp] = struct{}{}
+		}
+		for _, fp := range previous {
+			if _, ok := found[fp]; !ok {
+				fingerprints = append(fingerprints, fp)
+			}
+		}
+	}
+	if len(fingerprints) > maxFingerprints {
+		// The fingerprints of this run come first, so the ones kept from
+		// the previous run are dropped first.
+		fingerprints = fingerprints[:maxFingerprints]
+		limitHit = true
+	}
+	err = s.SetResultFingerprints(ctx, queryID, fingerprints)
+	if err != nil {
+		return nil, false, errors.Errorf("store.SetResultFingerprints: %w", err)
+	}
+
+	if previous == nil {
+		return nil, limitHit, nil
+	}
+	return added, limitHit, nil
+}
+
+// isComplete returns true if the search searched all repositories in scope and
+// returned all results.
+func isComplete(v *gqlSearchResponse) bool {
+	r := v.Data.Search.Results
+	return !r.LimitHit && len(r.Cloning) == 0 && len(r.Timedout) == 0
+}
+
 type actionRunner struct {
 	*cm.Store
 }
@@ -202,36 +299,48 @@ func (r *actionRunner) Handle(ctx context.Context, record workerutil.Record) (er
 	}
 	defer func() { err = s.Done(err) }()
 
-	var (
-		j    *cm.ActionJob
-		m    *cm.ActionJobMetadata
-		e    *cm.MonitorEmail
-		recs []*cm.Recipient
-		data *email.TemplateDataNewSearchResults
-	)
-
-	var ok bool
-	j, ok = record.(*cm.ActionJob)
+	j, ok := record.(*cm.ActionJob)
 	if !ok {
 		return errors.Errorf("type assertion failed")
 	}
 
-	m, err = s.GetActionJobMetadata(ctx, record.RecordID())
+	m, err := s.GetActionJobMetadata(ctx, record.RecordID())
 	if err != nil {
 		return errors.Errorf("store.GetActionJobMetadata: %w", err)
 	}
 
-	e, err = s.ActionEmailByIDInt64(ctx, j.Email)
+	switch {
+	case j.Email != nil:
+		return sendEmails(ctx, s, *j.Email, m)
+	case j.Webhook != nil:
+		w, err := s.ActionWebhookByIDInt64(ctx, *j.Webhook)
+		if err != nil {
+			return errors.Errorf("store.ActionWebhookByIDInt64: %w", err)
+		}
+		return sendWebhook(ctx, w, m)
+	case j.SlackWebhook != nil:
+		w, err := s.ActionSlackWebhookByIDInt64(ctx, *j.SlackWebhook)
+		if err != nil {
+			return errors.Errorf("store.ActionSlackWebhookByIDInt64: %w", err)
+		}
+		return sendSlackWebhook(ctx, w, m)
+	default:
+		return errors.Errorf("action job %d has no action", j.Id)
+	}
+}
+
+func sendEmails(ctx context.Context, s *cm.Store, emailID int64, m *cm.ActionJobMetadata) error {
+	e, err := s.ActionEmailByIDInt64(ctx, emailID)
 	if err != nil {
 		return errors.Errorf("store.ActionEmailByIDInt64: %w", err)
 	}
 
-	recs, err = s.AllRecipientsForEmailIDInt64(ctx, j.Email)
+	recs, err := s.AllRecipientsForEmailIDInt64(ctx, emailID)
 	if err != nil {
 		return errors.Errorf("store.AllRecipientsForEmailIDInt64: %w", err)
 	}
 
-	data, err = email.NewTemplateDataForNewSearchResults(ctx, m.Description, m.Query, e, zeroOrVal(m.NumResults))
+	data, err := email.NewTemplateDataForNewSearchResults(ctx, m.Description, m.Query, e, zeroOrVal(m.NumResults))
 	if err != nil {
 		return errors.Errorf("email.NewTemplateDataForNewSearchResults: %w", err)
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/graph-gophers/graphql-go/relay"
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
@@ -77,7 +78,8 @@ func (s *Store) triggerQueryByIDInt64(ctx context.Context, queryID int64) (*Moni
 const resetTriggerQueryTimestamps = `
 UPDATE cm_queries
 SET latest_result = null,
-    next_run = %s
+    next_run = %s,
+    result_fingerprints = '{}'
 WHERE id = %s;
 `
 
@@ -114,7 +116,8 @@ UPDATE cm_queries
 SET query = %s,
 	changed_by = %s,
 	changed_at = %s,
-	latest_result = %s
+	latest_result = %s,
+	result_fingerprints = CASE WHEN query = %s THEN result_fingerprints ELSE NULL END
 WHERE id = %s
 AND monitor = %s
 RETURNING %s;
@@ -142,6 +145,7 @@ func (s *Store) updateTriggerQueryQuery(ctx context.Context, args *graphqlbacken
 		a.UID,
 		now,
 		now,
+		args.Trigger.Update.Query,
 		triggerID,
 		monitorID,
 		sqlf.Join(queryColumns, ", "),
@@ -192,6 +196,40 @@ func (s *Store) SetTriggerQueryNextRun(ctx context.Context, triggerQueryID int64
 	return s.Exec(ctx, q)
 }
 
+const resultFingerprintsFmtStr = `
+SELECT result_fingerprints
+FROM cm_queries
+WHERE id = %s
+`
+
+// ResultFingerprints returns the fingerprints of the results of the last run of
+// a trigger query. It returns nil if no baseline has been stored yet, e.g.
+// before the first run or after the query changed.
+func (s *Store) ResultFingerprints(ctx context.Context, triggerQueryID int64) ([]string, error) {
+	var fingerprints []string
+	err := s.QueryRow(ctx, sqlf.Sprintf(resultFingerprintsFmtStr, triggerQueryID)).Scan(pq.Array(&fingerprints))
+	if err != nil {
+		return nil, err
+	}
+	return fingerprints, nil
+}
+
+const setResultFingerprintsFmtStr = `
+UPDATE cm_queries
+SET result_fingerprints = %s
+WHERE id = %s
+`
+
+// SetResultFingerprints stores the fingerprints of the results of the latest
+// run of a trigger query.
+func (s *Store) SetResultFingerprints(ctx context.Context, triggerQueryID int64, fingerprints []string) error {
+	if fingerprints == nil {
+		// A nil slice is stored as NULL, which means there is no baseline.
+		fingerprints = []string{}
+	}
+	return s.Exec(ctx, sqlf.Sprintf(setResultFingerprintsFmtStr, pq.Array(fingerprints), triggerQueryID))
+}
+
 func scanTriggerQueries(rows *sql.Rows) (ms []*MonitorQuery, err error) {
 	for rows.Next() {
 		m := &MonitorQuery{}
@@ -3,6 +3,7 @@ package codemonitors
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/graph-gophers/graphql-go"
@@ -50,6 +51,34 @@ func (s *Store) LogSearch(ctx context.Context, queryString string, numResults in
 	return s.Store.Exec(ctx, sqlf.Sprintf(logSearchFmtStr, queryString, numResults > 0, numResults, recordID))
 }
 
+const logLimitHitFmtStr = `
+UPDATE cm_trigger_jobs
+SET limit_hit = TRUE
+WHERE id = %s
+`
+
+// LogLimitHit marks a run as having hit the result or fingerprint limit of
+// code monitors.
+func (s *Store) LogLimitHit(ctx context.Context, recordID int) error {
+	return s.Store.Exec(ctx, sqlf.Sprintf(logLimitHitFmtStr, recordID))
+}
+
+const logSearchResultsFmtStr = `
+UPDATE cm_trigger_jobs
+SET search_results = %s
+WHERE id = %s
+`
+
+// LogSearchResults stores the search results of a run. Webhook and Slack
+// actions send them as payload.
+func (s *Store) LogSearchResults(ctx context.Context, results []interface{}, recordID int) error {
+	b, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	return s.Store.Exec(ctx, sqlf.Sprintf(logSearchResultsFmtStr, string(b), recordID))
+}
+
 const deleteObsoleteJobLogsFmtStr = `
 DELETE FROM cm_trigger_jobs
 WHERE results IS NOT TRUE
@@ -74,7 +103,7 @@ func (s *Store) DeleteOldJobLogs(ctx context.Context, retentionInDays int) error
 }
 
 const getEventsForQueryIDInt64FmtStr = `
-SELECT id, query, query_string, results, num_results, state, failure_message, started_at, finished_at, process_after, num_resets, num_failures, log_contents
+SELECT id, query, query_string, results, num_results, limit_hit, state, failure_message, started_at, finished_at, process_after, num_resets, num_failures, log_contents
 FROM cm_trigger_jobs
 WHERE ((state = 'completed' AND results IS TRUE) OR (state != 'completed'))
 AND query = %s
@@ -129,6 +158,9 @@ type TriggerJobs struct {
 	Results    *bool
 	NumResults *int
 
+	// Whether the run hit the result or fingerprint limit.
+	LimitHit bool
+
 	// Fields demanded for any dbworker.
 	State          string
 	FailureMessage *string
@@ -162,6 +194,7 @@ func scanTriggerJobs(rows *sql.Rows, err error) ([]*TriggerJobs, error) {
 			&m.QueryString,
 			&m.Results,
 			&m.NumResults,
+			&m.LimitHit,
 			&m.State,
 			&m.FailureMessage,
 			&m.StartedAt,
@@ -191,6 +224,7 @@ var TriggerJobsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_trigger_jobs.query_string"),
 	sqlf.Sprintf("cm_trigger_jobs.results"),
 	sqlf.Sprintf("cm_trigger_jobs.num_results"),
+	sqlf.Sprintf("cm_trigger_jobs.limit_hit"),
 	sqlf.Sprintf("cm_trigger_jobs.state"),
 	sqlf.Sprintf("cm_trigger_jobs.failure_message"),
 	sqlf.Sprintf("cm_trigger_jobs.started_at"),
@@ -4,6 +4,7 @@ package authz
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -91,3 +92,15 @@ type Provider interface {
 	// problems.
 	Validate() (problems []string)
 }
+
+// ErrUnimplemented is returned by a Provider for functionality the code host
+// has no means to support, e.g. listing the users with access to a repository.
+// Callers should skip the operation instead of treating it as a failure.
+type ErrUnimplemented struct {
+	// Feature is the name of the unimplemented functionality.
+	Feature string
+}
+
+func (e ErrUnimplemented) Error() string {
+	return fmt.Sprintf("%s is unimplemented", e.Feature)
+}
@@ -10,6 +10,7 @@ import (
 type Operations struct {
 	repoName           *observation.Operation
 	getJVMDependencies *observation.Operation
+	getDependencyRepos *observation.Operation
 }
 
 func NewOperationsMetrics(observationContext *observation.Context) *metrics.OperationMetrics {
@@ -33,5 +34,6 @@ func NewOperationsFromMetrics(observationContext *observation.Context, metrics *
 	return &Operations{
 		repoName:           op("RepoName"),
 		getJVMDependencies: op("GetJVMDependencies"),
+		getDependencyRepos: op("GetDependencyRepos"),
 	}
 }
@@ -96,6 +96,73 @@ func scanJVMDependencyRepo(rows *sql.Rows, queryErr error) (dependencies []JVMDe
 	return dependencies, nil
 }
 
+type GetDependencyReposOpts struct {
+	Scheme string
+	Name   string
+	After  int
+	Limit  int
+}
+
+type DependencyRepo struct {
+	ID      int
+	Name    string
+	Version string
+}
+
+// GetDependencyRepos returns the dependency repositories of the given package
+// scheme that have been referenced by precise code intelligence uploads.
+func (s *Store) GetDependencyRepos(ctx context.Context, filter GetDependencyReposOpts) (repos []DependencyRepo, err error) {
+	ctx, endObservation := s.operations.getDependencyRepos.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("scheme", filter.Scheme),
+		log.Int("after", filter.After),
+		log.Int("limit", filter.Limit),
+		log.Lazy(func(l log.Encoder) {
+			l.EmitInt("results", len(repos))
+		}),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	conds := make([]*sqlf.Query, 0, 3)
+	conds = append(conds, sqlf.Sprintf("scheme = %s", filter.Scheme))
+
+	if filter.After > 0 {
+		conds = append(conds, sqlf.Sprintf("id > %d", filter.After))
+	}
+
+	if filter.Name != "" {
+		conds = append(conds, sqlf.Sprintf("name = %s", filter.Name))
+	}
+
+	limit := sqlf.Sprintf("")
+	if filter.Limit != 0 {
+		limit = sqlf.Sprintf("LIMIT %s", filter.Limit)
+	}
+
+	return scanDependencyRepo(s.Query(ctx, sqlf.Sprintf(getLSIFDependencyReposQuery, sqlf.Join(conds, "AND"), limit)))
+}
+
+func scanDependencyRepo(rows *sql.Rows, queryErr error) (dependencies []DependencyRepo, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	for rows.Next() {
+		var dep DependencyRepo
+		if err = rows.Scan(
+			&dep.ID,
+			&dep.Name,
+			&dep.Version,
+		); err != nil {
+			return nil, err
+		}
+
+		dependencies = append(dependencies, dep)
+	}
+
+	return dependencies, nil
+}
+
 const getLSIFDependencyReposQuery = `
 -- source: internal/codeintel/stores/dbstore/repos.go:GetLSIFDependencyRepos
 SELECT id, name, version FROM lsif_dependency_repos
@@ -0,0 +1,104 @@
+package compute
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// CountBy counts values matched by MatchPattern across all search results,
+// grouped by the rendered KeyPattern template. For example, the template $1
+// groups by the value of the first capture group, and $repo groups by
+// repository. Templates may combine variables, e.g. "$repo $1".
+type CountBy struct {
+	MatchPattern *regexp.Regexp
+	KeyPattern   string
+}
+
+func (c *CountBy) String() string {
+	return fmt.Sprintf("Count by: (%s) -> (%s)", c.MatchPattern, c.KeyPattern)
+}
+
+// Run returns the group keys of every value in fm matched by MatchPattern.
+func (c *CountBy) Run(fm *result.FileMatch) []string {
+	metadata := newMetadata(fm)
+	var keys []string
+	for _, l := range fm.LineMatches {
+		for _, m := range c.MatchPattern.FindAllStringSubmatchIndex(l.Preview, -1) {
+			match := fromRegexpMatches([][]int{m}, c.MatchPattern.SubexpNames(), l.Preview, int(l.LineNumber))
+			keys = append(keys, substitute(c.KeyPattern, match, metadata))
+		}
+	}
+	return keys
+}
+
+// Count is the number of matched values for a group key.
+type Count struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// Counter tallies group keys. It is safe for concurrent use.
+type Counter struct {
+	mu     sync.Mutex
+	counts map[string]int
+	dirty  bool
+}
+
+func NewCounter() *Counter {
+	return &Counter{counts: make(map[string]int)}
+}
+
+// Add increments the count of each key.
+func (c *Counter) Add(keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, k := range keys {
+		c.counts[k]++
+	}
+	c.dirty = true
+}
+
+// Dirty returns true if keys were added since the last call to Dirty.
+func (c *Counter) Dirty() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	dirty := c.dirty
+	c.dirty = false
+	return dirty
+}
+
+// Len returns the number of distinct keys.
+func (c *Counter) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.counts)
+}
+
+// Top returns at most limit counts ordered by descending count, then by
+// value. If limit < 0 all counts are returned.
+func (c *Counter) Top(limit int) []Count {
+	c.mu.Lock()
+	counts := make([]Count, 0, len(c.counts))
+	for value, count := range c.counts {
+		counts = append(counts, Count{Value: value, Count: count})
+	}
+	c.mu.Unlock()
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Value < counts[j].Value
+	})
+	if limit >= 0 && len(counts) > limit {
+		counts = counts[:limit]
+	}
+	return counts
+}
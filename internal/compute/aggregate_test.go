@@ -0,0 +1,45 @@
+package compute
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestCountBy(t *testing.T) {
+	mkFileMatch := func(repo string, lines ...string) *result.FileMatch {
+		fm := &result.FileMatch{File: result.File{Repo: types.RepoName{Name: api.RepoName("github.com/" + repo)}}}
+		for _, l := range lines {
+			fm.LineMatches = append(fm.LineMatches, &result.LineMatch{Preview: l})
+		}
+		return fm
+	}
+
+	cmd := &CountBy{MatchPattern: regexp.MustCompile(`v(\d+)`), KeyPattern: "$1"}
+	counter := NewCounter()
+	counter.Add(cmd.Run(mkFileMatch("a", "v1 v2", "v1"))...)
+	counter.Add(cmd.Run(mkFileMatch("b", "v2", "nothing"))...)
+	counter.Add(cmd.Run(mkFileMatch("c", "v3"))...)
+
+	if got, want := counter.Top(-1), []Count{{"1", 2}, {"2", 2}, {"3", 1}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := counter.Top(1), []Count{{"1", 2}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := counter.Len(); got != 3 {
+		t.Fatalf("got %d distinct keys, want 3", got)
+	}
+	if !counter.Dirty() || counter.Dirty() {
+		t.Fatal("expected counter to be dirty exactly once after adding keys")
+	}
+
+	byRepo := &CountBy{MatchPattern: regexp.MustCompile(`v\d+`), KeyPattern: "$repo"}
+	if got, want := byRepo.Run(mkFileMatch("a", "v1 v2")), []string{"github.com/a", "github.com/a"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
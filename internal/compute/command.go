@@ -0,0 +1,111 @@
+package compute
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// Command is a compute operation that is performed over search results.
+type Command interface {
+	command()
+	String() string
+}
+
+var (
+	_ Command = (*MatchOnly)(nil)
+	_ Command = (*Replace)(nil)
+	_ Command = (*Output)(nil)
+	_ Command = (*CountBy)(nil)
+)
+
+func (*MatchOnly) command() {}
+func (*Replace) command()   {}
+func (*Output) command()    {}
+func (*CountBy) command()   {}
+
+// MatchOnly computes the match context, i.e., the values and environment of
+// submatches, of a pattern over search results.
+type MatchOnly struct {
+	MatchPattern *regexp.Regexp
+}
+
+// Replace substitutes every value matched by MatchPattern with the rendered
+// ReplacePattern template, and outputs the matched lines with replacements
+// applied.
+type Replace struct {
+	MatchPattern   *regexp.Regexp
+	ReplacePattern string
+}
+
+// Output renders the OutputPattern template for every value matched by
+// MatchPattern, and outputs the rendered values joined by Separator.
+type Output struct {
+	MatchPattern  *regexp.Regexp
+	OutputPattern string
+	Separator     string
+}
+
+func (c *MatchOnly) String() string {
+	return fmt.Sprintf("Match only: %s", c.MatchPattern)
+}
+
+func (c *Replace) String() string {
+	return fmt.Sprintf("Replace in place: (%s) -> (%s)", c.MatchPattern, c.ReplacePattern)
+}
+
+func (c *Output) String() string {
+	return fmt.Sprintf("Output with separator: (%s) -> (%s) separator: %q", c.MatchPattern, c.OutputPattern, c.Separator)
+}
+
+// Run returns the match context of MatchPattern in fm.
+func (c *MatchOnly) Run(fm *result.FileMatch) *MatchContext {
+	return FromFileMatch(fm, c.MatchPattern)
+}
+
+// Run returns the lines in fm that contain a value matched by MatchPattern,
+// with every such value replaced by the rendered template. It returns nil if
+// there are no matches.
+func (c *Replace) Run(fm *result.FileMatch) *Text {
+	metadata := newMetadata(fm)
+	var lines []string
+	for _, l := range fm.LineMatches {
+		regexpMatches := c.MatchPattern.FindAllStringSubmatchIndex(l.Preview, -1)
+		if len(regexpMatches) == 0 {
+			continue
+		}
+		var b strings.Builder
+		last := 0
+		for _, m := range regexpMatches {
+			match := fromRegexpMatches([][]int{m}, c.MatchPattern.SubexpNames(), l.Preview, int(l.LineNumber))
+			b.WriteString(l.Preview[last:m[0]])
+			b.WriteString(substitute(c.ReplacePattern, match, metadata))
+			last = m[1]
+		}
+		b.WriteString(l.Preview[last:])
+		lines = append(lines, b.String())
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return &Text{Value: strings.Join(lines, "\n"), Kind: "replace-in-place"}
+}
+
+// Run returns the rendered template for every value in fm matched by
+// MatchPattern. It returns nil if there are no matches.
+func (c *Output) Run(fm *result.FileMatch) *Text {
+	metadata := newMetadata(fm)
+	var values []string
+	for _, l := range fm.LineMatches {
+		for _, m := range c.MatchPattern.FindAllStringSubmatchIndex(l.Preview, -1) {
+			match := fromRegexpMatches([][]int{m}, c.MatchPattern.SubexpNames(), l.Preview, int(l.LineNumber))
+			values = append(values, substitute(c.OutputPattern, match, metadata))
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return &Text{Value: strings.Join(values, c.Separator), Kind: "output"}
+}
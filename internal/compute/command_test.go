@@ -0,0 +1,93 @@
+package compute
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRun(t *testing.T) {
+	data := &result.FileMatch{
+		File: result.File{
+			Repo:     types.RepoName{Name: "github.com/sourcegraph/sourcegraph"},
+			CommitID: api.CommitID("deadbeef"),
+			Path:     "package.json",
+		},
+		LineMatches: []*result.LineMatch{
+			{Preview: `"lodash": "4.17.21",`, LineNumber: 3},
+			{Preview: `"react": "16.14.0", "redux": "4.1.1"`, LineNumber: 4},
+		},
+	}
+
+	pattern := regexp.MustCompile(`"(?P<name>\w+)": "([\d.]+)"`)
+
+	output := func(template string) string {
+		t := (&Output{MatchPattern: pattern, OutputPattern: template, Separator: "\n"}).Run(data)
+		if t == nil {
+			return "<nil>"
+		}
+		return t.Value
+	}
+
+	replace := func(template string) string {
+		t := (&Replace{MatchPattern: pattern, ReplacePattern: template}).Run(data)
+		if t == nil {
+			return "<nil>"
+		}
+		return t.Value
+	}
+
+	autogold.Want("output capture groups", "lodash -> 4.17.21\nreact -> 16.14.0\nredux -> 4.1.1").
+		Equal(t, output("${name} -> $2"))
+
+	autogold.Want("output metadata", "github.com/sourcegraph/sourcegraph@deadbeef:package.json \"lodash\": \"4.17.21\"\ngithub.com/sourcegraph/sourcegraph@deadbeef:package.json \"react\": \"16.14.0\"\ngithub.com/sourcegraph/sourcegraph@deadbeef:package.json \"redux\": \"4.1.1\"").
+		Equal(t, output("$repo@$commit:$path $content"))
+
+	autogold.Want("output unknown variable", "$unknown\n$unknown\n$unknown").
+		Equal(t, output("$unknown"))
+
+	autogold.Want("output unknown braced variable", "${unknown} 4.17.21\n${unknown} 16.14.0\n${unknown} 4.1.1").
+		Equal(t, output("${unknown} $2"))
+
+	autogold.Want("output longest name", "$2x 4.17.21.\n$2x 16.14.0.\n$2x 4.1.1.").
+		Equal(t, output("$2x ${2}."))
+
+	autogold.Want("output literal dollars", "$lodash $ ${ ${2\n$react $ ${ ${2\n$redux $ ${ ${2").
+		Equal(t, output("$$$name $ ${ ${2"))
+
+	autogold.Want("replace in place", "lodash@4.17.21,\nreact@16.14.0, redux@4.1.1").
+		Equal(t, replace("$name@$2"))
+
+	if got := (&Output{MatchPattern: regexp.MustCompile("nothing"), OutputPattern: "$1"}).Run(data); got != nil {
+		t.Fatalf("expected no output for no matches, got %v", got)
+	}
+}
+
+func TestSubstitute(t *testing.T) {
+	match := Match{
+		Value:       "abc",
+		Environment: Environment{"1": {Value: "one"}, "12": {Value: "twelve"}},
+	}
+	m := &metadata{Repo: "r", Path: "p", Commit: "c"}
+
+	for template, want := range map[string]string{
+		"$1 $12":              "one twelve",
+		"${1}2":               "one2",
+		"$repo/$path@$commit": "r/p@c",
+		"${content}!":         "abc!",
+		"$3 ${3}":             "$3 ${3}",
+		"${1":                 "${1",
+		"${}":                 "${}",
+		"$ $$1 $":             "$ $1 $",
+		"no variables":        "no variables",
+	} {
+		if got := substitute(template, match, m); got != want {
+			t.Errorf("substitute(%q) = %q, want %q", template, got, want)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package compute
+
+// EventResult is a compute result for a single file, sent by the streaming
+// compute endpoint. Exactly one of MatchContext or Text is set.
+type EventResult struct {
+	Repository   string        `json:"repository"`
+	Commit       string        `json:"commit,omitempty"`
+	Path         string        `json:"path"`
+	MatchContext *MatchContext `json:"matchContext,omitempty"`
+	Text         *Text         `json:"text,omitempty"`
+}
+
+// EventAggregate is the current tally of a count-by command, sent by the
+// streaming compute endpoint whenever the tally changes.
+type EventAggregate struct {
+	Counts []Count `json:"counts"`
+	// Distinct is the number of distinct group keys seen so far. It may be
+	// larger than len(Counts) if only the top counts are sent.
+	Distinct int `json:"distinct"`
+	// LimitHit is true if the search stopped at its result limit, so the
+	// counts do not cover all matching results.
+	LimitHit bool `json:"limitHit,omitempty"`
+}
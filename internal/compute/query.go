@@ -0,0 +1,206 @@
+package compute
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// Query is a parsed compute query. It consists of a search query, which
+// produces the results to compute over, and a command to run on each result.
+type Query struct {
+	Command Command
+	// SearchQuery is the query that is run to obtain the search results to
+	// compute over.
+	SearchQuery string
+}
+
+// CountByMaxResults is the number of search results that a count-by command
+// aggregates over if its query does not specify count:. It is larger than the
+// default search limit, since aggregates over few results are misleading.
+const CountByMaxResults = 10000
+
+// commandSeparator separates the match pattern from the template in
+// content:replace(...), content:output(...) and content:count-by(...) commands.
+const commandSeparator = " -> "
+
+// Parse parses a compute query. A compute query is a regular expression search
+// query that may contain at most one command parameter of the form
+//
+//	content:replace(<regexp> -> <template>)
+//	content:output(<regexp> -> <template>)
+//	content:count-by(<regexp> -> <template>)
+//
+// If the query has no command parameter, the command computes the match
+// context of the query's search pattern.
+func Parse(q string) (*Query, error) {
+	rest, name, params, err := scanCommand(q)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		pattern, err := regexpFromQuery(q)
+		if err != nil {
+			return nil, err
+		}
+		return &Query{Command: &MatchOnly{MatchPattern: pattern}, SearchQuery: q}, nil
+	}
+
+	parts := strings.SplitN(params, commandSeparator, 2)
+	if len(parts) != 2 {
+		return nil, errors.Errorf("invalid %s command: expected a pattern and a template separated by '%s'", name, strings.TrimSpace(commandSeparator))
+	}
+	pattern, err := regexp.Compile(parts[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s command pattern", name)
+	}
+
+	var command Command
+	switch name {
+	case "replace":
+		command = &Replace{MatchPattern: pattern, ReplacePattern: parts[1]}
+	case "output":
+		command = &Output{MatchPattern: pattern, OutputPattern: parts[1], Separator: "\n"}
+	case "count-by":
+		command = &CountBy{MatchPattern: pattern, KeyPattern: parts[1]}
+	}
+
+	searchQuery := strings.TrimSpace(rest + " content:" + quote(parts[0]))
+	plan, err := query.Pipeline(query.Init(searchQuery, query.SearchTypeRegex))
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := command.(*CountBy); ok && !hasCount(plan) {
+		searchQuery += " count:" + strconv.Itoa(CountByMaxResults)
+	}
+	return &Query{Command: command, SearchQuery: searchQuery}, nil
+}
+
+// hasCount returns true if any query in plan specifies count:.
+func hasCount(plan query.Plan) bool {
+	for _, b := range plan {
+		if b.GetCount() != "" {
+			return true
+		}
+	}
+	return false
+}
+
+var commandRegexp = regexp.MustCompile(`^content:(replace|output|count-by)\(`)
+
+// findCommands returns the locations of the command parameters in q, in the
+// form returned by regexp.FindAllStringSubmatchIndex. Commands start at the
+// beginning of q or after whitespace. Quoted values are skipped, so a quoted
+// pattern that looks like a command is not one.
+func findCommands(q string) [][]int {
+	var locs [][]int
+	for i := 0; i < len(q); i++ {
+		if q[i] == '"' || q[i] == '\'' {
+			// Like the query parser, only treat quotes that start a value
+			// as delimiters, e.g. not the one in don't.
+			if i == 0 || unicode.IsSpace(rune(q[i-1])) || q[i-1] == ':' {
+				i = skipQuoted(q, i)
+			}
+			continue
+		}
+		if i > 0 && !unicode.IsSpace(rune(q[i-1])) {
+			continue
+		}
+		loc := commandRegexp.FindStringSubmatchIndex(q[i:])
+		if loc == nil {
+			continue
+		}
+		for j := range loc {
+			loc[j] += i
+		}
+		locs = append(locs, loc)
+		// Skip the command's parameters, which may contain quotes.
+		if _, advance, ok := query.ScanBalancedParens([]byte(q[loc[1]-1:])); ok {
+			i = loc[1] - 1 + advance - 1
+		} else {
+			i = len(q)
+		}
+	}
+	return locs
+}
+
+// skipQuoted returns the index of the quote that closes the quoted string
+// starting at q[start], or the last index of q if it is not closed.
+func skipQuoted(q string, start int) int {
+	for i := start + 1; i < len(q); i++ {
+		switch q[i] {
+		case '\\':
+			i++
+		case q[start]:
+			return i
+		}
+	}
+	return len(q) - 1
+}
+
+// scanCommand finds a content:replace(...), content:output(...) or
+// content:count-by(...) parameter in q. It returns q without the parameter,
+// the command name and the command parameters. If q has no command, name is
+// empty and rest is q.
+func scanCommand(q string) (rest, name, params string, err error) {
+	locs := findCommands(q)
+	switch len(locs) {
+	case 0:
+		return q, "", "", nil
+	case 1:
+	default:
+		return "", "", "", errors.New("compute queries support only one command")
+	}
+
+	loc := locs[0]
+	name = q[loc[2]:loc[3]]
+	// The match ends just after the opening parenthesis of the command.
+	value, advance, ok := query.ScanBalancedParens([]byte(q[loc[1]-1:]))
+	if !ok {
+		return "", "", "", errors.Errorf("unbalanced parentheses in %s command", name)
+	}
+	params = value[1 : len(value)-1]
+	rest = strings.TrimSpace(q[:loc[0]]) + " " + strings.TrimSpace(q[loc[1]-1+advance:])
+	return strings.TrimSpace(rest), name, params, nil
+}
+
+// quote returns v as a double-quoted query value.
+func quote(v string) string {
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `"`, `\"`)
+	return `"` + v + `"`
+}
+
+func regexpFromQuery(q string) (*regexp.Regexp, error) {
+	plan, err := query.Pipeline(query.Init(q, query.SearchTypeRegex))
+	if err != nil {
+		return nil, err
+	}
+	if len(plan) != 1 {
+		return nil, errors.New("compute endpoint only supports one search pattern currently ('and' or 'or' operators are not supported yet)")
+	}
+	switch node := plan[0].Pattern.(type) {
+	case query.Operator:
+		if len(node.Operands) == 1 {
+			if pattern, ok := node.Operands[0].(query.Pattern); ok && !pattern.Negated {
+				rp, err := regexp.Compile(pattern.Value)
+				if err != nil {
+					return nil, errors.Wrap(err, "regular expression is not valid for compute endpoint")
+				}
+				return rp, nil
+			}
+		}
+		return nil, errors.New("compute endpoint only supports one search pattern currently ('and' or 'or' operators are not supported yet)")
+	case query.Pattern:
+		if !node.Negated {
+			return regexp.Compile(node.Value)
+		}
+	}
+	// unreachable
+	return nil, nil
+}
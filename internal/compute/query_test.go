@@ -0,0 +1,50 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/hexops/autogold"
+)
+
+func TestParse(t *testing.T) {
+	test := func(input string) string {
+		q, err := Parse(input)
+		if err != nil {
+			return err.Error()
+		}
+		return q.Command.String() + "\nSearch query: " + q.SearchQuery
+	}
+
+	autogold.Want("match only", "Match only: (a)b\nSearch query: repo:foo (a)b").
+		Equal(t, test("repo:foo (a)b"))
+
+	autogold.Want("output command", "Output with separator: ((\\w+)\\.version) -> ($1 -> ${name}) separator: \"\\n\"\nSearch query: repo:foo content:\"(\\\\w+)\\\\.version\"").
+		Equal(t, test(`repo:foo content:output((\w+)\.version -> $1 -> ${name})`))
+
+	autogold.Want("replace command", "Replace in place: (a(b)) -> (c$1)\nSearch query: repo:foo file:bar content:\"a(b)\"").
+		Equal(t, test(`repo:foo content:replace(a(b) -> c$1) file:bar`))
+
+	autogold.Want("count-by command", "Count by: (errors (v[\\d.]+)) -> ($repo $1)\nSearch query: file:go\\.mod content:\"errors (v[\\\\d.]+)\" count:10000").
+		Equal(t, test(`file:go\.mod content:count-by(errors (v[\d.]+) -> $repo $1)`))
+
+	autogold.Want("count-by command with count", "Count by: (errors (v[\\d.]+)) -> ($1)\nSearch query: count:50 content:\"errors (v[\\\\d.]+)\"").
+		Equal(t, test(`count:50 content:count-by(errors (v[\d.]+) -> $1)`))
+
+	autogold.Want("quoted command", "Match only: content:output(a -> b)\nSearch query: \"content:output(a -> b)\"").
+		Equal(t, test(`"content:output(a -> b)"`))
+
+	autogold.Want("quoted command in field", "Output with separator: (a) -> ($1) separator: \"\\n\"\nSearch query: file:\"content:replace(x)\" content:\"a\"").
+		Equal(t, test(`file:"content:replace(x)" content:output(a -> $1)`))
+
+	autogold.Want("command after apostrophe", "Output with separator: (a) -> ($1) separator: \"\\n\"\nSearch query: don't content:\"a\"").
+		Equal(t, test(`don't content:output(a -> $1)`))
+
+	autogold.Want("missing separator", "invalid output command: expected a pattern and a template separated by '->'").
+		Equal(t, test(`content:output(a)`))
+
+	autogold.Want("multiple commands", "compute queries support only one command").
+		Equal(t, test(`content:output(a -> b) content:replace(a -> b)`))
+
+	autogold.Want("unbalanced command", "unbalanced parentheses in output command").
+		Equal(t, test(`content:output(a -> b`))
+}
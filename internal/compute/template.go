@@ -0,0 +1,117 @@
+package compute
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// metadata holds the variables of a search result that are available to
+// templates in addition to the match environment.
+type metadata struct {
+	Repo   string
+	Path   string
+	Commit string
+}
+
+func newMetadata(fm *result.FileMatch) *metadata {
+	return &metadata{
+		Repo:   string(fm.Repo.Name),
+		Path:   fm.Path,
+		Commit: string(fm.CommitID),
+	}
+}
+
+// substitute renders template over the environment of match. Variables are
+// written as $name or ${name}, where name is a capture group number or name.
+// The variables $repo, $path and $commit refer to the metadata of the search
+// result, and $content refers to the entire matched value. Capture groups take
+// precedence over metadata variables of the same name.
+//
+// As in regexp.Expand, in the $name form name is taken to be as long as
+// possible: $1x is equivalent to ${1x}, not ${1}x, and $12 refers to capture
+// group 12. $$ renders a literal $. Unknown variables, a $ that does not start
+// a variable and an unterminated ${ are left as-is.
+func substitute(template string, match Match, m *metadata) string {
+	lookup := func(variable string) (string, bool) {
+		if data, ok := match.Environment[variable]; ok {
+			return data.Value, true
+		}
+		switch variable {
+		case "repo":
+			return m.Repo, true
+		case "path":
+			return m.Path, true
+		case "commit":
+			return m.Commit, true
+		case "content":
+			return match.Value, true
+		}
+		return "", false
+	}
+
+	var b strings.Builder
+	for {
+		i := strings.IndexByte(template, '$')
+		if i < 0 {
+			break
+		}
+		b.WriteString(template[:i])
+		template = template[i:]
+
+		if strings.HasPrefix(template, "$$") {
+			b.WriteByte('$')
+			template = template[2:]
+			continue
+		}
+
+		name, n := scanVariable(template)
+		if n == 0 {
+			b.WriteByte('$')
+			template = template[1:]
+			continue
+		}
+		if value, ok := lookup(name); ok {
+			b.WriteString(value)
+		} else {
+			b.WriteString(template[:n])
+		}
+		template = template[n:]
+	}
+	b.WriteString(template)
+	return b.String()
+}
+
+// scanVariable scans the variable at the start of s, which begins with $. It
+// returns the name of the variable and the length of the variable in s, or 0
+// if s does not start with a variable.
+func scanVariable(s string) (name string, n int) {
+	if strings.HasPrefix(s, "${") {
+		end := strings.IndexByte(s, '}')
+		if end < 0 {
+			return "", 0
+		}
+		name = s[2:end]
+		if name == "" || len(name) != nameLen(name) {
+			return "", 0
+		}
+		return name, end + 1
+	}
+	l := nameLen(s[1:])
+	if l == 0 {
+		return "", 0
+	}
+	return s[1 : 1+l], 1 + l
+}
+
+// nameLen returns the length of the longest prefix of s made of letters,
+// digits and underscores.
+func nameLen(s string) int {
+	for i, r := range s {
+		if !(r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return i
+		}
+	}
+	return len(s)
+}
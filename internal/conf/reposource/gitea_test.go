@@ -0,0 +1,50 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGitea_cloneURLToRepoName(t *testing.T) {
+	tests := []struct {
+		conn schema.GiteaConnection
+		urls []urlToRepoName
+	}{
+		{
+			conn: schema.GiteaConnection{
+				Url: "https://gitea.mycompany.com",
+			},
+			urls: []urlToRepoName{
+				{"git@gitea.mycompany.com:org/repo.git", "gitea.mycompany.com/org/repo"},
+				{"https://gitea.mycompany.com/org/repo.git", "gitea.mycompany.com/org/repo"},
+				{"https://token@gitea.mycompany.com/org/repo.git", "gitea.mycompany.com/org/repo"},
+
+				{"git@asdf.com:org/repo.git", ""},
+				{"https://asdf.com/org/repo.git", ""},
+			},
+		},
+		{
+			conn: schema.GiteaConnection{
+				Url:                   "https://gitea.mycompany.com",
+				RepositoryPathPattern: "gitea/{nameWithOwner}",
+			},
+			urls: []urlToRepoName{
+				{"git@gitea.mycompany.com:org/repo.git", "gitea/org/repo"},
+				{"https://gitea.mycompany.com/org/repo.git", "gitea/org/repo"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		for _, u := range test.urls {
+			repoName, err := Gitea{&test.conn}.CloneURLToRepoName(u.cloneURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.repoName != string(repoName) {
+				t.Errorf("expected %q but got %q for clone URL %q (connection: %+v)", u.repoName, repoName, u.cloneURL, test.conn)
+			}
+		}
+	}
+}
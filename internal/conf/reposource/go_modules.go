@@ -0,0 +1,94 @@
+package reposource
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+type GoModule struct {
+	// Path is the module path, for example `golang.org/x/net`.
+	Path string
+}
+
+// PackageSyntax returns the module path.
+func (m *GoModule) PackageSyntax() string {
+	return m.Path
+}
+
+func (m *GoModule) MatchesDependencyString(dependency string) bool {
+	return strings.HasPrefix(dependency, m.Path+"@")
+}
+
+func (m *GoModule) RepoName() api.RepoName {
+	return api.RepoName("go/" + m.Path)
+}
+
+func (m *GoModule) CloneURL() string {
+	cloneURL := url.URL{Path: string(m.RepoName())}
+	return cloneURL.String()
+}
+
+type GoDependency struct {
+	GoModule
+	Version string
+}
+
+func (d GoDependency) PackageManagerSyntax() string {
+	return fmt.Sprintf("%s@%s", d.Path, d.Version)
+}
+
+// GitTagFromVersion returns the version itself, since Go module versions
+// already are valid semantic version tags such as `v1.2.3`.
+func (d GoDependency) GitTagFromVersion() string {
+	return d.Version
+}
+
+// SortGoDependencies sorts the dependencies by semantic version in descending
+// order. The latest version of a module becomes the first element of the
+// slice.
+func SortGoDependencies(dependencies []GoDependency) {
+	sort.Slice(dependencies, func(i, j int) bool {
+		if dependencies[i].GoModule == dependencies[j].GoModule {
+			return semver.Compare(dependencies[i].Version, dependencies[j].Version) > 0
+		}
+		return dependencies[i].Path > dependencies[j].Path
+	})
+}
+
+// ParseGoDependency parses a dependency string in the Go format (module path
+// and version separated by `@`) into a GoDependency.
+func ParseGoDependency(dependency string) (GoDependency, error) {
+	i := strings.LastIndex(dependency, "@")
+	if i <= 0 {
+		return GoDependency{}, fmt.Errorf("dependency %q must be of the form \"module@version\"", dependency)
+	}
+
+	dep := GoDependency{
+		GoModule: GoModule{Path: dependency[:i]},
+		Version:  dependency[i+1:],
+	}
+	if err := module.Check(dep.Path, dep.Version); err != nil {
+		return GoDependency{}, err
+	}
+	return dep, nil
+}
+
+// ParseGoModuleFromRepoURL returns the Go module for the provided URL path,
+// without a leading `/`.
+func ParseGoModuleFromRepoURL(urlPath string) (GoModule, error) {
+	if !strings.HasPrefix(urlPath, "go/") {
+		return GoModule{}, fmt.Errorf("failed to parse a Go module from the path %s", urlPath)
+	}
+	path := strings.TrimPrefix(urlPath, "go/")
+	if err := module.CheckPath(path); err != nil {
+		return GoModule{}, err
+	}
+	return GoModule{Path: path}, nil
+}
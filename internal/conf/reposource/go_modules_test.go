@@ -0,0 +1,49 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestParseGoDependency(t *testing.T) {
+	dep, err := ParseGoDependency("golang.org/x/net@v0.0.0-20211025183914-5ac77ca3eb0b")
+	assert.Nil(t, err)
+	assert.Equal(t, "golang.org/x/net", dep.Path)
+	assert.Equal(t, "v0.0.0-20211025183914-5ac77ca3eb0b", dep.GitTagFromVersion())
+	assert.Equal(t, api.RepoName("go/golang.org/x/net"), dep.RepoName())
+
+	for _, dependency := range []string{"golang.org/x/net", "golang.org/x/net@1.0.0", "@v1.0.0", "github.com/foo/bar/v2@v1.0.0"} {
+		_, err := ParseGoDependency(dependency)
+		assert.NotNil(t, err, dependency)
+	}
+}
+
+func TestParseGoModuleFromRepoURL(t *testing.T) {
+	mod, err := ParseGoModuleFromRepoURL("go/github.com/gorilla/mux")
+	assert.Nil(t, err)
+	assert.Equal(t, GoModule{Path: "github.com/gorilla/mux"}, mod)
+
+	for _, path := range []string{"npm/react", "go/", "go/../foo"} {
+		_, err := ParseGoModuleFromRepoURL(path)
+		assert.NotNil(t, err, path)
+	}
+}
+
+func TestSortGoDependencies(t *testing.T) {
+	var dependencies []GoDependency
+	for _, d := range []string{"a.com/b@v1.2.0", "a.com/a@v1.0.0", "a.com/b@v1.11.0", "a.com/b@v1.2.0-rc.1"} {
+		dependency, err := ParseGoDependency(d)
+		assert.Nil(t, err)
+		dependencies = append(dependencies, dependency)
+	}
+	SortGoDependencies(dependencies)
+
+	var have []string
+	for _, d := range dependencies {
+		have = append(have, d.PackageManagerSyntax())
+	}
+	assert.Equal(t, []string{"a.com/b@v1.11.0", "a.com/b@v1.2.0", "a.com/b@v1.2.0-rc.1", "a.com/a@v1.0.0"}, have)
+}
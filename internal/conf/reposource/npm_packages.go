@@ -0,0 +1,124 @@
+package reposource
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// npmPackageNamePattern matches the names of npm packages, with an optional
+// scope, as accepted by the npm registry. See
+// https://github.com/npm/validate-npm-package-name.
+var npmPackageNamePattern = regexp.MustCompile(`^(?:@([a-z0-9-~][a-z0-9-._~]*)/)?([a-z0-9-~][a-z0-9-._~]*)$`)
+
+type NpmPackage struct {
+	// Scope is the optional scope of the package, without the leading `@`.
+	Scope string
+	Name  string
+}
+
+// NewNpmPackage returns the npm package with the given scope and name, or an
+// error if they aren't valid.
+func NewNpmPackage(scope, name string) (NpmPackage, error) {
+	pkg := NpmPackage{Scope: scope, Name: name}
+	if !npmPackageNamePattern.MatchString(pkg.PackageSyntax()) {
+		return NpmPackage{}, fmt.Errorf("invalid npm package name %q", pkg.PackageSyntax())
+	}
+	return pkg, nil
+}
+
+// PackageSyntax returns the name of the package as used by npm, for example
+// `@types/lodash` or `react`.
+func (p *NpmPackage) PackageSyntax() string {
+	if p.Scope != "" {
+		return fmt.Sprintf("@%s/%s", p.Scope, p.Name)
+	}
+	return p.Name
+}
+
+func (p *NpmPackage) MatchesDependencyString(dependency string) bool {
+	return strings.HasPrefix(dependency, p.PackageSyntax()+"@")
+}
+
+func (p *NpmPackage) RepoName() api.RepoName {
+	if p.Scope != "" {
+		return api.RepoName(fmt.Sprintf("npm/%s/%s", p.Scope, p.Name))
+	}
+	return api.RepoName("npm/" + p.Name)
+}
+
+func (p *NpmPackage) CloneURL() string {
+	cloneURL := url.URL{Path: string(p.RepoName())}
+	return cloneURL.String()
+}
+
+type NpmDependency struct {
+	NpmPackage
+	Version string
+}
+
+func (d NpmDependency) PackageManagerSyntax() string {
+	return fmt.Sprintf("%s@%s", d.PackageSyntax(), d.Version)
+}
+
+func (d NpmDependency) GitTagFromVersion() string {
+	return "v" + d.Version
+}
+
+// SortNpmDependencies sorts the dependencies by version in descending order.
+// The latest version of a package becomes the first element of the slice.
+func SortNpmDependencies(dependencies []NpmDependency) {
+	sort.Slice(dependencies, func(i, j int) bool {
+		if dependencies[i].NpmPackage == dependencies[j].NpmPackage {
+			return versionGreaterThan(dependencies[i].Version, dependencies[j].Version)
+		}
+		return dependencies[i].PackageSyntax() > dependencies[j].PackageSyntax()
+	})
+}
+
+// ParseNpmDependency parses a dependency string in the npm format (package
+// name, optionally with a scope, and version separated by `@`) into an
+// NpmDependency.
+func ParseNpmDependency(dependency string) (NpmDependency, error) {
+	i := strings.LastIndex(dependency, "@")
+	if i <= 0 || i == len(dependency)-1 {
+		return NpmDependency{}, fmt.Errorf("dependency %q must be of the form \"(@scope/)?name@version\"", dependency)
+	}
+
+	pkg, err := ParseNpmPackage(dependency[:i])
+	if err != nil {
+		return NpmDependency{}, err
+	}
+	return NpmDependency{NpmPackage: pkg, Version: dependency[i+1:]}, nil
+}
+
+// ParseNpmPackage parses a package name in the npm format, for example
+// `@types/lodash`.
+func ParseNpmPackage(name string) (NpmPackage, error) {
+	m := npmPackageNamePattern.FindStringSubmatch(name)
+	if m == nil {
+		return NpmPackage{}, fmt.Errorf("invalid npm package name %q", name)
+	}
+	return NpmPackage{Scope: m[1], Name: m[2]}, nil
+}
+
+// ParseNpmPackageFromRepoURL returns the npm package for the provided URL
+// path, without a leading `/`.
+func ParseNpmPackageFromRepoURL(urlPath string) (NpmPackage, error) {
+	if !strings.HasPrefix(urlPath, "npm/") {
+		return NpmPackage{}, fmt.Errorf("failed to parse an npm package from the path %s", urlPath)
+	}
+	parts := strings.Split(strings.TrimPrefix(urlPath, "npm/"), "/")
+	switch len(parts) {
+	case 1:
+		return NewNpmPackage("", parts[0])
+	case 2:
+		return NewNpmPackage(parts[0], parts[1])
+	default:
+		return NpmPackage{}, fmt.Errorf("failed to parse an npm package from the path %s", urlPath)
+	}
+}
@@ -0,0 +1,61 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestParseNpmDependency(t *testing.T) {
+	for dependency, want := range map[string]NpmDependency{
+		"react@17.0.2":                 {NpmPackage{Name: "react"}, "17.0.2"},
+		"@types/lodash@4.14.172":       {NpmPackage{Scope: "types", Name: "lodash"}, "4.14.172"},
+		"@babel/core@7.0.0-beta.44":    {NpmPackage{Scope: "babel", Name: "core"}, "7.0.0-beta.44"},
+		"lodash.debounce@4.0.8":        {NpmPackage{Name: "lodash.debounce"}, "4.0.8"},
+		"@sourcegraph/shared@1.0.0-rc": {NpmPackage{Scope: "sourcegraph", Name: "shared"}, "1.0.0-rc"},
+	} {
+		have, err := ParseNpmDependency(dependency)
+		assert.Nil(t, err, dependency)
+		assert.Equal(t, want, have)
+		assert.Equal(t, dependency, have.PackageManagerSyntax())
+	}
+
+	for _, dependency := range []string{"react", "react@", "@types/lodash", "@types@1.0.0", "React@17.0.2", "a/b/c@1.0.0"} {
+		_, err := ParseNpmDependency(dependency)
+		assert.NotNil(t, err, dependency)
+	}
+}
+
+func TestParseNpmPackageFromRepoURL(t *testing.T) {
+	pkg, err := ParseNpmPackageFromRepoURL("npm/types/lodash")
+	assert.Nil(t, err)
+	assert.Equal(t, NpmPackage{Scope: "types", Name: "lodash"}, pkg)
+	assert.Equal(t, api.RepoName("npm/types/lodash"), pkg.RepoName())
+
+	pkg, err = ParseNpmPackageFromRepoURL("npm/react")
+	assert.Nil(t, err)
+	assert.Equal(t, api.RepoName("npm/react"), pkg.RepoName())
+
+	for _, path := range []string{"maven/react", "npm/a/b/c", "npm/"} {
+		_, err := ParseNpmPackageFromRepoURL(path)
+		assert.NotNil(t, err, path)
+	}
+}
+
+func TestSortNpmDependencies(t *testing.T) {
+	var dependencies []NpmDependency
+	for _, d := range []string{"a@1.2.0", "@b/a@1.0.0", "a@1.11.0", "a@1.2.0-beta.1"} {
+		dependency, err := ParseNpmDependency(d)
+		assert.Nil(t, err)
+		dependencies = append(dependencies, dependency)
+	}
+	SortNpmDependencies(dependencies)
+
+	var have []string
+	for _, d := range dependencies {
+		have = append(have, d.PackageManagerSyntax())
+	}
+	assert.Equal(t, []string{"a@1.11.0", "a@1.2.0", "a@1.2.0-beta.1", "@b/a@1.0.0"}, have)
+}
@@ -36,6 +36,12 @@ type Store struct {
 	// BeforeEvict, when non-nil, is a function to call before evicting a file.
 	// It is passed the path to the file to be evicted.
 	BeforeEvict func(string)
+
+	// AuxiliaryPaths, when non-nil, returns the paths of the files stored
+	// next to the cached file at path, such as indexes of it. Their sizes are
+	// counted with the size of the cached file, and they are removed when it
+	// is evicted.
+	AuxiliaryPaths func(path string) []string
 }
 
 // File is an os.File, but includes the Path
@@ -139,6 +145,16 @@ func (s *Store) OpenWithPath(ctx context.Context, key string, fetcher FetcherWit
 	}
 }
 
+// Lookup returns the path of the item cached for key. Unlike Open, it never
+// fills the cache: ok is false if key is not already on disk.
+func (s *Store) Lookup(key string) (path string, ok bool) {
+	path = s.path(key)
+	if _, err := os.Stat(path); err != nil {
+		return "", false
+	}
+	return path, true
+}
+
 // path returns the path for key.
 func (s *Store) path(key string) string {
 	// path uses a sha256 hash of the key since we want to use it for the
@@ -242,18 +258,31 @@ func (s *Store) Evict(maxCacheSizeBytes int64) (stats EvictStats, err error) {
 	}
 
 	list := make([]fs.FileInfo, len(entries))
+	sizes := make(map[string]int64, len(entries))
 	for i := range entries {
 		list[i], err = entries[i].Info()
 		if err != nil {
 			return stats, err
 		}
+		sizes[filepath.Join(s.Dir, list[i].Name())] = list[i].Size()
+	}
+
+	// entrySize returns the size of the zip at path and its auxiliary files.
+	entrySize := func(path string) int64 {
+		size := sizes[path]
+		if s.AuxiliaryPaths != nil {
+			for _, aux := range s.AuxiliaryPaths(path) {
+				size += sizes[aux]
+			}
+		}
+		return size
 	}
 
 	// Sum up the total size of all zips
 	var size int64
 	for _, fi := range list {
 		if isZip(fi) {
-			size += fi.Size()
+			size += entrySize(filepath.Join(s.Dir, fi.Name()))
 		}
 	}
 	stats.CacheSize = size
@@ -284,8 +313,15 @@ func (s *Store) Evict(maxCacheSizeBytes int64) (stats EvictStats, err error) {
 			log.Printf("failed to remove %s: %s", path, err)
 			continue
 		}
+		if s.AuxiliaryPaths != nil {
+			for _, aux := range s.AuxiliaryPaths(path) {
+				if err := os.Remove(aux); err != nil && !os.IsNotExist(err) {
+					log.Printf("failed to remove %s: %s", aux, err)
+				}
+			}
+		}
 		stats.Evicted++
-		size -= fi.Size()
+		size -= entrySize(path)
 	}
 
 	return stats, nil
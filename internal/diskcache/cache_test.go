@@ -60,3 +60,80 @@ func TestOpen(t *testing.T) {
 		t.Fatal("Item was not properly evicted")
 	}
 }
+
+func TestLookup(t *testing.T) {
+	dir, err := os.MkdirTemp("", "diskcache_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := &Store{
+		Dir:       dir,
+		Component: "test",
+	}
+
+	if _, ok := store.Lookup("key"); ok {
+		t.Fatal("Expected key to be missing from empty cache")
+	}
+
+	f, err := store.Open(context.Background(), "key", func(ctx context.Context) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader([]byte("foobar"))), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	path, ok := store.Lookup("key")
+	if !ok {
+		t.Fatal("Expected key to be present after Open")
+	}
+	if path != f.Path {
+		t.Fatalf("got path %q, want %q", path, f.Path)
+	}
+}
+
+func TestEvictAuxiliaryPaths(t *testing.T) {
+	dir, err := os.MkdirTemp("", "diskcache_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := &Store{
+		Dir:       dir,
+		Component: "test",
+		AuxiliaryPaths: func(path string) []string {
+			return []string{path + ".aux"}
+		},
+	}
+
+	f, err := store.Open(context.Background(), "key", func(ctx context.Context) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader([]byte("foobar"))), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	aux := f.Path + ".aux"
+	if err := os.WriteFile(aux, []byte("0123456789"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := store.Evict(1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := int64(len("foobar") + len("0123456789")); stats.CacheSize != want {
+		t.Fatalf("got cache size %d, want %d", stats.CacheSize, want)
+	}
+
+	if _, err := store.Evict(0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(aux); !os.IsNotExist(err) {
+		t.Fatalf("expected auxiliary file to be evicted, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package bitbucketcloud
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -50,6 +51,10 @@ type Client struct {
 	// The username and app password credentials for accessing the server.
 	Username, AppPassword string
 
+	// Token is an OAuth access token. If set, it is used instead of the
+	// username and app password.
+	Token string
+
 	// RateLimit is the self-imposed rate limiter (since Bitbucket does not have a concept
 	// of rate limiting in HTTP response headers).
 	RateLimit *rate.Limiter
@@ -104,6 +109,51 @@ func (c *Client) Repos(ctx context.Context, pageToken *PageToken, accountName st
 	return repos, next, err
 }
 
+// WithCredentials returns a copy of the Client authenticating with the given
+// username and app password.
+func (c *Client) WithCredentials(username, appPassword string) *Client {
+	cc := *c
+	cc.Username = username
+	cc.AppPassword = appPassword
+	return &cc
+}
+
+// WithToken returns a copy of the Client authenticating with the given OAuth
+// access token.
+func (c *Client) WithToken(token string) *Client {
+	cc := *c
+	cc.Token = token
+	return &cc
+}
+
+// CurrentUser returns the account the client is authenticated as.
+func (c *Client) CurrentUser(ctx context.Context) (*Account, error) {
+	var account Account
+	if err := c.send(ctx, "GET", "/2.0/user", nil, nil, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (c *Client) send(ctx context.Context, method, path string, qry url.Values, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		bs, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(bs)
+	}
+
+	u := url.URL{Path: path, RawQuery: qry.Encode()}
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, req, result)
+}
+
 func (c *Client) page(ctx context.Context, path string, qry url.Values, token *PageToken, results interface{}) (*PageToken, error) {
 	if qry == nil {
 		qry = make(url.Values)
@@ -195,6 +245,10 @@ func (c *Client) do(ctx context.Context, req *http.Request, result interface{})
 }
 
 func (c *Client) authenticate(req *http.Request) error {
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+		return nil
+	}
 	req.SetBasicAuth(c.Username, c.AppPassword)
 	return nil
 }
@@ -236,6 +290,15 @@ type Repo struct {
 	Links       Links  `json:"links"`
 }
 
+// Account is a Bitbucket Cloud user or team.
+type Account struct {
+	AccountID   string `json:"account_id"`
+	DisplayName string `json:"display_name"`
+	Nickname    string `json:"nickname"`
+	UUID        string `json:"uuid"`
+	Links       Links  `json:"links"`
+}
+
 type Links struct {
 	Clone CloneLinks `json:"clone"`
 	HTML  Link       `json:"html"`
@@ -277,3 +340,7 @@ func (e *httpError) Unauthorized() bool {
 func (e *httpError) NotFound() bool {
 	return e.StatusCode == http.StatusNotFound
 }
+
+func (e *httpError) BadRequest() bool {
+	return e.StatusCode == http.StatusBadRequest
+}
@@ -0,0 +1,142 @@
+package bitbucketcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const eventKeyHeader = "X-Event-Key"
+
+// WebhookEventKey returns the event key of the given webhook request, such as
+// "pullrequest:approved".
+func WebhookEventKey(r *http.Request) string {
+	return r.Header.Get(eventKeyHeader)
+}
+
+// ParseWebhookEvent parses the payload of a webhook request with the given
+// event key.
+func ParseWebhookEvent(eventKey string, payload []byte) (e interface{}, err error) {
+	switch eventKey {
+	case "pullrequest:approved":
+		e = &PullRequestApprovedEvent{}
+	case "pullrequest:unapproved":
+		e = &PullRequestUnapprovedEvent{}
+	case "pullrequest:changes_request_created":
+		e = &PullRequestChangesRequestCreatedEvent{}
+	case "pullrequest:changes_request_removed":
+		e = &PullRequestChangesRequestRemovedEvent{}
+	case "pullrequest:fulfilled":
+		e = &PullRequestFulfilledEvent{}
+	case "pullrequest:rejected":
+		e = &PullRequestRejectedEvent{}
+	case "repo:commit_status_created", "repo:commit_status_updated":
+		e = &RepoCommitStatusEvent{}
+	default:
+		return nil, errors.Errorf("unknown webhook event key: %q", eventKey)
+	}
+
+	return e, json.Unmarshal(payload, e)
+}
+
+// PullRequestEvent contains the fields common to all pull request webhook
+// payloads.
+type PullRequestEvent struct {
+	Actor       Account     `json:"actor"`
+	PullRequest PullRequest `json:"pullrequest"`
+	Repository  Repo        `json:"repository"`
+}
+
+func (e *PullRequestEvent) reviewKey(kind string, a *Approval) string {
+	return fmt.Sprintf("%d:%s:%s:%d", e.PullRequest.ID, kind, a.User.UUID, a.Date.UnixNano())
+}
+
+// ReviewEvents returns an approval or request for changes event for each
+// participant of the pull request who approved it or requested changes. They
+// are derived from the current participants, so they are known even if no
+// webhook is configured. The participation date stands in for the date of the
+// review.
+func (pr *PullRequest) ReviewEvents() []interface{ Key() string } {
+	var events []interface{ Key() string }
+	for _, p := range pr.Participants {
+		event := PullRequestEvent{Actor: p.User, PullRequest: PullRequest{ID: pr.ID}}
+		review := Approval{Date: p.ParticipatedOn, User: p.User}
+		switch p.State {
+		case ParticipantStateApproved:
+			events = append(events, &PullRequestApprovedEvent{PullRequestEvent: event, Approval: review})
+		case ParticipantStateChangesRequested:
+			events = append(events, &PullRequestChangesRequestCreatedEvent{PullRequestEvent: event, ChangesRequest: review})
+		}
+	}
+	return events
+}
+
+// Approval is an approval or a request for changes of a pull request.
+type Approval struct {
+	Date time.Time `json:"date"`
+	User Account   `json:"user"`
+}
+
+type PullRequestApprovedEvent struct {
+	PullRequestEvent
+	Approval Approval `json:"approval"`
+}
+
+func (e *PullRequestApprovedEvent) Key() string {
+	return e.reviewKey("approved", &e.Approval)
+}
+
+type PullRequestUnapprovedEvent struct {
+	PullRequestEvent
+	Approval Approval `json:"approval"`
+}
+
+func (e *PullRequestUnapprovedEvent) Key() string {
+	return e.reviewKey("unapproved", &e.Approval)
+}
+
+type PullRequestChangesRequestCreatedEvent struct {
+	PullRequestEvent
+	ChangesRequest Approval `json:"changes_request"`
+}
+
+func (e *PullRequestChangesRequestCreatedEvent) Key() string {
+	return e.reviewKey("changes_request_created", &e.ChangesRequest)
+}
+
+type PullRequestChangesRequestRemovedEvent struct {
+	PullRequestEvent
+	ChangesRequest Approval `json:"changes_request"`
+}
+
+func (e *PullRequestChangesRequestRemovedEvent) Key() string {
+	return e.reviewKey("changes_request_removed", &e.ChangesRequest)
+}
+
+// PullRequestFulfilledEvent is sent when a pull request is merged.
+type PullRequestFulfilledEvent struct {
+	PullRequestEvent
+}
+
+func (e *PullRequestFulfilledEvent) Key() string {
+	return fmt.Sprintf("%d:fulfilled", e.PullRequest.ID)
+}
+
+// PullRequestRejectedEvent is sent when a pull request is declined.
+type PullRequestRejectedEvent struct {
+	PullRequestEvent
+}
+
+func (e *PullRequestRejectedEvent) Key() string {
+	return fmt.Sprintf("%d:rejected:%d", e.PullRequest.ID, e.PullRequest.UpdatedOn.UnixNano())
+}
+
+// RepoCommitStatusEvent is sent when a commit status is created or updated.
+type RepoCommitStatusEvent struct {
+	Actor        Account      `json:"actor"`
+	Repository   Repo         `json:"repository"`
+	CommitStatus CommitStatus `json:"commit_status"`
+}
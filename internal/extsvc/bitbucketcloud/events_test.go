@@ -0,0 +1,32 @@
+package bitbucketcloud
+
+import "testing"
+
+func TestParseWebhookEvent(t *testing.T) {
+	payload := []byte(`{
+		"actor": {"uuid": "{actor}"},
+		"repository": {"uuid": "{repo}"},
+		"pullrequest": {"id": 12},
+		"approval": {"date": "2021-08-01T10:00:00Z", "user": {"uuid": "{user}"}}
+	}`)
+
+	e, err := ParseWebhookEvent("pullrequest:approved", payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	approved, ok := e.(*PullRequestApprovedEvent)
+	if !ok {
+		t.Fatalf("unexpected event type %T", e)
+	}
+	if have, want := approved.Key(), "12:approved:{user}:1627812000000000000"; have != want {
+		t.Errorf("wrong key: have %q, want %q", have, want)
+	}
+	if have, want := approved.Repository.UUID, "{repo}"; have != want {
+		t.Errorf("wrong repository: have %q, want %q", have, want)
+	}
+
+	if _, err := ParseWebhookEvent("repo:push", payload); err == nil {
+		t.Error("expected error for unknown event key")
+	}
+}
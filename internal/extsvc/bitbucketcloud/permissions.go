@@ -0,0 +1,94 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Workspace is a Bitbucket Cloud workspace.
+type Workspace struct {
+	UUID string `json:"uuid"`
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// WorkspacePermission is the permission of a user in a workspace. Permission
+// is one of "owner", "collaborator" or "member".
+type WorkspacePermission struct {
+	Permission string     `json:"permission"`
+	User       *Account   `json:"user"`
+	Workspace  *Workspace `json:"workspace"`
+}
+
+// WorkspaceMembership is the membership of a user in a workspace.
+type WorkspaceMembership struct {
+	User      *Account   `json:"user"`
+	Workspace *Workspace `json:"workspace"`
+}
+
+// RepoPermission is the effective permission of a user on a repository.
+// Permission is one of "admin", "write" or "read".
+type RepoPermission struct {
+	Permission string   `json:"permission"`
+	User       *Account `json:"user"`
+	Repository *Repo    `json:"repository"`
+}
+
+// AdministeredWorkspaces returns the permissions of the authenticated user in
+// the workspaces they own. Only workspace owners are allowed to list the
+// members and repository permissions of a workspace.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-user-permissions-workspaces-get
+func (c *Client) AdministeredWorkspaces(ctx context.Context, pageToken *PageToken) ([]*WorkspacePermission, *PageToken, error) {
+	qry := url.Values{"q": {`permission="owner"`}}
+
+	var perms []*WorkspacePermission
+	next, err := c.pageOrNext(ctx, "/2.0/user/permissions/workspaces", qry, pageToken, &perms)
+	return perms, next, err
+}
+
+// WorkspaceMembers returns the members of the given workspace.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-members-get
+func (c *Client) WorkspaceMembers(ctx context.Context, pageToken *PageToken, workspace string) ([]*WorkspaceMembership, *PageToken, error) {
+	var members []*WorkspaceMembership
+	next, err := c.pageOrNext(ctx, fmt.Sprintf("/2.0/workspaces/%s/members", workspace), nil, pageToken, &members)
+	return members, next, err
+}
+
+// WorkspaceRepoPermissions returns the effective repository permissions of
+// all users in the repositories of the given workspace, optionally filtered
+// by the given query, e.g. `user.uuid="{...}"`. Effective permissions include
+// permissions granted through group membership.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-permissions-repositories-get
+func (c *Client) WorkspaceRepoPermissions(ctx context.Context, pageToken *PageToken, workspace, query string) ([]*RepoPermission, *PageToken, error) {
+	var qry url.Values
+	if query != "" {
+		qry = url.Values{"q": {query}}
+	}
+
+	var perms []*RepoPermission
+	next, err := c.pageOrNext(ctx, fmt.Sprintf("/2.0/workspaces/%s/permissions/repositories", workspace), qry, pageToken, &perms)
+	return perms, next, err
+}
+
+// RepoPermissions returns the effective permissions of all users on the given
+// repository.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-permissions-repositories-repo-slug-get
+func (c *Client) RepoPermissions(ctx context.Context, pageToken *PageToken, workspace, repoSlug string) ([]*RepoPermission, *PageToken, error) {
+	var perms []*RepoPermission
+	next, err := c.pageOrNext(ctx, fmt.Sprintf("/2.0/workspaces/%s/permissions/repositories/%s", workspace, repoSlug), nil, pageToken, &perms)
+	return perms, next, err
+}
+
+// pageOrNext requests the next page of pageToken if there is one, or the
+// first page of path otherwise.
+func (c *Client) pageOrNext(ctx context.Context, path string, qry url.Values, pageToken *PageToken, results interface{}) (*PageToken, error) {
+	if pageToken.HasMore() {
+		return c.reqPage(ctx, pageToken.Next, results)
+	}
+	return c.page(ctx, path, qry, pageToken, results)
+}
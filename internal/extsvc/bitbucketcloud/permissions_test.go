@@ -0,0 +1,80 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_AdministeredWorkspaces(t *testing.T) {
+	var next string
+	cli := newPullRequestTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if have, want := r.URL.Path, "/2.0/user/permissions/workspaces"; have != want {
+			t.Errorf("wrong path: have %q, want %q", have, want)
+		}
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"values": [{"permission": "owner", "workspace": {"slug": "second"}}]}`)
+			return
+		}
+		if have, want := r.URL.Query().Get("q"), `permission="owner"`; have != want {
+			t.Errorf("wrong query: have %q, want %q", have, want)
+		}
+		fmt.Fprintf(w, `{"values": [{"permission": "owner", "workspace": {"slug": "first"}}], "next": %q}`, next)
+	})
+	next = cli.URL.String() + "/2.0/user/permissions/workspaces?page=2"
+
+	var slugs []string
+	for token := (&PageToken{}); ; {
+		perms, nextToken, err := cli.AdministeredWorkspaces(context.Background(), token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range perms {
+			slugs = append(slugs, p.Workspace.Slug)
+		}
+		if !nextToken.HasMore() {
+			break
+		}
+		token = nextToken
+	}
+
+	if diff := cmp.Diff([]string{"first", "second"}, slugs); diff != "" {
+		t.Fatalf("unexpected workspaces (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_WorkspaceRepoPermissions(t *testing.T) {
+	cli := newPullRequestTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if have, want := r.URL.Path, "/2.0/workspaces/sourcegraph/permissions/repositories"; have != want {
+			t.Errorf("wrong path: have %q, want %q", have, want)
+		}
+		if have, want := r.URL.Query().Get("q"), `user.uuid="{alice}"`; have != want {
+			t.Errorf("wrong query: have %q, want %q", have, want)
+		}
+		fmt.Fprint(w, `{"values": [{
+			"permission": "read",
+			"user": {"uuid": "{alice}", "nickname": "alice"},
+			"repository": {"uuid": "{repo}", "full_name": "sourcegraph/sourcegraph"}
+		}]}`)
+	})
+
+	perms, next, err := cli.WorkspaceRepoPermissions(context.Background(), &PageToken{}, "sourcegraph", `user.uuid="{alice}"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.HasMore() {
+		t.Fatal("unexpected next page")
+	}
+
+	want := []*RepoPermission{{
+		Permission: "read",
+		User:       &Account{UUID: "{alice}", Nickname: "alice"},
+		Repository: &Repo{UUID: "{repo}", FullName: "sourcegraph/sourcegraph"},
+	}}
+	if diff := cmp.Diff(want, perms); diff != "" {
+		t.Fatalf("unexpected permissions (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,289 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+// PullRequest is a Bitbucket Cloud pull request.
+type PullRequest struct {
+	ID                int64              `json:"id"`
+	Title             string             `json:"title"`
+	Description       string             `json:"description"`
+	State             PullRequestState   `json:"state"`
+	Author            Account            `json:"author"`
+	Source            PullRequestBranch  `json:"source"`
+	Destination       PullRequestBranch  `json:"destination"`
+	Participants      []Participant      `json:"participants"`
+	Reviewers         []Account          `json:"reviewers"`
+	CloseSourceBranch bool               `json:"close_source_branch"`
+	MergeCommit       *PullRequestCommit `json:"merge_commit"`
+	CreatedOn         time.Time          `json:"created_on"`
+	UpdatedOn         time.Time          `json:"updated_on"`
+	Links             PullRequestLinks   `json:"links"`
+
+	// Statuses are the commit statuses of the pull request's source commit.
+	// They are not part of the pull request API response and are loaded
+	// separately with LoadPullRequestStatuses.
+	Statuses []*CommitStatus `json:"statuses,omitempty"`
+}
+
+// HasSourceCommit returns true if the given commit hash is the head of the
+// pull request's source branch. The pull request API abbreviates commit
+// hashes, so hashes are compared by prefix.
+func (pr *PullRequest) HasSourceCommit(hash string) bool {
+	short := pr.Source.Commit.Hash
+	return short != "" && strings.HasPrefix(hash, short)
+}
+
+// PullRequestState is the state of a pull request.
+type PullRequestState string
+
+const (
+	PullRequestStateOpen       PullRequestState = "OPEN"
+	PullRequestStateMerged     PullRequestState = "MERGED"
+	PullRequestStateDeclined   PullRequestState = "DECLINED"
+	PullRequestStateSuperseded PullRequestState = "SUPERSEDED"
+)
+
+// PullRequestBranch is the source or destination of a pull request.
+type PullRequestBranch struct {
+	Branch     PullRequestBranchName `json:"branch"`
+	Commit     PullRequestCommit     `json:"commit"`
+	Repository PullRequestRepository `json:"repository"`
+}
+
+type PullRequestBranchName struct {
+	Name string `json:"name"`
+}
+
+type PullRequestCommit struct {
+	Hash string `json:"hash"`
+}
+
+type PullRequestRepository struct {
+	FullName string `json:"full_name"`
+	UUID     string `json:"uuid"`
+}
+
+type PullRequestLinks struct {
+	HTML Link `json:"html"`
+}
+
+// Participant is a user taking part in the review of a pull request.
+type Participant struct {
+	User           Account          `json:"user"`
+	Role           ParticipantRole  `json:"role"`
+	Approved       bool             `json:"approved"`
+	State          ParticipantState `json:"state"`
+	ParticipatedOn time.Time        `json:"participated_on"`
+}
+
+type ParticipantRole string
+
+const (
+	ParticipantRoleParticipant ParticipantRole = "PARTICIPANT"
+	ParticipantRoleReviewer    ParticipantRole = "REVIEWER"
+)
+
+type ParticipantState string
+
+const (
+	ParticipantStateApproved         ParticipantState = "approved"
+	ParticipantStateChangesRequested ParticipantState = "changes_requested"
+)
+
+// CommitStatus is a build status reported against a commit.
+type CommitStatus struct {
+	StatusKey   string            `json:"key"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	URL         string            `json:"url"`
+	State       CommitStatusState `json:"state"`
+	Commit      PullRequestCommit `json:"commit"`
+	CreatedOn   time.Time         `json:"created_on"`
+	UpdatedOn   time.Time         `json:"updated_on"`
+}
+
+func (s *CommitStatus) Key() string {
+	return fmt.Sprintf("%s:%s", s.Commit.Hash, s.StatusKey)
+}
+
+type CommitStatusState string
+
+const (
+	CommitStatusStateSuccessful CommitStatusState = "SUCCESSFUL"
+	CommitStatusStateFailed     CommitStatusState = "FAILED"
+	CommitStatusStateInProgress CommitStatusState = "INPROGRESS"
+	CommitStatusStateStopped    CommitStatusState = "STOPPED"
+)
+
+// PullRequestInput contains the fields used to create or update a pull
+// request. Branches are given without a refs/heads/ prefix.
+type PullRequestInput struct {
+	Title             string
+	Description       string
+	SourceBranch      string
+	DestinationBranch string
+	CloseSourceBranch bool
+}
+
+func (input *PullRequestInput) payload() interface{} {
+	type branch struct {
+		Name string `json:"name"`
+	}
+	type endpoint struct {
+		Branch branch `json:"branch"`
+	}
+
+	return struct {
+		Title             string   `json:"title"`
+		Description       string   `json:"description"`
+		Source            endpoint `json:"source"`
+		Destination       endpoint `json:"destination"`
+		CloseSourceBranch bool     `json:"close_source_branch"`
+	}{
+		Title:             input.Title,
+		Description:       input.Description,
+		Source:            endpoint{Branch: branch{Name: input.SourceBranch}},
+		Destination:       endpoint{Branch: branch{Name: input.DestinationBranch}},
+		CloseSourceBranch: input.CloseSourceBranch,
+	}
+}
+
+// ErrPullRequestNotFound is returned by GetPullRequest when the pull request
+// has been deleted on upstream, or never existed.
+var ErrPullRequestNotFound = errors.New("pull request not found")
+
+// ErrNotMergeable is returned by MergePullRequest when the pull request failed
+// to merge, because a precondition is not met.
+var ErrNotMergeable = errors.New("pull request cannot be merged")
+
+// FindOpenPullRequest returns the open pull request from the given source
+// branch to the given destination branch in the repository, or nil if there is
+// none.
+func (c *Client) FindOpenPullRequest(ctx context.Context, repo *Repo, source, destination string) (*PullRequest, error) {
+	qry := url.Values{
+		"q": {fmt.Sprintf(
+			"source.branch.name = %q AND destination.branch.name = %q AND state = %q",
+			source, destination, PullRequestStateOpen,
+		)},
+	}
+
+	var prs []*PullRequest
+	if _, err := c.page(ctx, pullRequestsPath(repo), qry, nil, &prs); err != nil {
+		return nil, err
+	}
+	if len(prs) == 0 {
+		return nil, nil
+	}
+	return prs[0], nil
+}
+
+// CreatePullRequest opens a new pull request in the given repository.
+func (c *Client) CreatePullRequest(ctx context.Context, repo *Repo, input *PullRequestInput) (*PullRequest, error) {
+	var pr PullRequest
+	if err := c.send(ctx, "POST", pullRequestsPath(repo), nil, input.payload(), &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// GetPullRequest loads the pull request with the given ID from the repository.
+func (c *Client) GetPullRequest(ctx context.Context, repo *Repo, id int64) (*PullRequest, error) {
+	var pr PullRequest
+	if err := c.send(ctx, "GET", pullRequestPath(repo, id), nil, nil, &pr); err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, ErrPullRequestNotFound
+		}
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// UpdatePullRequest updates the title, description and destination branch of
+// the given pull request.
+func (c *Client) UpdatePullRequest(ctx context.Context, repo *Repo, id int64, input *PullRequestInput) (*PullRequest, error) {
+	var pr PullRequest
+	if err := c.send(ctx, "PUT", pullRequestPath(repo, id), nil, input.payload(), &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// DeclinePullRequest declines the given pull request. Declined pull requests
+// cannot be reopened on Bitbucket Cloud.
+func (c *Client) DeclinePullRequest(ctx context.Context, repo *Repo, id int64) (*PullRequest, error) {
+	var pr PullRequest
+	if err := c.send(ctx, "POST", pullRequestPath(repo, id)+"/decline", nil, nil, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// MergePullRequest merges the given pull request, either with a merge commit or
+// by squashing its commits.
+func (c *Client) MergePullRequest(ctx context.Context, repo *Repo, id int64, squash bool) (*PullRequest, error) {
+	strategy := "merge_commit"
+	if squash {
+		strategy = "squash"
+	}
+	payload := struct {
+		MergeStrategy string `json:"merge_strategy"`
+	}{strategy}
+
+	var pr PullRequest
+	if err := c.send(ctx, "POST", pullRequestPath(repo, id)+"/merge", nil, payload, &pr); err != nil {
+		if errcode.IsBadRequest(err) {
+			return nil, errors.Wrap(ErrNotMergeable, err.Error())
+		}
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// CreatePullRequestComment posts a comment on the given pull request.
+func (c *Client) CreatePullRequestComment(ctx context.Context, repo *Repo, id int64, text string) error {
+	payload := struct {
+		Content struct {
+			Raw string `json:"raw"`
+		} `json:"content"`
+	}{}
+	payload.Content.Raw = text
+
+	return c.send(ctx, "POST", pullRequestPath(repo, id)+"/comments", nil, payload, nil)
+}
+
+// LoadPullRequestStatuses loads the commit statuses of the given pull request
+// into its Statuses field.
+func (c *Client) LoadPullRequestStatuses(ctx context.Context, repo *Repo, pr *PullRequest) error {
+	var statuses []*CommitStatus
+
+	next, err := c.page(ctx, pullRequestPath(repo, pr.ID)+"/statuses", nil, nil, &statuses)
+	for err == nil && next.HasMore() {
+		var page []*CommitStatus
+		next, err = c.reqPage(ctx, next.Next, &page)
+		statuses = append(statuses, page...)
+	}
+	if err != nil {
+		return err
+	}
+
+	pr.Statuses = statuses
+	return nil
+}
+
+func pullRequestsPath(repo *Repo) string {
+	return fmt.Sprintf("/2.0/repositories/%s/pullrequests", repo.FullName)
+}
+
+func pullRequestPath(repo *Repo, id int64) string {
+	return fmt.Sprintf("%s/%d", pullRequestsPath(repo), id)
+}
@@ -0,0 +1,187 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/go-cmp/cmp"
+)
+
+func newPullRequestTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cli := NewClient(u, srv.Client())
+	cli.Username = "user"
+	cli.AppPassword = "password"
+	return cli
+}
+
+var testRepo = &Repo{FullName: "sourcegraph/sourcegraph"}
+
+func TestClient_CreatePullRequest(t *testing.T) {
+	var payload map[string]interface{}
+	cli := newPullRequestTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if have, want := r.Method+" "+r.URL.Path, "POST /2.0/repositories/sourcegraph/sourcegraph/pullrequests"; have != want {
+			t.Errorf("wrong request: have %q, want %q", have, want)
+		}
+		if username, password, _ := r.BasicAuth(); username != "user" || password != "password" {
+			t.Errorf("wrong credentials: %q %q", username, password)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatal(err)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": 42, "title": "Title", "state": "OPEN", "source": {"branch": {"name": "my-branch"}, "commit": {"hash": "abcdef012345"}}}`))
+	})
+
+	pr, err := cli.CreatePullRequest(context.Background(), testRepo, &PullRequestInput{
+		Title:             "Title",
+		Description:       "Body",
+		SourceBranch:      "my-branch",
+		DestinationBranch: "main",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pr.ID != 42 || pr.State != PullRequestStateOpen || pr.Source.Branch.Name != "my-branch" {
+		t.Errorf("unexpected pull request: %+v", pr)
+	}
+	if !pr.HasSourceCommit("abcdef0123456789abcdef0123456789abcdef01") {
+		t.Error("expected full hash to match abbreviated source commit")
+	}
+
+	wantPayload := map[string]interface{}{
+		"title":               "Title",
+		"description":         "Body",
+		"source":              map[string]interface{}{"branch": map[string]interface{}{"name": "my-branch"}},
+		"destination":         map[string]interface{}{"branch": map[string]interface{}{"name": "main"}},
+		"close_source_branch": false,
+	}
+	if diff := cmp.Diff(wantPayload, payload); diff != "" {
+		t.Errorf("unexpected payload (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_FindOpenPullRequest(t *testing.T) {
+	for name, tc := range map[string]struct {
+		response string
+		wantID   int64
+	}{
+		"none":  {response: `{"values": []}`},
+		"found": {response: `{"values": [{"id": 7}]}`, wantID: 7},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cli := newPullRequestTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				want := `source.branch.name = "my-branch" AND destination.branch.name = "main" AND state = "OPEN"`
+				if have := r.URL.Query().Get("q"); have != want {
+					t.Errorf("wrong query: have %q, want %q", have, want)
+				}
+				_, _ = w.Write([]byte(tc.response))
+			})
+
+			pr, err := cli.FindOpenPullRequest(context.Background(), testRepo, "my-branch", "main")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.wantID == 0 {
+				if pr != nil {
+					t.Errorf("unexpected pull request: %+v", pr)
+				}
+				return
+			}
+			if pr == nil || pr.ID != tc.wantID {
+				t.Errorf("unexpected pull request: %+v", pr)
+			}
+		})
+	}
+}
+
+func TestClient_GetPullRequest_NotFound(t *testing.T) {
+	cli := newPullRequestTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := cli.GetPullRequest(context.Background(), testRepo, 1)
+	if err != ErrPullRequestNotFound {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestClient_MergePullRequest(t *testing.T) {
+	t.Run("squash", func(t *testing.T) {
+		cli := newPullRequestTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if have, want := r.URL.Path, "/2.0/repositories/sourcegraph/sourcegraph/pullrequests/3/merge"; have != want {
+				t.Errorf("wrong path: have %q, want %q", have, want)
+			}
+			body, _ := io.ReadAll(r.Body)
+			if have, want := string(body), `{"merge_strategy":"squash"}`; have != want {
+				t.Errorf("wrong payload: have %q, want %q", have, want)
+			}
+			_, _ = w.Write([]byte(`{"id": 3, "state": "MERGED"}`))
+		})
+
+		pr, err := cli.MergePullRequest(context.Background(), testRepo, 3, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pr.State != PullRequestStateMerged {
+			t.Errorf("unexpected state: %q", pr.State)
+		}
+	})
+
+	t.Run("not mergeable", func(t *testing.T) {
+		cli := newPullRequestTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": {"message": "You can't merge until you resolve all merge conflicts."}}`))
+		})
+
+		_, err := cli.MergePullRequest(context.Background(), testRepo, 3, false)
+		if !errors.Is(err, ErrNotMergeable) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestClient_LoadPullRequestStatuses(t *testing.T) {
+	var srvURL string
+	cli := newPullRequestTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`{"values": [{"key": "b", "state": "FAILED", "commit": {"hash": "abc"}}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"values": [{"key": "a", "state": "SUCCESSFUL", "commit": {"hash": "abc"}}], "next": "` + srvURL + `/2.0/repositories/sourcegraph/sourcegraph/pullrequests/5/statuses?page=2"}`))
+	})
+	srvURL = cli.URL.String()
+
+	pr := &PullRequest{ID: 5}
+	if err := cli.LoadPullRequestStatuses(context.Background(), testRepo, pr); err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []string
+	for _, s := range pr.Statuses {
+		keys = append(keys, s.Key())
+	}
+	if diff := cmp.Diff([]string{"abc:a", "abc:b"}, keys); diff != "" {
+		t.Errorf("unexpected statuses (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,64 @@
+package bitbucketcloud
+
+import (
+	"context"
+
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+// UserEmail is an email address of a Bitbucket Cloud user.
+type UserEmail struct {
+	Email       string `json:"email"`
+	IsPrimary   bool   `json:"is_primary"`
+	IsConfirmed bool   `json:"is_confirmed"`
+}
+
+// CurrentUserEmails returns the email addresses of the account the client is
+// authenticated as. It requires the "email" OAuth scope.
+func (c *Client) CurrentUserEmails(ctx context.Context) (emails []*UserEmail, err error) {
+	t := &PageToken{}
+	for first := true; first || t.HasMore(); first = false {
+		var page []*UserEmail
+		if t.HasMore() {
+			t, err = c.reqPage(ctx, t.Next, &page)
+		} else {
+			t, err = c.page(ctx, "/2.0/user/emails", nil, t, &page)
+		}
+		if err != nil {
+			return nil, err
+		}
+		emails = append(emails, page...)
+	}
+	return emails, nil
+}
+
+// GetExternalAccountData returns the deserialized user and token from the external account data
+// JSON blob in a typesafe way.
+func GetExternalAccountData(data *extsvc.AccountData) (usr *Account, tok *oauth2.Token, err error) {
+	var (
+		u Account
+		t oauth2.Token
+	)
+
+	if data.Data != nil {
+		if err := data.GetAccountData(&u); err != nil {
+			return nil, nil, err
+		}
+		usr = &u
+	}
+	if data.AuthData != nil {
+		if err := data.GetAuthData(&t); err != nil {
+			return nil, nil, err
+		}
+		tok = &t
+	}
+	return usr, tok, nil
+}
+
+// SetExternalAccountData sets the user and token into the external account data blob.
+func SetExternalAccountData(data *extsvc.AccountData, user *Account, token *oauth2.Token) {
+	data.SetAccountData(user)
+	data.SetAuthData(token)
+}
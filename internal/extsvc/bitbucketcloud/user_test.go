@@ -0,0 +1,38 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_CurrentUserEmails(t *testing.T) {
+	var next string
+	cli := newPullRequestTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if have, want := r.Header.Get("Authorization"), "Bearer token"; have != want {
+			t.Errorf("wrong authorization: have %q, want %q", have, want)
+		}
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"values": [{"email": "b@example.com", "is_confirmed": false}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"values": [{"email": "a@example.com", "is_primary": true, "is_confirmed": true}], "next": %q}`, next)
+	}).WithToken("token")
+	next = cli.URL.String() + "/2.0/user/emails?page=2"
+
+	emails, err := cli.CurrentUserEmails(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*UserEmail{
+		{Email: "a@example.com", IsPrimary: true, IsConfirmed: true},
+		{Email: "b@example.com"},
+	}
+	if diff := cmp.Diff(want, emails); diff != "" {
+		t.Fatalf("unexpected emails (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,260 @@
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing-contrib/go-stdlib/nethttp"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
+)
+
+var requestCounter = metrics.NewRequestMeter("gerrit_requests_count", "Total number of requests sent to the Gerrit API.")
+
+// These fields define the self-imposed Gerrit rate limit, since Gerrit does
+// not report rate limits in HTTP response headers.
+//
+// See https://godoc.org/golang.org/x/time/rate#Limiter for an explanation of these fields.
+const (
+	rateLimitRequestsPerSecond = 2 // 120/min or 7200/hr
+	rateLimitMaxBurstRequests  = 100
+)
+
+// perPage is the number of projects requested per page.
+const perPage = 100
+
+// magicPrefix prefixes all JSON responses of the Gerrit REST API to prevent
+// cross-site script inclusion. See
+// https://gerrit-review.googlesource.com/Documentation/rest-api.html#output.
+var magicPrefix = []byte(")]}'")
+
+// Client accesses a Gerrit instance via its REST API.
+type Client struct {
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// URL is the base URL of the Gerrit instance.
+	URL *url.URL
+
+	// The username and HTTP password credentials for accessing the instance.
+	username, password string
+
+	// runAs is the username of the user on whose behalf requests are sent, if
+	// any. It requires the authenticated user to have the Run As capability.
+	runAs string
+
+	// rateLimit is the self-imposed rate limiter.
+	rateLimit *rate.Limiter
+}
+
+// NewClient creates a new Gerrit API client for the instance at baseURL. If
+// username is non-empty, requests are authenticated with the given HTTP
+// password. If a nil httpClient is provided, httpcli.ExternalDoer will be
+// used.
+func NewClient(baseURL *url.URL, username, password string, httpClient httpcli.Doer) *Client {
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	httpClient = requestCounter.Doer(httpClient, func(u *url.URL) string {
+		// The first component of the Path after the optional /a/ prefix of
+		// authenticated requests maps to the type of API request we are
+		// making.
+		parts := strings.Split(strings.TrimPrefix(u.Path, "/a/"), "/")
+		return strings.Trim(parts[0], "/")
+	})
+
+	// Our registry returns an infinite limiter when nothing has been synced
+	// from config, but we always want some form of rate limiting for Gerrit.
+	defaultLimiter := rate.NewLimiter(rateLimitRequestsPerSecond, rateLimitMaxBurstRequests)
+
+	return &Client{
+		httpClient: httpClient,
+		URL:        baseURL,
+		username:   username,
+		password:   password,
+		rateLimit:  ratelimit.DefaultRegistry.GetOrSet(baseURL.String(), defaultLimiter),
+	}
+}
+
+// WithRunAs returns a copy of c that sends requests on behalf of the user with
+// the given username. The user c is authenticated as must have the Run As
+// global capability.
+func (c *Client) WithRunAs(username string) *Client {
+	c2 := *c
+	c2.runAs = username
+	return &c2
+}
+
+// ListProjects returns the code projects visible to the authenticated user,
+// skipping the first skip projects. more is true if there are more projects
+// after the returned ones.
+func (c *Client) ListProjects(ctx context.Context, skip int) (projects []*Project, more bool, err error) {
+	qry := url.Values{
+		"d":    nil, // include descriptions
+		"type": []string{"CODE"},
+		"n":    []string{strconv.Itoa(perPage)},
+		"S":    []string{strconv.Itoa(skip)},
+	}
+
+	var result map[string]*Project
+	if err := c.do(ctx, "projects/", qry, &result); err != nil {
+		return nil, false, err
+	}
+
+	projects = make([]*Project, 0, len(result))
+	for name, p := range result {
+		p.Name = name
+		if p.MoreProjects {
+			more = true
+		}
+		projects = append(projects, p)
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Name < projects[j].Name })
+	return projects, more, nil
+}
+
+// GetProject returns the project with the given name.
+func (c *Client) GetProject(ctx context.Context, name string) (*Project, error) {
+	var project Project
+	if err := c.do(ctx, "projects/"+url.PathEscape(name), nil, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (c *Client) do(ctx context.Context, path string, qry url.Values, result interface{}) error {
+	prefix := strings.TrimSuffix(c.URL.Path, "/") + "/"
+	if c.username != "" {
+		// Authenticated requests are sent to the /a/ endpoints.
+		prefix += "a/"
+	}
+
+	u := *c.URL
+	u.RawPath = prefix + path
+	u.Path, _ = url.PathUnescape(u.RawPath)
+	u.RawQuery = encodeQuery(qry)
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	if c.runAs != "" {
+		req.Header.Set("X-Gerrit-RunAs", c.runAs)
+	}
+
+	req, ht := nethttp.TraceRequest(ot.GetTracer(ctx),
+		req.WithContext(ctx),
+		nethttp.OperationName("Gerrit"),
+		nethttp.ClientTrace(false))
+	defer ht.Finish()
+
+	startWait := time.Now()
+	if err := c.rateLimit.Wait(ctx); err != nil {
+		return err
+	}
+	if d := time.Since(startWait); d > 200*time.Millisecond {
+		log15.Warn("Gerrit self-enforced API rate limit: request delayed longer than expected due to rate limit", "delay", d)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	return json.Unmarshal(bytes.TrimPrefix(bs, magicPrefix), result)
+}
+
+// encodeQuery encodes qry like url.Values.Encode, except that parameters
+// without values are encoded as flags without a "=".
+func encodeQuery(qry url.Values) string {
+	keys := make([]string, 0, len(qry))
+	for k := range qry {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var params []string
+	for _, k := range keys {
+		if len(qry[k]) == 0 {
+			params = append(params, url.QueryEscape(k))
+			continue
+		}
+		for _, v := range qry[k] {
+			params = append(params, url.QueryEscape(k)+"="+url.QueryEscape(v))
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// Project is a Gerrit project, as described by the ProjectInfo entity of the
+// REST API.
+type Project struct {
+	// ID is the URL-encoded name of the project.
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Parent      string `json:"parent,omitempty"`
+	Description string `json:"description,omitempty"`
+	// State is one of ACTIVE, READ_ONLY or HIDDEN.
+	State string `json:"state,omitempty"`
+
+	// MoreProjects is set on the last project of a page of results if there
+	// are more projects.
+	MoreProjects bool `json:"_more_projects,omitempty"`
+}
+
+// AccountData is the data stored for a Gerrit user's external account.
+type AccountData struct {
+	Username string `json:"username"`
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Gerrit API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
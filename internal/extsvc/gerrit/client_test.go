@@ -0,0 +1,104 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewClient(u, "admin", "secret", nil)
+}
+
+func TestClient_ListProjects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a/projects/", func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "admin" || password != "secret" {
+			t.Errorf("unexpected credentials %q:%q", username, password)
+		}
+		if have, want := r.URL.RawQuery, "S=0&d&n=100&type=CODE"; have != want {
+			t.Errorf("unexpected query. want=%q have=%q", want, have)
+		}
+		fmt.Fprint(w, `)]}'
+{
+  "tools/repo": {"id": "tools%2Frepo", "state": "ACTIVE"},
+  "platform/build": {"id": "platform%2Fbuild", "description": "Build system", "state": "ACTIVE", "_more_projects": true}
+}`)
+	})
+	c := newTestClient(t, mux)
+
+	projects, more, err := c.ListProjects(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Project{
+		{ID: "platform%2Fbuild", Name: "platform/build", Description: "Build system", State: "ACTIVE", MoreProjects: true},
+		{ID: "tools%2Frepo", Name: "tools/repo", State: "ACTIVE"},
+	}
+	if diff := cmp.Diff(want, projects); diff != "" {
+		t.Errorf("unexpected projects (-want +got):\n%s", diff)
+	}
+	if !more {
+		t.Errorf("expected more projects")
+	}
+}
+
+func TestClient_GetProject(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a/projects/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/a/projects/platform%2Fbuild" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `)]}'
+{"id": "platform%2Fbuild", "name": "platform/build", "state": "ACTIVE"}`)
+	})
+	c := newTestClient(t, mux)
+
+	project, err := c.GetProject(context.Background(), "platform/build")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&Project{ID: "platform%2Fbuild", Name: "platform/build", State: "ACTIVE"}, project); diff != "" {
+		t.Errorf("unexpected project (-want +got):\n%s", diff)
+	}
+
+	_, err = c.GetProject(context.Background(), "missing")
+	if !errcode.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestClient_WithRunAs(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a/projects/", func(w http.ResponseWriter, r *http.Request) {
+		if have, want := r.Header.Get("X-Gerrit-RunAs"), "alice"; have != want {
+			t.Errorf("unexpected X-Gerrit-RunAs header. want=%q have=%q", want, have)
+		}
+		fmt.Fprint(w, `)]}'
+{}`)
+	})
+	c := newTestClient(t, mux)
+
+	if _, _, err := c.WithRunAs("alice").ListProjects(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+	if c.runAs != "" {
+		t.Errorf("WithRunAs modified the original client")
+	}
+}
@@ -0,0 +1,277 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing-contrib/go-stdlib/nethttp"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/linkheader"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
+)
+
+var requestCounter = metrics.NewRequestMeter("gitea_requests_count", "Total number of requests sent to the Gitea API.")
+
+// These fields define the self-imposed Gitea rate limit, since Gitea does not
+// report rate limits in HTTP response headers.
+//
+// See https://godoc.org/golang.org/x/time/rate#Limiter for an explanation of these fields.
+const (
+	rateLimitRequestsPerSecond = 2 // 120/min or 7200/hr
+	rateLimitMaxBurstRequests  = 100
+)
+
+// perPage is the number of repositories requested per page. Gitea caps it at
+// its configured MAX_RESPONSE_ITEMS, which defaults to 50.
+const perPage = 50
+
+// Client accesses a Gitea instance via the REST API v1.
+type Client struct {
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// URL is the base URL of the Gitea instance.
+	URL *url.URL
+
+	// token is the access token used to authenticate API requests.
+	token string
+
+	// sudo is the username of the user on whose behalf requests are sent, if
+	// any. It requires the token of a site admin.
+	sudo string
+
+	// rateLimit is the self-imposed rate limiter.
+	rateLimit *rate.Limiter
+}
+
+// NewClient creates a new Gitea API client for the instance at baseURL,
+// authenticating with the given access token. If a nil httpClient is
+// provided, httpcli.ExternalDoer will be used.
+func NewClient(baseURL *url.URL, token string, httpClient httpcli.Doer) *Client {
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	httpClient = requestCounter.Doer(httpClient, func(u *url.URL) string {
+		// The third component of the Path (/api/v1/<category>/...) mostly
+		// maps to the type of API request we are making.
+		var category string
+		if parts := strings.SplitN(u.Path, "/", 5); len(parts) > 3 {
+			category = parts[3]
+		}
+		return category
+	})
+
+	// Our registry returns an infinite limiter when nothing has been synced
+	// from config, but we always want some form of rate limiting for Gitea.
+	defaultLimiter := rate.NewLimiter(rateLimitRequestsPerSecond, rateLimitMaxBurstRequests)
+
+	return &Client{
+		httpClient: httpClient,
+		URL:        baseURL,
+		token:      token,
+		rateLimit:  ratelimit.DefaultRegistry.GetOrSet(baseURL.String(), defaultLimiter),
+	}
+}
+
+// WithSudo returns a copy of c that sends requests on behalf of the user with
+// the given username. c must be authenticated as a site admin.
+func (c *Client) WithSudo(username string) *Client {
+	c2 := *c
+	c2.sudo = username
+	return &c2
+}
+
+// ListOrgRepos returns the given page of repositories of the organization
+// org. The returned next page is 0 if there are no more pages.
+func (c *Client) ListOrgRepos(ctx context.Context, org string, page int) (repos []*Repository, next int, err error) {
+	qry := pageQuery(page)
+	next, err = c.page(ctx, "orgs/"+org+"/repos", qry, &repos)
+	return repos, next, err
+}
+
+// SearchRepos returns the given page of repositories visible to the
+// authenticated user that match query. An empty query matches all
+// repositories. The returned next page is 0 if there are no more pages.
+func (c *Client) SearchRepos(ctx context.Context, query string, page int) (repos []*Repository, next int, err error) {
+	qry := pageQuery(page)
+	if query != "" {
+		qry.Set("q", query)
+	}
+
+	var result struct {
+		OK   bool          `json:"ok"`
+		Data []*Repository `json:"data"`
+	}
+	next, err = c.page(ctx, "repos/search", qry, &result)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !result.OK {
+		return nil, 0, errors.Errorf("Gitea repository search for %q failed", query)
+	}
+	return result.Data, next, nil
+}
+
+// GetRepo returns the repository with the given owner and name.
+func (c *Client) GetRepo(ctx context.Context, owner, name string) (*Repository, error) {
+	var repo Repository
+	if _, err := c.do(ctx, "repos/"+owner+"/"+name, nil, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// CurrentUser returns the user the client is authenticated as.
+func (c *Client) CurrentUser(ctx context.Context) (*User, error) {
+	var user User
+	if _, err := c.do(ctx, "user", nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func pageQuery(page int) url.Values {
+	if page < 1 {
+		page = 1
+	}
+	return url.Values{
+		"page":  []string{strconv.Itoa(page)},
+		"limit": []string{strconv.Itoa(perPage)},
+	}
+}
+
+// page requests a page of a paginated API endpoint and returns the number of
+// the next page, as advertised in the Link header of the response, or 0 if
+// this is the last page.
+func (c *Client) page(ctx context.Context, path string, qry url.Values, result interface{}) (int, error) {
+	resp, err := c.do(ctx, path, qry, result)
+	if err != nil {
+		return 0, err
+	}
+
+	nextURL, ok := linkheader.ExtractNextURL(resp)
+	if !ok {
+		return 0, nil
+	}
+	u, err := url.Parse(nextURL)
+	if err != nil {
+		return 0, errors.Wrap(err, "parsing next page URL")
+	}
+	next, err := strconv.Atoi(u.Query().Get("page"))
+	if err != nil {
+		return 0, errors.Wrap(err, "parsing next page number")
+	}
+	return next, nil
+}
+
+func (c *Client) do(ctx context.Context, path string, qry url.Values, result interface{}) (*http.Response, error) {
+	u := *c.URL
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/api/v1/" + path
+	u.RawQuery = qry.Encode()
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+	if c.sudo != "" {
+		req.Header.Set("Sudo", c.sudo)
+	}
+
+	req, ht := nethttp.TraceRequest(ot.GetTracer(ctx),
+		req.WithContext(ctx),
+		nethttp.OperationName("Gitea"),
+		nethttp.ClientTrace(false))
+	defer ht.Finish()
+
+	startWait := time.Now()
+	if err := c.rateLimit.Wait(ctx); err != nil {
+		return nil, err
+	}
+	if d := time.Since(startWait); d > 200*time.Millisecond {
+		log15.Warn("Gitea self-enforced API rate limit: request delayed longer than expected due to rate limit", "delay", d)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	return resp, json.Unmarshal(bs, result)
+}
+
+// Repository is a Gitea repository.
+type Repository struct {
+	ID          int64  `json:"id"`
+	Owner       *User  `json:"owner"`
+	Name        string `json:"name"`
+	FullName    string `json:"full_name"`
+	Description string `json:"description"`
+	Private     bool   `json:"private"`
+	Fork        bool   `json:"fork"`
+	Archived    bool   `json:"archived"`
+	Empty       bool   `json:"empty"`
+	Mirror      bool   `json:"mirror"`
+	HTMLURL     string `json:"html_url"`
+	CloneURL    string `json:"clone_url"`
+	SSHURL      string `json:"ssh_url"`
+}
+
+// User is a Gitea user or organization.
+type User struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+}
+
+// AccountData is the data stored for a Gitea user's external account.
+type AccountData struct {
+	Username string `json:"username"`
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Gitea API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
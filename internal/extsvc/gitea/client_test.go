@@ -0,0 +1,128 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL + "/gitea")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewClient(u, "secret", nil)
+}
+
+func TestClient_SearchRepos(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/gitea/api/v1/repos/search", func(w http.ResponseWriter, r *http.Request) {
+		if have, want := r.Header.Get("Authorization"), "token secret"; have != want {
+			t.Errorf("unexpected Authorization header. want=%q have=%q", want, have)
+		}
+		if have, want := r.URL.Query().Get("q"), "frontend"; have != want {
+			t.Errorf("unexpected query. want=%q have=%q", want, have)
+		}
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/gitea/api/v1/repos/search?limit=50&page=2&q=frontend>; rel="next"`, r.Host))
+			fmt.Fprint(w, `{"ok": true, "data": [{"id": 1, "full_name": "org/frontend"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"ok": true, "data": [{"id": 2, "full_name": "org/frontend-legacy"}]}`)
+		default:
+			t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+	})
+	c := newTestClient(t, mux)
+
+	repos, next, err := c.SearchRepos(context.Background(), "frontend", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]*Repository{{ID: 1, FullName: "org/frontend"}}, repos); diff != "" {
+		t.Errorf("unexpected repos (-want +got):\n%s", diff)
+	}
+	if next != 2 {
+		t.Fatalf("unexpected next page. want=%d have=%d", 2, next)
+	}
+
+	repos, next, err = c.SearchRepos(context.Background(), "frontend", next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]*Repository{{ID: 2, FullName: "org/frontend-legacy"}}, repos); diff != "" {
+		t.Errorf("unexpected repos (-want +got):\n%s", diff)
+	}
+	if next != 0 {
+		t.Errorf("unexpected next page. want=%d have=%d", 0, next)
+	}
+}
+
+func TestClient_ListOrgRepos(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/gitea/api/v1/orgs/org/repos", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 1, "full_name": "org/frontend", "private": true}]`)
+	})
+	c := newTestClient(t, mux)
+
+	repos, next, err := c.ListOrgRepos(context.Background(), "org", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]*Repository{{ID: 1, FullName: "org/frontend", Private: true}}, repos); diff != "" {
+		t.Errorf("unexpected repos (-want +got):\n%s", diff)
+	}
+	if next != 0 {
+		t.Errorf("unexpected next page. want=%d have=%d", 0, next)
+	}
+}
+
+func TestClient_GetRepo(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/gitea/api/v1/repos/org/frontend", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "full_name": "org/frontend", "owner": {"id": 3, "login": "org"}}`)
+	})
+	c := newTestClient(t, mux)
+
+	repo, err := c.GetRepo(context.Background(), "org", "frontend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&Repository{ID: 1, FullName: "org/frontend", Owner: &User{ID: 3, Login: "org"}}, repo); diff != "" {
+		t.Errorf("unexpected repo (-want +got):\n%s", diff)
+	}
+
+	_, err = c.GetRepo(context.Background(), "org", "missing")
+	if !errcode.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestClient_WithSudo(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/gitea/api/v1/repos/search", func(w http.ResponseWriter, r *http.Request) {
+		if have, want := r.Header.Get("Sudo"), "alice"; have != want {
+			t.Errorf("unexpected Sudo header. want=%q have=%q", want, have)
+		}
+		fmt.Fprint(w, `{"ok": true, "data": []}`)
+	})
+	c := newTestClient(t, mux)
+
+	if _, _, err := c.WithSudo("alice").SearchRepos(context.Background(), "", 1); err != nil {
+		t.Fatal(err)
+	}
+	if c.sudo != "" {
+		t.Errorf("WithSudo modified the original client")
+	}
+}
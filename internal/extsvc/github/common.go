@@ -174,8 +174,16 @@ type PullRequest struct {
 	IsDraft       bool
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+
+	// Mergeable is the MergeableState of the pull request: MERGEABLE,
+	// CONFLICTING or UNKNOWN while GitHub computes it in the background.
+	Mergeable string `json:",omitempty"`
 }
 
+// PullRequestMergeableStateConflicting is the MergeableState of pull requests
+// that can't be merged because of conflicts with the base branch.
+const PullRequestMergeableStateConflicting = "CONFLICTING"
+
 // AssignedEvent represents an 'assigned' event on a PullRequest.
 type AssignedEvent struct {
 	Actor     Actor
@@ -1386,6 +1394,7 @@ fragment pr on PullRequest {
   baseRefOid
   headRefName
   baseRefName
+  mergeable
   %s
   author {
     ...actor
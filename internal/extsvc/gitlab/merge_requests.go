@@ -24,6 +24,15 @@ const (
 	MergeRequestStateMerged MergeRequestState = "merged"
 )
 
+// MergeStatus is the result of GitLab checking whether a merge request can be
+// merged into its target branch.
+type MergeStatus string
+
+const (
+	MergeStatusCanBeMerged    MergeStatus = "can_be_merged"
+	MergeStatusCannotBeMerged MergeStatus = "cannot_be_merged"
+)
+
 type MergeRequest struct {
 	ID             ID                `json:"id"`
 	IID            ID                `json:"iid"`
@@ -41,6 +50,7 @@ type MergeRequest struct {
 	TargetBranch   string            `json:"target_branch"`
 	WebURL         string            `json:"web_url"`
 	WorkInProgress bool              `json:"work_in_progress"`
+	MergeStatus    MergeStatus       `json:"merge_status,omitempty"`
 	Author         User              `json:"author"`
 
 	DiffRefs DiffRefs `json:"diff_refs"`
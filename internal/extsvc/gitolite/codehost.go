@@ -27,3 +27,8 @@ func CloneURL(externalRepoSpec api.ExternalRepoSpec) string {
 	gitoliteName := externalRepoSpec.ID
 	return host + ":" + gitoliteName
 }
+
+// AccountData is the data stored for a Gitolite user's external account.
+type AccountData struct {
+	Username string `json:"username"`
+}
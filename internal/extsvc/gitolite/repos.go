@@ -4,8 +4,10 @@ import (
 	"context"
 	"net/url"
 	"os/exec"
+	"regexp"
 	"strings"
 
+	"github.com/cockroachdb/errors"
 	"github.com/inconshreveable/log15"
 )
 
@@ -35,7 +37,26 @@ func NewClient(host string) *Client {
 }
 
 func (c *Client) ListRepos(ctx context.Context) ([]*Repo, error) {
-	out, err := exec.CommandContext(ctx, "ssh", c.Host, "info").Output()
+	return c.listRepos(ctx, "info")
+}
+
+// usernamePattern matches valid Gitolite usernames. It mirrors
+// $USERNAME_PATT of Gitolite.
+var usernamePattern = regexp.MustCompile(`^[0-9a-zA-Z][-0-9a-zA-Z._@+]*$`)
+
+// ListReposForUser lists the repositories the given Gitolite user has read
+// access to. It runs the info command on behalf of user with the sudo
+// command, which must be enabled on the Gitolite server and is only allowed
+// for Gitolite admins.
+func (c *Client) ListReposForUser(ctx context.Context, user string) ([]*Repo, error) {
+	if !usernamePattern.MatchString(user) {
+		return nil, errors.Errorf("invalid Gitolite username %q", user)
+	}
+	return c.listRepos(ctx, "sudo", user, "info")
+}
+
+func (c *Client) listRepos(ctx context.Context, command ...string) ([]*Repo, error) {
+	out, err := exec.CommandContext(ctx, "ssh", append([]string{c.Host}, command...)...).Output()
 	if err != nil {
 		log15.Error("listing gitolite failed", "error", err, "out", string(out))
 		return nil, maybeUnauthorized(err)
@@ -1,6 +1,7 @@
 package gitolite
 
 import (
+	"context"
 	"testing"
 
 	"github.com/cockroachdb/errors"
@@ -75,3 +76,12 @@ func TestMaybeUnauthorized(t *testing.T) {
 		t.Errorf("Should be unauthorized")
 	}
 }
+
+func TestListReposForUser_InvalidUsername(t *testing.T) {
+	c := NewClient("git@gitolite.example.com")
+	for _, user := range []string{"", "-oProxyCommand=evil", "alice bob", "alice;info"} {
+		if _, err := c.ListReposForUser(context.Background(), user); err == nil {
+			t.Errorf("expected error for username %q", user)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Package proxy implements a client for Go module proxies, as described in
+// https://golang.org/ref/mod#goproxy-protocol.
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
+	"golang.org/x/mod/module"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// DefaultURL is the proxy used when none is configured.
+const DefaultURL = "https://proxy.golang.org"
+
+// Client fetches modules from Go module proxies.
+type Client interface {
+	// DoesDependencyExist returns whether the given version of a module can
+	// be fetched from one of the proxies.
+	DoesDependencyExist(ctx context.Context, dependency reposource.GoDependency) (bool, error)
+
+	// FetchZip returns the zip file of the given version of a module. The
+	// caller must close it.
+	FetchZip(ctx context.Context, dependency reposource.GoDependency) (io.ReadCloser, error)
+}
+
+// HTTPClient is a Client for the proxies configured in a Go modules
+// connection. Like the go command, it tries each proxy in turn until one of
+// them has the requested module.
+type HTTPClient struct {
+	urls    []string
+	doer    httpcli.Doer
+	limiter *rate.Limiter
+}
+
+var _ Client = &HTTPClient{}
+
+// httpFactory doesn't cache responses, since module zips can be large and
+// versions of modules are immutable anyway.
+var httpFactory = httpcli.NewFactory(
+	httpcli.NewMiddleware(httpcli.ContextErrorMiddleware),
+	httpcli.ExternalTransportOpt,
+	httpcli.TracedTransportOpt,
+)
+
+// NewHTTPClient returns a client for the proxies configured in c.
+func NewHTTPClient(c *schema.GoModulesConnection) (*HTTPClient, error) {
+	doer, err := httpFactory.Doer()
+	if err != nil {
+		return nil, err
+	}
+	urls := make([]string, 0, len(c.Urls))
+	for _, u := range c.Urls {
+		urls = append(urls, strings.TrimSuffix(u, "/"))
+	}
+	if len(urls) == 0 {
+		urls = []string{DefaultURL}
+	}
+	return &HTTPClient{
+		urls:    urls,
+		doer:    doer,
+		limiter: ratelimit.DefaultRegistry.Get("go"),
+	}, nil
+}
+
+func (c *HTTPClient) DoesDependencyExist(ctx context.Context, dependency reposource.GoDependency) (bool, error) {
+	resp, err := c.get(ctx, dependency, "info")
+	if err == nil {
+		resp.Body.Close()
+		return true, nil
+	}
+	if errcode.IsNotFound(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+func (c *HTTPClient) FetchZip(ctx context.Context, dependency reposource.GoDependency) (io.ReadCloser, error) {
+	resp, err := c.get(ctx, dependency, "zip")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch zip of Go module %s", dependency.PackageManagerSyntax())
+	}
+	return resp.Body, nil
+}
+
+// get requests the file with the given extension of dependency from each
+// proxy in turn. As with GOPROXY, we only fall back to the next proxy if the
+// module isn't found.
+func (c *HTTPClient) get(ctx context.Context, dependency reposource.GoDependency, ext string) (*http.Response, error) {
+	escapedPath, err := module.EscapePath(dependency.Path)
+	if err != nil {
+		return nil, err
+	}
+	escapedVersion, err := module.EscapeVersion(dependency.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs error
+	for _, proxyURL := range c.urls {
+		resp, err := c.do(ctx, fmt.Sprintf("%s/%s/@v/%s.%s", proxyURL, escapedPath, escapedVersion, ext))
+		if err == nil {
+			return resp, nil
+		}
+		if !errcode.IsNotFound(err) {
+			return nil, err
+		}
+		errs = multierror.Append(errs, err)
+	}
+	return nil, &notFoundError{dependency: dependency, err: errs}
+}
+
+// do sends a GET request to u and returns the response if it was successful.
+// The caller must close the body of the response.
+func (c *HTTPClient) do(ctx context.Context, u string) (*http.Response, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	// Proxies respond with 404 or 410 if they don't have a module.
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return nil, &notFoundError{err: errors.Errorf("%s: %s", u, body)}
+	}
+	return nil, errors.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, u, body)
+}
+
+type notFoundError struct {
+	dependency reposource.GoDependency
+	err        error
+}
+
+func (e *notFoundError) Error() string {
+	if e.dependency.Path == "" {
+		return fmt.Sprintf("not found: %s", e.err)
+	}
+	return fmt.Sprintf("not found: Go module %s: %s", e.dependency.PackageManagerSyntax(), e.err)
+}
+
+func (e *notFoundError) NotFound() bool {
+	return true
+}
@@ -0,0 +1,169 @@
+// Package npm implements a client for npm registries, such as
+// https://registry.npmjs.org.
+package npm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// DefaultRegistry is the registry used when none is configured.
+const DefaultRegistry = "https://registry.npmjs.org"
+
+// Client fetches packages from an npm registry.
+type Client interface {
+	// DoesDependencyExist returns whether the given version of a package is
+	// published on the registry.
+	DoesDependencyExist(ctx context.Context, dependency reposource.NpmDependency) (bool, error)
+
+	// FetchTarball returns the gzipped tarball of the given version of a
+	// package. The caller must close it.
+	FetchTarball(ctx context.Context, dependency reposource.NpmDependency) (io.ReadCloser, error)
+}
+
+// HTTPClient is a Client for the registry configured in an npm packages
+// connection.
+type HTTPClient struct {
+	registryURL string
+	credentials string
+	doer        httpcli.Doer
+	limiter     *rate.Limiter
+}
+
+var _ Client = &HTTPClient{}
+
+// httpFactory doesn't cache responses, since tarballs can be large and
+// versions of npm packages are immutable anyway.
+var httpFactory = httpcli.NewFactory(
+	httpcli.NewMiddleware(httpcli.ContextErrorMiddleware),
+	httpcli.ExternalTransportOpt,
+	httpcli.TracedTransportOpt,
+)
+
+// NewHTTPClient returns a client for the registry configured in c.
+func NewHTTPClient(c *schema.NpmPackagesConnection) (*HTTPClient, error) {
+	doer, err := httpFactory.Doer()
+	if err != nil {
+		return nil, err
+	}
+	registryURL := DefaultRegistry
+	if c.Registry != "" {
+		registryURL = c.Registry
+	}
+	return &HTTPClient{
+		registryURL: strings.TrimSuffix(registryURL, "/"),
+		credentials: c.Credentials,
+		doer:        doer,
+		limiter:     ratelimit.DefaultRegistry.Get("npm"),
+	}, nil
+}
+
+// packageVersion is the subset of the metadata of a package version returned
+// by the registry that we need.
+type packageVersion struct {
+	Dist struct {
+		Tarball string `json:"tarball"`
+	} `json:"dist"`
+}
+
+func (c *HTTPClient) DoesDependencyExist(ctx context.Context, dependency reposource.NpmDependency) (bool, error) {
+	_, err := c.getPackageVersion(ctx, dependency)
+	if err == nil {
+		return true, nil
+	}
+	if errcode.IsNotFound(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+func (c *HTTPClient) FetchTarball(ctx context.Context, dependency reposource.NpmDependency) (io.ReadCloser, error) {
+	info, err := c.getPackageVersion(ctx, dependency)
+	if err != nil {
+		return nil, err
+	}
+	if info.Dist.Tarball == "" {
+		return nil, errors.Errorf("no tarball for npm package %s", dependency.PackageManagerSyntax())
+	}
+
+	resp, err := c.do(ctx, info.Dist.Tarball)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch tarball of npm package %s", dependency.PackageManagerSyntax())
+	}
+	return resp.Body, nil
+}
+
+func (c *HTTPClient) getPackageVersion(ctx context.Context, dependency reposource.NpmDependency) (*packageVersion, error) {
+	// Scoped packages are requested as @scope%2Fname.
+	u := fmt.Sprintf("%s/%s/%s", c.registryURL, url.PathEscape(dependency.PackageSyntax()), url.PathEscape(dependency.Version))
+	resp, err := c.do(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var info packageVersion
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode metadata of npm package %s", dependency.PackageManagerSyntax())
+	}
+	return &info, nil
+}
+
+// do sends a GET request to u and returns the response if it was successful.
+// The caller must close the body of the response.
+func (c *HTTPClient) do(ctx context.Context, u string) (*http.Response, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Only send credentials to the registry itself, not to wherever it
+	// hosts tarballs.
+	if c.credentials != "" && strings.HasPrefix(u, c.registryURL+"/") {
+		req.Header.Set("Authorization", "Bearer "+c.credentials)
+	}
+
+	resp, err := c.doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, &notFoundError{url: u}
+	}
+	return nil, errors.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, u, body)
+}
+
+type notFoundError struct {
+	url string
+}
+
+func (e *notFoundError) Error() string {
+	return fmt.Sprintf("not found: %s", e.url)
+}
+
+func (e *notFoundError) NotFound() bool {
+	return true
+}
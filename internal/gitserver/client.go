@@ -51,6 +51,9 @@ func NewClient(cli httpcli.Doer) *Client {
 		Addrs: func() []string {
 			return conf.Get().ServiceConnections.GitServers
 		},
+		ReplicationFactor: func() int {
+			return conf.ExperimentalFeatures().GitServerReplicationFactor
+		},
 		HTTPClient:  cli,
 		HTTPLimiter: parallel.NewRun(500),
 		// Use the binary name for UserAgent. This should effectively identify
@@ -73,9 +76,18 @@ type Client struct {
 	// concurrent use. It may return different results at different times.
 	Addrs func() []string
 
+	// ReplicationFactor is a function which should return the number of
+	// gitservers holding a copy of each repository. If nil, or if it returns
+	// a value less than 1, repositories are not replicated.
+	ReplicationFactor func() int
+
 	// UserAgent is a string identifying who the client is. It will be logged in
 	// the telemetry in gitserver.
 	UserAgent string
+
+	// health tracks which gitservers recently failed requests, so that reads
+	// can skip them while a replica is available.
+	health shardHealth
 }
 
 // AddrForRepo returns the gitserver address to use for the given repo name.
@@ -192,7 +204,7 @@ func (c *Client) Archive(ctx context.Context, repo api.RepoName, opt ArchiveOpti
 	}
 
 	u := c.ArchiveURL(repo, opt)
-	resp, err := c.do(ctx, repo, "GET", u.String(), nil)
+	resp, err := c.doWithFailover(ctx, repo, "GET", "archive?"+u.RawQuery, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -257,7 +269,7 @@ func (c *Cmd) sendExec(ctx context.Context) (_ io.ReadCloser, _ http.Header, err
 		EnsureRevision: c.EnsureRevision,
 		Args:           c.Args[1:],
 	}
-	resp, err := c.client.httpPost(ctx, repoName, "exec", req)
+	resp, err := c.client.httpPostWithFailover(ctx, repoName, "exec", req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -305,7 +317,7 @@ func (c *Client) Search(ctx context.Context, args *protocol.SearchRequest, onMat
 		return false, err
 	}
 
-	resp, err := c.do(ctx, repoName, "POST", "search", buf.Bytes())
+	resp, err := c.doWithFailover(ctx, repoName, "POST", "search", buf.Bytes())
 	if err != nil {
 		return false, err
 	}
@@ -497,10 +509,20 @@ func (c *cmdReader) Close() error {
 
 // ListGitolite lists Gitolite repositories.
 func (c *Client) ListGitolite(ctx context.Context, gitoliteHost string) (list []*gitolite.Repo, err error) {
+	return c.listGitolite(ctx, url.Values{"gitolite": {gitoliteHost}})
+}
+
+// ListGitoliteForUser lists the Gitolite repositories the given Gitolite user
+// has read access to.
+func (c *Client) ListGitoliteForUser(ctx context.Context, gitoliteHost, user string) (list []*gitolite.Repo, err error) {
+	return c.listGitolite(ctx, url.Values{"gitolite": {gitoliteHost}, "user": {user}})
+}
+
+func (c *Client) listGitolite(ctx context.Context, qry url.Values) (list []*gitolite.Repo, err error) {
 	// The gitserver calls the shared Gitolite server in response to this request, so
 	// we need to only call a single gitserver (or else we'd get duplicate results).
-	addr := c.addrForKey(gitoliteHost)
-	req, err := http.NewRequest("GET", "http://"+addr+"/list-gitolite?gitolite="+url.QueryEscape(gitoliteHost), nil)
+	addr := c.addrForKey(qry.Get("gitolite"))
+	req, err := http.NewRequest("GET", "http://"+addr+"/list-gitolite?"+qry.Encode(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -511,6 +533,11 @@ func (c *Client) ListGitolite(ctx context.Context, gitoliteHost string) (list []
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return nil, errors.Errorf("ListGitolite: http status %d: %s", resp.StatusCode, body)
+	}
+
 	err = json.NewDecoder(resp.Body).Decode(&list)
 	return list, err
 }
@@ -598,11 +625,17 @@ func (c *Client) doListOne(ctx context.Context, urlSuffix, addr string) ([]strin
 // Repo updates are not guaranteed to occur. If a repo has been updated
 // recently (within the Since duration specified in the request), the
 // update won't happen.
+//
+// If repositories are replicated, the replicas of repo are updated too, in
+// the background. The response only describes the primary copy.
 func (c *Client) RequestRepoUpdate(ctx context.Context, repo api.RepoName, since time.Duration) (*protocol.RepoUpdateResponse, error) {
 	req := &protocol.RepoUpdateRequest{
 		Repo:  repo,
 		Since: since,
 	}
+
+	c.updateReplicasInBackground(repo, req)
+
 	resp, err := c.httpPost(ctx, repo, "repo-update", req)
 	if err != nil {
 		return nil, err
@@ -883,12 +916,27 @@ func (c *Client) doReposStats(ctx context.Context, addr string) (*protocol.Repos
 	return &stats, nil
 }
 
-// Remove removes the repository clone from gitserver.
+// Remove removes the repository clone from gitserver, including its replicas.
 func (c *Client) Remove(ctx context.Context, repo api.RepoName) error {
 	req := &protocol.RepoDeleteRequest{
 		Repo: repo,
 	}
-	resp, err := c.httpPost(ctx, repo, "delete", req)
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, addr := range c.AddrsForRepo(repo) {
+		if err := c.removeFrom(ctx, addr, repo, b); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+func (c *Client) removeFrom(ctx context.Context, addr string, repo api.RepoName, payload []byte) error {
+	resp, err := c.doURL(ctx, repo, "POST", "http://"+addr+"/delete", payload)
 	if err != nil {
 		return err
 	}
@@ -912,9 +960,18 @@ func (c *Client) httpPost(ctx context.Context, repo api.RepoName, op string, pay
 // do performs a request to a gitserver, sharding based on the given
 // repo name (the repo name is otherwise not used).
 func (c *Client) do(ctx context.Context, repo api.RepoName, method, op string, payload []byte) (resp *http.Response, err error) {
+	uri := op
+	if !strings.HasPrefix(op, "http") {
+		uri = "http://" + c.AddrForRepo(repo) + "/" + op
+	}
+	return c.doURL(ctx, repo, method, uri, payload)
+}
+
+// doURL performs a request to the given gitserver URL on behalf of repo.
+func (c *Client) doURL(ctx context.Context, repo api.RepoName, method, uri string, payload []byte) (resp *http.Response, err error) {
 	span, ctx := ot.StartSpanFromContext(ctx, "Client.do")
 	defer func() {
-		span.LogKV("repo", string(repo), "method", method, "op", op)
+		span.LogKV("repo", string(repo), "method", method, "uri", uri)
 		if err != nil {
 			ext.Error.Set(span, true)
 			span.SetTag("err", err.Error())
@@ -922,11 +979,6 @@ func (c *Client) do(ctx context.Context, repo api.RepoName, method, op string, p
 		span.Finish()
 	}()
 
-	uri := op
-	if !strings.HasPrefix(op, "http") {
-		uri = "http://" + c.AddrForRepo(repo) + "/" + op
-	}
-
 	req, err := http.NewRequest(method, uri, bytes.NewReader(payload))
 	if err != nil {
 		return nil, err
@@ -14,7 +14,9 @@ import (
 	"os/exec"
 	"path/filepath"
 	"sort"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/google/go-cmp/cmp"
@@ -287,6 +289,137 @@ func TestAddrForRepo(t *testing.T) {
 	}
 }
 
+func TestAddrsForRepo(t *testing.T) {
+	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+
+	testCases := []struct {
+		name              string
+		repo              api.RepoName
+		replicationFactor int
+		want              []string
+	}{
+		{
+			name:              "no replication",
+			repo:              api.RepoName("repo1"),
+			replicationFactor: 1,
+			want:              []string{"gitserver-3"},
+		},
+		{
+			name:              "replicas wrap around",
+			repo:              api.RepoName("repo1"),
+			replicationFactor: 2,
+			want:              []string{"gitserver-3", "gitserver-1"},
+		},
+		{
+			name:              "replicas follow primary",
+			repo:              api.RepoName("github.com/sourcegraph/sourcegraph.git"),
+			replicationFactor: 2,
+			want:              []string{"gitserver-2", "gitserver-3"},
+		},
+		{
+			name:              "capped at number of shards",
+			repo:              api.RepoName("repo1"),
+			replicationFactor: 5,
+			want:              []string{"gitserver-3", "gitserver-1", "gitserver-2"},
+		},
+		{
+			name:              "invalid replication factor",
+			repo:              api.RepoName("repo1"),
+			replicationFactor: 0,
+			want:              []string{"gitserver-3"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := gitserver.AddrsForRepo(tc.repo, addrs, tc.replicationFactor)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestClient_Failover(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	cli := &gitserver.Client{
+		Addrs:             func() []string { return []string{"gitserver-1", "gitserver-2", "gitserver-3"} },
+		ReplicationFactor: func() int { return 2 },
+		HTTPClient: httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+			mu.Lock()
+			requests = append(requests, r.URL.Host+r.URL.Path)
+			mu.Unlock()
+
+			switch r.URL.Host {
+			case "gitserver-3":
+				// The primary shard of repo1 is down.
+				return nil, errors.New("connection refused")
+			case "gitserver-1":
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBufferString("output")),
+					Trailer:    http.Header{"X-Exec-Exit-Status": {"0"}},
+				}, nil
+			default:
+				return nil, errors.Errorf("unexpected host: %s", r.URL.Host)
+			}
+		}),
+	}
+
+	ctx := context.Background()
+	read := func(t *testing.T) {
+		t.Helper()
+
+		cmd := cli.Command("git", "rev-parse", "HEAD")
+		cmd.Repo = "repo1"
+		out, err := cmd.Output(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != "output" {
+			t.Fatalf("wrong output: have %q, want %q", out, "output")
+		}
+	}
+
+	read(t)
+	if diff := cmp.Diff([]string{"gitserver-3/exec", "gitserver-1/exec"}, requests); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// The primary shard is remembered as unhealthy, so the next read goes
+	// straight to the replica.
+	requests = nil
+	read(t)
+	if diff := cmp.Diff([]string{"gitserver-1/exec"}, requests); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// Writes aren't failed over. The replica is updated in the background.
+	mu.Lock()
+	requests = nil
+	mu.Unlock()
+	if _, err := cli.RequestRepoUpdate(ctx, "repo1", 0); err == nil {
+		t.Fatal("expected repo-update to fail while the primary shard is down")
+	}
+	want := []string{"gitserver-1/repo-update", "gitserver-3/repo-update"}
+	var got []string
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		mu.Lock()
+		got = append(got[:0], requests...)
+		mu.Unlock()
+		if len(got) == len(want) {
+			break
+		}
+	}
+	sort.Strings(got)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
 func TestClient_P4Exec(t *testing.T) {
 	root, err := os.MkdirTemp("", t.Name())
 	if err != nil {
@@ -3,6 +3,8 @@ package gitserver
 import (
 	"fmt"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func BenchmarkAddrForKey(b *testing.B) {
@@ -19,3 +21,27 @@ func BenchmarkAddrForKey(b *testing.B) {
 		})
 	}
 }
+
+func TestClient_ReplicaAddrs(t *testing.T) {
+	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+	tests := map[string]struct {
+		addrs             []string
+		replicationFactor int
+		want              []string
+	}{
+		"not replicated":         {addrs: addrs, replicationFactor: 1, want: nil},
+		"no gitserver addresses": {addrs: nil, replicationFactor: 2, want: nil},
+		"replicated":             {addrs: addrs, replicationFactor: 2, want: []string{"gitserver-1"}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &Client{
+				Addrs:             func() []string { return test.addrs },
+				ReplicationFactor: func() int { return test.replicationFactor },
+			}
+			if diff := cmp.Diff(test.want, c.replicaAddrs("repo1")); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
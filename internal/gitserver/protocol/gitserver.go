@@ -210,6 +210,20 @@ type ReposStats struct {
 
 	// GitDirBytes is the amount of bytes stored in .git directories.
 	GitDirBytes int64
+
+	// RebalanceMovedAway is the number of repositories stored on this
+	// gitserver that belong to other gitservers since the gitserver addresses
+	// changed. They are removed once the gitservers they belong to have
+	// copied them. It is only computed while rebalancing is enabled.
+	RebalanceMovedAway int64
+
+	// RebalanceCopying is the number of repositories currently being copied
+	// to this gitserver from other gitservers.
+	RebalanceCopying int64
+
+	// RebalanceCopied is the number of repositories this gitserver copied
+	// from other gitservers since it started.
+	RebalanceCopied int64
 }
 
 // RepoCloneProgressRequest is a request for information about the clone progress of multiple
@@ -0,0 +1,272 @@
+package gitserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+)
+
+var (
+	failoverCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_client_failover_total",
+		Help: "Number of read requests that were retried against a replica because a gitserver shard was unavailable.",
+	})
+	replicaUpdateErrorCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_client_replica_update_errors_total",
+		Help: "Number of repo-update requests to replica gitserver shards that failed.",
+	})
+	replicaUpdateDroppedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_client_replica_updates_dropped_total",
+		Help: "Number of replica updates that were dropped because too many were in flight.",
+	})
+)
+
+// unhealthyShardTTL is how long a gitserver shard that failed a request is
+// skipped by reads that can be served by a replica.
+const unhealthyShardTTL = 30 * time.Second
+
+// replicaUpdateTimeout bounds how long the replicas of a repo are given to
+// update it in the background.
+const replicaUpdateTimeout = 5 * time.Minute
+
+// maxConcurrentReplicaUpdates is the number of repos whose replicas are
+// updated in the background at the same time.
+const maxConcurrentReplicaUpdates = 50
+
+// replicaUpdates holds a token for each repo whose replicas are being updated
+// in the background. Updates that don't fit are dropped, the replicas catch up
+// with the next update of the repo.
+var replicaUpdates = make(chan struct{}, maxConcurrentReplicaUpdates)
+
+// AddrsForRepo returns the addresses of the gitservers holding a copy of the
+// given repo name: the primary shard, followed by its replicas.
+func (c *Client) AddrsForRepo(repo api.RepoName) []string {
+	addrs := c.Addrs()
+	if len(addrs) == 0 {
+		panic("unexpected state: no gitserver addresses")
+	}
+	return AddrsForRepo(repo, addrs, c.replicationFactor())
+}
+
+func (c *Client) replicationFactor() int {
+	if c.ReplicationFactor == nil {
+		return 1
+	}
+	return c.ReplicationFactor()
+}
+
+// AddrsForRepo returns the addresses of the replicationFactor gitservers
+// holding a copy of repo. The first address is the primary shard, which is
+// always the one returned by AddrForRepo. The replicas are the shards
+// following it in addrs, so that adding a shard at the end of addrs only
+// moves the replicas of the repos whose primary shard moved too. It should
+// never be called with an empty slice.
+func AddrsForRepo(repo api.RepoName, addrs []string, replicationFactor int) []string {
+	if replicationFactor < 1 {
+		replicationFactor = 1
+	}
+	if replicationFactor > len(addrs) {
+		replicationFactor = len(addrs)
+	}
+
+	primary := AddrForRepo(repo, addrs)
+	if replicationFactor == 1 {
+		return []string{primary}
+	}
+
+	var i int
+	for i = range addrs {
+		if addrs[i] == primary {
+			break
+		}
+	}
+
+	replicas := make([]string, 0, replicationFactor)
+	for j := 0; j < replicationFactor; j++ {
+		replicas = append(replicas, addrs[(i+j)%len(addrs)])
+	}
+	return replicas
+}
+
+// doWithFailover performs a read request against the gitservers holding a
+// copy of repo. The request is sent to the primary shard and, if it is
+// unavailable, to each replica in turn. Shards that recently failed are tried
+// last.
+func (c *Client) doWithFailover(ctx context.Context, repo api.RepoName, method, op string, payload []byte) (*http.Response, error) {
+	addrs := c.AddrsForRepo(repo)
+	if len(addrs) == 1 {
+		return c.doURL(ctx, repo, method, "http://"+addrs[0]+"/"+op, payload)
+	}
+
+	var errs error
+	for _, addr := range c.health.order(addrs) {
+		resp, err := c.doURL(ctx, repo, method, "http://"+addr+"/"+op, payload)
+		if err == nil && !isUnavailableStatus(resp.StatusCode) {
+			c.health.markHealthy(addr)
+			return resp, nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			if resp != nil {
+				resp.Body.Close()
+			}
+			return nil, ctxErr
+		}
+		if err == nil {
+			resp.Body.Close()
+			err = errors.Errorf("http status %d", resp.StatusCode)
+		}
+
+		log15.Warn("gitserver shard unavailable, failing over to replica", "addr", addr, "repo", repo, "op", op, "error", err)
+		failoverCounter.Inc()
+		c.health.markUnhealthy(addr)
+		errs = multierror.Append(errs, errors.Wrapf(err, "gitserver %s", addr))
+	}
+	return nil, errs
+}
+
+// httpPostWithFailover is like httpPost, but fails over to replicas of repo
+// if its primary shard is unavailable. It must only be used for requests that
+// don't modify the repository.
+func (c *Client) httpPostWithFailover(ctx context.Context, repo api.RepoName, op string, payload interface{}) (*http.Response, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return c.doWithFailover(ctx, repo, "POST", op, b)
+}
+
+// replicaAddrs returns the addresses of the replicas of repo, without its
+// primary shard. It returns nil if repositories are not replicated or there
+// are no gitserver addresses.
+func (c *Client) replicaAddrs(repo api.RepoName) []string {
+	replicationFactor := c.replicationFactor()
+	if replicationFactor <= 1 {
+		return nil
+	}
+	addrs := c.Addrs()
+	if len(addrs) == 0 {
+		return nil
+	}
+	return AddrsForRepo(repo, addrs, replicationFactor)[1:]
+}
+
+// updateReplicasInBackground updates the replicas of repo in the background,
+// unless there are none or too many updates are in flight already.
+func (c *Client) updateReplicasInBackground(repo api.RepoName, req *protocol.RepoUpdateRequest) {
+	replicas := c.replicaAddrs(repo)
+	if len(replicas) == 0 {
+		return
+	}
+
+	select {
+	case replicaUpdates <- struct{}{}:
+	default:
+		replicaUpdateDroppedCounter.Inc()
+		log15.Warn("dropped replica update, too many in flight", "repo", repo)
+		return
+	}
+	go func() {
+		defer func() { <-replicaUpdates }()
+		c.updateReplicas(repo, replicas, req)
+	}()
+}
+
+// updateReplicas asks the replicas of repo to update their copy of it. Errors
+// are logged rather than returned, since the primary copy is the one that
+// needs to be up to date. It is called in the background, so it doesn't use
+// the context of the request that triggered it.
+func (c *Client) updateReplicas(repo api.RepoName, replicas []string, req *protocol.RepoUpdateRequest) {
+	ctx, cancel := context.WithTimeout(context.Background(), replicaUpdateTimeout)
+	defer cancel()
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		log15.Error("failed to encode repo-update request", "repo", repo, "error", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, addr := range replicas {
+		wg.Add(1)
+		go func(addr string) {
+			defer wg.Done()
+
+			resp, err := c.doURL(ctx, repo, "POST", "http://"+addr+"/repo-update", b)
+			if err == nil {
+				resp.Body.Close()
+				if resp.StatusCode != http.StatusOK {
+					err = errors.Errorf("http status %d", resp.StatusCode)
+				}
+			}
+			if err != nil {
+				replicaUpdateErrorCounter.Inc()
+				log15.Warn("failed to update replica", "addr", addr, "repo", repo, "error", err)
+			}
+		}(addr)
+	}
+	wg.Wait()
+}
+
+// isUnavailableStatus returns whether the status code of a response means
+// that the gitserver couldn't be reached, rather than that it failed to
+// handle the request.
+func isUnavailableStatus(code int) bool {
+	return code == http.StatusBadGateway || code == http.StatusServiceUnavailable || code == http.StatusGatewayTimeout
+}
+
+// shardHealth remembers which gitserver shards recently failed requests, so
+// that reads go straight to a replica rather than waiting for the shard to
+// fail again.
+type shardHealth struct {
+	mu             sync.Mutex
+	unhealthyUntil map[string]time.Time
+}
+
+func (h *shardHealth) markUnhealthy(addr string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.unhealthyUntil == nil {
+		h.unhealthyUntil = map[string]time.Time{}
+	}
+	h.unhealthyUntil[addr] = time.Now().Add(unhealthyShardTTL)
+}
+
+func (h *shardHealth) markHealthy(addr string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.unhealthyUntil, addr)
+}
+
+// order returns addrs with the shards that recently failed moved to the end,
+// otherwise preserving their order.
+func (h *shardHealth) order(addrs []string) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.unhealthyUntil) == 0 {
+		return addrs
+	}
+
+	now := time.Now()
+	healthy := make([]string, 0, len(addrs))
+	var unhealthy []string
+	for _, addr := range addrs {
+		if until, ok := h.unhealthyUntil[addr]; ok && now.Before(until) {
+			unhealthy = append(unhealthy, addr)
+		} else {
+			healthy = append(healthy, addr)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
@@ -13,6 +13,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/PuerkitoBio/rehttp"
@@ -282,6 +283,50 @@ func isUnwrappableTransport(cli *http.Client) bool {
 	return ok
 }
 
+// errPrivateIP is returned when a connection to a private IP address is
+// denied by DenyPrivateIPsOpt.
+var errPrivateIP = errors.New("connections to private IP addresses are not allowed")
+
+// DenyPrivateIPsOpt is an Opt that makes the http.Client's transport refuse to
+// connect to loopback, private, link-local and unspecified IP addresses. Use it
+// for requests to URLs supplied by users, so that they cannot reach internal
+// services. The check happens when dialing, after the host name is resolved,
+// so it also covers redirects and host names resolving to internal addresses.
+func DenyPrivateIPsOpt(cli *http.Client) error {
+	tr, err := getTransportForMutation(cli)
+	if err != nil {
+		return errors.Wrap(err, "httpcli.DenyPrivateIPsOpt")
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || IsPrivateIP(ip) {
+				return errors.Wrapf(errPrivateIP, "dial %s", address)
+			}
+			return nil
+		},
+	}
+	tr.DialContext = dialer.DialContext
+	return nil
+}
+
+// IsPrivateIP returns true if ip is a loopback, private, link-local or
+// unspecified address.
+func IsPrivateIP(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsUnspecified()
+}
+
 // NewCertPoolOpt returns a Opt that sets the RootCAs pool of an http.Client's
 // transport.
 func NewCertPoolOpt(certs ...string) Opt {
@@ -442,6 +487,11 @@ func NewRetryPolicy(max int) rehttp.RetryFn {
 		case context.DeadlineExceeded, context.Canceled:
 			return false
 		default:
+			// Don't retry connections denied by DenyPrivateIPsOpt.
+			if errors.Is(a.Error, errPrivateIP) {
+				return false
+			}
+
 			// Don't retry more than 3 times for no such host errors.
 			// This affords some resilience to dns unreliability while
 			// preventing 20 attempts with a non existing name.
@@ -281,6 +281,41 @@ func TestNewTimeoutOpt(t *testing.T) {
 	}
 }
 
+func TestDenyPrivateIPsOpt(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(srv.Close)
+
+	var cli http.Client
+	if err := DenyPrivateIPsOpt(&cli); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	_, err := cli.Get(srv.URL)
+	if !errors.Is(err, errPrivateIP) {
+		t.Fatalf("have error %v, want %v", err, errPrivateIP)
+	}
+}
+
+func TestIsPrivateIP(t *testing.T) {
+	for ip, want := range map[string]bool{
+		"127.0.0.1":       true,
+		"10.0.0.1":        true,
+		"172.16.0.1":      true,
+		"192.168.1.1":     true,
+		"169.254.169.254": true,
+		"0.0.0.0":         true,
+		"::1":             true,
+		"fd00::1":         true,
+		"fe80::1":         true,
+		"8.8.8.8":         false,
+		"2001:4860::8888": false,
+	} {
+		if have := IsPrivateIP(net.ParseIP(ip)); have != want {
+			t.Errorf("IsPrivateIP(%s): have %v, want %v", ip, have, want)
+		}
+	}
+}
+
 func TestErrorResilience(t *testing.T) {
 	failures := int64(5)
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,183 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A GerritSource yields repositories from a single Gerrit connection configured
+// in Sourcegraph via the external services configuration.
+type GerritSource struct {
+	svc     *types.ExternalService
+	config  *schema.GerritConnection
+	exclude excludeFunc
+	baseURL *url.URL
+	client  *gerrit.Client
+}
+
+// NewGerritSource returns a new GerritSource from the given external service.
+func NewGerritSource(svc *types.ExternalService, cf *httpcli.Factory) (*GerritSource, error) {
+	var c schema.GerritConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return newGerritSource(svc, &c, cf)
+}
+
+func newGerritSource(svc *types.ExternalService, c *schema.GerritConnection, cf *httpcli.Factory) (*GerritSource, error) {
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+	baseURL = extsvc.NormalizeBaseURL(baseURL)
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	var eb excludeBuilder
+	for _, p := range c.Exclude {
+		eb.Exact(p.Name)
+		eb.Pattern(p.Pattern)
+	}
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &GerritSource{
+		svc:     svc,
+		config:  c,
+		exclude: exclude,
+		baseURL: baseURL,
+		client:  gerrit.NewClient(baseURL, c.Username, c.Password, cli),
+	}, nil
+}
+
+// ListRepos returns all Gerrit projects configured with this GerritSource's
+// config. If no projects are configured, all projects visible to the
+// configured user are returned.
+func (s *GerritSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	yield := func(p *gerrit.Project) {
+		// Hidden projects are only visible to project owners, and can't be
+		// cloned by anyone else.
+		if p.State == "HIDDEN" || s.exclude(p.Name) {
+			return
+		}
+		results <- SourceResult{Source: s, Repo: s.makeRepo(p)}
+	}
+
+	if len(s.config.Projects) > 0 {
+		seen := make(map[string]bool, len(s.config.Projects))
+		for _, name := range s.config.Projects {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			p, err := s.client.GetProject(ctx, name)
+			if err != nil {
+				if errcode.IsNotFound(err) {
+					log15.Warn("skipping missing gerrit.projects entry:", "name", name, "err", err)
+					continue
+				}
+				results <- SourceResult{Source: s, Err: errors.Wrapf(err, "gerrit.projects: item=%q", name)}
+				continue
+			}
+			yield(p)
+		}
+		return
+	}
+
+	for skip, more := 0, true; more; {
+		var (
+			projects []*gerrit.Project
+			err      error
+		)
+		projects, more, err = s.client.ListProjects(ctx, skip)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "gerrit.projects: skip=%d", skip)}
+			return
+		}
+		for _, p := range projects {
+			yield(p)
+		}
+		skip += len(projects)
+	}
+}
+
+// GetRepo returns the Gerrit project with the given name.
+func (s *GerritSource) GetRepo(ctx context.Context, name string) (*types.Repo, error) {
+	p, err := s.client.GetProject(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return s.makeRepo(p), nil
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *GerritSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s *GerritSource) makeRepo(p *gerrit.Project) *types.Repo {
+	urn := s.svc.URN()
+	return &types.Repo{
+		Name: reposource.GerritRepoName(
+			s.config.RepositoryPathPattern,
+			s.baseURL.Hostname(),
+			p.Name,
+		),
+		URI: string(reposource.GerritRepoName(
+			"",
+			s.baseURL.Hostname(),
+			p.Name,
+		)),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          p.ID,
+			ServiceType: extsvc.TypeGerrit,
+			ServiceID:   s.baseURL.String(),
+		},
+		Description: p.Description,
+		Archived:    p.State == "READ_ONLY",
+		// Gerrit doesn't expose project visibility in its project list, so
+		// we treat all projects as private to be safe.
+		Private: true,
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: s.remoteURL(p),
+			},
+		},
+		Metadata: p,
+	}
+}
+
+// remoteURL returns the project's Git remote URL
+//
+// note: this does not contain credentials. If you need an authenticated clone
+// url use repos.CloneURL
+func (s *GerritSource) remoteURL(p *gerrit.Project) string {
+	u := *s.baseURL
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + p.Name
+	return u.String()
+}
@@ -0,0 +1,79 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGerritSource_ListRepos(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/a/projects/" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, ")]}'\n")
+		switch r.URL.Query().Get("S") {
+		case "0":
+			fmt.Fprint(w, `{
+				"a": {"id": "a", "state": "ACTIVE"},
+				"b": {"id": "b", "state": "READ_ONLY", "_more_projects": true}
+			}`)
+		case "2":
+			fmt.Fprint(w, `{
+				"c": {"id": "c", "state": "HIDDEN"},
+				"excluded": {"id": "excluded", "state": "ACTIVE"}
+			}`)
+		default:
+			t.Errorf("unexpected skip %q", r.URL.Query().Get("S"))
+		}
+	}))
+	defer s.Close()
+
+	svc := &types.ExternalService{ID: 1, Kind: extsvc.KindGerrit}
+	src, err := newGerritSource(svc, &schema.GerritConnection{
+		Url:      s.URL,
+		Username: "admin",
+		Password: "secret",
+		Exclude:  []*schema.ExcludedGerritProject{{Name: "excluded"}},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repos, err := listAll(context.Background(), src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type repo struct {
+		Name     string
+		Archived bool
+		CloneURL string
+	}
+	var got []repo
+	for _, r := range repos {
+		got = append(got, repo{
+			Name:     string(r.Name),
+			Archived: r.Archived,
+			CloneURL: r.Sources[svc.URN()].CloneURL,
+		})
+	}
+
+	host := src.baseURL.Hostname()
+	want := []repo{
+		{Name: host + "/a", CloneURL: s.URL + "/a"},
+		{Name: host + "/b", Archived: true, CloneURL: s.URL + "/b"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected repos (-want +got):\n%s", diff)
+	}
+}
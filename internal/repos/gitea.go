@@ -0,0 +1,209 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A GiteaSource yields repositories from a single Gitea connection configured
+// in Sourcegraph via the external services configuration.
+type GiteaSource struct {
+	svc     *types.ExternalService
+	config  *schema.GiteaConnection
+	exclude excludeFunc
+	baseURL *url.URL
+	client  *gitea.Client
+}
+
+// NewGiteaSource returns a new GiteaSource from the given external service.
+func NewGiteaSource(svc *types.ExternalService, cf *httpcli.Factory) (*GiteaSource, error) {
+	var c schema.GiteaConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return newGiteaSource(svc, &c, cf)
+}
+
+func newGiteaSource(svc *types.ExternalService, c *schema.GiteaConnection, cf *httpcli.Factory) (*GiteaSource, error) {
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+	baseURL = extsvc.NormalizeBaseURL(baseURL)
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	var eb excludeBuilder
+	for _, r := range c.Exclude {
+		eb.Exact(r.Name)
+		if r.Id != 0 {
+			eb.Exact(strconv.Itoa(r.Id))
+		}
+		eb.Pattern(r.Pattern)
+	}
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &GiteaSource{
+		svc:     svc,
+		config:  c,
+		exclude: exclude,
+		baseURL: baseURL,
+		client:  gitea.NewClient(baseURL, c.Token, cli),
+	}, nil
+}
+
+// ListRepos returns all Gitea repositories configured with this GiteaSource's config.
+func (s *GiteaSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	seen := make(map[int64]bool)
+	yield := func(r *gitea.Repository) {
+		if seen[r.ID] || s.excludes(r) {
+			return
+		}
+		seen[r.ID] = true
+		results <- SourceResult{Source: s, Repo: s.makeRepo(r)}
+	}
+
+	for _, nameWithOwner := range s.config.Repos {
+		r, err := s.getRepository(ctx, nameWithOwner)
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				log15.Warn("skipping missing gitea.repos entry:", "name", nameWithOwner, "err", err)
+				continue
+			}
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "gitea.repos: item=%q", nameWithOwner)}
+			continue
+		}
+		yield(r)
+	}
+
+	for _, org := range s.config.Orgs {
+		for page := 1; page != 0; {
+			repos, next, err := s.client.ListOrgRepos(ctx, org, page)
+			if err != nil {
+				results <- SourceResult{Source: s, Err: errors.Wrapf(err, "gitea.orgs: item=%q, page=%d", org, page)}
+				break
+			}
+			for _, r := range repos {
+				yield(r)
+			}
+			page = next
+		}
+	}
+
+	for _, query := range s.config.RepositoryQuery {
+		if query == "none" {
+			continue
+		}
+		q := query
+		if q == "all" {
+			q = ""
+		}
+		for page := 1; page != 0; {
+			repos, next, err := s.client.SearchRepos(ctx, q, page)
+			if err != nil {
+				results <- SourceResult{Source: s, Err: errors.Wrapf(err, "gitea.repositoryQuery: item=%q, page=%d", query, page)}
+				break
+			}
+			for _, r := range repos {
+				yield(r)
+			}
+			page = next
+		}
+	}
+}
+
+// GetRepo returns the Gitea repository with the given name and owner
+// ("org/repo-name").
+func (s *GiteaSource) GetRepo(ctx context.Context, nameWithOwner string) (*types.Repo, error) {
+	r, err := s.getRepository(ctx, nameWithOwner)
+	if err != nil {
+		return nil, err
+	}
+	return s.makeRepo(r), nil
+}
+
+func (s *GiteaSource) getRepository(ctx context.Context, nameWithOwner string) (*gitea.Repository, error) {
+	parts := strings.Split(nameWithOwner, "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid Gitea repository name %q, expected owner/name", nameWithOwner)
+	}
+	return s.client.GetRepo(ctx, parts[0], parts[1])
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *GiteaSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s *GiteaSource) makeRepo(r *gitea.Repository) *types.Repo {
+	urn := s.svc.URN()
+	return &types.Repo{
+		Name: reposource.GiteaRepoName(
+			s.config.RepositoryPathPattern,
+			s.baseURL.Hostname(),
+			r.FullName,
+		),
+		URI: string(reposource.GiteaRepoName(
+			"",
+			s.baseURL.Hostname(),
+			r.FullName,
+		)),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          strconv.FormatInt(r.ID, 10),
+			ServiceType: extsvc.TypeGitea,
+			ServiceID:   s.baseURL.String(),
+		},
+		Description: r.Description,
+		Fork:        r.Fork,
+		Archived:    r.Archived,
+		Private:     r.Private,
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: s.remoteURL(r),
+			},
+		},
+		Metadata: r,
+	}
+}
+
+// remoteURL returns the repository's Git remote URL
+//
+// note: this does not contain credentials. If you need an authenticated clone
+// url use repos.CloneURL
+func (s *GiteaSource) remoteURL(r *gitea.Repository) string {
+	if s.config.GitURLType == "ssh" {
+		return r.SSHURL
+	}
+	return r.CloneURL
+}
+
+func (s *GiteaSource) excludes(r *gitea.Repository) bool {
+	return s.exclude(r.FullName) || s.exclude(strconv.FormatInt(r.ID, 10))
+}
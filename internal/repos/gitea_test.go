@@ -0,0 +1,150 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGiteaSource_ListRepos(t *testing.T) {
+	repo := func(id int, fullName string) string {
+		return fmt.Sprintf(`{"id":%d,"full_name":%q,"clone_url":"https://gitea.example.com/%s.git","ssh_url":"git@gitea.example.com:%s.git"}`, id, fullName, fullName, fullName)
+	}
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/repos/sourcegraph/sourcegraph":
+			fmt.Fprint(w, repo(1, "sourcegraph/sourcegraph"))
+		case "/api/v1/orgs/sourcegraph/repos":
+			if r.URL.Query().Get("page") == "1" {
+				w.Header().Set("Link", fmt.Sprintf(`<%s/api/v1/orgs/sourcegraph/repos?page=2&limit=50>; rel="next"`, "http://"+r.Host))
+				fmt.Fprintf(w, "[%s,%s]", repo(1, "sourcegraph/sourcegraph"), repo(2, "sourcegraph/zoekt"))
+				return
+			}
+			fmt.Fprintf(w, "[%s,%s]", repo(3, "sourcegraph/excluded"), repo(4, "sourcegraph/excluded-by-id"))
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer s.Close()
+
+	svc := &types.ExternalService{ID: 1, Kind: extsvc.KindGitea}
+	src, err := newGiteaSource(svc, &schema.GiteaConnection{
+		Url:   s.URL,
+		Token: "secret",
+		Repos: []string{"sourcegraph/sourcegraph", "sourcegraph/missing"},
+		Orgs:  []string{"sourcegraph"},
+		Exclude: []*schema.ExcludedGiteaRepo{
+			{Name: "sourcegraph/excluded"},
+			{Id: 4},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repos, err := listAll(context.Background(), src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, r := range repos {
+		names = append(names, string(r.Name))
+	}
+	sort.Strings(names)
+
+	host := src.baseURL.Hostname()
+	want := []string{host + "/sourcegraph/sourcegraph", host + "/sourcegraph/zoekt"}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Fatalf("unexpected repos (-want +got):\n%s", diff)
+	}
+}
+
+func TestGiteaSource_makeRepo(t *testing.T) {
+	svc := &types.ExternalService{ID: 1, Kind: extsvc.KindGitea}
+	r := &gitea.Repository{
+		ID:          42,
+		FullName:    "sourcegraph/sourcegraph",
+		Description: "Code search",
+		Private:     true,
+		Archived:    true,
+		CloneURL:    "https://gitea.example.com/sourcegraph/sourcegraph.git",
+		SSHURL:      "git@gitea.example.com:sourcegraph/sourcegraph.git",
+	}
+
+	for _, tc := range []struct {
+		name string
+		conn *schema.GiteaConnection
+		want *types.Repo
+	}{{
+		name: "default",
+		conn: &schema.GiteaConnection{Url: "https://gitea.example.com"},
+		want: &types.Repo{
+			Name: "gitea.example.com/sourcegraph/sourcegraph",
+			URI:  "gitea.example.com/sourcegraph/sourcegraph",
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          "42",
+				ServiceType: extsvc.TypeGitea,
+				ServiceID:   "https://gitea.example.com/",
+			},
+			Description: "Code search",
+			Private:     true,
+			Archived:    true,
+			Sources: map[string]*types.SourceInfo{
+				"extsvc:gitea:1": {
+					ID:       "extsvc:gitea:1",
+					CloneURL: "https://gitea.example.com/sourcegraph/sourcegraph.git",
+				},
+			},
+			Metadata: r,
+		},
+	}, {
+		name: "ssh and path pattern",
+		conn: &schema.GiteaConnection{
+			Url:                   "https://gitea.example.com",
+			GitURLType:            "ssh",
+			RepositoryPathPattern: "gitea/{nameWithOwner}",
+		},
+		want: &types.Repo{
+			Name: "gitea/sourcegraph/sourcegraph",
+			URI:  "gitea.example.com/sourcegraph/sourcegraph",
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          "42",
+				ServiceType: extsvc.TypeGitea,
+				ServiceID:   "https://gitea.example.com/",
+			},
+			Description: "Code search",
+			Private:     true,
+			Archived:    true,
+			Sources: map[string]*types.SourceInfo{
+				"extsvc:gitea:1": {
+					ID:       "extsvc:gitea:1",
+					CloneURL: "git@gitea.example.com:sourcegraph/sourcegraph.git",
+				},
+			},
+			Metadata: r,
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			src, err := newGiteaSource(svc, tc.conn, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, src.makeRepo(r)); diff != "" {
+				t.Fatalf("unexpected repo (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -101,6 +101,9 @@ func (s GitoliteSource) makeRepo(repo *gitolite.Repo) *types.Repo {
 		Name:         api.RepoName(name),
 		URI:          name,
 		ExternalRepo: gitolite.ExternalRepoSpec(repo, gitolite.ServiceID(s.conn.Host)),
+		// Gitolite has no notion of public repositories, so we can only
+		// consider them private when permissions are enforced.
+		Private: s.conn.Authorization != nil,
 		Sources: map[string]*types.SourceInfo{
 			urn: {
 				ID:       urn,
@@ -0,0 +1,234 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gomodules"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gomodules/proxy"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	// goModulesScheme is the scheme of Go modules referenced by precise code
+	// intelligence uploads.
+	goModulesScheme = "gomod"
+
+	// goModulesNamePrefix prefixes the module paths of Go modules referenced
+	// by precise code intelligence uploads.
+	goModulesNamePrefix = "https://"
+)
+
+// A GoModulesSource creates git repositories from the zip files of Go modules
+// served by Go module proxies.
+type GoModulesSource struct {
+	svc     *types.ExternalService
+	config  *schema.GoModulesConnection
+	client  proxy.Client
+	dbStore DependencyRepoStore
+}
+
+// NewGoModulesSource returns a new GoModulesSource from the given external
+// service.
+func NewGoModulesSource(svc *types.ExternalService) (*GoModulesSource, error) {
+	var c schema.GoModulesConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, fmt.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	client, err := proxy.NewHTTPClient(&c)
+	if err != nil {
+		return nil, err
+	}
+	return &GoModulesSource{
+		svc:     svc,
+		config:  &c,
+		client:  client,
+		dbStore: nil, // set via SetDB decorator
+	}, nil
+}
+
+func (s *GoModulesSource) SetDB(db dbutil.DB) {
+	s.dbStore = newDependencyRepoStore(db)
+}
+
+// ListRepos returns all Go modules configured in the external service, and
+// those referenced by precise code intelligence uploads that exist on one of
+// the configured proxies.
+func (s *GoModulesSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	modules, err := GoModules(*s.config)
+	if err != nil {
+		results <- SourceResult{Err: err}
+		return
+	}
+
+	listed := make(map[reposource.GoModule]bool, len(modules))
+	for _, mod := range modules {
+		listed[mod] = true
+		results <- SourceResult{Source: s, Repo: s.makeRepo(mod)}
+	}
+
+	var (
+		totalDBFetched  int
+		totalDBResolved int
+		lastID          int
+	)
+	for {
+		dbDeps, err := s.dbStore.GetDependencyRepos(ctx, dbstore.GetDependencyReposOpts{
+			Scheme: goModulesScheme,
+			After:  lastID,
+			Limit:  100,
+		})
+		if err != nil {
+			results <- SourceResult{Err: err}
+			return
+		}
+		if len(dbDeps) == 0 {
+			break
+		}
+
+		totalDBFetched += len(dbDeps)
+		lastID = dbDeps[len(dbDeps)-1].ID
+
+		for _, dep := range dbDeps {
+			dependency, err := reposource.ParseGoDependency(strings.TrimPrefix(dep.Name, goModulesNamePrefix) + "@" + dep.Version)
+			if err != nil {
+				log15.Warn("error parsing Go module", "error", err, "module", dep.Name, "version", dep.Version)
+				continue
+			}
+			if listed[dependency.GoModule] {
+				continue
+			}
+
+			// As for JVM packages, we don't return modules that don't exist
+			// on the proxies, to reduce logspam from gitserver.
+			if exists, err := s.client.DoesDependencyExist(ctx, dependency); err != nil || !exists {
+				log15.Warn("Go module not resolvable from proxy", "module", dependency.PackageManagerSyntax(), "error", err)
+				continue
+			}
+
+			listed[dependency.GoModule] = true
+			totalDBResolved++
+			results <- SourceResult{Source: s, Repo: s.makeRepo(dependency.GoModule)}
+		}
+	}
+
+	log15.Info("finished listing resolvable Go modules", "totalDB", totalDBFetched, "resolvedDB", totalDBResolved, "totalConfig", len(modules))
+}
+
+func (s *GoModulesSource) GetRepo(ctx context.Context, repoPath string) (*types.Repo, error) {
+	mod, err := reposource.ParseGoModuleFromRepoURL(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies, err := GoDependencies(*s.config)
+	if err != nil {
+		return nil, err
+	}
+
+	dbDeps, err := s.dbStore.GetDependencyRepos(ctx, dbstore.GetDependencyReposOpts{
+		Scheme: goModulesScheme,
+		Name:   goModulesNamePrefix + mod.Path,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "dbstore.GetDependencyRepos")
+	}
+	for _, dep := range dbDeps {
+		dependencies = append(dependencies, reposource.GoDependency{GoModule: mod, Version: dep.Version})
+	}
+
+	var nonExistentDependencies []reposource.GoDependency
+	for _, dep := range dependencies {
+		if dep.GoModule != mod {
+			continue
+		}
+		exists, err := s.client.DoesDependencyExist(ctx, dep)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return s.makeRepo(mod), nil
+		}
+		nonExistentDependencies = append(nonExistentDependencies, dep)
+	}
+
+	return nil, &goDependencyNotFound{dependencies: nonExistentDependencies}
+}
+
+type goDependencyNotFound struct {
+	dependencies []reposource.GoDependency
+}
+
+func (e *goDependencyNotFound) Error() string {
+	return fmt.Sprintf("not found: Go dependency '%v'", e.dependencies)
+}
+
+func (e *goDependencyNotFound) NotFound() bool {
+	return true
+}
+
+func (s *GoModulesSource) makeRepo(mod reposource.GoModule) *types.Repo {
+	urn := s.svc.URN()
+	return &types.Repo{
+		Name: mod.RepoName(),
+		URI:  string(mod.RepoName()),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          string(mod.RepoName()),
+			ServiceID:   extsvc.TypeGoModules,
+			ServiceType: extsvc.TypeGoModules,
+		},
+		Private: false,
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: mod.CloneURL(),
+			},
+		},
+		Metadata: &gomodules.Metadata{
+			Module: mod,
+		},
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *GoModulesSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func GoDependencies(connection schema.GoModulesConnection) (dependencies []reposource.GoDependency, err error) {
+	for _, dep := range connection.Dependencies {
+		dependency, err := reposource.ParseGoDependency(dep)
+		if err != nil {
+			return nil, err
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies, nil
+}
+
+func GoModules(connection schema.GoModulesConnection) ([]reposource.GoModule, error) {
+	dependencies, err := GoDependencies(connection)
+	if err != nil {
+		return nil, err
+	}
+	isAdded := make(map[reposource.GoModule]bool)
+	modules := []reposource.GoModule{}
+	for _, dep := range dependencies {
+		if !isAdded[dep.GoModule] {
+			modules = append(modules, dep.GoModule)
+		}
+		isAdded[dep.GoModule] = true
+	}
+	return modules, nil
+}
@@ -43,6 +43,12 @@ type JVMPackagesRepoStore interface {
 	GetJVMDependencyRepos(ctx context.Context, filter dbstore.GetJVMDependencyReposOpts) ([]dbstore.JVMDependencyRepo, error)
 }
 
+// DependencyRepoStore returns the dependency repositories of a package scheme
+// referenced by precise code intelligence uploads.
+type DependencyRepoStore interface {
+	GetDependencyRepos(ctx context.Context, filter dbstore.GetDependencyReposOpts) ([]dbstore.DependencyRepo, error)
+}
+
 // NewJVMPackagesSource returns a new MavenSource from the given external
 // service.
 func NewJVMPackagesSource(svc *types.ExternalService) (*JVMPackagesSource, error) {
@@ -54,6 +60,13 @@ func NewJVMPackagesSource(svc *types.ExternalService) (*JVMPackagesSource, error
 }
 
 func (s *JVMPackagesSource) SetDB(db dbutil.DB) {
+	s.dbStore = newDependencyRepoStore(db)
+}
+
+// newDependencyRepoStore returns the store of dependency repositories
+// referenced by precise code intelligence uploads, which package sources list
+// in addition to the dependencies in their configuration.
+func newDependencyRepoStore(db dbutil.DB) *dbstore.Store {
 	once.Do(func() {
 		observationContext = &observation.Context{
 			Logger:     log15.Root(),
@@ -62,7 +75,7 @@ func (s *JVMPackagesSource) SetDB(db dbutil.DB) {
 		}
 		operationMetrics = dbstore.NewOperationsMetrics(observationContext)
 	})
-	s.dbStore = dbstore.NewWithDB(db, observationContext, operationMetrics)
+	return dbstore.NewWithDB(db, observationContext, operationMetrics)
 }
 
 func newJVMPackagesSource(svc *types.ExternalService, c *schema.JVMPackagesConnection) (*JVMPackagesSource, error) {
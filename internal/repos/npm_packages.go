@@ -0,0 +1,228 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/npmpackages"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/npmpackages/npm"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// npmPackagesScheme is the scheme of npm packages referenced by precise code
+// intelligence uploads.
+const npmPackagesScheme = "npm"
+
+// An NpmPackagesSource creates git repositories from the tarballs of npm
+// packages published on an npm registry.
+type NpmPackagesSource struct {
+	svc     *types.ExternalService
+	config  *schema.NpmPackagesConnection
+	client  npm.Client
+	dbStore DependencyRepoStore
+}
+
+// NewNpmPackagesSource returns a new NpmPackagesSource from the given external
+// service.
+func NewNpmPackagesSource(svc *types.ExternalService) (*NpmPackagesSource, error) {
+	var c schema.NpmPackagesConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, fmt.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	client, err := npm.NewHTTPClient(&c)
+	if err != nil {
+		return nil, err
+	}
+	return &NpmPackagesSource{
+		svc:     svc,
+		config:  &c,
+		client:  client,
+		dbStore: nil, // set via SetDB decorator
+	}, nil
+}
+
+func (s *NpmPackagesSource) SetDB(db dbutil.DB) {
+	s.dbStore = newDependencyRepoStore(db)
+}
+
+// ListRepos returns all npm packages configured in the external service, and
+// those referenced by precise code intelligence uploads that exist on the
+// registry.
+func (s *NpmPackagesSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	packages, err := NpmPackages(*s.config)
+	if err != nil {
+		results <- SourceResult{Err: err}
+		return
+	}
+
+	listed := make(map[reposource.NpmPackage]bool, len(packages))
+	for _, pkg := range packages {
+		listed[pkg] = true
+		results <- SourceResult{Source: s, Repo: s.makeRepo(pkg)}
+	}
+
+	var (
+		totalDBFetched  int
+		totalDBResolved int
+		lastID          int
+	)
+	for {
+		dbDeps, err := s.dbStore.GetDependencyRepos(ctx, dbstore.GetDependencyReposOpts{
+			Scheme: npmPackagesScheme,
+			After:  lastID,
+			Limit:  100,
+		})
+		if err != nil {
+			results <- SourceResult{Err: err}
+			return
+		}
+		if len(dbDeps) == 0 {
+			break
+		}
+
+		totalDBFetched += len(dbDeps)
+		lastID = dbDeps[len(dbDeps)-1].ID
+
+		for _, dep := range dbDeps {
+			pkg, err := reposource.ParseNpmPackage(dep.Name)
+			if err != nil {
+				log15.Warn("error parsing npm package", "error", err, "package", dep.Name)
+				continue
+			}
+			if listed[pkg] {
+				continue
+			}
+
+			// As for JVM packages, we don't return packages that don't
+			// exist on the registry, to reduce logspam from gitserver.
+			dependency := reposource.NpmDependency{NpmPackage: pkg, Version: dep.Version}
+			if exists, err := s.client.DoesDependencyExist(ctx, dependency); err != nil || !exists {
+				log15.Warn("npm package not resolvable from registry", "package", dependency.PackageManagerSyntax(), "error", err)
+				continue
+			}
+
+			listed[pkg] = true
+			totalDBResolved++
+			results <- SourceResult{Source: s, Repo: s.makeRepo(pkg)}
+		}
+	}
+
+	log15.Info("finished listing resolvable npm packages", "totalDB", totalDBFetched, "resolvedDB", totalDBResolved, "totalConfig", len(packages))
+}
+
+func (s *NpmPackagesSource) GetRepo(ctx context.Context, repoPath string) (*types.Repo, error) {
+	pkg, err := reposource.ParseNpmPackageFromRepoURL(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies, err := NpmDependencies(*s.config)
+	if err != nil {
+		return nil, err
+	}
+
+	dbDeps, err := s.dbStore.GetDependencyRepos(ctx, dbstore.GetDependencyReposOpts{
+		Scheme: npmPackagesScheme,
+		Name:   pkg.PackageSyntax(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "dbstore.GetDependencyRepos")
+	}
+	for _, dep := range dbDeps {
+		dependencies = append(dependencies, reposource.NpmDependency{NpmPackage: pkg, Version: dep.Version})
+	}
+
+	var nonExistentDependencies []reposource.NpmDependency
+	for _, dep := range dependencies {
+		if dep.NpmPackage != pkg {
+			continue
+		}
+		exists, err := s.client.DoesDependencyExist(ctx, dep)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return s.makeRepo(pkg), nil
+		}
+		nonExistentDependencies = append(nonExistentDependencies, dep)
+	}
+
+	return nil, &npmDependencyNotFound{dependencies: nonExistentDependencies}
+}
+
+type npmDependencyNotFound struct {
+	dependencies []reposource.NpmDependency
+}
+
+func (e *npmDependencyNotFound) Error() string {
+	return fmt.Sprintf("not found: npm dependency '%v'", e.dependencies)
+}
+
+func (e *npmDependencyNotFound) NotFound() bool {
+	return true
+}
+
+func (s *NpmPackagesSource) makeRepo(pkg reposource.NpmPackage) *types.Repo {
+	urn := s.svc.URN()
+	return &types.Repo{
+		Name: pkg.RepoName(),
+		URI:  string(pkg.RepoName()),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          string(pkg.RepoName()),
+			ServiceID:   extsvc.TypeNpmPackages,
+			ServiceType: extsvc.TypeNpmPackages,
+		},
+		Private: false,
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: pkg.CloneURL(),
+			},
+		},
+		Metadata: &npmpackages.Metadata{
+			Package: pkg,
+		},
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *NpmPackagesSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func NpmDependencies(connection schema.NpmPackagesConnection) (dependencies []reposource.NpmDependency, err error) {
+	for _, dep := range connection.Dependencies {
+		dependency, err := reposource.ParseNpmDependency(dep)
+		if err != nil {
+			return nil, err
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies, nil
+}
+
+func NpmPackages(connection schema.NpmPackagesConnection) ([]reposource.NpmPackage, error) {
+	dependencies, err := NpmDependencies(connection)
+	if err != nil {
+		return nil, err
+	}
+	isAdded := make(map[reposource.NpmPackage]bool)
+	packages := []reposource.NpmPackage{}
+	for _, dep := range dependencies {
+		if !isAdded[dep.NpmPackage] {
+			packages = append(packages, dep.NpmPackage)
+		}
+		isAdded[dep.NpmPackage] = true
+	}
+	return packages, nil
+}
@@ -0,0 +1,209 @@
+// Package codeowners parses CODEOWNERS files and resolves the code owners of
+// files in search results.
+package codeowners
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Ruleset is a parsed CODEOWNERS file.
+type Ruleset struct {
+	// sections holds the rules of each section of a GitLab CODEOWNERS file.
+	// The first section holds the rules preceding any section header, and
+	// is the only section of a GitHub CODEOWNERS file.
+	sections []*section
+}
+
+type section struct {
+	name          string
+	defaultOwners []string
+	rules         []rule
+}
+
+type rule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// sectionHeader matches a GitLab section header like `[Docs]`, `^[Docs]`
+// (optional section) or `[Docs][2]` (required approvals), followed by the
+// default owners of the section.
+var sectionHeader = regexp.MustCompile(`^\^?\[([^\]]+)\](?:\[\d+\])?(.*)$`)
+
+// Parse parses a CODEOWNERS file in the syntax of GitHub or GitLab. Each
+// non-empty line which is not a comment consists of a gitignore-style path
+// pattern followed by owners. Spaces in patterns are escaped with a
+// backslash. GitLab section headers group the rules following them.
+func Parse(data []byte) (*Ruleset, error) {
+	current := &section{}
+	rs := &Ruleset{sections: []*section{current}}
+	sectionsByName := map[string]*section{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		if m := sectionHeader.FindStringSubmatch(line); m != nil {
+			// Sections are case-insensitive, and repeated sections are
+			// merged.
+			name := strings.TrimSpace(m[1])
+			if s, ok := sectionsByName[strings.ToLower(name)]; ok {
+				current = s
+			} else {
+				current = &section{name: name}
+				sectionsByName[strings.ToLower(name)] = current
+				rs.sections = append(rs.sections, current)
+			}
+			if owners := strings.Fields(m[2]); len(owners) > 0 {
+				current.defaultOwners = owners
+			}
+			continue
+		}
+
+		fields := splitFields(line)
+		pattern, err := compilePattern(strings.ReplaceAll(fields[0], `\#`, "#"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", lineNumber)
+		}
+		// A pattern without owners is valid. It removes the owners of the
+		// files it matches, unless its section has default owners.
+		owners := fields[1:]
+		if len(owners) == 0 {
+			owners = current.defaultOwners
+		}
+		current.rules = append(current.rules, rule{pattern: pattern, owners: owners})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+// Match returns the owners of the file at path, relative to the root of the
+// repository. In each section the last rule which matches path determines
+// its owners, and the owners of all sections are combined.
+func (rs *Ruleset) Match(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+
+	var owners []string
+	seen := map[string]struct{}{}
+	for _, s := range rs.sections {
+		for i := len(s.rules) - 1; i >= 0; i-- {
+			if !s.rules[i].pattern.MatchString(path) {
+				continue
+			}
+			for _, owner := range s.rules[i].owners {
+				if _, ok := seen[owner]; !ok {
+					seen[owner] = struct{}{}
+					owners = append(owners, owner)
+				}
+			}
+			break
+		}
+	}
+	return owners
+}
+
+// HasOwner returns true if owner is one of owners. Owners are compared
+// case-insensitively, like user and team names on code hosts.
+func HasOwner(owners []string, owner string) bool {
+	for _, o := range owners {
+		if strings.EqualFold(o, owner) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitFields splits line on whitespace which is not escaped with a
+// backslash.
+func splitFields(line string) []string {
+	var fields []string
+	var b strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '\\' && i+1 < len(line) && (line[i+1] == ' ' || line[i+1] == '\t'):
+			b.WriteByte(line[i+1])
+			i++
+		case c == ' ' || c == '\t':
+			if b.Len() > 0 {
+				fields = append(fields, b.String())
+				b.Reset()
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	if b.Len() > 0 {
+		fields = append(fields, b.String())
+	}
+	return fields
+}
+
+// stripComment removes the comment from line. An escaped \# does not start a
+// comment.
+func stripComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] == '#' && (i == 0 || line[i-1] != '\\') {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// compilePattern compiles a gitignore-style pattern to a regular expression
+// matching the paths of the files it applies to:
+//
+// - A pattern which contains a slash other than a trailing slash is relative
+//   to the root of the repository. Otherwise it matches at any depth.
+// - A pattern matching a directory applies to all files in it, except for
+//   patterns ending in /*, which only apply to the files directly in it.
+// - * matches anything but a slash, ** matches anything.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	onlyDirectChildren := strings.HasSuffix(pattern, "/*")
+	pattern = strings.Trim(pattern, "/")
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		default:
+			// Quote the literal run up to the next wildcard as a whole, so
+			// that multi-byte characters are kept intact.
+			n := strings.IndexAny(pattern[i:], "*?")
+			if n < 0 {
+				n = len(pattern) - i
+			}
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+n]))
+			i += n - 1
+		}
+	}
+	if onlyDirectChildren {
+		b.WriteString("$")
+	} else {
+		b.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,150 @@
+package codeowners
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRulesetMatch(t *testing.T) {
+	rs, err := Parse([]byte(`
+# Default owners
+*                @global-owner
+
+*.js             @js-owner
+/build/logs/     @doctocat
+docs/*           docs@example.com
+apps/            @octocat
+/scripts/**/test @test-owner
+\#notes.txt      @hash-owner # trailing comment
+/café/           @cafe-owner
+naïve-*.txt      @naive-owner
+/vendor/
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		path string
+		want string
+	}{
+		{"README.md", "@global-owner"},
+		{"web/app.js", "@js-owner"},
+		{"build/logs/out.txt", "@doctocat"},
+		{"src/build/logs/out.txt", "@global-owner"},
+		{"docs/index.md", "docs@example.com"},
+		{"docs/api/index.md", "@global-owner"},
+		{"apps/web/main.go", "@octocat"},
+		{"pkg/apps/main.go", "@octocat"},
+		{"scripts/test", "@test-owner"},
+		{"scripts/ci/lint/test/run.sh", "@test-owner"},
+		{"#notes.txt", "@hash-owner"},
+		{"café/menu.md", "@cafe-owner"},
+		{"src/naïve-bayes.txt", "@naive-owner"},
+		{"vendor/lib.go", ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			if got := strings.Join(rs.Match(tc.path), " "); got != tc.want {
+				t.Errorf("got owners %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRulesetMatchGitLabSections(t *testing.T) {
+	rs, err := Parse([]byte(`
+*.go @backend
+
+[Documentation] @docs-team
+docs/
+README.md @alice
+
+^[Security][2] @security
+/internal/auth/ @security @bob
+docs/security\ guide.md @security
+
+[documentation]
+*.md @bob
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		path string
+		want string
+	}{
+		{"main.go", "@backend"},
+		{"docs/index.html", "@docs-team"},
+		{"docs/index.md", "@bob"},
+		{"README.md", "@bob"},
+		{"internal/auth/auth.go", "@backend @security @bob"},
+		{"docs/security guide.md", "@bob @security"},
+		{"web/app.js", ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			if got := strings.Join(rs.Match(tc.path), " "); got != tc.want {
+				t.Errorf("got owners %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHasOwner(t *testing.T) {
+	owners := []string{"@sourcegraph/Search", "alice@example.com"}
+	for owner, want := range map[string]bool{
+		"@sourcegraph/search": true,
+		"ALICE@example.com":   true,
+		"@sourcegraph":        false,
+		"search":              false,
+	} {
+		if got := HasOwner(owners, owner); got != want {
+			t.Errorf("HasOwner(%q) = %v, want %v", owner, got, want)
+		}
+	}
+}
+
+func TestOwnerFilter(t *testing.T) {
+	rs, err := Parse([]byte("*.go @backend\n/docs/ @docs @backend\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Prime the cache of the resolver so that it doesn't read from gitserver.
+	r := NewResolver()
+	prime := func(repo api.RepoName, rs *Ruleset, err error) {
+		e := &rulesetEntry{ruleset: rs, err: err}
+		e.once.Do(func() {})
+		r.rulesets[rulesetKey{repo: repo, commit: "c"}] = e
+	}
+	prime("owned", rs, nil)
+	prime("broken", nil, errors.New("boom"))
+
+	fileMatch := func(repo api.RepoName, path string) *result.FileMatch {
+		return &result.FileMatch{File: result.File{Repo: types.RepoName{Name: repo}, CommitID: "c", Path: path}}
+	}
+	matches := []result.Match{
+		fileMatch("owned", "main.go"),
+		fileMatch("owned", "docs/index.md"),
+		fileMatch("owned", "README.md"),
+		fileMatch("broken", "main.go"),
+		&result.RepoMatch{Name: "owned"},
+	}
+
+	f := NewOwnerFilter(r, []string{"@Backend", "@docs"})
+	got := f.Filter(context.Background(), matches)
+	if len(got) != 1 || got[0].(*result.FileMatch).Path != "docs/index.md" {
+		t.Fatalf("unexpected matches %v", got)
+	}
+	if f.Err() == nil {
+		t.Fatal("expected the error resolving the owners of the broken repository")
+	}
+}
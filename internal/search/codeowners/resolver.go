@@ -0,0 +1,178 @@
+package codeowners
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// Paths are the locations of a CODEOWNERS file in a repository, in the order
+// they are looked up. GitHub and GitLab both support the root and docs/
+// directories, and each supports a directory of its own.
+var Paths = []string{".github/CODEOWNERS", ".gitlab/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// maxFileSize is the maximum size of a CODEOWNERS file we read.
+const maxFileSize = 3 * 1024 * 1024
+
+// Resolver resolves the owners of files. It caches the CODEOWNERS file of
+// each repository revision it reads, so a Resolver should be used for the
+// duration of a single search.
+type Resolver struct {
+	mu       sync.Mutex
+	rulesets map[rulesetKey]*rulesetEntry
+}
+
+type rulesetKey struct {
+	repo   api.RepoName
+	commit api.CommitID
+}
+
+type rulesetEntry struct {
+	once    sync.Once
+	ruleset *Ruleset
+	err     error
+}
+
+func NewResolver() *Resolver {
+	return &Resolver{rulesets: make(map[rulesetKey]*rulesetEntry)}
+}
+
+// Owners returns the owners of the file at path in repo at commit. It returns
+// nil if the repository has no CODEOWNERS file.
+func (r *Resolver) Owners(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) ([]string, error) {
+	rs, err := r.ruleset(ctx, repo, commit)
+	if err != nil || rs == nil {
+		return nil, err
+	}
+	return rs.Match(path), nil
+}
+
+func (r *Resolver) ruleset(ctx context.Context, repo api.RepoName, commit api.CommitID) (*Ruleset, error) {
+	key := rulesetKey{repo: repo, commit: commit}
+	r.mu.Lock()
+	e, ok := r.rulesets[key]
+	if !ok {
+		e = &rulesetEntry{}
+		r.rulesets[key] = e
+	}
+	r.mu.Unlock()
+
+	e.once.Do(func() {
+		e.ruleset, e.err = readRuleset(ctx, repo, commit)
+	})
+	return e.ruleset, e.err
+}
+
+func readRuleset(ctx context.Context, repo api.RepoName, commit api.CommitID) (*Ruleset, error) {
+	for _, path := range Paths {
+		data, err := git.ReadFile(ctx, repo, commit, path, maxFileSize)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return Parse(data)
+	}
+	return nil, nil
+}
+
+// AddOwners sets the owners of the file matches in matches. If the owners of
+// a file cannot be resolved, AddOwners continues with the other file matches
+// and returns the first error.
+func (r *Resolver) AddOwners(ctx context.Context, matches []result.Match) (err error) {
+	for _, m := range matches {
+		fm, ok := m.(*result.FileMatch)
+		if !ok || fm.CommitID == "" {
+			continue
+		}
+		owners, ownersErr := r.Owners(ctx, fm.Repo.Name, fm.CommitID, fm.Path)
+		if ownersErr != nil {
+			if err == nil {
+				err = ownersErr
+			}
+			continue
+		}
+		fm.Owners = owners
+	}
+	return err
+}
+
+// OwnerFilter restricts search results to the files owned by all of a set of
+// owners, as requested by file:has.owner(). File matches whose owners cannot
+// be resolved are dropped, and the first such error is reported by Err.
+type OwnerFilter struct {
+	resolver *Resolver
+	owners   []string
+
+	mu  sync.Mutex
+	err error
+}
+
+func NewOwnerFilter(r *Resolver, owners []string) *OwnerFilter {
+	return &OwnerFilter{resolver: r, owners: owners}
+}
+
+// Filter sets the owners of the file matches in matches, and returns the file
+// matches owned by all owners of the filter. Other matches are dropped.
+func (f *OwnerFilter) Filter(ctx context.Context, matches []result.Match) []result.Match {
+	if err := f.resolver.AddOwners(ctx, matches); err != nil {
+		f.mu.Lock()
+		if f.err == nil {
+			f.err = err
+		}
+		f.mu.Unlock()
+	}
+
+	filtered := matches[:0]
+	for _, m := range matches {
+		if fm, ok := m.(*result.FileMatch); ok && f.ownedBy(fm.Owners) {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered
+}
+
+func (f *OwnerFilter) ownedBy(owners []string) bool {
+	for _, owner := range f.owners {
+		if !HasOwner(owners, owner) {
+			return false
+		}
+	}
+	return true
+}
+
+// Stream returns a child Stream of parent which filters the results of each
+// event.
+func (f *OwnerFilter) Stream(ctx context.Context, parent streaming.Sender) streaming.Sender {
+	return streaming.StreamFunc(func(e streaming.SearchEvent) {
+		e.Results = f.Filter(ctx, e.Results)
+		parent.Send(e)
+	})
+}
+
+// Err returns the first error encountered while resolving owners, if any.
+func (f *OwnerFilter) Err() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.err
+}
+
+// WithOwners returns a child Stream of parent which sets the owners of the
+// file matches of each event. Errors are logged, and leave the owners of the
+// affected file matches empty.
+func WithOwners(ctx context.Context, parent streaming.Sender, r *Resolver) streaming.Sender {
+	return streaming.StreamFunc(func(e streaming.SearchEvent) {
+		if err := r.AddOwners(ctx, e.Results); err != nil {
+			log15.Warn("failed to resolve code owners", "error", err)
+		}
+		parent.Send(e)
+	})
+}
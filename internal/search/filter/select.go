@@ -34,18 +34,24 @@ type object map[string]object
 
 var validSelectors = object{
 	Commit: object{
+		"author": nil,
 		"diff": object{
 			"added":   nil,
 			"removed": nil,
 		},
+		"message": nil,
 	},
 	Content: nil,
 	File: {
 		"directory": nil,
+		"owners":    nil,
 		"path":      nil,
 	},
 	Repository: nil,
 	Symbol: object{
+		// The enclosing class, namespace, etc. of a symbol. Not a symbol kind.
+		"container": nil,
+
 		/* cf. SymbolKind https://microsoft.github.io/language-server-protocol/specification */
 		"file":           nil,
 		"module":         nil,
@@ -34,6 +34,7 @@ const (
 	FieldTimeout   = "timeout"
 	FieldCombyRule = "rule"
 	FieldSelect    = "select"
+	FieldMultiline = "multiline"
 )
 
 var allFields = map[string]struct{}{
@@ -72,6 +73,7 @@ var allFields = map[string]struct{}{
 	FieldRev:                empty,
 	"revision":              empty,
 	FieldSelect:             empty,
+	FieldMultiline:          empty,
 }
 
 var aliases = map[string]string{
@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
 )
 
 type Predicate interface {
@@ -30,11 +32,14 @@ var DefaultPredicateRegistry = predicateRegistry{
 		"contains":              func() Predicate { return &RepoContainsPredicate{} },
 		"contains.file":         func() Predicate { return &RepoContainsFilePredicate{} },
 		"contains.content":      func() Predicate { return &RepoContainsContentPredicate{} },
+		"contains.symbol":       func() Predicate { return &RepoContainsSymbolPredicate{} },
 		"contains.commit.after": func() Predicate { return &RepoContainsCommitAfterPredicate{} },
 	},
 	FieldFile: {
 		"contains.content": func() Predicate { return &FileContainsContentPredicate{} },
 		"contains":         func() Predicate { return &FileContainsContentPredicate{} },
+		"contains.symbol":  func() Predicate { return &FileContainsSymbolPredicate{} },
+		"has.owner":        func() Predicate { return &FileHasOwnerPredicate{} },
 	},
 }
 
@@ -220,6 +225,25 @@ func (f *RepoContainsFilePredicate) Plan(parent Basic) (Plan, error) {
 	return contains.Plan(parent)
 }
 
+/* repo:contains.symbol(pattern) */
+
+type RepoContainsSymbolPredicate struct {
+	symbolPredicate
+}
+
+func (f *RepoContainsSymbolPredicate) Field() string { return FieldRepo }
+func (f *RepoContainsSymbolPredicate) Name() string  { return "contains.symbol" }
+func (f *RepoContainsSymbolPredicate) Plan(parent Basic) (Plan, error) {
+	// Without a kind we can project symbol results directly onto repos.
+	// With a kind, select the symbol kind so that non-matching symbols are
+	// dropped, and let the caller reduce the file results to their repos.
+	selectValue := filter.Repository
+	if f.Kind != "" {
+		selectValue = filter.Symbol + "." + f.Kind
+	}
+	return f.plan(parent, selectValue)
+}
+
 /* repo:contains.commit.after(...) */
 
 type RepoContainsCommitAfterPredicate struct {
@@ -284,6 +308,132 @@ func (f *FileContainsContentPredicate) Plan(parent Basic) (Plan, error) {
 	return ToPlan(Dnf(nodes))
 }
 
+/* file:contains.symbol(pattern) */
+
+type FileContainsSymbolPredicate struct {
+	symbolPredicate
+}
+
+func (f FileContainsSymbolPredicate) Field() string { return FieldFile }
+func (f FileContainsSymbolPredicate) Name() string  { return "contains.symbol" }
+func (f *FileContainsSymbolPredicate) Plan(parent Basic) (Plan, error) {
+	selectValue := ""
+	if f.Kind != "" {
+		selectValue = filter.Symbol + "." + f.Kind
+	}
+	return f.plan(parent, selectValue)
+}
+
+/* file:has.owner(owner) */
+
+// FileHasOwnerPredicate represents the `file:has.owner()` predicate, which
+// filters to files owned by a user, team or email address according to the
+// CODEOWNERS file of their repository.
+//
+// Ownership cannot be expressed as a query, so the predicate is not expanded
+// into one. Instead, callers remove it with Basic.FileOwners and filter the
+// results of the remaining query to the files of the owners.
+type FileHasOwnerPredicate struct {
+	Owner string
+}
+
+func (f *FileHasOwnerPredicate) ParseParams(params string) error {
+	owner := strings.TrimSpace(params)
+	if owner == "" {
+		return errors.Errorf("file:has.owner argument should not be empty")
+	}
+	if strings.ContainsAny(owner, " \t\n") {
+		return errors.Errorf("file:has.owner argument should be a single owner, like @user, @org/team or an email address")
+	}
+	f.Owner = owner
+	return nil
+}
+
+func (f FileHasOwnerPredicate) Field() string { return FieldFile }
+func (f FileHasOwnerPredicate) Name() string  { return "has.owner" }
+func (f *FileHasOwnerPredicate) Plan(parent Basic) (Plan, error) {
+	return nil, errors.New("file:has.owner() filters search results and has no plan")
+}
+
+// FileOwners returns the owners of the file:has.owner() predicates in b, and
+// a copy of b without them.
+func (b Basic) FileOwners() ([]string, Basic) {
+	var owners []string
+	parameters := make([]Parameter, 0, len(b.Parameters))
+	for _, p := range b.Parameters {
+		if p.Field == FieldFile && p.Annotation.Labels.IsSet(IsPredicate) {
+			name, params := ParseAsPredicate(p.Value)
+			f := &FileHasOwnerPredicate{}
+			if name == f.Name() && f.ParseParams(params) == nil {
+				owners = append(owners, f.Owner)
+				continue
+			}
+		}
+		parameters = append(parameters, p)
+	}
+	return owners, b.MapParameters(parameters)
+}
+
+// symbolPredicate holds the parameters shared by the contains.symbol
+// predicates. Its parameters are a symbol name pattern, optionally preceded
+// by a symbol kind, for example `contains.symbol(kind:class Foo)`.
+type symbolPredicate struct {
+	Pattern string
+	Kind    string
+}
+
+func (f *symbolPredicate) ParseParams(params string) error {
+	params = strings.TrimSpace(params)
+	if strings.HasPrefix(params, "kind:") {
+		kind := strings.TrimPrefix(params, "kind:")
+		params = ""
+		if i := strings.IndexAny(kind, " \t\n"); i >= 0 {
+			kind, params = kind[:i], strings.TrimSpace(kind[i:])
+		}
+		if _, err := filter.SelectPathFromString(filter.Symbol + "." + kind); err != nil || kind == "container" {
+			return errors.Errorf("contains.symbol has invalid symbol kind %q", kind)
+		}
+		f.Kind = kind
+	}
+	if _, err := regexp.Compile(params); err != nil {
+		return errors.Errorf("contains.symbol argument: %w", err)
+	}
+	if params == "" {
+		return errors.Errorf("contains.symbol argument should not be empty")
+	}
+	f.Pattern = params
+	return nil
+}
+
+// plan returns a symbol search for the predicate pattern, scoped to the
+// repos of parent. If selectValue is non-empty, it is added as a select:
+// parameter.
+func (f *symbolPredicate) plan(parent Basic, selectValue string) (Plan, error) {
+	nodes := make([]Node, 0, 4)
+	nodes = append(nodes, Parameter{
+		Field: FieldCount,
+		Value: "99999",
+	}, Parameter{
+		Field: FieldType,
+		Value: "symbol",
+	})
+
+	if selectValue != "" {
+		nodes = append(nodes, Parameter{
+			Field: FieldSelect,
+			Value: selectValue,
+		})
+	}
+
+	nodes = append(nodes, Pattern{
+		Value:      f.Pattern,
+		Annotation: Annotation{Labels: Regexp},
+	})
+
+	nodes = append(nodes, nonPredicateRepos(parent)...)
+	return ToPlan(Dnf(nodes))
+}
+
 // nonPredicateRepos returns the repo nodes in a query that aren't predicates,
 // respecting parameters that determine repo results.
 func nonPredicateRepos(q Basic) []Node {
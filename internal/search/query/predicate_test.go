@@ -56,6 +56,161 @@ func TestRepoContainsPredicate(t *testing.T) {
 	})
 }
 
+func TestSymbolPredicate(t *testing.T) {
+	t.Run("ParseParams", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			expected *symbolPredicate
+		}
+
+		valid := []test{
+			{`pattern`, `Foo`, &symbolPredicate{Pattern: "Foo"}},
+			{`regexp pattern`, `^New\w+$`, &symbolPredicate{Pattern: `^New\w+$`}},
+			{`kind and pattern`, `kind:class Foo`, &symbolPredicate{Pattern: "Foo", Kind: "class"}},
+			{`kind with dash`, `kind:enum-member  Foo`, &symbolPredicate{Pattern: "Foo", Kind: "enum-member"}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &symbolPredicate{}
+				err := p.ParseParams(tc.params)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`empty`, ``, nil},
+			{`kind only`, `kind:class`, nil},
+			{`unknown kind`, `kind:widget Foo`, nil},
+			{`invalid regexp`, `([)`, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &symbolPredicate{}
+				err := p.ParseParams(tc.params)
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+
+	t.Run("Plan", func(t *testing.T) {
+		parent, err := ParseLiteral("repo:foo file:contains.symbol(Foo)")
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ToBasicQuery(parent)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tests := []struct {
+			predicate Predicate
+			params    string
+			want      string
+		}{
+			{&RepoContainsSymbolPredicate{}, `Foo`, `(and "count:99999" "type:symbol" "select:repo" "repo:foo" "Foo")`},
+			{&RepoContainsSymbolPredicate{}, `kind:function Foo`, `(and "count:99999" "type:symbol" "select:symbol.function" "repo:foo" "Foo")`},
+			{&FileContainsSymbolPredicate{}, `Foo`, `(and "count:99999" "type:symbol" "repo:foo" "Foo")`},
+			{&FileContainsSymbolPredicate{}, `kind:class Foo`, `(and "count:99999" "type:symbol" "select:symbol.class" "repo:foo" "Foo")`},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.params, func(t *testing.T) {
+				if err := tc.predicate.ParseParams(tc.params); err != nil {
+					t.Fatal(err)
+				}
+				plan, err := tc.predicate.Plan(b)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := plan.ToParseTree().String(); got != tc.want {
+					t.Fatalf("expected %s, got %s", tc.want, got)
+				}
+			})
+		}
+	})
+}
+
+func TestFileHasOwnerPredicate(t *testing.T) {
+	t.Run("ParseParams", func(t *testing.T) {
+		valid := map[string]string{
+			`@sourcegraph/search`:  "@sourcegraph/search",
+			` @alice `:             "@alice",
+			`alice@example.com`:    "alice@example.com",
+			`@group/subgroup/team`: "@group/subgroup/team",
+		}
+		for params, want := range valid {
+			p := &FileHasOwnerPredicate{}
+			if err := p.ParseParams(params); err != nil {
+				t.Fatalf("unexpected error for %q: %s", params, err)
+			}
+			if p.Owner != want {
+				t.Fatalf("expected owner %q, got %q", want, p.Owner)
+			}
+		}
+
+		for _, params := range []string{``, `@alice @bob`} {
+			p := &FileHasOwnerPredicate{}
+			if err := p.ParseParams(params); err == nil {
+				t.Fatalf("expected error for %q but got none", params)
+			}
+		}
+	})
+
+	t.Run("FileOwners", func(t *testing.T) {
+		tests := []struct {
+			query  string
+			owners []string
+			want   string
+		}{
+			{
+				query: `repo:foo deprecatedAPI`,
+				want:  `"repo:foo" "deprecatedAPI"`,
+			},
+			{
+				query:  `repo:foo deprecatedAPI file:has.owner(@team)`,
+				owners: []string{"@team"},
+				want:   `"repo:foo" "deprecatedAPI"`,
+			},
+			{
+				query:  `repo:foo file:\.go$ file:has.owner(@team) file:contains(x) file:has.owner(@other) select:repo deprecatedAPI`,
+				owners: []string{"@team", "@other"},
+				want:   `"repo:foo" "file:\\.go$" "file:contains(x)" "select:repo" "deprecatedAPI"`,
+			},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.query, func(t *testing.T) {
+				parent, err := ParseLiteral(tc.query)
+				if err != nil {
+					t.Fatal(err)
+				}
+				b, err := ToBasicQuery(parent)
+				if err != nil {
+					t.Fatal(err)
+				}
+				owners, rest := b.FileOwners()
+				if !reflect.DeepEqual(owners, tc.owners) {
+					t.Fatalf("expected owners %q, got %q", tc.owners, owners)
+				}
+				if got := rest.ToParseTree().String(); got != tc.want {
+					t.Fatalf("expected %s, got %s", tc.want, got)
+				}
+			})
+		}
+	})
+}
+
 func TestParseAsPredicate(t *testing.T) {
 	tests := []struct {
 		input  string
@@ -197,6 +197,10 @@ func (b Basic) IsCaseSensitive() bool {
 	return Q(ToNodes(b.Parameters)).IsCaseSensitive()
 }
 
+func (b Basic) IsMultiline() bool {
+	return Q(ToNodes(b.Parameters)).IsMultiline()
+}
+
 func (b Basic) Index() YesNoOnly {
 	v := Q(ToNodes(b.Parameters)).yesNoOnlyValue(FieldIndex)
 	if v == nil {
@@ -326,6 +330,10 @@ func (q Q) IsCaseSensitive() bool {
 	return q.BoolValue("case")
 }
 
+func (q Q) IsMultiline() bool {
+	return q.BoolValue(FieldMultiline)
+}
+
 func (q Q) Repositories() (repos []string, negatedRepos []string) {
 	VisitField(q, FieldRepo, func(value string, negated bool, _ Annotation) {
 		if negated {
@@ -367,7 +375,8 @@ func (q Q) valueToTypedValue(field, value string, label labels) []*Value {
 		return []*Value{{String: &value}}
 
 	case
-		FieldCase:
+		FieldCase,
+		FieldMultiline:
 		b, _ := parseBool(value)
 		return []*Value{{Bool: &b}}
 
@@ -152,11 +152,17 @@ func ToTextPatternInfo(q query.Basic, p Protocol, transform query.BasicPass) *Te
 		negated = p.Negated
 	}
 
+	multiline := false
+	if !q.IsStructural() {
+		multiline = q.IsMultiline() || (isRegexp && isMultilinePattern(pattern))
+	}
+
 	return &TextPatternInfo{
 		// Values dependent on pattern atom.
 		IsRegExp:        isRegexp,
 		IsStructuralPat: q.IsStructural(),
 		IsCaseSensitive: q.IsCaseSensitive(),
+		IsMultiline:     multiline,
 		FileMatchLimit:  int32(count),
 		Pattern:         pattern,
 		IsNegated:       negated,
@@ -190,8 +196,38 @@ func TimeoutDuration(b query.Basic) time.Duration {
 	return d
 }
 
+// isMultilinePattern returns whether the regexp pattern can only be
+// matched across lines, because it contains a newline or a dot which matches
+// newlines (the s flag).
+func isMultilinePattern(pattern string) bool {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return false
+	}
+	var visit func(*syntax.Regexp) bool
+	visit = func(re *syntax.Regexp) bool {
+		switch re.Op {
+		case syntax.OpAnyChar:
+			return true
+		case syntax.OpLiteral:
+			for _, r := range re.Rune {
+				if r == '\n' {
+					return true
+				}
+			}
+		}
+		for _, s := range re.Sub {
+			if visit(s) {
+				return true
+			}
+		}
+		return false
+	}
+	return visit(re)
+}
+
 func fileRe(pattern string, queryIsCaseSensitive bool) (zoekt.Q, error) {
-	return parseRe(pattern, true, false, queryIsCaseSensitive)
+	return parseRe(pattern, true, false, queryIsCaseSensitive, false)
 }
 
 func noOpAnyChar(re *syntax.Regexp) {
@@ -203,13 +239,17 @@ func noOpAnyChar(re *syntax.Regexp) {
 	}
 }
 
-func parseRe(pattern string, filenameOnly bool, contentOnly bool, queryIsCaseSensitive bool) (zoekt.Q, error) {
+func parseRe(pattern string, filenameOnly bool, contentOnly bool, queryIsCaseSensitive bool, multiline bool) (zoekt.Q, error) {
 	// these are the flags used by zoekt, which differ to searcher.
 	re, err := syntax.Parse(pattern, syntax.ClassNL|syntax.PerlX|syntax.UnicodeGroups)
 	if err != nil {
 		return nil, err
 	}
-	noOpAnyChar(re)
+	// A multiline search matches the whole file content, so dots may match
+	// newlines.
+	if !multiline {
+		noOpAnyChar(re)
+	}
 	// zoekt decides to use its literal optimization at the query parser
 	// level, so we check if our regex can just be a literal.
 	if re.Op == syntax.OpLiteral {
@@ -236,7 +276,7 @@ func QueryToZoektQuery(p *TextPatternInfo, typ IndexedRequestType) (zoekt.Q, err
 	if p.IsRegExp {
 		fileNameOnly := p.PatternMatchesPath && !p.PatternMatchesContent
 		contentOnly := !p.PatternMatchesPath && p.PatternMatchesContent
-		q, err = parseRe(p.Pattern, fileNameOnly, contentOnly, p.IsCaseSensitive)
+		q, err = parseRe(p.Pattern, fileNameOnly, contentOnly, p.IsCaseSensitive, p.IsMultiline)
 		if err != nil {
 			return nil, err
 		}
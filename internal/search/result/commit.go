@@ -20,6 +20,12 @@ type CommitMatch struct {
 	MessagePreview *HighlightedString
 	DiffPreview    *HighlightedString
 	Body           HighlightedString
+
+	// SelectedValue is the author or message of the commit if this match is
+	// the projection of a commit by select:commit.author or
+	// select:commit.message. Projections with the same value in a repository
+	// are deduplicated.
+	SelectedValue string
 }
 
 // ResultCount for CommitSearchResult returns the number of highlights if there
@@ -71,6 +77,9 @@ func (r *CommitMatch) Select(path filter.SelectPath) Match {
 			}
 			return nil
 		}
+		if len(fields) > 0 {
+			return selectCommitField(r, fields[0])
+		}
 		return r
 	}
 	return nil
@@ -89,6 +98,13 @@ func (r *CommitMatch) AppendMatches(src *CommitMatch) {
 
 // Key implements Match interface's Key() method
 func (r *CommitMatch) Key() Key {
+	if r.SelectedValue != "" {
+		return Key{
+			TypeRank: rankCommitMatch,
+			Repo:     r.Repo.Name,
+			Value:    r.SelectedValue,
+		}
+	}
 	typeRank := rankCommitMatch
 	if r.DiffPreview != nil {
 		typeRank = rankDiffMatch
@@ -159,6 +175,28 @@ func modifiedLinesExist(lines []string, prefix string) bool {
 	return false
 }
 
+// selectCommitField projects c onto its author or message. The projection
+// links to c, the first commit with the selected value.
+func selectCommitField(c *CommitMatch, field string) Match {
+	var value, body string
+	switch field {
+	case "author":
+		value = fmt.Sprintf("%s <%s>", c.Commit.Author.Name, c.Commit.Author.Email)
+		body = "```\n" + value + "\n```"
+	case "message":
+		value = string(c.Commit.Message)
+		body = "```COMMIT_EDITMSG\n" + value + "\n```"
+	default:
+		return nil
+	}
+	return &CommitMatch{
+		Commit:        c.Commit,
+		Repo:          c.Repo,
+		Body:          HighlightedString{Value: body},
+		SelectedValue: value,
+	}
+}
+
 // selectCommitDiffKind returns a commit match `c` if it contains `added` (resp.
 // `removed`) lines set by `field. It ensures that highlight information only
 // applies to the modified lines selected by `field`. If there are no matches
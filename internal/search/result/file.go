@@ -38,14 +38,23 @@ func (f *File) URL() *url.URL {
 
 // FileMatch represents either:
 // - A collection of symbol results (len(Symbols) > 0)
-// - A collection of text content results (len(LineMatches) > 0)
-// - A result repsenting the whole file (len(Symbols) == 0 && len(LineMatches) == 0)
+// - A collection of text content results (len(LineMatches) > 0 || len(MultilineMatches) > 0)
+// - A result repsenting the whole file (no symbols, line or multiline matches)
 type FileMatch struct {
 	File
 
 	LineMatches []*LineMatch
 	Symbols     []*SymbolMatch `json:"-"`
 
+	// MultilineMatches are the matches of a multiline pattern, which may
+	// span several lines.
+	MultilineMatches []*MultilineMatch
+
+	// Owners are the code owners of the file according to the CODEOWNERS
+	// file of the repository. It is only resolved for queries which need
+	// it, e.g. select:file.owners.
+	Owners []string `json:"-"`
+
 	LimitHit bool
 }
 
@@ -56,7 +65,7 @@ func (fm *FileMatch) RepoName() types.RepoName {
 func (fm *FileMatch) searchResultMarker() {}
 
 func (fm *FileMatch) ResultCount() int {
-	rc := len(fm.Symbols)
+	rc := len(fm.Symbols) + len(fm.MultilineMatches)
 	for _, m := range fm.LineMatches {
 		rc += len(m.OffsetAndLengths)
 	}
@@ -74,7 +83,19 @@ func (fm *FileMatch) Select(selectPath filter.SelectPath) Match {
 			ID:   fm.Repo.ID,
 		}
 	case filter.File:
+		if len(selectPath) > 1 && selectPath[1] == "owners" {
+			if len(fm.Owners) == 0 {
+				return nil
+			}
+			return &OwnerMatch{
+				Owners:   fm.Owners,
+				Repo:     fm.Repo,
+				CommitID: fm.CommitID,
+				InputRev: fm.InputRev,
+			}
+		}
 		fm.LineMatches = nil
+		fm.MultilineMatches = nil
 		fm.Symbols = nil
 		if len(selectPath) > 1 && selectPath[1] == "directory" {
 			fm.Path = path.Clean(path.Dir(fm.Path)) + "/" // Add trailing slash for clarity.
@@ -83,6 +104,15 @@ func (fm *FileMatch) Select(selectPath filter.SelectPath) Match {
 	case filter.Symbol:
 		if len(fm.Symbols) > 0 {
 			fm.LineMatches = nil // Only return symbol match if symbols exist
+			fm.MultilineMatches = nil
+			if len(selectPath) > 1 && selectPath[1] == "container" {
+				containers := selectSymbolContainers(fm.Symbols)
+				if len(containers) == 0 {
+					return nil
+				}
+				fm.Symbols = containers
+				return fm
+			}
 			if len(selectPath) > 1 {
 				filteredSymbols := SelectSymbolKind(fm.Symbols, selectPath[1])
 				if len(filteredSymbols) == 0 {
@@ -95,7 +125,7 @@ func (fm *FileMatch) Select(selectPath filter.SelectPath) Match {
 		return nil
 	case filter.Content:
 		// Only return file match if line matches exist
-		if len(fm.LineMatches) > 0 {
+		if len(fm.LineMatches) > 0 || len(fm.MultilineMatches) > 0 {
 			fm.Symbols = nil
 			return fm
 		}
@@ -110,6 +140,7 @@ func (fm *FileMatch) Select(selectPath filter.SelectPath) Match {
 // counts and limit.
 func (fm *FileMatch) AppendMatches(src *FileMatch) {
 	fm.LineMatches = append(fm.LineMatches, src.LineMatches...)
+	fm.MultilineMatches = append(fm.MultilineMatches, src.MultilineMatches...)
 	fm.Symbols = append(fm.Symbols, src.Symbols...)
 	fm.LimitHit = fm.LimitHit || src.LimitHit
 }
@@ -130,6 +161,7 @@ func (fm *FileMatch) Limit(limit int) int {
 		after := limit - len(m.OffsetAndLengths)
 		if after <= 0 {
 			fm.Symbols = nil
+			fm.MultilineMatches = nil
 			fm.LineMatches = fm.LineMatches[:i+1]
 			m.OffsetAndLengths = m.OffsetAndLengths[:limit]
 			return 0
@@ -137,6 +169,13 @@ func (fm *FileMatch) Limit(limit int) int {
 		limit = after
 	}
 
+	if len(fm.MultilineMatches) >= limit {
+		fm.Symbols = nil
+		fm.MultilineMatches = fm.MultilineMatches[:limit]
+		return 0
+	}
+	limit -= len(fm.MultilineMatches)
+
 	fm.Symbols = fm.Symbols[:limit]
 	return 0
 }
@@ -5,7 +5,11 @@ import (
 	"testing"
 
 	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git/gitapi"
 )
 
 func TestSelect(t *testing.T) {
@@ -30,3 +34,97 @@ func TestSelect(t *testing.T) {
 	autogold.Want("filter any symbol", "a():func, b():function, var c:variable").Equal(t, test("symbol"))
 	autogold.Want("filter symbol kind variable", "var c:variable").Equal(t, test("symbol.variable"))
 }
+
+func TestSelectSymbolContainers(t *testing.T) {
+	data := &FileMatch{
+		Symbols: []*SymbolMatch{
+			{Symbol: Symbol{Name: "Server", Kind: "struct", Line: 1}},
+			{Symbol: Symbol{Name: "Serve", Kind: "method", Line: 2, Parent: "Server", ParentKind: "struct"}},
+			{Symbol: Symbol{Name: "Close", Kind: "method", Line: 3, Parent: "Server", ParentKind: "struct"}},
+			{Symbol: Symbol{Name: "Handler", Kind: "interface", Line: 4, Parent: "http", ParentKind: "package"}},
+		},
+	}
+
+	test := func(input string) string {
+		selectPath, _ := filter.SelectPathFromString(input)
+		fm, ok := data.Select(selectPath).(*FileMatch)
+		if !ok {
+			return "<nil>"
+		}
+		var values []string
+		for _, s := range fm.Symbols {
+			values = append(values, s.Symbol.Name+":"+s.Symbol.Kind)
+		}
+		return strings.Join(values, ", ")
+	}
+
+	autogold.Want("select symbol containers", "Server:struct, http:package").Equal(t, test("symbol.container"))
+
+	data.Symbols = data.Symbols[:1]
+	autogold.Want("no symbol containers", "<nil>").Equal(t, test("symbol.container"))
+}
+
+func TestSelectCommitFields(t *testing.T) {
+	commit := func(id, author, message string) *CommitMatch {
+		return &CommitMatch{
+			Repo: types.RepoName{Name: "github.com/sourcegraph/sourcegraph"},
+			Commit: gitapi.Commit{
+				ID:      api.CommitID(id),
+				Author:  gitapi.Signature{Name: author, Email: strings.ToLower(author) + "@example.com"},
+				Message: gitapi.Message(message),
+			},
+		}
+	}
+	commits := []*CommitMatch{
+		commit("a", "Alice", "fix bug"),
+		commit("b", "Bob", "fix bug"),
+		commit("c", "Alice", "add feature"),
+	}
+
+	test := func(input string) []string {
+		selectPath, _ := filter.SelectPathFromString(input)
+		dedup := NewDeduper()
+		for _, c := range commits {
+			dedup.Add(c.Select(selectPath))
+		}
+		var values []string
+		for _, m := range dedup.Results() {
+			values = append(values, m.(*CommitMatch).SelectedValue)
+		}
+		return values
+	}
+
+	autogold.Want("select commit authors", []string{"Alice <alice@example.com>", "Bob <bob@example.com>"}).Equal(t, test("commit.author"))
+	autogold.Want("select commit messages", []string{"fix bug", "add feature"}).Equal(t, test("commit.message"))
+}
+
+func TestSelectFileOwners(t *testing.T) {
+	file := func(path string, owners ...string) *FileMatch {
+		return &FileMatch{
+			File: File{
+				Repo: types.RepoName{Name: "github.com/sourcegraph/sourcegraph"},
+				Path: path,
+			},
+			Owners: owners,
+		}
+	}
+
+	selectPath, _ := filter.SelectPathFromString("file.owners")
+	dedup := NewDeduper()
+	for _, fm := range []*FileMatch{
+		file("README.md"),
+		file("web/app.js", "@web", "@frontend"),
+		file("web/index.js", "@web", "@frontend"),
+		file("cmd/main.go", "@backend"),
+	} {
+		if m := fm.Select(selectPath); m != nil {
+			dedup.Add(m)
+		}
+	}
+
+	var values []string
+	for _, m := range dedup.Results() {
+		values = append(values, strings.Join(m.(*OwnerMatch).Owners, " "))
+	}
+	autogold.Want("select file owners", []string{"@web @frontend", "@backend"}).Equal(t, values)
+}
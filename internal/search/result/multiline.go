@@ -0,0 +1,133 @@
+package result
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// MultilineMatch is a match of a multiline pattern. Unlike a LineMatch, it
+// may span several lines of a file.
+type MultilineMatch struct {
+	// Preview is the content of the lines spanned by the match, without the
+	// trailing newline.
+	Preview string
+
+	// Range is the location of the match. Offsets are relative to the start
+	// of Preview, and lines are 0-based line numbers in the file. The end is
+	// exclusive, so a match ending with a newline ends at column 0 of the
+	// following line.
+	Range Range
+}
+
+// NewMultilineMatches returns the matches at the byte ranges locs of
+// content. locs must be sorted and not overlap, like the result of
+// regexp.FindAllIndex.
+func NewMultilineMatches(content []byte, locs [][]int) []*MultilineMatch {
+	matches := make([]*MultilineMatch, 0, len(locs))
+
+	// line is the number of the line which starts at lineStart, and pos is
+	// where we stopped counting lines.
+	line, lineStart, pos := 0, 0, 0
+	advance := func(off int) {
+		for pos < off {
+			i := bytes.IndexByte(content[pos:off], '\n')
+			if i < 0 {
+				pos = off
+				break
+			}
+			line++
+			pos += i + 1
+			lineStart = pos
+		}
+	}
+
+	for _, loc := range locs {
+		start, end := loc[0], loc[1]
+
+		advance(start)
+		previewStart := lineStart
+		startLoc := Location{
+			Offset: utf8.RuneCount(content[previewStart:start]),
+			Line:   line,
+			Column: utf8.RuneCount(content[lineStart:start]),
+		}
+
+		advance(end)
+		endLoc := Location{
+			Offset: startLoc.Offset + utf8.RuneCount(content[start:end]),
+			Line:   line,
+			Column: utf8.RuneCount(content[lineStart:end]),
+		}
+
+		// The preview ends with the line of the last matched character.
+		last := end
+		if end > start && content[end-1] == '\n' {
+			last = end - 1
+		}
+		previewEnd := len(content)
+		if i := bytes.IndexByte(content[last:], '\n'); i >= 0 {
+			previewEnd = last + i
+		}
+
+		matches = append(matches, &MultilineMatch{
+			Preview: string(content[previewStart:previewEnd]),
+			Range:   Range{Start: startLoc, End: endLoc},
+		})
+	}
+	return matches
+}
+
+// AsLineMatches splits m into one LineMatch per spanned line, for consumers
+// which only understand single line matches. Lines on which the match is
+// empty, such as the line following a match ending with a newline, are
+// omitted unless the whole match is empty.
+func (m *MultilineMatch) AsLineMatches() []*LineMatch {
+	lines := bytes.Split([]byte(m.Preview), []byte{'\n'})
+	lms := make([]*LineMatch, 0, m.Range.End.Line-m.Range.Start.Line+1)
+	for i := m.Range.Start.Line; i <= m.Range.End.Line; i++ {
+		var preview []byte
+		if n := i - m.Range.Start.Line; n < len(lines) {
+			preview = lines[n]
+		}
+
+		start, end := 0, utf8.RuneCount(preview)
+		if i == m.Range.Start.Line {
+			start = m.Range.Start.Column
+		}
+		if i == m.Range.End.Line {
+			end = m.Range.End.Column
+		}
+		if end <= start && len(lms) > 0 {
+			continue
+		}
+
+		lms = append(lms, &LineMatch{
+			Preview:          string(preview),
+			LineNumber:       int32(i),
+			OffsetAndLengths: [][2]int32{{int32(start), int32(end - start)}},
+		})
+	}
+	return lms
+}
+
+// AllLineMatches returns the line matches of fm followed by its
+// multiline matches split into line matches. Multiline matches ending on the
+// line the next one starts on share a LineMatch.
+func (fm *FileMatch) AllLineMatches() []*LineMatch {
+	if len(fm.MultilineMatches) == 0 {
+		return fm.LineMatches
+	}
+	lms := append([]*LineMatch{}, fm.LineMatches...)
+	var last *LineMatch
+	for _, m := range fm.MultilineMatches {
+		for _, lm := range m.AsLineMatches() {
+			if last != nil && last.LineNumber == lm.LineNumber {
+				last.OffsetAndLengths = append(last.OffsetAndLengths, lm.OffsetAndLengths...)
+				continue
+			}
+			lms = append(lms, lm)
+			last = lm
+		}
+	}
+	return lms
+}
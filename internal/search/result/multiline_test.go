@@ -0,0 +1,93 @@
+package result
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewMultilineMatches(t *testing.T) {
+	content := []byte("package a\n\nfunc ä() {\n\treturn\n}\n")
+
+	test := func(pattern string) []*MultilineMatch {
+		re := regexp.MustCompile(pattern)
+		return NewMultilineMatches(content, re.FindAllIndex(content, -1))
+	}
+
+	got := test(`(?s)func.*?\}`)
+	want := []*MultilineMatch{{
+		Preview: "func ä() {\n\treturn\n}",
+		Range: Range{
+			Start: Location{Offset: 0, Line: 2, Column: 0},
+			End:   Location{Offset: 20, Line: 4, Column: 1},
+		},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("func (-want +got):\n%s", diff)
+	}
+
+	got = test(`\(\) \{\n`)
+	want = []*MultilineMatch{{
+		Preview: "func ä() {",
+		Range: Range{
+			Start: Location{Offset: 6, Line: 2, Column: 6},
+			End:   Location{Offset: 11, Line: 3, Column: 0},
+		},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("trailing newline (-want +got):\n%s", diff)
+	}
+
+	got = test(`a|n`)
+	if len(got) != 5 || got[4].Range.Start.Line != 3 || got[4].Preview != "\treturn" {
+		t.Errorf("single line matches: got %+v", got)
+	}
+}
+
+func TestMultilineMatch_AsLineMatches(t *testing.T) {
+	fm := &FileMatch{MultilineMatches: []*MultilineMatch{{
+		Preview: "func ä() {\n\treturn\n}",
+		Range: Range{
+			Start: Location{Offset: 5, Line: 2, Column: 5},
+			End:   Location{Offset: 20, Line: 4, Column: 1},
+		},
+	}, {
+		Preview: "}",
+		Range: Range{
+			Start: Location{Offset: 0, Line: 4, Column: 0},
+			End:   Location{Offset: 1, Line: 5, Column: 0},
+		},
+	}}}
+
+	got := fm.AllLineMatches()
+	want := []*LineMatch{{
+		Preview:          "func ä() {",
+		LineNumber:       2,
+		OffsetAndLengths: [][2]int32{{5, 5}},
+	}, {
+		Preview:          "\treturn",
+		LineNumber:       3,
+		OffsetAndLengths: [][2]int32{{0, 7}},
+	}, {
+		Preview:          "}",
+		LineNumber:       4,
+		OffsetAndLengths: [][2]int32{{0, 1}, {0, 1}},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func TestFileMatch_LimitMultiline(t *testing.T) {
+	fm := &FileMatch{MultilineMatches: []*MultilineMatch{{}, {}, {}}}
+	if got := fm.ResultCount(); got != 3 {
+		t.Fatalf("ResultCount: got %d, want 3", got)
+	}
+	if got := fm.Limit(5); got != 2 {
+		t.Errorf("Limit(5): got %d, want 2", got)
+	}
+	if got := fm.Limit(2); got != 0 || len(fm.MultilineMatches) != 2 {
+		t.Errorf("Limit(2): got %d with %d matches, want 0 with 2", got, len(fm.MultilineMatches))
+	}
+}
@@ -0,0 +1,75 @@
+package result
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// OwnerMatch is the projection of a file match onto its code owners by
+// select:file.owners. Files with the same owners in a repository are
+// deduplicated.
+type OwnerMatch struct {
+	// Owners are the owners of a CODEOWNERS rule, e.g. "@sourcegraph/search"
+	// or "alice@example.com".
+	Owners []string
+
+	Repo     types.RepoName
+	CommitID api.CommitID
+	InputRev *string
+}
+
+func (o *OwnerMatch) RepoName() types.RepoName {
+	return o.Repo
+}
+
+func (o *OwnerMatch) ResultCount() int {
+	return 1
+}
+
+func (o *OwnerMatch) Limit(limit int) int {
+	// Always represents one result and limit > 0 so we just return limit - 1.
+	return limit - 1
+}
+
+func (o *OwnerMatch) Select(path filter.SelectPath) Match {
+	switch path.Root() {
+	case filter.Repository:
+		return &RepoMatch{
+			Name: o.Repo.Name,
+			ID:   o.Repo.ID,
+		}
+	case filter.File:
+		if len(path) > 1 && path[1] == "owners" {
+			return o
+		}
+	}
+	return nil
+}
+
+// URL links to the repository, since the owners may be defined by any of the
+// CODEOWNERS files a repository can have.
+func (o *OwnerMatch) URL() *url.URL {
+	return (&RepoMatch{Name: o.Repo.Name, ID: o.Repo.ID, Rev: o.rev()}).URL()
+}
+
+func (o *OwnerMatch) rev() string {
+	if o.InputRev != nil {
+		return *o.InputRev
+	}
+	return ""
+}
+
+func (o *OwnerMatch) Key() Key {
+	return Key{
+		TypeRank: rankOwnerMatch,
+		Repo:     o.Repo.Name,
+		Rev:      o.rev(),
+		Value:    strings.Join(o.Owners, " "),
+	}
+}
+
+func (o *OwnerMatch) searchResultMarker() {}
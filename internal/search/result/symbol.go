@@ -19,6 +19,7 @@ type Symbol struct {
 	// merge Symbol and SymbolMatch.
 	Path       string
 	Line       int
+	EndLine    int // last line of the definition, 0 if the parser does not report it
 	Kind       string
 	Language   string
 	Parent     string
@@ -310,3 +311,44 @@ func SelectSymbolKind(symbols []*SymbolMatch, field string) []*SymbolMatch {
 		return field == toSelectKind[strings.ToLower(s.Symbol.Kind)]
 	})
 }
+
+// selectSymbolContainers returns the containers, e.g. classes or namespaces,
+// of symbols. Each container is returned once. A container which is itself
+// one of symbols is returned as is. Otherwise it is located at the first of
+// its members, since symbols do not record where their container is defined.
+func selectSymbolContainers(symbols []*SymbolMatch) []*SymbolMatch {
+	type container struct{ name, kind string }
+	defined := make(map[container]*SymbolMatch, len(symbols))
+	for _, s := range symbols {
+		defined[container{s.Symbol.Name, s.Symbol.Kind}] = s
+	}
+
+	seen := make(map[container]struct{})
+	var containers []*SymbolMatch
+	for _, s := range symbols {
+		c := container{s.Symbol.Parent, s.Symbol.ParentKind}
+		if c.name == "" {
+			continue
+		}
+		if _, ok := seen[c]; ok {
+			continue
+		}
+		seen[c] = struct{}{}
+
+		if d, ok := defined[c]; ok {
+			containers = append(containers, d)
+			continue
+		}
+		containers = append(containers, &SymbolMatch{
+			Symbol: Symbol{
+				Name:     c.name,
+				Kind:     c.kind,
+				Path:     s.Symbol.Path,
+				Line:     s.Symbol.Line,
+				Language: s.Symbol.Language,
+			},
+			File: s.File,
+		})
+	}
+	return containers
+}
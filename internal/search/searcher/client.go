@@ -71,6 +71,7 @@ func Search(
 			IsStructuralPat:              p.IsStructuralPat,
 			IsWordMatch:                  p.IsWordMatch,
 			IsCaseSensitive:              p.IsCaseSensitive,
+			IsMultiline:                  p.IsMultiline,
 			PathPatternsAreCaseSensitive: p.PathPatternsAreCaseSensitive,
 			IsNegated:                    p.IsNegated,
 			PatternMatchesContent:        p.PatternMatchesContent,
@@ -2,6 +2,7 @@ package search
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -85,6 +86,18 @@ type SymbolsParameters struct {
 	// need to match to get included in the result
 	ExcludePattern string
 
+	// Kinds, if non-empty, restricts the result to symbols of these kinds,
+	// e.g. "function". Kinds are matched case insensitively.
+	Kinds []string
+
+	// Languages, if non-empty, restricts the result to symbols in files of
+	// these languages, e.g. "Go". Languages are matched case insensitively.
+	Languages []string
+
+	// ContainerPattern is an optional regex that the name of the symbol's
+	// parent (e.g. its class) needs to match to get included in the result
+	ContainerPattern string
+
 	// First indicates that only the first n symbols should be returned.
 	First int
 }
@@ -143,6 +156,11 @@ type ZoektParameters struct {
 	FileMatchLimit int32
 	Select         filter.SelectPath
 
+	// Multiline is the regexp of a multiline search, see
+	// TextPatternInfo.IsMultiline. If set, zoekt returns whole file contents
+	// and the matches are found with Multiline.
+	Multiline *regexp.Regexp
+
 	Zoekt zoekt.Streamer
 }
 
@@ -220,8 +238,10 @@ type TextPatternInfo struct {
 	Index           query.YesNoOnly
 	Select          filter.SelectPath
 
-	// We do not support IsMultiline
-	// IsMultiline     bool
+	// IsMultiline matches the pattern against whole file contents, so that
+	// matches may span several lines.
+	IsMultiline bool
+
 	IncludePatterns []string
 	ExcludePattern  string
 
@@ -254,6 +274,9 @@ func (p *TextPatternInfo) String() string {
 	if p.IsCaseSensitive {
 		args = append(args, "case")
 	}
+	if p.IsMultiline {
+		args = append(args, "multiline")
+	}
 	if !p.PatternMatchesContent {
 		args = append(args, "nocontent")
 	}
@@ -160,6 +160,17 @@ func newToMatches(repo types.RepoName, commit api.CommitID, rev *string) func([]
 				})
 			}
 
+			var multilineMatches []*result.MultilineMatch
+			for _, mm := range fm.MultilineMatches {
+				multilineMatches = append(multilineMatches, &result.MultilineMatch{
+					Preview: mm.Preview,
+					Range: result.Range{
+						Start: result.Location(mm.Start),
+						End:   result.Location(mm.End),
+					},
+				})
+			}
+
 			matches = append(matches, &result.FileMatch{
 				File: result.File{
 					Path:     fm.Path,
@@ -167,8 +178,9 @@ func newToMatches(repo types.RepoName, commit api.CommitID, rev *string) func([]
 					CommitID: commit,
 					InputRev: rev,
 				},
-				LineMatches: lineMatches,
-				LimitHit:    fm.LimitHit,
+				LineMatches:      lineMatches,
+				MultilineMatches: multilineMatches,
+				LimitHit:         fm.LimitHit,
 			})
 		}
 		return matches
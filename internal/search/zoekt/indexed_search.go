@@ -226,11 +226,19 @@ func NewIndexedSearchRequest(ctx context.Context, args *search.TextParameters, t
 	if err != nil {
 		return nil, err
 	}
+	var multiline *regexp.Regexp
+	if args.PatternInfo.IsMultiline && typ == search.TextRequest {
+		multiline, err = multilineRegexp(args.PatternInfo)
+		if err != nil {
+			return nil, err
+		}
+	}
 	zoektArgs := &search.ZoektParameters{
 		Query:          q,
 		Typ:            typ,
 		FileMatchLimit: args.PatternInfo.FileMatchLimit,
 		Select:         args.PatternInfo.Select,
+		Multiline:      multiline,
 		Zoekt:          args.Zoekt,
 	}
 
@@ -345,7 +353,7 @@ func (s *IndexedSubsetSearchRequest) Search(ctx context.Context, c streaming.Sen
 		since = s.since
 	}
 
-	return zoektSearch(ctx, s.RepoRevs, s.Args.Query, s.Args.Typ, s.Args.Zoekt, s.Args.FileMatchLimit, s.Args.Select, since, c)
+	return zoektSearch(ctx, s.RepoRevs, s.Args.Query, s.Args.Typ, s.Args.Zoekt, s.Args.FileMatchLimit, s.Args.Select, s.Args.Multiline, since, c)
 }
 
 const maxUnindexedRepoRevSearchesPerQuery = 200
@@ -485,6 +493,9 @@ func doZoektSearchGlobal(ctx context.Context, args *search.ZoektParameters, c st
 
 	k := ResultCountFactor(0, args.FileMatchLimit, true)
 	searchOpts := SearchOpts(ctx, k, args.FileMatchLimit)
+	if args.Multiline != nil {
+		setMultiline(&searchOpts, args.FileMatchLimit)
+	}
 
 	if deadline, ok := ctx.Deadline(); ok {
 		// If the user manually specified a timeout, allow zoekt to use all of the remaining timeout.
@@ -533,12 +544,12 @@ func doZoektSearchGlobal(ctx context.Context, args *search.ZoektParameters, c st
 				Name: api.RepoName(file.Repository),
 			}
 			return repo, []string{""}
-		}, args.Typ, c)
+		}, args.Typ, args.Multiline, int(args.FileMatchLimit), c)
 	}))
 }
 
 // zoektSearch searches repositories using zoekt.
-func zoektSearch(ctx context.Context, repos *IndexedRepoRevs, q zoektquery.Q, typ search.IndexedRequestType, client zoekt.Streamer, fileMatchLimit int32, selector filter.SelectPath, since func(t time.Time) time.Duration, c streaming.Sender) error {
+func zoektSearch(ctx context.Context, repos *IndexedRepoRevs, q zoektquery.Q, typ search.IndexedRequestType, client zoekt.Streamer, fileMatchLimit int32, selector filter.SelectPath, multiline *regexp.Regexp, since func(t time.Time) time.Duration, c streaming.Sender) error {
 	if len(repos.repoRevs) == 0 {
 		return nil
 	}
@@ -555,6 +566,9 @@ func zoektSearch(ctx context.Context, repos *IndexedRepoRevs, q zoektquery.Q, ty
 
 	k := ResultCountFactor(len(repos.repoRevs), fileMatchLimit, false)
 	searchOpts := SearchOpts(ctx, k, fileMatchLimit)
+	if multiline != nil {
+		setMultiline(&searchOpts, fileMatchLimit)
+	}
 
 	// Start event stream.
 	t0 := time.Now()
@@ -591,7 +605,7 @@ func zoektSearch(ctx context.Context, repos *IndexedRepoRevs, q zoektquery.Q, ty
 	foundResults := atomic.Bool{}
 	err := client.StreamSearch(ctx, finalQuery, &searchOpts, backend.ZoektStreamFunc(func(event *zoekt.SearchResult) {
 		foundResults.CAS(false, event.FileCount != 0 || event.MatchCount != 0)
-		sendMatches(event, repos.getRepoInputRev, typ, c)
+		sendMatches(event, repos.getRepoInputRev, typ, multiline, int(fileMatchLimit), c)
 	}))
 	if err != nil {
 		return err
@@ -611,7 +625,9 @@ func zoektSearch(ctx context.Context, repos *IndexedRepoRevs, q zoektquery.Q, ty
 	return nil
 }
 
-func sendMatches(event *zoekt.SearchResult, getRepoInputRev repoRevFunc, typ search.IndexedRequestType, c streaming.Sender) {
+// sendMatches sends the file matches of event. At most matchLimit multiline
+// matches are reported per file.
+func sendMatches(event *zoekt.SearchResult, getRepoInputRev repoRevFunc, typ search.IndexedRequestType, multiline *regexp.Regexp, matchLimit int, c streaming.Sender) {
 	files := event.Files
 	limitHit := event.FilesSkipped+event.ShardsSkipped > 0
 
@@ -626,8 +642,16 @@ func sendMatches(event *zoekt.SearchResult, getRepoInputRev repoRevFunc, typ sea
 	for _, file := range files {
 		repo, inputRevs := getRepoInputRev(&file)
 
-		var lines []*result.LineMatch
-		if typ != search.SymbolRequest {
+		var (
+			lines            []*result.LineMatch
+			multilineMatches []*result.MultilineMatch
+		)
+		if multiline != nil {
+			var multilineLimitHit bool
+			multilineMatches, multilineLimitHit = zoektFileMatchToMultilineMatches(&file, multiline, matchLimit)
+			limitHit = limitHit || multilineLimitHit
+		}
+		if typ != search.SymbolRequest && len(multilineMatches) == 0 {
 			lines = zoektFileMatchToLineMatches(&file)
 		}
 
@@ -639,8 +663,9 @@ func sendMatches(event *zoekt.SearchResult, getRepoInputRev repoRevFunc, typ sea
 				symbols = zoektFileMatchToSymbolResults(repo, inputRev, &file)
 			}
 			fm := result.FileMatch{
-				LineMatches: lines,
-				Symbols:     symbols,
+				LineMatches:      lines,
+				MultilineMatches: multilineMatches,
+				Symbols:          symbols,
 				File: result.File{
 					InputRev: &inputRev,
 					CommitID: api.CommitID(file.Version),
@@ -718,6 +743,41 @@ func zoektFileMatchToLineMatches(file *zoekt.FileMatch) []*result.LineMatch {
 	return lines
 }
 
+// multilineRegexp returns the regexp matching the pattern of a multiline
+// search in file contents, like searcher does.
+func multilineRegexp(p *search.TextPatternInfo) (*regexp.Regexp, error) {
+	expr := "(?m:" + p.Pattern + ")"
+	if !p.IsCaseSensitive {
+		expr = "(?i)" + expr
+	}
+	return regexp.Compile(expr)
+}
+
+// setMultiline makes searchOpts return the content of matching files, which
+// multiline matches are found in. The content of every displayed file is sent
+// to us, so only as many files as the result limit are displayed, without the
+// extra files other searches display to populate RepoStatusLimitHit.
+func setMultiline(searchOpts *zoekt.SearchOptions, fileMatchLimit int32) {
+	searchOpts.Whole = true
+	searchOpts.MaxDocDisplayCount = int(fileMatchLimit)
+}
+
+// zoektFileMatchToMultilineMatches finds at most limit matches of re in the
+// content of file, like searcher does. limitHit is true if there are more.
+// zoekt splits matches at newlines, so we match the content returned for a
+// Whole search again to report each match as a single range.
+func zoektFileMatchToMultilineMatches(file *zoekt.FileMatch, re *regexp.Regexp, limit int) (matches []*result.MultilineMatch, limitHit bool) {
+	if len(file.Content) == 0 {
+		return nil, false
+	}
+	locs := re.FindAllIndex(file.Content, limit+1)
+	if len(locs) > limit {
+		locs = locs[:limit]
+		limitHit = true
+	}
+	return result.NewMultilineMatches(file.Content, locs), limitHit
+}
+
 func zoektFileMatchToSymbolResults(repoName types.RepoName, inputRev string, file *zoekt.FileMatch) []*result.SymbolMatch {
 	newFile := &result.File{
 		Path:     file.FileName,
@@ -661,6 +661,63 @@ func TestZoektFileMatchToSymbolResults(t *testing.T) {
 	}
 }
 
+func TestZoektFileMatchToMultilineMatches(t *testing.T) {
+	re, err := multilineRegexp(&search.TextPatternInfo{Pattern: `(?s)\{.*?RETURN`})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// zoekt splits the match at newlines, the content of a Whole search is
+	// matched again.
+	file := &zoekt.FileMatch{
+		FileName: "a.go",
+		Content:  []byte("package a\n\nfunc Hello() {\n\treturn\n}\n"),
+		LineMatches: []zoekt.LineMatch{{
+			Line:          []byte("func Hello() {"),
+			LineNumber:    3,
+			LineFragments: []zoekt.LineFragmentMatch{{LineOffset: 13, MatchLength: 1}},
+		}, {
+			Line:          []byte("\treturn"),
+			LineNumber:    4,
+			LineFragments: []zoekt.LineFragmentMatch{{LineOffset: 0, MatchLength: 7}},
+		}},
+	}
+
+	got, limitHit := zoektFileMatchToMultilineMatches(file, re, 10)
+	want := []*result.MultilineMatch{{
+		Preview: "func Hello() {\n\treturn",
+		Range: result.Range{
+			Start: result.Location{Offset: 13, Line: 2, Column: 13},
+			End:   result.Location{Offset: 22, Line: 3, Column: 7},
+		},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" || limitHit {
+		t.Fatalf("multiline match mismatch (limitHit %t) (-want +got):\n%s", limitHit, diff)
+	}
+
+	// At most limit matches are reported per file.
+	twice := &zoekt.FileMatch{FileName: "b.go", Content: append(append([]byte{}, file.Content...), file.Content...)}
+	got, limitHit = zoektFileMatchToMultilineMatches(twice, re, 1)
+	if diff := cmp.Diff(want, got); diff != "" || !limitHit {
+		t.Fatalf("limited multiline match mismatch (limitHit %t) (-want +got):\n%s", limitHit, diff)
+	}
+
+	// Files returned without content, such as path matches, have no
+	// multiline matches.
+	file.Content = nil
+	if got, _ := zoektFileMatchToMultilineMatches(file, re, 10); got != nil {
+		t.Fatalf("got %v, want no matches", got)
+	}
+}
+
+func TestSetMultiline(t *testing.T) {
+	searchOpts := SearchOpts(context.Background(), 1, 30)
+	setMultiline(&searchOpts, 30)
+	if !searchOpts.Whole || searchOpts.MaxDocDisplayCount != 30 {
+		t.Fatalf("got Whole %t and MaxDocDisplayCount %d, want true and 30", searchOpts.Whole, searchOpts.MaxDocDisplayCount)
+	}
+}
+
 func repoRevsSliceToMap(rs []*search.RepositoryRevisions) map[api.RepoID]*search.RepositoryRevisions {
 	m := map[api.RepoID]*search.RepositoryRevisions{}
 	for _, r := range rs {
@@ -43,11 +43,15 @@ const maxFileSize = 1 << 20 // 1MB; match https://sourcegraph.com/search?q=repo:
 // do not want to search.
 //
 // We use an LRU to do cache eviction:
-// * When to evict is based on the total size of *.zip on disk.
+// * When to evict is based on the total size of *.zip and their indexes on disk.
 // * What to evict uses the LRU algorithm.
 // * We touch files when opening them, so can do LRU based on file
 //   modification times.
 //
+// Next to each zip, the store builds a trigram index in the background (see
+// TrigramIndex), which counts towards the size of the zip and is evicted
+// together with it.
+//
 // Note: The store fetches tarballs but stores zips. We want to be able to
 // filter which files we cache, so we need a format that supports streaming
 // (tar). We want to be able to support random concurrent access for reading,
@@ -79,6 +83,12 @@ type Store struct {
 	// fetchLimiter limits concurrent calls to FetchTar.
 	fetchLimiter *mutablelimiter.Limiter
 
+	// indexing is the set of zip paths whose trigram index is being built.
+	indexing sync.Map
+
+	// indexLimiter limits the number of trigram indexes built concurrently.
+	indexLimiter chan struct{}
+
 	// ZipCache provides efficient access to repo zip files.
 	ZipCache ZipCache
 }
@@ -94,13 +104,18 @@ type FilterFunc func(hdr *tar.Header) bool
 func (s *Store) Start() {
 	s.once.Do(func() {
 		s.fetchLimiter = mutablelimiter.New(15)
+		s.indexLimiter = make(chan struct{}, 2)
 		s.cache = &diskcache.Store{
 			Dir:               s.Path,
 			Component:         "store",
 			BackgroundTimeout: 10 * time.Minute,
 			BeforeEvict:       s.ZipCache.delete,
+			AuxiliaryPaths: func(path string) []string {
+				return []string{trigramIndexPath(path)}
+			},
 		}
 		_ = os.MkdirAll(s.Path, 0700)
+		go sweepTrigramIndexes(s.Path)
 		metrics.MustRegisterDiskMonitor(s.Path)
 		go s.watchAndEvict()
 		go s.watchConfig()
@@ -160,6 +175,8 @@ func (s *Store) PrepareZip(ctx context.Context, repo api.RepoName, commit api.Co
 		}
 		if err != nil {
 			log15.Error("failed to fetch archive", "repo", repo, "commit", commit, "duration", time.Since(start), "error", err)
+		} else {
+			s.indexZip(path)
 		}
 		resC <- result{path, err}
 	}()
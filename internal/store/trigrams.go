@@ -0,0 +1,346 @@
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/casetransform"
+)
+
+// A TrigramIndex records which files of a zip archive contain each trigram
+// (sequence of three bytes) of the lowercased file contents. It lets searches
+// skip the files that cannot contain a literal substring of the pattern.
+//
+// The index is stored on disk next to the zip archive it indexes, see
+// trigramIndexPath. Its layout is:
+//
+//	magic      [4]byte
+//	numFiles   uint32
+//	count      uint32
+//	trigrams   [count]uint32 // sorted
+//	offsets    [count]uint32 // start of the postings of each trigram
+//	postings   []byte        // uvarint deltas of file indexes
+//
+// Files are identified by their index in ZipFile.Files.
+type TrigramIndex struct {
+	numFiles int
+	trigrams []uint32
+	offsets  []uint32
+	postings []byte
+}
+
+var trigramIndexMagic = [4]byte{'t', 'r', 'i', '1'}
+
+// maxCandidateTrigrams bounds the number of trigrams of a literal that are
+// looked up. Any subset of the trigrams gives a correct result.
+const maxCandidateTrigrams = 32
+
+// trigramIndexPath returns the path of the trigram index of the zip archive
+// at zipPath. The disk cache counts its size with the archive's and deletes it
+// together with the archive.
+func trigramIndexPath(zipPath string) string {
+	return zipPath + trigramIndexSuffix
+}
+
+const trigramIndexSuffix = ".trigrams"
+
+// Candidates returns the files that may contain lit, indexed like
+// ZipFile.Files. The comparison is case insensitive for ASCII letters. It
+// returns nil if every file may contain lit, because lit is shorter than a
+// trigram.
+func (ix *TrigramIndex) Candidates(lit []byte) []bool {
+	if len(lit) < 3 {
+		return nil
+	}
+
+	lower := make([]byte, len(lit))
+	casetransform.BytesToLowerASCII(lower, lit)
+
+	seen := map[uint32]bool{}
+	var trigrams []uint32
+	for i := 0; i+3 <= len(lower) && len(trigrams) < maxCandidateTrigrams; i++ {
+		t := trigramAt(lower, i)
+		if !seen[t] {
+			seen[t] = true
+			trigrams = append(trigrams, t)
+		}
+	}
+
+	// counts[i] is the number of trigrams of lit found in file i.
+	counts := make([]uint8, ix.numFiles)
+	for _, t := range trigrams {
+		postings, ok := ix.postingsFor(t)
+		if !ok {
+			// No file contains t.
+			return make([]bool, ix.numFiles)
+		}
+		file := uint64(0)
+		for len(postings) > 0 {
+			delta, n := binary.Uvarint(postings)
+			if n <= 0 {
+				// Corrupt postings. Fall back to searching all files.
+				return nil
+			}
+			postings = postings[n:]
+			file += delta
+			if file < uint64(len(counts)) {
+				counts[file]++
+			}
+		}
+	}
+	return counts2candidates(counts, len(trigrams))
+}
+
+// counts2candidates returns which files contain all n trigrams.
+func counts2candidates(counts []uint8, n int) []bool {
+	candidates := make([]bool, len(counts))
+	for i, c := range counts {
+		candidates[i] = int(c) == n
+	}
+	return candidates
+}
+
+// postingsFor returns the encoded postings of trigram t.
+func (ix *TrigramIndex) postingsFor(t uint32) ([]byte, bool) {
+	i := sort.Search(len(ix.trigrams), func(i int) bool { return ix.trigrams[i] >= t })
+	if i == len(ix.trigrams) || ix.trigrams[i] != t {
+		return nil, false
+	}
+	end := uint32(len(ix.postings))
+	if i+1 < len(ix.offsets) {
+		end = ix.offsets[i+1]
+	}
+	return ix.postings[ix.offsets[i]:end], true
+}
+
+func trigramAt(b []byte, i int) uint32 {
+	return uint32(b[i])<<16 | uint32(b[i+1])<<8 | uint32(b[i+2])
+}
+
+// newTrigramIndex indexes the trigrams of the files in zf.
+func newTrigramIndex(zf *ZipFile) *TrigramIndex {
+	type posting struct {
+		last uint32
+		buf  []byte
+	}
+	postings := map[uint32]*posting{}
+
+	// seen is a bitset of the trigrams already found in the current file, and
+	// found is the list of those trigrams, used to reset seen.
+	seen := make([]uint64, 1<<24/64)
+	var found []uint32
+
+	lower := make([]byte, zf.MaxLen)
+	var varint [binary.MaxVarintLen32]byte
+	for i := range zf.Files {
+		data := lower[:zf.Files[i].Len]
+		casetransform.BytesToLowerASCII(data, zf.DataFor(&zf.Files[i]))
+
+		for j := 0; j+3 <= len(data); j++ {
+			t := trigramAt(data, j)
+			if seen[t/64]&(1<<(t%64)) != 0 {
+				continue
+			}
+			seen[t/64] |= 1 << (t % 64)
+			found = append(found, t)
+
+			p, ok := postings[t]
+			if !ok {
+				p = &posting{}
+				postings[t] = p
+			}
+			n := binary.PutUvarint(varint[:], uint64(uint32(i)-p.last))
+			p.buf = append(p.buf, varint[:n]...)
+			p.last = uint32(i)
+		}
+
+		for _, t := range found {
+			seen[t/64] = 0
+		}
+		found = found[:0]
+	}
+
+	ix := &TrigramIndex{
+		numFiles: len(zf.Files),
+		trigrams: make([]uint32, 0, len(postings)),
+		offsets:  make([]uint32, 0, len(postings)),
+	}
+	for t := range postings {
+		ix.trigrams = append(ix.trigrams, t)
+	}
+	sort.Slice(ix.trigrams, func(i, j int) bool { return ix.trigrams[i] < ix.trigrams[j] })
+	for _, t := range ix.trigrams {
+		ix.offsets = append(ix.offsets, uint32(len(ix.postings)))
+		ix.postings = append(ix.postings, postings[t].buf...)
+	}
+	return ix
+}
+
+// write atomically writes ix to path, the index of the zip archive at
+// zipPath. Nothing is written if the archive is evicted in the meantime.
+func (ix *TrigramIndex) write(zipPath, path string) (err error) {
+	var buf bytes.Buffer
+	buf.Write(trigramIndexMagic[:])
+	for _, v := range []interface{}{uint32(ix.numFiles), uint32(len(ix.trigrams)), ix.trigrams, ix.offsets} {
+		_ = binary.Write(&buf, binary.LittleEndian, v)
+	}
+	buf.Write(ix.postings)
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}()
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(zipPath); err != nil {
+		return err
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return err
+	}
+
+	// The archive may have been evicted between the check above and the
+	// rename, after the eviction tried to delete the index. Eviction deletes
+	// the archive first, so checking again after the rename catches it.
+	if _, err := os.Stat(zipPath); err != nil {
+		os.Remove(path)
+		return err
+	}
+	return nil
+}
+
+// readTrigramIndex reads the trigram index written to path.
+func readTrigramIndex(path string) (*TrigramIndex, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < 12 || !bytes.Equal(data[:4], trigramIndexMagic[:]) {
+		return nil, errors.Errorf("%s is not a trigram index", path)
+	}
+	numFiles := binary.LittleEndian.Uint32(data[4:])
+	count := int(binary.LittleEndian.Uint32(data[8:]))
+	data = data[12:]
+	if len(data) < 8*count {
+		return nil, errors.Errorf("trigram index %s is truncated", path)
+	}
+
+	ix := &TrigramIndex{
+		numFiles: int(numFiles),
+		trigrams: make([]uint32, count),
+		offsets:  make([]uint32, count),
+	}
+	for i := 0; i < count; i++ {
+		ix.trigrams[i] = binary.LittleEndian.Uint32(data[4*i:])
+		ix.offsets[i] = binary.LittleEndian.Uint32(data[4*(count+i):])
+	}
+	ix.postings = data[8*count:]
+	for i, off := range ix.offsets {
+		if int(off) > len(ix.postings) || (i > 0 && off < ix.offsets[i-1]) {
+			return nil, errors.Errorf("trigram index %s is corrupt", path)
+		}
+	}
+	return ix, nil
+}
+
+// indexZip builds the trigram index of the zip archive at zipPath in the
+// background, unless it already exists or is being built.
+func (s *Store) indexZip(zipPath string) {
+	indexPath := trigramIndexPath(zipPath)
+	if _, err := os.Stat(indexPath); err == nil {
+		return
+	}
+	if _, building := s.indexing.LoadOrStore(zipPath, struct{}{}); building {
+		return
+	}
+
+	go func() {
+		defer s.indexing.Delete(zipPath)
+
+		s.indexLimiter <- struct{}{}
+		defer func() { <-s.indexLimiter }()
+
+		if err := buildTrigramIndex(zipPath, indexPath); err != nil {
+			if os.IsNotExist(err) {
+				// The archive was evicted while we indexed it.
+				return
+			}
+			log15.Warn("failed to build trigram index", "path", zipPath, "error", err)
+			trigramIndexBuildFailed.Inc()
+			return
+		}
+		trigramIndexBuilds.Inc()
+	}()
+}
+
+// buildTrigramIndex writes the trigram index of the zip archive at zipPath to
+// indexPath. It reads the archive itself rather than through the ZipCache, so
+// that indexing an archive does not keep it in memory.
+func buildTrigramIndex(zipPath, indexPath string) error {
+	zf, err := readZipFile(zipPath)
+	if err != nil {
+		return err
+	}
+	defer zf.release()
+
+	return newTrigramIndex(zf).write(zipPath, indexPath)
+}
+
+// sweepTrigramIndexes deletes the temporary files of trigram indexes left
+// behind in dir, e.g. by a crash, and the indexes whose archive is gone.
+func sweepTrigramIndexes(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		log15.Warn("failed to sweep trigram indexes", "dir", dir, "error", err)
+		return
+	}
+
+	for _, e := range entries {
+		name := e.Name()
+		path := filepath.Join(dir, name)
+		switch {
+		case strings.Contains(name, trigramIndexSuffix+".") && strings.HasSuffix(name, ".tmp"):
+		case strings.HasSuffix(name, trigramIndexSuffix):
+			if _, err := os.Stat(strings.TrimSuffix(path, trigramIndexSuffix)); !os.IsNotExist(err) {
+				continue
+			}
+		default:
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			log15.Warn("failed to remove stale trigram index", "path", path, "error", err)
+		}
+	}
+}
+
+var (
+	trigramIndexBuilds = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "searcher_store_trigram_index_builds",
+		Help: "The total number of trigram indexes built for archives.",
+	})
+	trigramIndexBuildFailed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "searcher_store_trigram_index_build_failed",
+		Help: "The total number of trigram indexes that failed to build.",
+	})
+)
@@ -0,0 +1,163 @@
+package store
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestTrigramIndex(t *testing.T) {
+	s, cleanup := tmpStore(t)
+	defer cleanup()
+
+	files := map[string]string{
+		"a.go":     "package a\n\nfunc Hello() {}\n",
+		"b.go":     "package b\n\n// hello, world\n",
+		"c.txt":    "unrelated\n",
+		"empty.go": "",
+	}
+	s.FetchTar = func(ctx context.Context, repo api.RepoName, commit api.CommitID) (io.ReadCloser, error) {
+		return tarOf(t, files), nil
+	}
+
+	path, err := s.PrepareZip(context.Background(), "foo", "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef")
+	if err != nil {
+		t.Fatal(err)
+	}
+	zf, err := s.ZipCache.Get(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zf.Close()
+
+	// The index is built in the background.
+	var ix *TrigramIndex
+	for i := 0; i < 500 && ix == nil; i++ {
+		time.Sleep(10 * time.Millisecond)
+		ix = zf.TrigramIndex()
+	}
+	if ix == nil {
+		t.Fatal("timed out waiting for the trigram index")
+	}
+
+	candidates := func(lit string) []string {
+		c := ix.Candidates([]byte(lit))
+		if c == nil {
+			return nil
+		}
+		names := []string{}
+		for i, ok := range c {
+			if ok {
+				names = append(names, zf.Files[i].Name)
+			}
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	for _, test := range []struct {
+		lit  string
+		want []string
+	}{
+		{lit: "hello", want: []string{"a.go", "b.go"}},
+		{lit: "HELLO", want: []string{"a.go", "b.go"}},
+		{lit: "func Hello", want: []string{"a.go"}},
+		{lit: "package", want: []string{"a.go", "b.go"}},
+		{lit: "related", want: []string{"c.txt"}},
+		{lit: "missing", want: []string{}},
+		// Literals shorter than a trigram cannot be filtered.
+		{lit: "he", want: nil},
+	} {
+		if diff := cmp.Diff(test.want, candidates(test.lit)); diff != "" {
+			t.Errorf("unexpected candidates for %q (-want +got):\n%s", test.lit, diff)
+		}
+	}
+}
+
+func TestTrigramIndexEvict(t *testing.T) {
+	s, cleanup := tmpStore(t)
+	defer cleanup()
+
+	s.FetchTar = func(ctx context.Context, repo api.RepoName, commit api.CommitID) (io.ReadCloser, error) {
+		return tarOf(t, map[string]string{"a.go": "package a\n"}), nil
+	}
+
+	path, err := s.PrepareZip(context.Background(), "foo", "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	indexPath := trigramIndexPath(path)
+	for i := 0; i < 500; i++ {
+		if _, err := os.Stat(indexPath); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, err := os.Stat(indexPath); err != nil {
+		t.Fatal("timed out waiting for the trigram index:", err)
+	}
+
+	if _, err := s.cache.Evict(0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(indexPath); !os.IsNotExist(err) {
+		t.Errorf("expected the trigram index to be evicted, got %v", err)
+	}
+}
+
+func TestSweepTrigramIndexes(t *testing.T) {
+	dir := t.TempDir()
+
+	write := func(name string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	zip := write("a.zip")
+	index := write("a.zip.trigrams")
+	orphan := write("b.zip.trigrams")
+	tmp := write("a.zip.trigrams.123.tmp")
+
+	sweepTrigramIndexes(dir)
+
+	for _, path := range []string{zip, index} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("expected %s to be kept, got %v", path, err)
+		}
+	}
+	for _, path := range []string{orphan, tmp} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed, got %v", path, err)
+		}
+	}
+}
+
+func tarOf(t *testing.T, files map[string]string) io.ReadCloser {
+	buf := new(bytes.Buffer)
+	w := tar.NewWriter(buf)
+	for name, body := range files {
+		if err := w.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(body))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return io.NopCloser(bytes.NewReader(buf.Bytes()))
+}
@@ -77,18 +77,7 @@ func (c *ZipCache) delete(path string) {
 	}
 	// Wait for all clients using this zipFile to complete their work.
 	zf.wg.Wait()
-	// Mock zipFiles have nil f. Only try to munmap and close f if it is non-nil.
-	if zf.f != nil {
-		// For now, only log errors here.
-		// These calls shouldn't ever fail, and if they do,
-		// there's not much to do about it; best to just limp along.
-		if err := unix.Munmap(zf.Data); err != nil {
-			log.Printf("failed to munmap %q: %v", zf.f.Name(), err)
-		}
-		if err := zf.f.Close(); err != nil {
-			log.Printf("failed to close %q: %v", zf.f.Name(), err)
-		}
-	}
+	zf.release()
 	delete(shard.m, path)
 }
 
@@ -101,6 +90,9 @@ type ZipFile struct {
 	Data   []byte
 	f      *os.File
 	wg     sync.WaitGroup // ensures underlying file is not munmap'd or closed while in use
+
+	trigramsMu sync.Mutex
+	trigrams   *TrigramIndex // loaded lazily by TrigramIndex
 }
 
 func readZipFile(path string) (*ZipFile, error) {
@@ -165,6 +157,41 @@ func (f *ZipFile) PopulateFiles(r *zip.Reader) error {
 	return nil
 }
 
+// release unmaps and closes the underlying file of f.
+func (f *ZipFile) release() {
+	// Mock zipFiles have nil f. Only try to munmap and close f if it is non-nil.
+	if f.f == nil {
+		return
+	}
+	// For now, only log errors here.
+	// These calls shouldn't ever fail, and if they do,
+	// there's not much to do about it; best to just limp along.
+	if err := unix.Munmap(f.Data); err != nil {
+		log.Printf("failed to munmap %q: %v", f.f.Name(), err)
+	}
+	if err := f.f.Close(); err != nil {
+		log.Printf("failed to close %q: %v", f.f.Name(), err)
+	}
+}
+
+// TrigramIndex returns the trigram index of f, or nil if it has not been
+// built yet. Searches must then consider all files.
+func (f *ZipFile) TrigramIndex() *TrigramIndex {
+	if f.f == nil {
+		return nil
+	}
+
+	f.trigramsMu.Lock()
+	defer f.trigramsMu.Unlock()
+	if f.trigrams == nil {
+		ix, err := readTrigramIndex(trigramIndexPath(f.f.Name()))
+		if err == nil && ix.numFiles == len(f.Files) {
+			f.trigrams = ix
+		}
+	}
+	return f.trigrams
+}
+
 // Close allows resources associated with f to be released.
 // It MUST be called exactly once for every file retrieved using get.
 // Contents from any SrcFile from within f MUST NOT be used after
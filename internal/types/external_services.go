@@ -4,12 +4,24 @@ import (
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
+type BitbucketCloudConnection struct {
+	// The unique resource identifier of the external service.
+	URN string
+	*schema.BitbucketCloudConnection
+}
+
 type BitbucketServerConnection struct {
 	// The unique resource identifier of the external service.
 	URN string
 	*schema.BitbucketServerConnection
 }
 
+type GerritConnection struct {
+	// The unique resource identifier of the external service.
+	URN string
+	*schema.GerritConnection
+}
+
 type GitHubConnection struct {
 	// The unique resource identifier of the external service.
 	URN string
@@ -22,6 +34,18 @@ type GitLabConnection struct {
 	*schema.GitLabConnection
 }
 
+type GiteaConnection struct {
+	// The unique resource identifier of the external service.
+	URN string
+	*schema.GiteaConnection
+}
+
+type GitoliteConnection struct {
+	// The unique resource identifier of the external service.
+	URN string
+	*schema.GitoliteConnection
+}
+
 type PerforceConnection struct {
 	// The unique resource identifier of the external service.
 	URN string
@@ -53,6 +53,10 @@ func (e *ExternalService) RedactConfigSecrets() (string, error) {
 		newCfg, err = redactField(e.Config, fields...)
 	case *schema.BitbucketCloudConnection:
 		newCfg, err = redactField(e.Config, []string{"appPassword"})
+	case *schema.GiteaConnection:
+		newCfg, err = redactField(e.Config, []string{"token"})
+	case *schema.GerritConnection:
+		newCfg, err = redactField(e.Config, []string{"password"})
 	case *schema.AWSCodeCommitConnection:
 		newCfg, err = redactField(e.Config, []string{"secretAccessKey"}, []string{"gitCredentials", "password"})
 	case *schema.PhabricatorConnection:
@@ -66,6 +70,10 @@ func (e *ExternalService) RedactConfigSecrets() (string, error) {
 		newCfg, err = redactField(e.Config, []string{"url"})
 	case *schema.JVMPackagesConnection:
 		newCfg, err = e.Config, nil
+	case *schema.NpmPackagesConnection:
+		newCfg, err = redactField(e.Config, []string{"credentials"})
+	case *schema.GoModulesConnection:
+		newCfg, err = e.Config, nil
 	default:
 		// return an error here, it's safer to fail than to incorrectly return unsafe data.
 		err = errors.Errorf("RedactExternalServiceConfig: kind %q not implemented", e.Kind)
@@ -129,6 +137,10 @@ func (e *ExternalService) UnredactConfig(old *ExternalService) error {
 		unredacted, err = unredactField(old.Config, e.Config, &cfg, fields...)
 	case *schema.BitbucketCloudConnection:
 		unredacted, err = unredactField(old.Config, e.Config, &cfg, jsonStringField{[]string{"appPassword"}, &cfg.AppPassword})
+	case *schema.GiteaConnection:
+		unredacted, err = unredactField(old.Config, e.Config, &cfg, jsonStringField{[]string{"token"}, &cfg.Token})
+	case *schema.GerritConnection:
+		unredacted, err = unredactField(old.Config, e.Config, &cfg, jsonStringField{[]string{"password"}, &cfg.Password})
 	case *schema.AWSCodeCommitConnection:
 		unredacted, err = unredactField(old.Config,
 			e.Config,
@@ -147,6 +159,10 @@ func (e *ExternalService) UnredactConfig(old *ExternalService) error {
 		unredacted, err = unredactField(old.Config, e.Config, &cfg, jsonStringField{[]string{"url"}, &cfg.Url})
 	case *schema.JVMPackagesConnection:
 		unredacted, err = e.Config, nil
+	case *schema.NpmPackagesConnection:
+		unredacted, err = unredactField(old.Config, e.Config, &cfg, jsonStringField{[]string{"credentials"}, &cfg.Credentials})
+	case *schema.GoModulesConnection:
+		unredacted, err = e.Config, nil
 	default:
 		// return an error here, it's safer to fail than to incorrectly return unsafe data.
 		err = errors.Errorf("UnRedactExternalServiceConfig: kind %q not implemented", e.Kind)
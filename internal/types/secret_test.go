@@ -47,10 +47,23 @@ func TestRoundTripRedactExternalServiceConfig(t *testing.T) {
 	gitoliteConfig := schema.GitoliteConnection{
 		Host: "https://gitolite.ninja",
 	}
+	giteaConfig := schema.GiteaConnection{
+		Token: someSecret,
+		Url:   "https://gitea.com",
+	}
+	gerritConfig := schema.GerritConnection{
+		Password: someSecret,
+		Url:      "https://gerrit.com",
+		Username: "admin",
+	}
 	perforceConfig := schema.PerforceConnection{
 		P4Passwd: someSecret,
 		P4User:   "admin",
 	}
+	npmPackagesConfig := schema.NpmPackagesConnection{
+		Credentials: someSecret,
+		Registry:    "https://registry.npmjs.org",
+	}
 	otherConfig := schema.OtherExternalServiceConnection{
 		Url:                   someSecret,
 		RepositoryPathPattern: "foo",
@@ -115,12 +128,30 @@ func TestRoundTripRedactExternalServiceConfig(t *testing.T) {
 			config:    &gitoliteConfig,
 			editField: &gitoliteConfig.Host,
 		},
+		{
+			kind:        extsvc.KindGitea,
+			config:      &giteaConfig,
+			editField:   &giteaConfig.Url,
+			secretField: &giteaConfig.Token,
+		},
+		{
+			kind:        extsvc.KindGerrit,
+			config:      &gerritConfig,
+			editField:   &gerritConfig.Url,
+			secretField: &gerritConfig.Password,
+		},
 		{
 			kind:        extsvc.KindPerforce,
 			config:      &perforceConfig,
 			editField:   &perforceConfig.P4User,
 			secretField: &perforceConfig.P4Passwd,
 		},
+		{
+			kind:        extsvc.KindNpmPackages,
+			config:      &npmPackagesConfig,
+			editField:   &npmPackagesConfig.Registry,
+			secretField: &npmPackagesConfig.Credentials,
+		},
 		{
 			kind:        extsvc.KindOther,
 			config:      &otherConfig,
@@ -1,7 +1,9 @@
 package git
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"strings"
 
@@ -9,6 +11,8 @@ import (
 	"github.com/sourcegraph/go-diff/diff"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
 )
 
 type DiffOptions struct {
@@ -73,3 +77,64 @@ func (i *DiffFileIterator) Close() error {
 func (i *DiffFileIterator) Next() (*diff.FileDiff, error) {
 	return i.mfdr.ReadFile()
 }
+
+// PathChanges are the paths that differ between the trees of two commits.
+type PathChanges struct {
+	Added    []string
+	Modified []string
+	Deleted  []string
+}
+
+// DiffPaths returns the paths that were added, modified or deleted between
+// commits a and b. Renames are reported as a deletion of the old path and an
+// addition of the new path.
+func DiffPaths(ctx context.Context, repo api.RepoName, a, b api.CommitID) (*PathChanges, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "Git: DiffPaths")
+	span.SetTag("A", a)
+	span.SetTag("B", b)
+	defer span.Finish()
+
+	for _, commit := range []api.CommitID{a, b} {
+		if err := checkSpecArgSafety(string(commit)); err != nil {
+			return nil, err
+		}
+	}
+
+	cmd := gitserver.DefaultClient.Command("git", "diff", "-z", "--name-status", "--no-renames", string(a), string(b), "--")
+	cmd.Repo = repo
+	out, err := cmd.Output(ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("git command %v failed (output: %q)", cmd.Args, out))
+	}
+	return parseDiffNameStatus(out)
+}
+
+// parseDiffNameStatus parses the output of `git diff -z --name-status
+// --no-renames`, which is a sequence of NUL terminated status and path pairs.
+func parseDiffNameStatus(out []byte) (*PathChanges, error) {
+	var changes PathChanges
+
+	fields := bytes.Split(bytes.TrimSuffix(out, []byte{0}), []byte{0})
+	if len(fields) == 1 && len(fields[0]) == 0 {
+		return &changes, nil
+	}
+	if len(fields)%2 != 0 {
+		return nil, errors.Errorf("unexpected git diff output: %q", out)
+	}
+
+	for i := 0; i < len(fields); i += 2 {
+		status, path := string(fields[i]), string(fields[i+1])
+		switch status {
+		case "A":
+			changes.Added = append(changes.Added, path)
+		case "M", "T":
+			changes.Modified = append(changes.Modified, path)
+		case "D":
+			changes.Deleted = append(changes.Deleted, path)
+		default:
+			return nil, errors.Errorf("unexpected git diff status %q for path %q", status, path)
+		}
+	}
+
+	return &changes, nil
+}
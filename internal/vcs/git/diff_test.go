@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/cockroachdb/errors"
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestDiff(t *testing.T) {
@@ -176,6 +177,60 @@ func TestDiffFileIterator(t *testing.T) {
 	})
 }
 
+func TestDiffPaths(t *testing.T) {
+	ctx := context.Background()
+
+	repo := MakeGitRepository(t,
+		"echo a > a",
+		"echo b > b",
+		"echo c > c",
+		"git add a b c",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m foo --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"git tag base",
+		"echo b2 > b",
+		"git rm c",
+		"git mv a 'd e'",
+		"echo f > f",
+		"git add b f",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m bar --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+	)
+
+	base, err := ResolveRevision(ctx, repo, "base", ResolveRevisionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	head, err := ResolveRevision(ctx, repo, "HEAD", ResolveRevisionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := DiffPaths(ctx, repo, base, head)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &PathChanges{
+		Added:    []string{"d e", "f"},
+		Modified: []string{"b"},
+		Deleted:  []string{"a", "c"},
+	}
+	if diff := cmp.Diff(want, changes); diff != "" {
+		t.Errorf("unexpected changes (-want +got):\n%s", diff)
+	}
+
+	changes, err = DiffPaths(ctx, repo, head, head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&PathChanges{}, changes); diff != "" {
+		t.Errorf("unexpected changes (-want +got):\n%s", diff)
+	}
+
+	if _, err := DiffPaths(ctx, repo, "-foo", head); err == nil {
+		t.Error("expected error for unsafe commit argument")
+	}
+}
+
 type closer bool
 
 func (c *closer) Read(p []byte) (int, error) {
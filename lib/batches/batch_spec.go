@@ -46,6 +46,26 @@ type ChangesetTemplate struct {
 	Branch    string                       `json:"branch,omitempty" yaml:"branch"`
 	Commit    ExpandedGitCommitDescription `json:"commit,omitempty" yaml:"commit"`
 	Published *overridable.BoolOrString    `json:"published" yaml:"published"`
+	AutoMerge *AutoMerge                   `json:"autoMerge,omitempty" yaml:"autoMerge,omitempty"`
+	Rollout   *Rollout                     `json:"rollout,omitempty" yaml:"rollout,omitempty"`
+}
+
+// AutoMergeStrategy is the merge strategy used when auto-merging changesets.
+type AutoMergeStrategy string
+
+const (
+	AutoMergeStrategyMerge  AutoMergeStrategy = "merge"
+	AutoMergeStrategySquash AutoMergeStrategy = "squash"
+)
+
+type AutoMerge struct {
+	Strategy         AutoMergeStrategy `json:"strategy,omitempty" yaml:"strategy"`
+	MaxMergesPerHour int               `json:"maxMergesPerHour,omitempty" yaml:"maxMergesPerHour"`
+}
+
+// Squash returns whether the changesets should be squash merged.
+func (a *AutoMerge) Squash() bool {
+	return a.Strategy == AutoMergeStrategySquash
 }
 
 type GitCommitAuthor struct {
@@ -170,6 +190,12 @@ func parseBatchSpec(schema string, data []byte, opts ParseBatchSpecOptions) (*Ba
 		errs = multierror.Append(errs, NewValidationError(errors.New("batch spec includes workspaces, which is not supported in this Sourcegraph version")))
 	}
 
+	if spec.ChangesetTemplate != nil && spec.ChangesetTemplate.Rollout != nil {
+		if err := spec.ChangesetTemplate.Rollout.validate(); err != nil {
+			errs = multierror.Append(errs, NewValidationError(err))
+		}
+	}
+
 	if !opts.AllowConditionalExec {
 		for i, step := range spec.Steps {
 			if step.IfCondition() != "" {
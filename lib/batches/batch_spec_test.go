@@ -3,6 +3,8 @@ package batches
 import (
 	"fmt"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestParseBatchSpec(t *testing.T) {
@@ -30,6 +32,117 @@ changesetTemplate:
 		}
 	})
 
+	t.Run("autoMerge", func(t *testing.T) {
+		const spec = `
+name: hello-world
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  autoMerge:
+    strategy: squash
+    maxMergesPerHour: 10
+`
+
+		have, err := ParseBatchSpec([]byte(spec), ParseBatchSpecOptions{})
+		if err != nil {
+			t.Fatalf("parsing valid spec returned error: %s", err)
+		}
+
+		autoMerge := have.ChangesetTemplate.AutoMerge
+		if autoMerge == nil || !autoMerge.Squash() || autoMerge.MaxMergesPerHour != 10 {
+			t.Fatalf("wrong autoMerge policy: %+v", autoMerge)
+		}
+	})
+
+	t.Run("invalid autoMerge strategy", func(t *testing.T) {
+		const spec = `
+name: hello-world
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  autoMerge:
+    strategy: rebase
+`
+
+		if _, err := ParseBatchSpec([]byte(spec), ParseBatchSpecOptions{}); err == nil {
+			t.Fatal("no error returned")
+		}
+	})
+
+	t.Run("rollout", func(t *testing.T) {
+		const spec = `
+name: hello-world
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  rollout:
+    stages:
+      - repositories: [github.com/sourcegraph/core-lib]
+      - percentage: 10
+        mergedPercentage: 50
+`
+
+		have, err := ParseBatchSpec([]byte(spec), ParseBatchSpecOptions{})
+		if err != nil {
+			t.Fatalf("parsing valid spec returned error: %s", err)
+		}
+
+		want := &Rollout{Stages: []RolloutStage{
+			{Repositories: []string{"github.com/sourcegraph/core-lib"}},
+			{Percentage: 10, MergedPercentage: 50},
+		}}
+		if diff := cmp.Diff(want, have.ChangesetTemplate.Rollout); diff != "" {
+			t.Fatalf("wrong rollout (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("invalid rollout stages", func(t *testing.T) {
+		for name, stages := range map[string]string{
+			"repositories and percentage": "[{repositories: [a], percentage: 10}]",
+			"empty stage":                 "[{mergedPercentage: 10}]",
+			"invalid pattern":             "[{repositories: ['github.com/[a']}]",
+		} {
+			t.Run(name, func(t *testing.T) {
+				spec := `
+name: hello-world
+on:
+  - repositoriesMatchingQuery: file:README.md
+changesetTemplate:
+  title: Hello World
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  rollout:
+    stages: ` + stages + "\n"
+
+				if _, err := ParseBatchSpec([]byte(spec), ParseBatchSpecOptions{}); err == nil {
+					t.Fatal("no error returned")
+				}
+			})
+		}
+	})
+
 	t.Run("missing changesetTemplate", func(t *testing.T) {
 		const spec = `
 name: hello-world
@@ -0,0 +1,158 @@
+package batches
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gobwas/glob"
+)
+
+// Rollout describes the order in which the changesets of a batch change are
+// published. Changesets in a stage are only published once enough changesets
+// of all earlier stages have been merged.
+type Rollout struct {
+	Stages []RolloutStage `json:"stages,omitempty" yaml:"stages"`
+}
+
+// RolloutStage is a single stage of a Rollout. A stage either contains the
+// changesets in the repositories matching one of the given patterns, or the
+// given percentage of all changesets.
+type RolloutStage struct {
+	Repositories     []string `json:"repositories,omitempty" yaml:"repositories"`
+	Percentage       int      `json:"percentage,omitempty" yaml:"percentage"`
+	MergedPercentage int      `json:"mergedPercentage,omitempty" yaml:"mergedPercentage"`
+}
+
+// MergedThreshold returns the percentage of the stage's changesets that need
+// to be merged before the next stage is published.
+func (s *RolloutStage) MergedThreshold() int {
+	if s.MergedPercentage == 0 {
+		return 100
+	}
+	return s.MergedPercentage
+}
+
+// RolloutTarget identifies a changeset by the repository and branch it is
+// created in.
+type RolloutTarget struct {
+	Repository string
+	Branch     string
+}
+
+// FinalStage returns the stage of the changesets that aren't part of any of
+// the configured stages. They are published last.
+func (r *Rollout) FinalStage() int {
+	return len(r.Stages)
+}
+
+// AssignStages returns the rollout stage of each of the given targets, in the
+// same order as the targets.
+//
+// Stages with repository patterns are assigned first, with the earliest
+// matching stage winning. The remaining targets are then sorted by repository
+// and branch, and handed out to the percentage based stages in order. Targets
+// that aren't part of any stage end up in the final stage.
+func (r *Rollout) AssignStages(targets []RolloutTarget) ([]int, error) {
+	stages := make([]int, len(targets))
+	assigned := make([]bool, len(targets))
+
+	for i, stage := range r.Stages {
+		if len(stage.Repositories) == 0 {
+			continue
+		}
+
+		patterns, err := compileRolloutPatterns(stage.Repositories)
+		if err != nil {
+			return nil, errors.Wrapf(err, "rollout stage %d", i+1)
+		}
+
+		for j, target := range targets {
+			if !assigned[j] && patterns.match(target) {
+				stages[j] = i
+				assigned[j] = true
+			}
+		}
+	}
+
+	var remaining []int
+	for j := range targets {
+		if !assigned[j] {
+			remaining = append(remaining, j)
+		}
+	}
+	sort.SliceStable(remaining, func(a, b int) bool {
+		ta, tb := targets[remaining[a]], targets[remaining[b]]
+		if ta.Repository != tb.Repository {
+			return ta.Repository < tb.Repository
+		}
+		return ta.Branch < tb.Branch
+	})
+
+	for i, stage := range r.Stages {
+		if stage.Percentage == 0 {
+			continue
+		}
+
+		n := int(math.Ceil(float64(len(targets)*stage.Percentage) / 100))
+		if n > len(remaining) {
+			n = len(remaining)
+		}
+		for _, j := range remaining[:n] {
+			stages[j] = i
+		}
+		remaining = remaining[n:]
+	}
+
+	for _, j := range remaining {
+		stages[j] = r.FinalStage()
+	}
+
+	return stages, nil
+}
+
+func (r *Rollout) validate() error {
+	for i, stage := range r.Stages {
+		if _, err := compileRolloutPatterns(stage.Repositories); err != nil {
+			return errors.Wrapf(err, "rollout stage %d", i+1)
+		}
+	}
+	return nil
+}
+
+type rolloutPattern struct {
+	repo   glob.Glob
+	branch string
+}
+
+type rolloutPatterns []rolloutPattern
+
+// compileRolloutPatterns compiles the given repository patterns, which can
+// optionally be restricted to a branch with an "@branch" suffix.
+func compileRolloutPatterns(patterns []string) (rolloutPatterns, error) {
+	compiled := make(rolloutPatterns, 0, len(patterns))
+	for _, p := range patterns {
+		var branch string
+		if split := strings.SplitN(p, "@", 2); len(split) > 1 {
+			p, branch = split[0], split[1]
+		}
+
+		g, err := glob.Compile(p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "compiling repository pattern %q", p)
+		}
+		compiled = append(compiled, rolloutPattern{repo: g, branch: branch})
+	}
+	return compiled, nil
+}
+
+func (ps rolloutPatterns) match(target RolloutTarget) bool {
+	branch := strings.TrimPrefix(target.Branch, "refs/heads/")
+	for _, p := range ps {
+		if p.repo.Match(target.Repository) && (p.branch == "" || p.branch == branch) {
+			return true
+		}
+	}
+	return false
+}
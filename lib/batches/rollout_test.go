@@ -0,0 +1,79 @@
+package batches
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRollout_AssignStages(t *testing.T) {
+	targets := []RolloutTarget{
+		{Repository: "github.com/sourcegraph/e", Branch: "refs/heads/main"},
+		{Repository: "github.com/sourcegraph/core", Branch: "refs/heads/main"},
+		{Repository: "github.com/sourcegraph/d", Branch: "refs/heads/main"},
+		{Repository: "github.com/sourcegraph/core", Branch: "refs/heads/release"},
+		{Repository: "github.com/sourcegraph/c", Branch: "refs/heads/main"},
+		{Repository: "github.com/sourcegraph/b", Branch: "refs/heads/main"},
+		{Repository: "github.com/sourcegraph/a", Branch: "refs/heads/main"},
+		{Repository: "github.com/sourcegraph/f", Branch: "refs/heads/main"},
+		{Repository: "github.com/sourcegraph/g", Branch: "refs/heads/main"},
+		{Repository: "github.com/sourcegraph/h", Branch: "refs/heads/main"},
+	}
+
+	for name, tc := range map[string]struct {
+		stages []RolloutStage
+		want   []int
+	}{
+		"no stages": {
+			want: []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+		"repositories": {
+			stages: []RolloutStage{
+				{Repositories: []string{"github.com/sourcegraph/core@main"}},
+				{Repositories: []string{"github.com/sourcegraph/core", "github.com/sourcegraph/[ab]"}},
+			},
+			want: []int{2, 0, 2, 1, 2, 1, 1, 2, 2, 2},
+		},
+		"percentage": {
+			stages: []RolloutStage{
+				{Percentage: 10},
+				{Percentage: 25},
+			},
+			want: []int{2, 1, 2, 2, 1, 1, 0, 2, 2, 2},
+		},
+		"repositories before percentage": {
+			stages: []RolloutStage{
+				{Percentage: 20},
+				{Repositories: []string{"*/core"}},
+			},
+			want: []int{2, 1, 2, 1, 2, 0, 0, 2, 2, 2},
+		},
+		"percentage exceeding remaining": {
+			stages: []RolloutStage{
+				{Repositories: []string{"*"}},
+				{Percentage: 50},
+			},
+			want: []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := &Rollout{Stages: tc.stages}
+			have, err := r.AssignStages(targets)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Errorf("unexpected stages (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRolloutStage_MergedThreshold(t *testing.T) {
+	if have := (&RolloutStage{}).MergedThreshold(); have != 100 {
+		t.Errorf("wrong default threshold: %d", have)
+	}
+	if have := (&RolloutStage{MergedPercentage: 30}).MergedThreshold(); have != 30 {
+		t.Errorf("wrong threshold: %d", have)
+	}
+}
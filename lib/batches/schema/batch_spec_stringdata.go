@@ -328,6 +328,68 @@ const BatchSpecJSON = `{
               }
             }
           ]
+        },
+        "autoMerge": {
+          "title": "ChangesetAutoMerge",
+          "type": "object",
+          "description": "Opt-in policy to automatically merge changesets once all of their checks have passed and their reviews are approved.",
+          "additionalProperties": false,
+          "properties": {
+            "strategy": {
+              "type": "string",
+              "description": "The merge strategy to use. Defaults to \"merge\".",
+              "enum": ["merge", "squash"],
+              "default": "merge"
+            },
+            "maxMergesPerHour": {
+              "type": "integer",
+              "description": "The maximum number of changesets of the batch change that are automatically merged per hour. Changesets over the cap are merged once they are synced again after the hour has passed. If omitted, there is no cap.",
+              "minimum": 1
+            }
+          }
+        },
+        "rollout": {
+          "title": "ChangesetRollout",
+          "type": "object",
+          "description": "Publishes the changesets in stages. Changesets of a stage are held as unpublished until enough changesets of all earlier stages have been merged. Changesets that aren't part of any stage are published after the last stage.",
+          "additionalProperties": false,
+          "required": ["stages"],
+          "properties": {
+            "stages": {
+              "type": "array",
+              "description": "The stages of the rollout, in the order in which they are published.",
+              "minItems": 1,
+              "items": {
+                "title": "ChangesetRolloutStage",
+                "type": "object",
+                "additionalProperties": false,
+                "oneOf": [{ "required": ["repositories"] }, { "required": ["percentage"] }],
+                "properties": {
+                  "repositories": {
+                    "type": "array",
+                    "description": "Glob patterns matching the names of the repositories whose changesets are part of this stage. A pattern can be restricted to a branch with an \"@branch\" suffix.",
+                    "items": {
+                      "type": "string"
+                    },
+                    "minItems": 1
+                  },
+                  "percentage": {
+                    "type": "integer",
+                    "description": "The percentage of all changesets of the batch change that are part of this stage. Changesets are picked in order of their repository name and branch, skipping those matched by stages with repositories.",
+                    "minimum": 1,
+                    "maximum": 100
+                  },
+                  "mergedPercentage": {
+                    "type": "integer",
+                    "description": "The percentage of the changesets of this stage that need to be merged before the next stage is published. Defaults to 100.",
+                    "minimum": 1,
+                    "maximum": 100,
+                    "default": 100
+                  }
+                }
+              }
+            }
+          }
         }
       }
     }
@@ -0,0 +1,108 @@
+package inference
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func RustPatterns() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		pathPattern(rawPattern("Cargo.toml")),
+	}
+}
+
+func CanIndexRustRepo(gitclient GitClient, paths []string) bool {
+	for _, path := range paths {
+		if isCargoManifestPath(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+const lsifRustImage = "sourcegraph/lsif-rust:autoindex"
+
+func InferRustIndexJobs(gitclient GitClient, paths []string) (indexes []config.IndexJob) {
+	workspaces := map[string]bool{}
+	for _, path := range paths {
+		if isCargoManifestPath(path) {
+			workspaces[dirWithoutDot(path)] = isCargoWorkspaceManifest(gitclient, path)
+		}
+	}
+
+	for _, path := range paths {
+		if !isCargoManifestPath(path) {
+			continue
+		}
+
+		root := dirWithoutDot(path)
+		if isCargoWorkspaceMember(root, workspaces) {
+			// The enclosing workspace is indexed as a whole
+			continue
+		}
+
+		indexes = append(indexes, config.IndexJob{
+			Steps: []config.DockerStep{
+				{
+					Root:     root,
+					Image:    lsifRustImage,
+					Commands: []string{"cargo fetch"},
+				},
+			},
+			Root:        root,
+			Indexer:     lsifRustImage,
+			IndexerArgs: []string{"lsif-rust", "index"},
+			Outfile:     "dump.lsif",
+		})
+	}
+
+	return indexes
+}
+
+// isCargoWorkspaceMember returns true if any proper ancestor of the given directory
+// contains a Cargo manifest declaring a workspace.
+func isCargoWorkspaceMember(dir string, workspaces map[string]bool) bool {
+	if dir == "" {
+		return false
+	}
+
+	for _, ancestor := range ancestorDirs(dir) {
+		if workspaces[ancestor] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isCargoWorkspaceManifest returns true if the Cargo manifest at the given path
+// contains a [workspace] table.
+func isCargoWorkspaceManifest(gitclient GitClient, path string) bool {
+	contents, err := gitclient.RawContents(context.TODO(), path)
+	if err != nil {
+		return false
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "[workspace]" || strings.HasPrefix(line, "[workspace.") {
+			return true
+		}
+	}
+
+	return false
+}
+
+var rustSegmentBlockList = append([]string{"target", "vendor"}, segmentBlockList...)
+
+func isCargoManifestPath(path string) bool {
+	return filepath.Base(path) == "Cargo.toml" && containsNoSegments(path, rustSegmentBlockList...)
+}
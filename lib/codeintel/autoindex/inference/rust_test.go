@@ -0,0 +1,166 @@
+package inference
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestRustPatterns(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected bool
+	}{
+		{"Cargo.toml", true},
+		{"crates/foo/Cargo.toml", true},
+		{"Cargo.lock", false},
+		{"Cargo.toml/subdir", false},
+	}
+
+	for _, testCase := range testCases {
+		match := false
+		for _, pattern := range RustPatterns() {
+			if pattern.MatchString(testCase.path) {
+				match = true
+				break
+			}
+		}
+
+		if match {
+			if !testCase.expected {
+				t.Error(fmt.Sprintf("did not expect match: %s", testCase.path))
+			}
+
+		} else if testCase.expected {
+			t.Error(fmt.Sprintf("expected match: %s", testCase.path))
+		}
+	}
+}
+
+func TestCanIndexRustRepo(t *testing.T) {
+	testCases := []struct {
+		paths    []string
+		expected bool
+	}{
+		{paths: []string{"Cargo.toml"}, expected: true},
+		{paths: []string{"a/Cargo.toml"}, expected: true},
+		{paths: []string{"target/package/foo/Cargo.toml"}, expected: false},
+		{paths: []string{"go.mod"}, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		name := strings.Join(testCase.paths, ", ")
+
+		t.Run(name, func(t *testing.T) {
+			if value := CanIndexRustRepo(NewMockGitClient(), testCase.paths); value != testCase.expected {
+				t.Errorf("unexpected result from CanIndex. want=%v have=%v", testCase.expected, value)
+			}
+		})
+	}
+}
+
+func TestInferRustIndexJobsWorkspace(t *testing.T) {
+	contents := map[string]string{
+		"Cargo.toml":                 "[workspace]\nmembers = [\"crates/*\"]\n",
+		"crates/foo/Cargo.toml":      "[package]\nname = \"foo\"\n",
+		"crates/bar/Cargo.toml":      "[package]\nname = \"bar\"\n",
+		"tools/codegen/Cargo.toml":   "[package]\nname = \"codegen\"\n",
+		"tools/other/Cargo.toml":     "[package]\nname = \"other\"\n\n[workspace.dependencies]\n",
+		"tools/other/sub/Cargo.toml": "[package]\nname = \"sub\"\n",
+	}
+
+	mockGit := NewMockGitClient()
+	mockGit.RawContentsFunc.SetDefaultHook(func(ctx context.Context, path string) ([]byte, error) {
+		return []byte(contents[path]), nil
+	})
+
+	paths := []string{
+		"crates/bar/Cargo.toml",
+		"crates/foo/Cargo.toml",
+		"tools/codegen/Cargo.toml",
+		"tools/other/Cargo.toml",
+		"tools/other/sub/Cargo.toml",
+	}
+
+	expectedIndexJobs := []config.IndexJob{
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "",
+					Image:    lsifRustImage,
+					Commands: []string{"cargo fetch"},
+				},
+			},
+			Root:        "",
+			Indexer:     lsifRustImage,
+			IndexerArgs: []string{"lsif-rust", "index"},
+			Outfile:     "dump.lsif",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, InferRustIndexJobs(mockGit, append([]string{"Cargo.toml"}, paths...))); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+
+	expectedIndexJobs = []config.IndexJob{
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "crates/bar",
+					Image:    lsifRustImage,
+					Commands: []string{"cargo fetch"},
+				},
+			},
+			Root:        "crates/bar",
+			Indexer:     lsifRustImage,
+			IndexerArgs: []string{"lsif-rust", "index"},
+			Outfile:     "dump.lsif",
+		},
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "crates/foo",
+					Image:    lsifRustImage,
+					Commands: []string{"cargo fetch"},
+				},
+			},
+			Root:        "crates/foo",
+			Indexer:     lsifRustImage,
+			IndexerArgs: []string{"lsif-rust", "index"},
+			Outfile:     "dump.lsif",
+		},
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "tools/codegen",
+					Image:    lsifRustImage,
+					Commands: []string{"cargo fetch"},
+				},
+			},
+			Root:        "tools/codegen",
+			Indexer:     lsifRustImage,
+			IndexerArgs: []string{"lsif-rust", "index"},
+			Outfile:     "dump.lsif",
+		},
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "tools/other",
+					Image:    lsifRustImage,
+					Commands: []string{"cargo fetch"},
+				},
+			},
+			Root:        "tools/other",
+			Indexer:     lsifRustImage,
+			IndexerArgs: []string{"lsif-rust", "index"},
+			Outfile:     "dump.lsif",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, InferRustIndexJobs(mockGit, paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
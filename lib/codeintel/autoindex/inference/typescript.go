@@ -5,6 +5,10 @@ import (
 	"encoding/json"
 	"path/filepath"
 	"regexp"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"gopkg.in/yaml.v2"
 
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 )
@@ -15,6 +19,7 @@ func TypeScriptPatterns() []*regexp.Regexp {
 		pathPattern(rawPattern("package.json")),
 		pathPattern(rawPattern("lerna.json")),
 		pathPattern(rawPattern("yarn.lock")),
+		pathPattern(rawPattern("pnpm-workspace.yaml")),
 		pathPattern(rawPattern(".nvmrc")),
 		pathPattern(rawPattern(".node-version")),
 		pathPattern(rawPattern(".n-node-version")),
@@ -42,9 +47,30 @@ func InferTypeScriptIndexJobs(gitclient GitClient, paths []string) (indexes []co
 
 		// check first if anywhere along the ancestor path there is a lerna.json
 		isYarn := checkLernaFile(gitclient, path, paths)
+		canDeriveNodeVersion := checkCanDeriveNodeVersion(gitclient, path, paths)
+
+		workspace, inWorkspace := findNodeWorkspace(gitclient, path, paths)
+		if inWorkspace && workspace.root == dirWithoutDot(path) && workspace.hasPackageWithTsConfig(paths) {
+			// The workspace root config generally only references the configs of the
+			// packages it contains. Index each package separately instead.
+			continue
+		}
 
 		var dockerSteps []config.DockerStep
 		for _, dir := range ancestorDirs(path) {
+			if inWorkspace && dir == workspace.root {
+				// A single install at the workspace root installs the dependencies
+				// of every package in the workspace.
+				dockerSteps = append(dockerSteps, config.DockerStep{
+					Root:     dir,
+					Image:    lsifTscImage,
+					Commands: workspace.installCommands(),
+				})
+				continue
+			}
+			if inWorkspace && isWithinDir(dir, workspace.root) {
+				continue
+			}
 			if !contains(paths, filepath.Join(dir, "package.json")) {
 				continue
 			}
@@ -64,7 +90,7 @@ func InferTypeScriptIndexJobs(gitclient GitClient, paths []string) (indexes []co
 		}
 
 		var localSteps []string
-		if checkCanDeriveNodeVersion(gitclient, path, paths) {
+		if canDeriveNodeVersion {
 			for i, step := range dockerSteps {
 				step.Commands = append([]string{nMuslCommand}, step.Commands...)
 				dockerSteps[i] = step
@@ -146,6 +172,142 @@ func hasEnginesField(gitclient GitClient, packageJSONPath string) (hasField bool
 	return
 }
 
+// nodeWorkspace describes a yarn or pnpm workspace root and the globs matching the
+// directories of the packages it contains.
+type nodeWorkspace struct {
+	root   string
+	isPnpm bool
+	globs  []string
+}
+
+// findNodeWorkspace returns the nearest workspace enclosing the given tsconfig.json
+// path. A tsconfig.json is enclosed by a workspace if it lives in the workspace root
+// or in the directory of one of the workspace's packages.
+func findNodeWorkspace(gitclient GitClient, path string, paths []string) (nodeWorkspace, bool) {
+	for _, dir := range ancestorDirs(path) {
+		workspace, ok := readNodeWorkspace(gitclient, dir, paths)
+		if !ok {
+			continue
+		}
+
+		if dirWithoutDot(path) == dir || workspace.containsPackage(dirWithoutDot(path)) {
+			return workspace, true
+		}
+	}
+
+	return nodeWorkspace{}, false
+}
+
+// readNodeWorkspace reads the workspace declaration rooted at the given directory,
+// if one exists. Yarn workspaces are declared in the workspaces field of the root
+// package.json; pnpm workspaces are declared in pnpm-workspace.yaml.
+func readNodeWorkspace(gitclient GitClient, dir string, paths []string) (nodeWorkspace, bool) {
+	if pnpmWorkspacePath := filepath.Join(dir, "pnpm-workspace.yaml"); contains(paths, pnpmWorkspacePath) {
+		pnpmWorkspace := struct {
+			Packages []string `yaml:"packages"`
+		}{}
+
+		if b, err := gitclient.RawContents(context.TODO(), pnpmWorkspacePath); err == nil {
+			if err := yaml.Unmarshal(b, &pnpmWorkspace); err == nil && len(pnpmWorkspace.Packages) > 0 {
+				return nodeWorkspace{root: dir, isPnpm: true, globs: pnpmWorkspace.Packages}, true
+			}
+		}
+
+		return nodeWorkspace{}, false
+	}
+
+	packageJSONPath := filepath.Join(dir, "package.json")
+	if !contains(paths, packageJSONPath) || !contains(paths, filepath.Join(dir, "yarn.lock")) {
+		return nodeWorkspace{}, false
+	}
+
+	packageJSON := struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}{}
+
+	b, err := gitclient.RawContents(context.TODO(), packageJSONPath)
+	if err != nil || json.Unmarshal(b, &packageJSON) != nil || len(packageJSON.Workspaces) == 0 {
+		return nodeWorkspace{}, false
+	}
+
+	// The workspaces field is either a list of globs or an object with a packages
+	// field containing a list of globs.
+	var globs []string
+	if err := json.Unmarshal(packageJSON.Workspaces, &globs); err != nil {
+		workspaces := struct {
+			Packages []string `json:"packages"`
+		}{}
+		if err := json.Unmarshal(packageJSON.Workspaces, &workspaces); err != nil {
+			return nodeWorkspace{}, false
+		}
+		globs = workspaces.Packages
+	}
+	if len(globs) == 0 {
+		return nodeWorkspace{}, false
+	}
+
+	return nodeWorkspace{root: dir, globs: globs}, true
+}
+
+// containsPackage returns true if the given directory is matched by one of the
+// workspace's package globs and is not excluded by a negated glob.
+func (w nodeWorkspace) containsPackage(dir string) bool {
+	if !isWithinDir(dir, w.root) {
+		return false
+	}
+
+	relative := dir
+	if w.root != "" {
+		relative = strings.TrimPrefix(dir, w.root+"/")
+	}
+
+	matched := false
+	for _, pattern := range w.globs {
+		negated := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(pattern, "!"), "./"), "/")
+
+		g, err := glob.Compile(pattern, '/')
+		if err != nil || !g.Match(relative) {
+			continue
+		}
+		if negated {
+			return false
+		}
+		matched = true
+	}
+
+	return matched
+}
+
+// hasPackageWithTsConfig returns true if any of the given paths is a tsconfig.json
+// file in one of the workspace's packages.
+func (w nodeWorkspace) hasPackageWithTsConfig(paths []string) bool {
+	for _, path := range paths {
+		if canIndexTypeScriptPath(path) && w.containsPackage(dirWithoutDot(path)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (w nodeWorkspace) installCommands() []string {
+	if w.isPnpm {
+		return []string{"npm install -g pnpm", "pnpm install"}
+	}
+
+	return []string{"yarn --ignore-engines"}
+}
+
+// isWithinDir returns true if the given path is a proper descendant of dir.
+func isWithinDir(path, dir string) bool {
+	if dir == "" {
+		return path != ""
+	}
+
+	return strings.HasPrefix(path, dir+"/")
+}
+
 var tscSegmentBlockList = append([]string{"node_modules"}, segmentBlockList...)
 
 func canIndexTypeScriptPath(path string) bool {
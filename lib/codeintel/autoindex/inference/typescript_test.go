@@ -1,6 +1,7 @@
 package inference
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -21,6 +22,7 @@ func TestTypeScriptPatterns(t *testing.T) {
 		{".nvmrc", true},
 		{"subdir/package.json", true},
 		{"subdir/yarn.lock", true},
+		{"pnpm-workspace.yaml", true},
 	}
 
 	for _, testCase := range testCases {
@@ -390,3 +392,126 @@ func TestInferTypeScriptIndexJobsNodeVersionInferrence(t *testing.T) {
 		}
 	}
 }
+
+func TestInferTypeScriptIndexJobsYarnWorkspace(t *testing.T) {
+	mockGit := NewMockGitClient()
+	mockGit.RawContentsFunc.SetDefaultHook(func(ctx context.Context, path string) ([]byte, error) {
+		if path == "package.json" {
+			return []byte(`{"private": true, "workspaces": {"packages": ["packages/*", "!packages/legacy"]}}`), nil
+		}
+		return []byte(`{}`), nil
+	})
+
+	paths := []string{
+		"package.json",
+		"yarn.lock",
+		"tsconfig.json",
+		"packages/a/package.json",
+		"packages/a/tsconfig.json",
+		"packages/b/package.json",
+		"packages/b/tsconfig.json",
+		"packages/legacy/package.json",
+		"packages/legacy/tsconfig.json",
+	}
+
+	expectedIndexJobs := []config.IndexJob{
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "",
+					Image:    lsifTscImage,
+					Commands: []string{"yarn --ignore-engines"},
+				},
+			},
+			Root:        "packages/a",
+			Indexer:     lsifTscImage,
+			IndexerArgs: []string{"lsif-tsc", "-p", "."},
+			Outfile:     "",
+		},
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "",
+					Image:    lsifTscImage,
+					Commands: []string{"yarn --ignore-engines"},
+				},
+			},
+			Root:        "packages/b",
+			Indexer:     lsifTscImage,
+			IndexerArgs: []string{"lsif-tsc", "-p", "."},
+			Outfile:     "",
+		},
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "",
+					Image:    lsifTscImage,
+					Commands: []string{"yarn --ignore-engines"},
+				},
+				{
+					Root:     "packages/legacy",
+					Image:    lsifTscImage,
+					Commands: []string{"npm install"},
+				},
+			},
+			Root:        "packages/legacy",
+			Indexer:     lsifTscImage,
+			IndexerArgs: []string{"lsif-tsc", "-p", "."},
+			Outfile:     "",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, InferTypeScriptIndexJobs(mockGit, paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
+
+func TestInferTypeScriptIndexJobsPnpmWorkspace(t *testing.T) {
+	mockGit := NewMockGitClient()
+	mockGit.RawContentsFunc.SetDefaultHook(func(ctx context.Context, path string) ([]byte, error) {
+		if path == "web/pnpm-workspace.yaml" {
+			return []byte("packages:\n  - 'apps/**'\n  - 'libs/*'\n"), nil
+		}
+		return []byte(`{}`), nil
+	})
+
+	paths := []string{
+		"web/package.json",
+		"web/pnpm-workspace.yaml",
+		"web/apps/site/client/package.json",
+		"web/apps/site/client/tsconfig.json",
+		"web/libs/ui/package.json",
+		"web/libs/ui/tsconfig.json",
+	}
+
+	expectedIndexJobs := []config.IndexJob{
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "web",
+					Image:    lsifTscImage,
+					Commands: []string{"npm install -g pnpm", "pnpm install"},
+				},
+			},
+			Root:        "web/apps/site/client",
+			Indexer:     lsifTscImage,
+			IndexerArgs: []string{"lsif-tsc", "-p", "."},
+			Outfile:     "",
+		},
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "web",
+					Image:    lsifTscImage,
+					Commands: []string{"npm install -g pnpm", "pnpm install"},
+				},
+			},
+			Root:        "web/libs/ui",
+			Indexer:     lsifTscImage,
+			IndexerArgs: []string{"lsif-tsc", "-p", "."},
+			Outfile:     "",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, InferTypeScriptIndexJobs(mockGit, paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
@@ -30,8 +30,9 @@ func TestGroupBundleData(t *testing.T) {
 						End:   protocol.Pos{Line: 3, Character: 4},
 					},
 				},
-				DefinitionResultID: 3001,
-				ReferenceResultID:  0,
+				DefinitionResultID:     3001,
+				ReferenceResultID:      0,
+				ImplementationResultID: 3010,
 			},
 			2002: {
 				Range: reader.Range{
@@ -151,6 +152,11 @@ func TestGroupBundleData(t *testing.T) {
 				1003: datastructures.IDSetWith(2007, 2009),
 			}),
 		},
+		ImplementationData: map[int]*datastructures.DefaultIDSetMap{
+			3010: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{
+				1002: datastructures.IDSetWith(2004),
+			}),
+		},
 		HoverData: map[int]string{
 			3008: "foo",
 			3009: "bar",
@@ -340,14 +346,15 @@ func TestGroupBundleData(t *testing.T) {
 		"foo.go": {
 			Ranges: map[precise.ID]precise.RangeData{
 				"2001": {
-					StartLine:          1,
-					StartCharacter:     2,
-					EndLine:            3,
-					EndCharacter:       4,
-					DefinitionResultID: "3001",
-					ReferenceResultID:  "",
-					HoverResultID:      "",
-					MonikerIDs:         []precise.ID{"4001", "4002"},
+					StartLine:              1,
+					StartCharacter:         2,
+					EndLine:                3,
+					EndCharacter:           4,
+					DefinitionResultID:     "3001",
+					ReferenceResultID:      "",
+					ImplementationResultID: "3010",
+					HoverResultID:          "",
+					MonikerIDs:             []precise.ID{"4001", "4002"},
 				},
 				"2002": {
 					StartLine:          2,
@@ -585,6 +592,9 @@ func TestGroupBundleData(t *testing.T) {
 					{DocumentID: "1003", RangeID: "2007"},
 					{DocumentID: "1003", RangeID: "2009"},
 				},
+				"3010": {
+					{DocumentID: "1002", RangeID: "2004"},
+				},
 			},
 		},
 	}
@@ -651,6 +661,32 @@ func TestGroupBundleData(t *testing.T) {
 	if diff := cmp.Diff(expectedReferences, references); diff != "" {
 		t.Errorf("unexpected references (-want +got):\n%s", diff)
 	}
+
+	var implementations []precise.MonikerLocations
+	for v := range actualBundleData.Implementations {
+		implementations = append(implementations, v)
+	}
+	sortMonikerLocations(implementations)
+
+	expectedImplementations := []precise.MonikerLocations{
+		{
+			Scheme:     "scheme A",
+			Identifier: "ident A",
+			Locations: []precise.LocationData{
+				{URI: "bar.go", StartLine: 4, StartCharacter: 5, EndLine: 6, EndCharacter: 7},
+			},
+		},
+		{
+			Scheme:     "scheme B",
+			Identifier: "ident B",
+			Locations: []precise.LocationData{
+				{URI: "bar.go", StartLine: 4, StartCharacter: 5, EndLine: 6, EndCharacter: 7},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedImplementations, implementations); diff != "" {
+		t.Errorf("unexpected implementations (-want +got):\n%s", diff)
+	}
 }
 
 //
@@ -118,6 +118,13 @@ func Diff(old, new *precise.GroupedBundleDataMaps) string {
 				fmt.Sprintf("Reference: %v -> ", locationString(location)),
 			)
 
+			diffLocations(
+				&builder,
+				oldResult.Implementations,
+				newResult.Implementations,
+				fmt.Sprintf("Implementation: %v -> ", locationString(location)),
+			)
+
 			diffQualifiedMonikers(
 				&builder,
 				oldResult.Monikers,
@@ -129,6 +136,7 @@ func Diff(old, new *precise.GroupedBundleDataMaps) string {
 
 	diffMonikers(&builder, old.Definitions, new.Definitions, "MonikerDefinition: ")
 	diffMonikers(&builder, old.References, new.References, "MonikerReference: ")
+	diffMonikers(&builder, old.Implementations, new.Implementations, "MonikerImplementation: ")
 
 	return builder.String()
 }
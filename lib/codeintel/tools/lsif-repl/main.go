@@ -173,6 +173,14 @@ func queryBundle(bundle *precise.GroupedBundleDataMaps, path string, line, chara
 			fmt.Printf("Reference:    %v:%v:(%v, %v)\n", reference.URI, reference.StartLine, reference.StartCharacter, reference.EndCharacter)
 		}
 
+		for idx, implementation := range result.Implementations {
+			if idx >= 10 {
+				fmt.Printf("Abridging implementations...\n")
+				break
+			}
+			fmt.Printf("Impl:         %v:%v:(%v, %v)\n", implementation.URI, implementation.StartLine, implementation.StartCharacter, implementation.EndCharacter)
+		}
+
 		for idx, moniker := range result.Monikers {
 			if idx >= 10 {
 				fmt.Printf("Abridging monikers...\n")
@@ -0,0 +1,17 @@
+BEGIN;
+
+ALTER TABLE cm_trigger_jobs
+    DROP COLUMN IF EXISTS search_results;
+
+DELETE FROM cm_action_jobs WHERE email IS NULL;
+
+ALTER TABLE cm_action_jobs
+    DROP CONSTRAINT IF EXISTS cm_action_jobs_only_one_action_type,
+    DROP COLUMN IF EXISTS webhook,
+    DROP COLUMN IF EXISTS slack_webhook,
+    ALTER COLUMN email SET NOT NULL;
+
+DROP TABLE IF EXISTS cm_webhooks;
+DROP TABLE IF EXISTS cm_slack_webhooks;
+
+COMMIT;
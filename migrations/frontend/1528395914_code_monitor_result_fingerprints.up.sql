@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE cm_queries
+    ADD COLUMN IF NOT EXISTS result_fingerprints TEXT[];
+
+COMMENT ON COLUMN cm_queries.result_fingerprints IS 'Fingerprints of the results of the last run of a query that does not search commits or diffs. A run reports the results whose fingerprints are not in this set. NULL until the first run stores a baseline.';
+
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+
+ALTER TABLE cm_emails
+    DROP CONSTRAINT IF EXISTS cm_emails_rate_limit_valid,
+    DROP COLUMN IF EXISTS delivery,
+    DROP COLUMN IF EXISTS max_notifications,
+    DROP COLUMN IF EXISTS notification_window;
+
+ALTER TABLE cm_webhooks
+    DROP CONSTRAINT IF EXISTS cm_webhooks_rate_limit_valid,
+    DROP COLUMN IF EXISTS delivery,
+    DROP COLUMN IF EXISTS max_notifications,
+    DROP COLUMN IF EXISTS notification_window;
+
+ALTER TABLE cm_slack_webhooks
+    DROP CONSTRAINT IF EXISTS cm_slack_webhooks_rate_limit_valid,
+    DROP COLUMN IF EXISTS delivery,
+    DROP COLUMN IF EXISTS max_notifications,
+    DROP COLUMN IF EXISTS notification_window;
+
+DROP TYPE IF EXISTS cm_action_delivery;
+
+COMMIT;
@@ -0,0 +1,42 @@
+BEGIN;
+
+CREATE TYPE cm_action_delivery AS ENUM ('IMMEDIATE', 'HOURLY_DIGEST', 'DAILY_DIGEST');
+
+ALTER TABLE cm_emails
+    ADD COLUMN IF NOT EXISTS delivery cm_action_delivery NOT NULL DEFAULT 'IMMEDIATE',
+    ADD COLUMN IF NOT EXISTS max_notifications INTEGER,
+    ADD COLUMN IF NOT EXISTS notification_window INTEGER,
+    ADD CONSTRAINT cm_emails_rate_limit_valid CHECK (
+        (max_notifications IS NULL AND notification_window IS NULL)
+        OR (max_notifications > 0 AND notification_window > 0)
+    );
+
+ALTER TABLE cm_webhooks
+    ADD COLUMN IF NOT EXISTS delivery cm_action_delivery NOT NULL DEFAULT 'IMMEDIATE',
+    ADD COLUMN IF NOT EXISTS max_notifications INTEGER,
+    ADD COLUMN IF NOT EXISTS notification_window INTEGER,
+    ADD CONSTRAINT cm_webhooks_rate_limit_valid CHECK (
+        (max_notifications IS NULL AND notification_window IS NULL)
+        OR (max_notifications > 0 AND notification_window > 0)
+    );
+
+ALTER TABLE cm_slack_webhooks
+    ADD COLUMN IF NOT EXISTS delivery cm_action_delivery NOT NULL DEFAULT 'IMMEDIATE',
+    ADD COLUMN IF NOT EXISTS max_notifications INTEGER,
+    ADD COLUMN IF NOT EXISTS notification_window INTEGER,
+    ADD CONSTRAINT cm_slack_webhooks_rate_limit_valid CHECK (
+        (max_notifications IS NULL AND notification_window IS NULL)
+        OR (max_notifications > 0 AND notification_window > 0)
+    );
+
+COMMENT ON COLUMN cm_emails.delivery IS 'Whether notifications are sent immediately or batched into an hourly or daily digest';
+COMMENT ON COLUMN cm_emails.max_notifications IS 'The maximum number of notifications sent within notification_window. NULL if notifications are not rate limited';
+COMMENT ON COLUMN cm_emails.notification_window IS 'The length of the rate limit window in minutes';
+COMMENT ON COLUMN cm_webhooks.delivery IS 'Whether notifications are sent immediately or batched into an hourly or daily digest';
+COMMENT ON COLUMN cm_webhooks.max_notifications IS 'The maximum number of notifications sent within notification_window. NULL if notifications are not rate limited';
+COMMENT ON COLUMN cm_webhooks.notification_window IS 'The length of the rate limit window in minutes';
+COMMENT ON COLUMN cm_slack_webhooks.delivery IS 'Whether notifications are sent immediately or batched into an hourly or daily digest';
+COMMENT ON COLUMN cm_slack_webhooks.max_notifications IS 'The maximum number of notifications sent within notification_window. NULL if notifications are not rate limited';
+COMMENT ON COLUMN cm_slack_webhooks.notification_window IS 'The length of the rate limit window in minutes';
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE IF EXISTS batch_spec_workspace_execution_jobs
+  DROP COLUMN IF EXISTS commit,
+  DROP COLUMN IF EXISTS changeset_id;
+
+ALTER TABLE IF EXISTS batch_changes
+  DROP COLUMN IF EXISTS keep_up_to_date;
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+ALTER TABLE IF EXISTS batch_changes
+  ADD COLUMN IF NOT EXISTS keep_up_to_date boolean NOT NULL DEFAULT FALSE;
+
+ALTER TABLE IF EXISTS batch_spec_workspace_execution_jobs
+  ADD COLUMN IF NOT EXISTS changeset_id bigint REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE DEFAULT NULL,
+  ADD COLUMN IF NOT EXISTS commit text DEFAULT NULL;
+
+COMMENT ON COLUMN batch_changes.keep_up_to_date IS 'Whether changesets with merge conflicts are re-executed against the new head of their base branch.';
+COMMENT ON COLUMN batch_spec_workspace_execution_jobs.changeset_id IS 'The published changeset this job refreshes after its base branch moved. NULL for jobs that are part of a batch spec execution.';
+COMMENT ON COLUMN batch_spec_workspace_execution_jobs.commit IS 'The commit of the base branch a refresh job executes the workspace against, in place of the commit of the workspace. NULL for jobs that are part of a batch spec execution.';
+
+COMMIT;
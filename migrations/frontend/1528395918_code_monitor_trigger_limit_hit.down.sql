@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE cm_trigger_jobs
+    DROP COLUMN IF EXISTS limit_hit;
+
+COMMIT;
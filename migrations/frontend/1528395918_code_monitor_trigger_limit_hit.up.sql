@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE cm_trigger_jobs
+    ADD COLUMN IF NOT EXISTS limit_hit BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN cm_trigger_jobs.limit_hit IS 'Whether the run hit the result or fingerprint limit of code monitors. Results beyond the limit are not monitored.';
+
+COMMIT;
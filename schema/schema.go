@@ -84,12 +84,13 @@ type AuthProviderCommon struct {
 	DisplayName string `json:"displayName,omitempty"`
 }
 type AuthProviders struct {
-	Builtin       *BuiltinAuthProvider
-	Saml          *SAMLAuthProvider
-	Openidconnect *OpenIDConnectAuthProvider
-	HttpHeader    *HTTPHeaderAuthProvider
-	Github        *GitHubAuthProvider
-	Gitlab        *GitLabAuthProvider
+	Builtin        *BuiltinAuthProvider
+	Saml           *SAMLAuthProvider
+	Openidconnect  *OpenIDConnectAuthProvider
+	HttpHeader     *HTTPHeaderAuthProvider
+	Github         *GitHubAuthProvider
+	Gitlab         *GitLabAuthProvider
+	Bitbucketcloud *BitbucketCloudAuthProvider
 }
 
 func (v AuthProviders) MarshalJSON() ([]byte, error) {
@@ -111,6 +112,9 @@ func (v AuthProviders) MarshalJSON() ([]byte, error) {
 	if v.Gitlab != nil {
 		return json.Marshal(v.Gitlab)
 	}
+	if v.Bitbucketcloud != nil {
+		return json.Marshal(v.Bitbucketcloud)
+	}
 	return nil, errors.New("tagged union type must have exactly 1 non-nil field value")
 }
 func (v *AuthProviders) UnmarshalJSON(data []byte) error {
@@ -121,6 +125,8 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	switch d.DiscriminantProperty {
+	case "bitbucketcloud":
+		return json.Unmarshal(data, &v.Bitbucketcloud)
 	case "builtin":
 		return json.Unmarshal(data, &v.Builtin)
 	case "github":
@@ -134,7 +140,7 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 	case "saml":
 		return json.Unmarshal(data, &v.Saml)
 	}
-	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab"})
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab", "bitbucketcloud"})
 }
 
 type BackendInsight struct {
@@ -185,12 +191,34 @@ type BatchSpec struct {
 	Workspaces []*WorkspaceConfiguration `json:"workspaces,omitempty"`
 }
 
+// BitbucketCloudAuthProvider description: Configures the Bitbucket Cloud OAuth authentication provider for SSO. In addition to specifying this configuration object, you must also create an OAuth consumer in your Bitbucket Cloud workspace: https://support.atlassian.com/bitbucket-cloud/docs/use-oauth-on-bitbucket-cloud/. The consumer should have the `Account: Read` and `Account: Email` permissions and the callback URL set to the concatenation of your Sourcegraph instance URL and "/.auth/bitbucketcloud/callback".
+type BitbucketCloudAuthProvider struct {
+	// AllowSignup description: Allows new visitors to sign up for accounts via Bitbucket Cloud authentication. If false, users signing in via Bitbucket Cloud must have an existing Sourcegraph account, which will be linked to their Bitbucket Cloud identity after sign-in.
+	AllowSignup bool `json:"allowSignup,omitempty"`
+	// ApiURL description: The API URL of Bitbucket Cloud. Only set this when testing against a mock.
+	ApiURL string `json:"apiURL,omitempty"`
+	// ClientKey description: The Key of the Bitbucket Cloud OAuth consumer.
+	ClientKey string `json:"clientKey"`
+	// ClientSecret description: The Secret of the Bitbucket Cloud OAuth consumer.
+	ClientSecret string `json:"clientSecret"`
+	DisplayName  string `json:"displayName,omitempty"`
+	Type         string `json:"type"`
+	// Url description: URL of Bitbucket Cloud. It must match the `url` of the Bitbucket Cloud code host connections whose permissions are enforced.
+	Url string `json:"url,omitempty"`
+}
+
+// BitbucketCloudAuthorization description: If non-null, enforces Bitbucket Cloud repository permissions. This requires that there be an item in the `auth.providers` field of type "bitbucketcloud" with the same `url` field as specified in this `BitbucketCloudConnection`, since permissions are only granted to users who signed in with Bitbucket Cloud. The configured user must be an administrator of the workspaces whose repository permissions are enforced.
+type BitbucketCloudAuthorization struct {
+}
+
 // BitbucketCloudConnection description: Configuration for a connection to Bitbucket Cloud.
 type BitbucketCloudConnection struct {
 	// ApiURL description: The API URL of Bitbucket Cloud, such as https://api.bitbucket.org. Generally, admin should not modify the value of this option because Bitbucket Cloud is a public hosting platform.
 	ApiURL string `json:"apiURL,omitempty"`
 	// AppPassword description: The app password to use when authenticating to the Bitbucket Cloud. Also set the corresponding "username" field.
 	AppPassword string `json:"appPassword"`
+	// Authorization description: If non-null, enforces Bitbucket Cloud repository permissions. This requires that there be an item in the `auth.providers` field of type "bitbucketcloud" with the same `url` field as specified in this `BitbucketCloudConnection`, since permissions are only granted to users who signed in with Bitbucket Cloud. The configured user must be an administrator of the workspaces whose repository permissions are enforced.
+	Authorization *BitbucketCloudAuthorization `json:"authorization,omitempty"`
 	// Exclude description: A list of repositories to never mirror from Bitbucket Cloud. Takes precedence over "teams" configuration.
 	//
 	// Supports excluding by name ({"name": "myorg/myrepo"}) or by UUID ({"uuid": "{fceb73c7-cef6-4abe-956d-e471281126bd}"}).
@@ -217,6 +245,8 @@ type BitbucketCloudConnection struct {
 	Url string `json:"url"`
 	// Username description: The username to use when authenticating to the Bitbucket Cloud. Also set the corresponding "appPassword" field.
 	Username string `json:"username"`
+	// WebhookSecret description: The secret used to authenticate incoming webhook payloads from Bitbucket Cloud. It must be included as the "secret" query parameter of the webhook URL configured on Bitbucket Cloud.
+	WebhookSecret string `json:"webhookSecret,omitempty"`
 }
 
 // BitbucketCloudRateLimit description: Rate limit applied when making background API requests to Bitbucket Cloud.
@@ -377,8 +407,32 @@ type BuiltinAuthProvider struct {
 	Type        string `json:"type"`
 }
 
+// ChangesetAutoMerge description: Opt-in policy to automatically merge changesets once all of their checks have passed and their reviews are approved.
+type ChangesetAutoMerge struct {
+	// MaxMergesPerHour description: The maximum number of changesets of the batch change that are automatically merged per hour. Changesets over the cap are merged once they are synced again after the hour has passed. If omitted, there is no cap.
+	MaxMergesPerHour int `json:"maxMergesPerHour,omitempty"`
+	// Strategy description: The merge strategy to use. Defaults to "merge".
+	Strategy string `json:"strategy,omitempty"`
+}
+
+// ChangesetRollout description: Publishes the changesets in stages. Changesets of a stage are held as unpublished until enough changesets of all earlier stages have been merged. Changesets that aren't part of any stage are published after the last stage.
+type ChangesetRollout struct {
+	// Stages description: The stages of the rollout, in the order in which they are published.
+	Stages []*ChangesetRolloutStage `json:"stages"`
+}
+type ChangesetRolloutStage struct {
+	// MergedPercentage description: The percentage of the changesets of this stage that need to be merged before the next stage is published. Defaults to 100.
+	MergedPercentage int `json:"mergedPercentage,omitempty"`
+	// Percentage description: The percentage of all changesets of the batch change that are part of this stage. Changesets are picked in order of their repository name and branch, skipping those matched by stages with repositories.
+	Percentage int `json:"percentage,omitempty"`
+	// Repositories description: Glob patterns matching the names of the repositories whose changesets are part of this stage. A pattern can be restricted to a branch with an "@branch" suffix.
+	Repositories []string `json:"repositories,omitempty"`
+}
+
 // ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 type ChangesetTemplate struct {
+	// AutoMerge description: Opt-in policy to automatically merge changesets once all of their checks have passed and their reviews are approved.
+	AutoMerge *ChangesetAutoMerge `json:"autoMerge,omitempty"`
 	// Body description: The body (description) of the changeset.
 	Body string `json:"body,omitempty"`
 	// Branch description: The name of the Git branch to create or update on each repository with the changes.
@@ -387,6 +441,8 @@ type ChangesetTemplate struct {
 	Commit ExpandedGitCommitDescription `json:"commit"`
 	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host. If omitted, the publication state is controlled from the Batch Changes UI.
 	Published interface{} `json:"published,omitempty"`
+	// Rollout description: Publishes the changesets in stages. Changesets of a stage are held as unpublished until enough changesets of all earlier stages have been merged. Changesets that aren't part of any stage are published after the last stage.
+	Rollout *ChangesetRollout `json:"rollout,omitempty"`
 	// Title description: The title of the changeset.
 	Title string `json:"title"`
 }
@@ -501,6 +557,12 @@ type ExcludedBitbucketServerRepo struct {
 	// Pattern description: Regular expression which matches against the name of a Bitbucket Server repo.
 	Pattern string `json:"pattern,omitempty"`
 }
+type ExcludedGerritProject struct {
+	// Name description: The name of a Gerrit project ("platform/build") to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of a Gerrit project.
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExcludedGitHubRepo struct {
 	// Archived description: If set to true, archived repositories will be excluded.
 	Archived bool `json:"archived,omitempty"`
@@ -519,6 +581,14 @@ type ExcludedGitLabProject struct {
 	// Name description: The name of a GitLab project ("group/name") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 }
+type ExcludedGiteaRepo struct {
+	// Id description: The ID of a Gitea repository (as returned by the Gitea API) to exclude from mirroring.
+	Id int `json:"id,omitempty"`
+	// Name description: The name of a Gitea repository ("myorg/myrepo") to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of a Gitea repository.
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExcludedGitoliteRepo struct {
 	// Name description: The name of a Gitolite repo ("my-repo") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
@@ -552,8 +622,18 @@ type ExperimentalFeatures struct {
 	EnablePostSignupFlow bool `json:"enablePostSignupFlow,omitempty"`
 	// EventLogging description: Enables user event logging inside of the Sourcegraph instance. This will allow admins to have greater visibility of user activity, such as frequently viewed pages, frequent searches, and more. These event logs (and any specific user actions) are only stored locally, and never leave this Sourcegraph instance.
 	EventLogging string `json:"eventLogging,omitempty"`
+	// GitPartialClone description: JSON array of configuration that maps from Git clone URL domain/path patterns to a partial clone filter. Matching repositories are cloned without the objects excluded by the filter, which are fetched from the code host on demand. Only applies to repositories cloned after the setting was added.
+	GitPartialClone []*GitPartialCloneMapping `json:"gitPartialClone,omitempty"`
+	// GitServerRebalancing description: Configures how repositories move between gitserver shards when the list of gitserver addresses changes.
+	GitServerRebalancing *GitServerRebalancing `json:"gitServerRebalancing,omitempty"`
+	// GitServerReplicationFactor description: The number of gitserver shards that hold a copy of each repository. The first copy lives on the shard chosen by hashing the repository name, and the others on the following shards. Replicas are kept up to date alongside the primary copy, and reads fail over to them while the primary shard is unavailable. Must not exceed the number of gitserver shards. Defaults to 1 (no replication).
+	GitServerReplicationFactor int `json:"gitServerReplicationFactor,omitempty"`
+	// GoPackages description: Allow adding Go module proxy code host connections
+	GoPackages string `json:"goPackages,omitempty"`
 	// JvmPackages description: Allow adding JVM packages code host connections
 	JvmPackages string `json:"jvmPackages,omitempty"`
+	// NpmPackages description: Allow adding npm packages code host connections
+	NpmPackages string `json:"npmPackages,omitempty"`
 	// Perforce description: Allow adding Perforce code host connections
 	Perforce string `json:"perforce,omitempty"`
 	// Ranking description: Experimental search result ranking options.
@@ -566,6 +646,8 @@ type ExperimentalFeatures struct {
 	SearchMultipleRevisionsPerRepository *bool `json:"searchMultipleRevisionsPerRepository,omitempty"`
 	// StructuralSearch description: Enables structural search.
 	StructuralSearch string `json:"structuralSearch,omitempty"`
+	// SymbolParsers description: Selects the parser that the symbols service uses to find the symbols of each language. `ctags` (the default) uses universal-ctags. `tree-sitter` uses tree-sitter grammars, which find nested scopes, line ranges and signatures more accurately. Only applies to commits whose symbols are indexed after the setting changed.
+	SymbolParsers *SymbolParsers `json:"symbolParsers,omitempty"`
 	// TlsExternal description: Global TLS/SSL settings for Sourcegraph to use when communicating with code hosts.
 	TlsExternal *TlsExternal `json:"tls.external,omitempty"`
 	// VersionContexts description: JSON array of version context configuration
@@ -594,6 +676,45 @@ type ExternalIdentity struct {
 }
 
 // GitCommitAuthor description: The author of the Git commit.
+// GerritAuthorization description: If non-null, enforces Gerrit project permissions. Sourcegraph assumes that usernames are identical in Sourcegraph and Gerrit, and `auth.enableUsernameChanges` must be set to false for security reasons. The configured user must have the Run As global capability, so that Sourcegraph can list the projects visible to each user.
+type GerritAuthorization struct {
+}
+
+// GerritConnection description: Configuration for a connection to Gerrit.
+type GerritConnection struct {
+	// Authorization description: If non-null, enforces Gerrit project permissions. Sourcegraph assumes that usernames are identical in Sourcegraph and Gerrit, and `auth.enableUsernameChanges` must be set to false for security reasons. The configured user must have the Run As global capability, so that Sourcegraph can list the projects visible to each user.
+	Authorization *GerritAuthorization `json:"authorization,omitempty"`
+	// Exclude description: A list of projects to never mirror from Gerrit. Takes precedence over "projects" configuration.
+	//
+	// Supports excluding by name ({"name": "platform/build"}) or by regular expression ({"pattern": "^private/.*"}).
+	Exclude []*ExcludedGerritProject `json:"exclude,omitempty"`
+	// Password description: The HTTP password of the Gerrit user, as generated on the user's "HTTP Credentials" settings page. It is used to list projects through the Gerrit REST API and to clone them over HTTP(S).
+	Password string `json:"password"`
+	// Projects description: An array of Gerrit project names to mirror on Sourcegraph. If empty or unset, all projects visible to the user are mirrored.
+	Projects []string `json:"projects,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to Gerrit.
+	RateLimit *GerritRateLimit `json:"rateLimit,omitempty"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for a Gerrit project.
+	//
+	//  - "{host}" is replaced with the Gerrit URL's host (such as gerrit.example.com), and "{name}" is replaced with the Gerrit project's name (such as "platform/build").
+	//
+	// For example, if your Gerrit is https://gerrit.example.com and your Sourcegraph is https://src.example.com, then a repositoryPathPattern of "{host}/{name}" would mean that a Gerrit project named platform/build is available on Sourcegraph at https://src.example.com/gerrit.example.com/platform/build.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// Url description: URL of a Gerrit instance, such as https://gerrit.example.com.
+	Url string `json:"url"`
+	// Username description: The username of a Gerrit user with read access to all projects that should be mirrored. Also set the corresponding "password" field.
+	Username string `json:"username"`
+}
+
+// GerritRateLimit description: Rate limit applied when making background API requests to Gerrit.
+type GerritRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
 type GitCommitAuthor struct {
 	// Email description: The Git commit author email.
 	Email string `json:"email"`
@@ -800,8 +921,84 @@ type GitLabWebhook struct {
 	Secret string `json:"secret"`
 }
 
+// GitPartialCloneMapping description: Mapping from a Git clone URL domain/path pattern to a partial clone filter. The first mapping whose pattern matches a repository is used.
+type GitPartialCloneMapping struct {
+	// Filter description: The object filter passed to `git fetch --filter`, such as `blob:none`, `blob:limit=1m` or `sparse:oid=<blob-ish>`.
+	Filter string `json:"filter"`
+	// Pattern description: Regular expression matched against the Git clone URL domain/path, such as `^github\.example\.com/` to match all repositories of a code host.
+	Pattern string `json:"pattern"`
+}
+
+// GitServerRebalancing description: Configures how repositories move between gitserver shards when the list of gitserver addresses changes.
+type GitServerRebalancing struct {
+	// Enabled description: When enabled, a gitserver shard that becomes responsible for a repository copies it from another shard that has a clone instead of cloning it from the code host, and shards remove their clones of repositories that moved away once the new shard has copied them.
+	Enabled bool `json:"enabled,omitempty"`
+	// MaxConcurrentCopies description: Maximum number of repositories copied between gitserver shards at the same time, across all shards. Each shard runs an equal share of the copies, and at least one. Defaults to 10.
+	MaxConcurrentCopies int `json:"maxConcurrentCopies,omitempty"`
+}
+
+// GiteaAuthorization description: If non-null, enforces Gitea repository permissions. Sourcegraph assumes that usernames are identical in Sourcegraph and Gitea, and `auth.enableUsernameChanges` must be set to false for security reasons. The token must belong to a Gitea site admin, so that Sourcegraph can list the repositories visible to each user.
+type GiteaAuthorization struct {
+}
+
+// GiteaConnection description: Configuration for a connection to Gitea.
+type GiteaConnection struct {
+	// Authorization description: If non-null, enforces Gitea repository permissions. Sourcegraph assumes that usernames are identical in Sourcegraph and Gitea, and `auth.enableUsernameChanges` must be set to false for security reasons. The token must belong to a Gitea site admin, so that Sourcegraph can list the repositories visible to each user.
+	Authorization *GiteaAuthorization `json:"authorization,omitempty"`
+	// Exclude description: A list of repositories to never mirror from Gitea. Takes precedence over "orgs", "repos" and "repositoryQuery" configuration.
+	//
+	// Supports excluding by name ({"name": "myorg/myrepo"}), by ID ({"id": 42}) or by regular expression ({"pattern": "^myorg/.*"}).
+	Exclude []*ExcludedGiteaRepo `json:"exclude,omitempty"`
+	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this Gitea instance.
+	//
+	// If "http", Sourcegraph will access Gitea repositories using Git URLs of the form https://gitea.example.com/myorg/myrepo.git, authenticating with the token.
+	//
+	// If "ssh", Sourcegraph will access Gitea repositories using Git URLs of the form git@gitea.example.com:myorg/myrepo.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
+	GitURLType string `json:"gitURLType,omitempty"`
+	// Orgs description: An array of organization names identifying Gitea organizations whose repositories should be mirrored on Sourcegraph.
+	Orgs []string `json:"orgs,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to Gitea.
+	RateLimit *GiteaRateLimit `json:"rateLimit,omitempty"`
+	// Repos description: An array of repository "owner/name" strings specifying which Gitea repositories to mirror on Sourcegraph.
+	Repos []string `json:"repos,omitempty"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for a Gitea repository.
+	//
+	//  - "{host}" is replaced with the Gitea URL's host (such as gitea.example.com), and "{nameWithOwner}" is replaced with the Gitea repository's "owner/name" (such as "myorg/myrepo").
+	//
+	// For example, if your Gitea is https://gitea.example.com and your Sourcegraph is https://src.example.com, then a repositoryPathPattern of "{host}/{nameWithOwner}" would mean that a Gitea repository at https://gitea.example.com/alice/my-repo is available on Sourcegraph at https://src.example.com/gitea.example.com/alice/my-repo.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// RepositoryQuery description: An array of strings specifying which Gitea repositories to mirror on Sourcegraph. The valid values are:
+	//
+	// - `all` mirrors all repositories visible to the token's user.
+	//
+	// - `none` mirrors no repositories (except those specified in the `orgs` or `repos` configuration property or added manually).
+	//
+	// - Any other string is used as a keyword to search repositories by name, and all matching repositories visible to the token's user are mirrored.
+	RepositoryQuery []string `json:"repositoryQuery,omitempty"`
+	// Token description: An access token for a Gitea user, with read access to all repositories that should be mirrored. It is used to list repositories through the Gitea API and to clone them over HTTP(S).
+	Token string `json:"token"`
+	// Url description: URL of a Gitea instance, such as https://gitea.example.com.
+	Url string `json:"url"`
+}
+
+// GiteaRateLimit description: Rate limit applied when making background API requests to Gitea.
+type GiteaRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
+// GitoliteAuthorization description: If non-null, enforces Gitolite repository permissions. Sourcegraph assumes that usernames are identical in Sourcegraph and Gitolite, and `auth.enableUsernameChanges` must be set to false for security reasons. The `sudo` command must be enabled on the Gitolite server, and the SSH key used by Sourcegraph must belong to a Gitolite admin.
+type GitoliteAuthorization struct {
+}
+
 // GitoliteConnection description: Configuration for a connection to Gitolite.
 type GitoliteConnection struct {
+	// Authorization description: If non-null, enforces Gitolite repository permissions. Sourcegraph assumes that usernames are identical in Sourcegraph and Gitolite, and `auth.enableUsernameChanges` must be set to false for security reasons. The `sudo` command must be enabled on the Gitolite server, and the SSH key used by Sourcegraph must belong to a Gitolite admin.
+	Authorization *GitoliteAuthorization `json:"authorization,omitempty"`
 	// Exclude description: A list of repositories to never mirror from this Gitolite instance. Supports excluding by exact name ({"name": "foo"}).
 	Exclude []*ExcludedGitoliteRepo `json:"exclude,omitempty"`
 	// Host description: Gitolite host that stores the repositories (e.g., git@gitolite.example.com, ssh://git@gitolite.example.com:2222/).
@@ -816,6 +1013,24 @@ type GitoliteConnection struct {
 	Prefix string `json:"prefix"`
 }
 
+// GoModulesConnection description: Configuration for a connection to Go module proxies
+type GoModulesConnection struct {
+	// Dependencies description: An array of "module@version" strings specifying which Go modules to mirror on Sourcegraph.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to the configured Go module proxies.
+	RateLimit *GoRateLimit `json:"rateLimit,omitempty"`
+	// Urls description: The list of Go module proxy URLs to fetch modules from. Each proxy is tried in order, as with the GOPROXY environment variable.
+	Urls []string `json:"urls"`
+}
+
+// GoRateLimit description: Rate limit applied when making background API requests to the configured Go module proxies.
+type GoRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
 // HTTPHeaderAuthProvider description: Configures the HTTP header authentication provider (which authenticates users by consulting an HTTP request header set by an authentication proxy such as https://github.com/bitly/oauth2_proxy).
 type HTTPHeaderAuthProvider struct {
 	// EmailHeader description: The name (case-insensitive) of an HTTP header whose value is taken to be the email of the client requesting the page. Set this value when using an HTTP proxy that authenticates requests, and you don't want the extra configurability of the other authentication methods.
@@ -1059,6 +1274,26 @@ type NotifierWebhook struct {
 	Url         string `json:"url"`
 	Username    string `json:"username,omitempty"`
 }
+
+// NpmPackagesConnection description: Configuration for a connection to an npm packages repository.
+type NpmPackagesConnection struct {
+	// Credentials description: Access token for logging into the npm registry.
+	Credentials string `json:"credentials,omitempty"`
+	// Dependencies description: An array of "(@scope/)?packageName@version" strings specifying which npm packages to mirror on Sourcegraph.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to the npm registry.
+	RateLimit *NpmRateLimit `json:"rateLimit,omitempty"`
+	// Registry description: The URL at which the npm registry can be found.
+	Registry string `json:"registry,omitempty"`
+}
+
+// NpmRateLimit description: Rate limit applied when making background API requests to the npm registry.
+type NpmRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
 type OAuthIdentity struct {
 	Type string `json:"type"`
 }
@@ -1618,6 +1853,18 @@ type Step struct {
 	Run string `json:"run"`
 }
 
+// SymbolParsers description: Selects the parser that the symbols service uses to find the symbols of each language. `ctags` (the default) uses universal-ctags. `tree-sitter` uses tree-sitter grammars, which find nested scopes, line ranges and signatures more accurately. Only applies to commits whose symbols are indexed after the setting changed.
+type SymbolParsers struct {
+	// Go description: The parser for Go files.
+	Go string `json:"go,omitempty"`
+	// Java description: The parser for Java files.
+	Java string `json:"java,omitempty"`
+	// Python description: The parser for Python files.
+	Python string `json:"python,omitempty"`
+	// Typescript description: The parser for TypeScript (.ts and .tsx) files.
+	Typescript string `json:"typescript,omitempty"`
+}
+
 // TlsExternal description: Global TLS/SSL settings for Sourcegraph to use when communicating with code hosts.
 type TlsExternal struct {
 	// Certificates description: TLS certificates to accept. This is only necessary if you are using self-signed certificates or an internal CA. Can be an internal CA certificate or a self-signed certificate. To get the certificate of a webserver run `openssl s_client -connect HOST:443 -showcerts < /dev/null 2> /dev/null | openssl x509 -outform PEM`. To escape the value into a JSON string, you may want to use a tool like https://json-escape-text.now.sh.